@@ -0,0 +1,104 @@
+#!/usr/bin/env python3
+"""
+Aggregates - Function counts, lines of code, coverage, and complexity rolled up per directory and language
+Every directory level gets the totals of all files below it, so "which subsystem is worst documented"
+is answered straight from codebase_summary.json without post-processing
+"""
+
+from pathlib import Path
+from typing import Dict, Any, Iterable, List
+
+# Directories with fewer counted functions than this stay out of the worst-documented ranking
+MIN_RANKED_FUNCTIONS = 10
+
+
+def _empty() -> Dict[str, Any]:
+    """Running totals for one directory or language"""
+    return {"files": 0, "lines_of_code": 0, "functions": 0, "documented": 0, "complexity_sum": 0,
+            "scored": 0, "max_complexity": 0}
+
+
+def _file_counts(analysis: Dict[str, Any]) -> Dict[str, Any]:
+    """One file's counted functions and complexity scores (kept instead of the whole analysis)"""
+    scores = [symbol["complexity"] for symbol in analysis.get("symbols", [])
+              if "complexity" in symbol and not symbol.get("doc_exempt")]
+    return {"functions": analysis.get("function_count", 0), "documented": analysis.get("documented_count", 0),
+            "complexity_sum": sum(scores), "scored": len(scores), "max_complexity": max(scores, default=0)}
+
+
+def _add_file(totals: Dict[str, Any], source: Dict[str, Any], counts: Dict[str, Any]):
+    """Add one file's lines, and its counted functions and complexity scores when it has any"""
+    totals["files"] += 1
+    totals["lines_of_code"] += source.get("lines_of_code", 0)
+    for key in ("functions", "documented", "complexity_sum", "scored"):
+        totals[key] += counts.get(key, 0)
+    totals["max_complexity"] = max(totals["max_complexity"], counts.get("max_complexity", 0))
+
+
+def _finish(totals: Dict[str, Any]) -> Dict[str, Any]:
+    """Totals as a summary entry: coverage percentage, undocumented count, and average complexity"""
+    functions, scored = totals["functions"], totals.pop("scored")
+    totals["undocumented"] = functions - totals["documented"]
+    totals["coverage_percentage"] = round(totals["documented"] / functions * 100, 2) if functions else 100.0
+    totals["average_complexity"] = round(totals.pop("complexity_sum") / scored, 2) if scored else 0
+    return totals
+
+
+def rollup(source_files: List[Dict[str, Any]], file_analysis: Iterable[Dict[str, Any]], groups_of,
+           groups: Iterable[Any] = ()) -> Dict[Any, Dict[str, Any]]:
+    """
+    # @codebase-summary: Totals for arbitrary groups of files, counted like build_aggregates
+    - groups_of(file) lists the groups a scanned file counts toward (its project and a combined total, say)
+    - groups get an entry even when no file counts toward them
+    - Each entry has files, lines_of_code, functions, documented, undocumented, coverage_percentage,
+      average_complexity, and max_complexity
+    """
+    counts = {analysis["file"]: _file_counts(analysis) for analysis in file_analysis}
+    totals = {group: _empty() for group in groups}
+    for source in source_files:
+        for group in groups_of(source["file"]):
+            _add_file(totals.setdefault(group, _empty()), source, counts.get(source["file"], {}))
+    return {group: _finish(group_totals) for group, group_totals in totals.items()}
+
+
+def _directories(file: str) -> List[str]:
+    """Every directory containing a file, from the project root ('.') down to its parent"""
+    parts = Path(file).parent.parts
+    return ["."] + ["/".join(parts[:depth]) for depth in range(1, len(parts) + 1)]
+
+
+def build_aggregates(source_files: List[Dict[str, Any]], file_analysis: List[Dict[str, Any]],
+                     language_of, top: int = 10) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Per-directory (every level) and per-language rollups of the scanned code
+    - source_files are all scanned code files ({file, language, lines_of_code}); file_analysis adds
+      the counted functions and complexity of files that have them
+    - Each directory totals every file below it; '.' is the whole project
+    - worst_documented ranks directories with at least MIN_RANKED_FUNCTIONS functions by coverage,
+      most undocumented functions first among equals
+    """
+    counts = {analysis["file"]: _file_counts(analysis) for analysis in file_analysis}
+    directories: Dict[str, Dict[str, Any]] = {}
+    languages: Dict[str, Dict[str, Any]] = {}
+    for source in source_files:
+        file_counts = counts.get(source["file"], {})
+        language = language_of(source["language"])
+        _add_file(languages.setdefault(language, _empty()), source, file_counts)
+        for directory in _directories(source["file"]):
+            totals = directories.setdefault(directory, {**_empty(), "depth": 0 if directory == "." else
+                                                        directory.count("/") + 1, "languages": {}})
+            _add_file(totals, source, file_counts)
+            totals["languages"][language] = totals["languages"].get(language, 0) + 1
+
+    by_directory = {directory: _finish(totals) for directory, totals in sorted(directories.items())}
+    ranked = [{"directory": directory, "coverage_percentage": totals["coverage_percentage"],
+               "undocumented": totals["undocumented"], "functions": totals["functions"]}
+              for directory, totals in by_directory.items()
+              if directory != "." and totals["functions"] >= MIN_RANKED_FUNCTIONS]
+    ranked.sort(key=lambda d: (d["coverage_percentage"], -d["undocumented"], d["directory"]))
+    return {
+        "by_directory": by_directory,
+        "by_language": {language: _finish(totals)
+                        for language, totals in sorted(languages.items(), key=lambda item: (-item[1]["files"], item[0]))},
+        "worst_documented": ranked[:top],
+    }
@@ -0,0 +1,304 @@
+#!/usr/bin/env python3
+"""
+Annotate - Inserts skeleton @codebase-summary breadcrumbs above undocumented symbols
+Bootstraps documentation: every symbol the scanner reports as missing a breadcrumb gets a stub in
+the language's comment syntax, with placeholders for its parameters, ready to be filled in
+"""
+
+import ast
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set, Tuple
+
+import breadcrumb_styles
+import link_policy
+import text_encoding
+
+# Line-comment prefix per scanner language key; CSS only has block comments
+COMMENT_PREFIXES = {
+    'python': '#', 'ruby': '#', 'shell': '#', 'r': '#', 'elixir': '#', 'powershell': '#',
+    'hcl': '#', 'graphql': '#', 'starlark': '#', 'julia': '#',
+    'javascript': '//', 'typescript': '//', 'tsx': '//', 'vue': '//', 'svelte': '//', 'java': '//',
+    'kotlin': '//', 'scala': '//', 'go': '//', 'rust': '//', 'c': '//', 'cpp': '//', 'objc': '//',
+    'csharp': '//', 'swift': '//', 'dart': '//', 'php': '//', 'zig': '//', 'proto': '//', 'solidity': '//',
+    'lua': '--', 'haskell': '--', 'sql': '--',
+    'clojure': ';;',
+}
+BLOCK_COMMENTS = {'css': ('/*', '*/')}
+
+# Receivers and placeholders that are not worth documenting as parameters
+_SKIPPED_PARAMS = {'self', 'cls', 'this', '&self', '&mut self', 'void', '...', '_'}
+# Decorator/annotation/attribute lines a stub goes above, so it does not split them from the declaration
+DECORATOR_PREFIXES = {
+    'python': '@', 'java': '@', 'kotlin': '@', 'scala': '@', 'typescript': '@', 'tsx': '@',
+    'javascript': '@', 'dart': '@', 'swift': '@', 'rust': '#[', 'csharp': '[',
+}
+# What may sit between a symbol's name and its parameter list (generics, '<-'/'=' assignments, 'function')
+_HEAD_GAP = re.compile(r'^[\s\w<>,.:?\[\]=\-\\!&*]*$')
+
+
+def _split_top_level(text: str) -> List[str]:
+    """Split a parameter list on commas that are not nested in brackets"""
+    parts, depth, current = [], 0, ''
+    for char in text:
+        if char in '([{<':
+            depth += 1
+        elif char in ')]}>' and depth:
+            depth -= 1
+        if char == ',' and depth == 0:
+            parts.append(current)
+            current = ''
+        else:
+            current += char
+    parts.append(current)
+    return [part.strip() for part in parts if part.strip()]
+
+
+def _parameter_name(param: str, language: str) -> Optional[str]:
+    """Best-effort parameter name from one declaration ('x: int = 1' / 'int x' / 'x int' -> 'x')"""
+    param = re.sub(r'@\w+(?:\([^)]*\))?\s*', '', param.split('=')[0]).strip()
+    if param in _SKIPPED_PARAMS or not param:
+        return None
+    if ':' in param.replace('::', ''):
+        words = param.split(':')[0].split()
+    elif language == 'go':
+        words = param.split()[:1]
+    else:
+        words = re.sub(r'\[[^\]]*\]', '', param).split()[-1:]
+    name = words[-1].lstrip('*&.$') if words else ''
+    if language == 'php':
+        name = '$' + name
+    return name if name and name not in _SKIPPED_PARAMS and re.match(r'^\$?[\w-]+\??$', name) else None
+
+
+def symbol_parameters(lines: List[str], symbol: Dict[str, Any], language: str) -> List[str]:
+    """
+    # @codebase-summary: Parameter names from a symbol's declaration head
+    - Reads the parenthesized list (Clojure: the argument vector) opening after the symbol's name
+      on its declaration line, joining up to six lines for multi-line signatures
+    - Drops receivers (self, this, cls), defaults, type annotations, and decorators
+    - Returns an empty list when no parameter list follows the name
+    """
+    start = symbol["line"] - 1
+    name = symbol["name"].split('.')[-1]
+    position = lines[start].find(name)
+    if position == -1:
+        return []
+    opener, closer = ('[', ']') if language == 'clojure' else ('(', ')')
+    # The list must open on the declaration line; only its contents may continue below
+    open_index = lines[start].find(opener, position + len(name))
+    if open_index == -1 or not _HEAD_GAP.match(lines[start][position + len(name):open_index]):
+        return []
+    head = " ".join(lines[start:start + 6])
+    depth = 0
+    for index in range(open_index, len(head)):
+        if head[index] == opener:
+            depth += 1
+        elif head[index] == closer:
+            depth -= 1
+            if depth == 0:
+                inner = head[open_index + 1:index]
+                break
+    else:
+        return []
+    if language == 'clojure':
+        return [p for p in inner.split() if p not in ('&',) and re.match(r'^[\w*+!?<>=-]+$', p)]
+    names = (_parameter_name(param, language) for param in _split_top_level(inner))
+    return [param for param in names if param]
+
+
+def breadcrumb_stub(symbol: Dict[str, Any], params: List[str], template: Optional[List[str]] = None) -> List[str]:
+    """
+    Stub body lines (without comment syntax): the summary line plus parameter/return placeholders.
+    A configured template's lines may use {name}, {kind}, and {params}; {params} lines are dropped
+    for symbols without parameters
+    """
+    if template:
+        values = {"name": symbol["name"], "kind": symbol.get("kind", "function"), "params": ", ".join(params)}
+        return [entry.format(**values) for entry in template if params or "{params}" not in entry]
+    stub = [f"@codebase-summary: TODO describe what {symbol['name']} does"]
+    if params:
+        stub.append(f"- Parameters: {', '.join(params)} (TODO describe)")
+    if symbol.get("kind", "function") in ("function", "method", "macro"):
+        stub.append("- Returns: TODO")
+    return stub
+
+
+def _python_docstring_position(tree: Optional[ast.AST], line: int) -> Optional[Tuple[int, int]]:
+    """
+    (insert index, indent) for a docstring stub in the body of the def/class declared on line,
+    or None when the body already has a docstring or the signature spans several lines (the
+    breadcrumb would fall outside the scanner's search window)
+    """
+    if tree is None:
+        return None
+    for node in ast.walk(tree):
+        if isinstance(node, (ast.FunctionDef, ast.AsyncFunctionDef, ast.ClassDef)) and node.lineno == line:
+            first = node.body[0]
+            if ast.get_docstring(node, clean=False) is not None or first.lineno != line + 1:
+                return None
+            return first.lineno - 1, first.col_offset
+    return None
+
+
+def _above_decorators(lines: List[str], index: int, language: str, stub_length: int) -> int:
+    """
+    Insert index above the declaration's decorators, as long as the breadcrumb stays within the
+    scanner's five-line search window above the declaration
+    """
+    decorator = DECORATOR_PREFIXES.get(language)
+    start = index
+    while decorator and start > 0 and lines[start - 1].lstrip().startswith(decorator) and \
+            index - (start - 1) + stub_length <= 5:
+        start -= 1
+    return start
+
+
+def annotate_lines(lines: List[str], symbols: List[Dict[str, Any]], language: str, prefix: Optional[str] = None,
+                   template: Optional[List[str]] = None) -> Tuple[List[str], List[Dict[str, Any]]]:
+    """
+    # @codebase-summary: Stub insertion for one file's undocumented symbols
+    - Python symbols get a docstring stub in the repo's breadcrumb style; other languages get a
+      comment block directly above the declaration, indented to match it
+    - prefix overrides the line-comment syntax (config-defined languages declare their own);
+      template replaces the default stub with the language's configured breadcrumb style
+    - Inserts bottom-up so earlier symbols' line numbers stay valid; one stub per declaration line
+    - Returns the new lines and the symbols that were annotated
+    """
+    prefix = prefix or COMMENT_PREFIXES.get(language)
+    block = BLOCK_COMMENTS.get(language)
+    tree = None
+    if language == 'python':
+        try:
+            tree = ast.parse("\n".join(lines))
+        except SyntaxError:
+            pass
+
+    targets = {}
+    for symbol in symbols:
+        if not symbol.get("documented") and not symbol.get("doc_exempt") and 0 < symbol.get("line", 0) <= len(lines):
+            targets.setdefault(symbol["line"], symbol)
+
+    result = list(lines)
+    annotated = []
+    for line in sorted(targets, reverse=True):
+        symbol = targets[line]
+        stub = breadcrumb_stub(symbol, symbol_parameters(lines, symbol, language), template)
+        declaration = lines[line - 1]
+        indent = declaration[:len(declaration) - len(declaration.lstrip())]
+
+        docstring = _python_docstring_position(tree, line) if language == 'python' else None
+        if docstring:
+            index, column = docstring
+            body_indent = ' ' * column
+            # The default breadcrumb reads '# @codebase-summary:' inside docstrings; templates are verbatim
+            first = stub[0] if template else f"# {stub[0]}"
+            block_lines = [f'{body_indent}"""', f'{body_indent}{first}'] + \
+                          [f'{body_indent}{entry}' for entry in stub[1:]] + [f'{body_indent}"""']
+            result[index:index] = block_lines
+        elif prefix:
+            index = _above_decorators(lines, line - 1, language, len(stub))
+            result[index:index] = [f"{indent}{prefix} {entry}" for entry in stub]
+        elif block:
+            index = _above_decorators(lines, line - 1, language, 1)
+            result[index:index] = [f"{indent}{block[0]} {' '.join(stub)} {block[1]}"]
+        else:
+            continue
+        annotated.append(symbol)
+    return result, list(reversed(annotated))
+
+
+def language_key(generator, path: Path, lines: List[str]) -> Optional[str]:
+    """Scanner language key for a file, resolved the way the scanner's analysis does"""
+    import language_extractors
+    ext = path.suffix.lower()
+    if path.name in generator.code_filenames:
+        return generator.code_filenames[path.name]
+    if not ext:
+        return language_extractors.shebang_language(lines[0]) if lines else None
+    if ext == '.h':
+        return language_extractors.header_language(lines)
+    return generator.language_map.get(ext)
+
+
+def line_comment_prefixes(generator) -> Dict[str, str]:
+    """Line-comment prefix per language key: COMMENT_PREFIXES plus config-defined languages (workflow_config.json)"""
+    return {**COMMENT_PREFIXES, **{plugin["language"]: plugin["definition"]["line_comment"]
+                                   for plugin in getattr(generator, "extractor_plugins", [])
+                                   if (plugin.get("definition") or {}).get("line_comment")}}
+
+
+def _candidate_files(generator, targets: List[Path], changed: Optional[Set[str]]) -> List[Path]:
+    """Code files under the target paths (the whole project by default), optionally limited to changed files"""
+    root = Path(generator.project_root)
+    real_root = root.resolve()
+    walker = link_policy.LinkWalker(root, getattr(generator, "symlink_policy", "follow"))
+    files = []
+    for target in targets or [root]:
+        target = target.resolve()
+        if target.is_file():
+            walk = [(str(target.parent), [], [target.name])]
+        else:
+            walk = walker.walk(target, ignore=generator._should_ignore_path)
+        for current, dirs, names in walk:
+            current_path = Path(current)
+            for name in names:
+                path = current_path / name
+                # Notebooks are JSON - their cells cannot take comments line by line; stubs are never
+                # written through a symlink (of the file or a directory above it) to outside the project
+                if not generator._is_code_file(path) or path.suffix.lower() == '.ipynb' or \
+                        not path.resolve().is_relative_to(real_root) or generator._should_ignore_path(path):
+                    continue
+                try:
+                    rel_path = str(path.relative_to(root))
+                except ValueError:
+                    continue
+                if changed is None or rel_path in changed:
+                    files.append(path)
+    return files
+
+
+def annotate_project(generator, targets: List[Path], dry_run: bool = False) -> int:
+    """
+    # @codebase-summary: 'annotate' command - breadcrumb stubs across the project
+    - Limits the run to the given files/directories and, with --since <ref>, to files changed
+      since that git ref; the scanner's ignore rules always apply
+    - Re-analyzes each file with the scanner so "undocumented" means exactly what coverage counts
+    - --dry-run lists the stubs without writing; returns the number of symbols annotated
+    """
+    changed = None
+    if generator.since_ref:
+        changed = generator._git_changed_files(generator.since_ref)
+        if changed is None:
+            return 0
+    comment_prefixes = line_comment_prefixes(generator)
+
+    total = 0
+    files_changed = 0
+    for path in _candidate_files(generator, targets, changed):
+        analysis = generator._analyze_code_file(str(path))
+        if not analysis.get("missing_breadcrumbs"):
+            continue
+        # Written back in the file's own encoding (and BOM), so UTF-16 and legacy code page files stay as they were
+        content, encoding = text_encoding.read_source(path)
+        lines = content.split('\n')
+        language = language_key(generator, path, lines)
+        if language not in comment_prefixes and language not in BLOCK_COMMENTS:
+            print(f"⚠️ No comment syntax known for {language or path.suffix} - skipping {analysis['file']}")
+            continue
+        template = breadcrumb_styles.style_for(generator.breadcrumb_styles, language)["template"]
+        new_lines, annotated = annotate_lines(lines, analysis["symbols"], language, comment_prefixes.get(language), template)
+        if not annotated:
+            continue
+
+        for symbol in annotated:
+            print(f"{'🔎' if dry_run else '📝'} {analysis['file']}:{symbol['line']} {symbol['name']}")
+        if not dry_run:
+            with open(path, 'wb') as f:
+                f.write(text_encoding.encode('\n'.join(new_lines), encoding))
+        total += len(annotated)
+        files_changed += 1
+
+    verb = "Would insert" if dry_run else "Inserted"
+    print(f"✅ {verb} {total} breadcrumb stub(s) in {files_changed} file(s)")
+    return total
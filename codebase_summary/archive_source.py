@@ -0,0 +1,243 @@
+#!/usr/bin/env python3
+"""
+Archive Source - Read-only view of a .zip or .tar(.gz/.bz2/.xz) artifact as a virtual project tree
+Members are read into memory and never extracted, so a release artifact or CI bundle can be scanned
+where it lies; paths look like <archive>/src/app.py, and summaries keep the same structure as a checkout
+"""
+
+import posixpath
+import stat
+import tarfile
+import zipfile
+import zlib
+from pathlib import Path
+from typing import Dict, Any, Callable, Iterator, List, Optional, Tuple
+
+from link_policy import summarize_links
+from memory_budget import format_size
+
+ARCHIVE_SUFFIXES = (".zip", ".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz")
+# Uncompressed bytes read from one archive before the scan gives up, so a zip bomb cannot exhaust memory
+MAX_TOTAL_SIZE = 2 * 1024 ** 3
+
+
+def is_archive(path) -> bool:
+    """Whether a path names a file with an archive suffix"""
+    return Path(path).name.lower().endswith(ARCHIVE_SUFFIXES)
+
+
+def archive_stem(path) -> str:
+    """Archive name without its suffix ('release-1.2.0.tar.gz' -> 'release-1.2.0')"""
+    name = Path(path).name
+    suffix = next((s for s in ARCHIVE_SUFFIXES if name.lower().endswith(s)), "")
+    return name[:len(name) - len(suffix)] if suffix else name
+
+
+def _member_name(name: str) -> Optional[str]:
+    """Normalized relative member path ('/x' becomes 'x'), or None for names that escape the archive ('../x')"""
+    name = posixpath.normpath(name.replace("\\", "/")).lstrip("/")
+    if name in ("", ".") or name == ".." or name.startswith("../"):
+        return None
+    return name
+
+
+class ArchiveTree:
+    """
+    # @codebase-summary: In-memory file tree of an archive that stands in for the project directory
+    - Regular files only: a symlink member is recorded as a skipped link (there is no target to follow
+      outside the archive), and unsafe, encrypted, or special members are listed as skipped
+    - Hardlinks count once, on their first path in walk order, like link_policy.LinkWalker on disk
+    - Members over max_member_size keep their size but are not read, so the size skip rule reports them
+    - A single top-level directory (project-1.2.0/) is dropped, so paths match the repository layout
+    - walk(), errors(), and summary() mirror LinkWalker, so the scanner's traversal works unchanged
+    """
+
+    policy = "skip"
+
+    def __init__(self, path, max_member_size: Optional[int] = None):
+        self.path = Path(path)
+        self.root = self.path.resolve()
+        self.max_member_size = max_member_size
+        self.links: List[Dict[str, Any]] = []  # symlink members: {path, target, kind, action}
+        self.duplicates: List[Dict[str, str]] = []  # {path, same_as} for hardlink members
+        self.skipped: List[Dict[str, str]] = []  # {member, reason} for members that are not files to scan
+        self.prefix = ""
+        self._data: Dict[str, Optional[bytes]] = {}
+        self._sizes: Dict[str, int] = {}
+        self._hardlinks: List[Tuple[str, str]] = []  # (member, member it links to)
+        self._total = 0
+        try:
+            if zipfile.is_zipfile(self.path):
+                self.format = "zip"
+                self._read_zip()
+            elif tarfile.is_tarfile(self.path):
+                self.format = "tar" + next((f".{kind}" for kind, suffixes in
+                                            (("gz", (".gz", ".tgz")), ("bz2", (".bz2", ".tbz2")), ("xz", (".xz", ".txz")))
+                                            if self.path.name.lower().endswith(suffixes)), "")
+                self._read_tar()
+            else:
+                raise ValueError(f"{self.path.name} is not a zip or tar archive")
+        except (zipfile.BadZipFile, tarfile.TarError, EOFError, zlib.error) as e:
+            raise ValueError(f"{self.path.name} is damaged: {e}") from e
+        self._strip_prefix()
+        self._build_tree()
+        self._dedupe_hardlinks()
+
+    def _add(self, name: str, size: int, read: Callable[[], bytes]):
+        """Record one regular-file member and read it unless it is over the member size limit"""
+        member = _member_name(name)
+        if member is None:
+            self.skipped.append({"member": name, "reason": "unsafe path"})
+            return
+        self._sizes[member] = size
+        if self.max_member_size is not None and size > self.max_member_size:
+            self._data[member] = None
+            return
+        self._total += size
+        if self._total > MAX_TOTAL_SIZE:
+            raise ValueError(f"{self.path.name} expands past {format_size(MAX_TOTAL_SIZE)}")
+        self._data[member] = read()
+
+    def _add_symlink(self, name: str, target: str):
+        member = _member_name(name)
+        if member is None:
+            self.skipped.append({"member": name, "reason": "unsafe path"})
+        else:
+            self.links.append({"path": member, "target": target, "kind": "file", "action": "skipped"})
+
+    def _read_zip(self):
+        with zipfile.ZipFile(self.path) as archive:
+            for info in archive.infolist():
+                if info.is_dir():
+                    continue
+                try:
+                    if stat.S_ISLNK(info.external_attr >> 16):
+                        # A zipped symlink stores its target path as the member content
+                        self._add_symlink(info.filename, archive.read(info).decode("utf-8", errors="replace"))
+                    elif info.flag_bits & 0x1:
+                        self.skipped.append({"member": info.filename, "reason": "encrypted"})
+                    else:
+                        self._add(info.filename, info.file_size, lambda: archive.read(info))
+                except (zipfile.BadZipFile, OSError, NotImplementedError) as e:
+                    self.skipped.append({"member": info.filename, "reason": f"unreadable: {e}"})
+
+    def _read_tar(self):
+        # Streaming mode reads a compressed tarball front to back once, without seeking
+        with tarfile.open(self.path, "r|*") as archive:
+            for info in archive:
+                if info.isdir():
+                    continue
+                if info.issym():
+                    self._add_symlink(info.name, info.linkname)
+                elif info.islnk():
+                    # A hardlink refers to a file stored earlier in the stream
+                    member, target = _member_name(info.name), _member_name(info.linkname)
+                    if target not in self._sizes:
+                        self.skipped.append({"member": info.name, "reason": f"hardlink to missing {info.linkname}"})
+                        continue
+                    self._add(info.name, self._sizes[target], lambda: self._data[target])
+                    if member is not None:
+                        self._hardlinks.append((member, target))
+                elif not info.isfile():
+                    self.skipped.append({"member": info.name, "reason": "special file"})
+                else:
+                    self._add(info.name, info.size, lambda: archive.extractfile(info).read())
+
+    def _strip_prefix(self):
+        """Drop a top-level directory shared by every member"""
+        members = list(self._sizes) + [link["path"] for link in self.links]
+        tops = {member.split("/", 1)[0] for member in members}
+        if len(tops) != 1 or all("/" not in member for member in members):
+            return
+        self.prefix = tops.pop()
+        cut = len(self.prefix) + 1
+        self._sizes = {member[cut:]: size for member, size in self._sizes.items()}
+        self._data = {member[cut:]: data for member, data in self._data.items()}
+        self._hardlinks = [(member[cut:], target[cut:]) for member, target in self._hardlinks]
+        for link in self.links:
+            link["path"] = link["path"][cut:]
+
+    def _build_tree(self):
+        """Directory -> (subdirectories, files) index for walk()"""
+        self._tree: Dict[str, Tuple[set, List[str]]] = {"": (set(), [])}
+        for member in self._sizes:
+            directory, _, name = member.rpartition("/")
+            self._tree.setdefault(directory, (set(), []))[1].append(name)
+            while directory:
+                parent, _, child = directory.rpartition("/")
+                self._tree.setdefault(parent, (set(), []))[0].add(child)
+                directory = parent
+        self.links.sort(key=lambda link: link["path"])
+        for link in self.links:
+            target = posixpath.normpath(posixpath.join(posixpath.dirname(link["path"]), link["target"]))
+            if target in self._tree:
+                link["kind"] = "directory"
+
+    def _dedupe_hardlinks(self):
+        """Keep each group of hardlinked members on the path the walk reaches first"""
+        if not self._hardlinks:
+            return
+        order: Dict[str, int] = {}
+        for root, _, files in self.walk():
+            for name in files:
+                order[self._member(Path(root) / name)] = len(order)
+        groups: Dict[str, List[str]] = {}
+        for member, target in self._hardlinks:
+            groups.setdefault(target, [target]).append(member)
+        for members in groups.values():
+            first, *others = sorted(set(members), key=order.get)
+            for member in others:
+                self.duplicates.append({"path": member, "same_as": first})
+                del self._sizes[member], self._data[member]
+        self.duplicates.sort(key=lambda entry: order[entry["path"]])
+        self._build_tree()
+
+    def _member(self, path) -> Optional[str]:
+        """Member key of a virtual path, or None outside the archive"""
+        path = Path(path)
+        if path != self.root and self.root not in path.parents:
+            return None
+        member = path.relative_to(self.root).as_posix()
+        return "" if member == "." else member
+
+    def read(self, path) -> Optional[bytes]:
+        """Content of a member, or None for missing and unread (oversized) members"""
+        return self._data.get(self._member(path))
+
+    def size(self, path) -> Optional[int]:
+        """Uncompressed size of a member, or None when it is not a file in the archive"""
+        return self._sizes.get(self._member(path))
+
+    def walk(self, top: Optional[Path] = None,
+             ignore: Optional[Callable[[Path], bool]] = None) -> Iterator[Tuple[str, List[str], List[str]]]:
+        """(root, dirs, files) over the member tree like LinkWalker.walk: sorted, pruned, dirs editable in place"""
+        directory = self._member(top or self.root)
+        if directory not in self._tree:
+            return
+        root_path = self.root / directory if directory else self.root
+        subdirs, files = self._tree[directory]
+        dirs = [name for name in sorted(subdirs) if not (ignore and ignore(root_path / name))]
+        files = [name for name in sorted(files) if not (ignore and ignore(root_path / name))]
+        yield str(root_path), dirs, files
+        for name in dirs:
+            yield from self.walk(root_path / name, ignore)
+
+    def errors(self) -> List[Dict[str, Any]]:
+        """Archives have no symlinks to fail on: link members are never followed"""
+        return []
+
+    def summary(self, limit: int = 50) -> Dict[str, Any]:
+        """project_structure 'links' section, as LinkWalker.summary() reports it for a directory"""
+        return summarize_links(self.policy, self.links, self.duplicates, limit)
+
+    def source(self, limit: int = 50) -> Dict[str, Any]:
+        """Top-level 'source' section: the archive scanned, its format, and members left out"""
+        return {
+            "archive": self.path.name,
+            "format": self.format,
+            "prefix": self.prefix,
+            "members": len(self._sizes),
+            "uncompressed_bytes": sum(self._sizes.values()),
+            "skipped_members": len(self.skipped),
+            "skipped": self.skipped[:limit],
+        }
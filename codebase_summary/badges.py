@@ -0,0 +1,88 @@
+#!/usr/bin/env python3
+"""
+Badges - Documentation coverage badge from a summary ('badge' subcommand)
+Writes a shields.io endpoint JSON (for img.shields.io/endpoint?url=...) and a self-contained SVG in the
+shields 'flat' look, so a README can show "docs coverage 87%" from CI artifacts or a committed file
+"""
+
+import json
+from pathlib import Path
+from typing import Dict, Any, List, Tuple
+from xml.sax.saxutils import escape
+
+DEFAULT_LABEL = "docs coverage"
+STYLES = ("flat", "flat-square")
+# (minimum coverage, shields color name, hex) - the same steps shields.io uses for coverage badges
+COLOR_STEPS: List[Tuple[float, str, str]] = [
+    (90, "brightgreen", "#4c1"), (75, "green", "#97ca00"), (60, "yellowgreen", "#a4a61d"),
+    (40, "yellow", "#dfb317"), (20, "orange", "#fe7d37"), (0, "red", "#e05d44"),
+]
+# Verdana 11px advance widths (shields' metrics); other characters count as CHAR_WIDTH
+_NARROW = {**dict.fromkeys("ijlI.,:;!|' ", 3.9), **dict.fromkeys("frt()[]-", 4.9)}
+_WIDE = {**dict.fromkeys("mwMW%", 10.9), **dict.fromkeys("ABCDGHKNOQRUVXY&", 8.4)}
+CHAR_WIDTH = 7.0
+PADDING = 6
+
+
+def badge_color(coverage: float) -> Tuple[str, str]:
+    """(shields color name, hex) for a coverage percentage"""
+    return next((name, hex_color) for minimum, name, hex_color in COLOR_STEPS if coverage >= minimum)
+
+
+def format_coverage(coverage: float) -> str:
+    """Badge message: whole percent, one decimal below 10% so small gains still show"""
+    return f"{coverage:.1f}%" if coverage < 10 else f"{coverage:.0f}%"
+
+
+def _text_width(text: str) -> float:
+    """Approximate rendered width of badge text"""
+    return sum(_NARROW.get(ch) or _WIDE.get(ch) or CHAR_WIDTH for ch in text)
+
+
+def endpoint_json(label: str, message: str, color: str) -> Dict[str, Any]:
+    """shields.io endpoint badge document"""
+    return {"schemaVersion": 1, "label": label, "message": message, "color": color}
+
+
+def badge_svg(label: str, message: str, color: str, style: str = "flat") -> str:
+    """
+    # @codebase-summary: Two-part badge SVG (grey label, colored message) without external requests
+    - 'flat' has rounded corners and a light gradient; 'flat-square' neither
+    - Text gets a one-pixel shadow like shields badges, and the title doubles as the accessible label
+    """
+    label_width = round(_text_width(label) + 2 * PADDING)
+    message_width = round(_text_width(message) + 2 * PADDING)
+    width = label_width + message_width
+    label_text, message_text = escape(label, {'"': "&quot;"}), escape(message, {'"': "&quot;"})
+    title = f"{label_text}: {message_text}"
+    rounded = style != "flat-square"
+    gradient = ('<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/>'
+                '<stop offset="1" stop-opacity=".1"/></linearGradient>') if rounded else ""
+    overlay = f'<rect width="{width}" height="20" fill="url(#s)"/>' if rounded else ""
+
+    def text(x: float, value: str) -> str:
+        return (f'<text x="{x:g}" y="15" fill="#010101" fill-opacity=".3">{value}</text>'
+                f'<text x="{x:g}" y="14">{value}</text>')
+
+    return (
+        f'<svg xmlns="http://www.w3.org/2000/svg" width="{width}" height="20" role="img" aria-label="{title}">'
+        f'<title>{title}</title>{gradient}'
+        f'<clipPath id="r"><rect width="{width}" height="20" rx="{3 if rounded else 0}" fill="#fff"/></clipPath>'
+        f'<g clip-path="url(#r)"><rect width="{label_width}" height="20" fill="#555"/>'
+        f'<rect x="{label_width}" width="{message_width}" height="20" fill="{color}"/>{overlay}</g>'
+        f'<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">'
+        f'{text(label_width / 2, label_text)}{text(label_width + message_width / 2, message_text)}</g></svg>\n'
+    )
+
+
+def write_badges(svg_path: Path, coverage: float, label: str = DEFAULT_LABEL, style: str = "flat") -> Tuple[Path, Path]:
+    """Write the SVG and, next to it with a .json suffix, the shields endpoint JSON; returns both paths"""
+    message = format_coverage(coverage)
+    color_name, hex_color = badge_color(coverage)
+    json_path = svg_path.with_suffix(".json")
+    svg_path.parent.mkdir(parents=True, exist_ok=True)
+    svg_path.write_text(badge_svg(label, message, hex_color, style), encoding="utf-8")
+    with open(json_path, "w", encoding="utf-8") as f:
+        json.dump(endpoint_json(label, message, color_name), f, indent=2)
+        f.write("\n")
+    return svg_path, json_path
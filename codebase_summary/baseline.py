@@ -0,0 +1,76 @@
+#!/usr/bin/env python3
+"""
+Baseline - Suppresses undocumented functions that already existed when the baseline was taken
+Records every currently undocumented symbol; later runs fail only on undocumented symbols that are
+not in the baseline, so legacy repositories can adopt breadcrumbs gradually
+"""
+
+import datetime
+import json
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set, Tuple
+
+from doc_drift import qualified_name
+
+
+def undocumented_symbols(file_analysis: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+    """
+    Undocumented symbols with a stable key ('file::Class.method', '#2' for a repeated name) -
+    line numbers are not part of the key so unrelated edits do not invalidate the baseline
+    """
+    missing = []
+    for analysis in file_analysis:
+        occurrences: Dict[str, int] = {}
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("doc_exempt"):
+                continue
+            name = qualified_name(symbol)
+            occurrences[name] = occurrences.get(name, 0) + 1
+            if symbol.get("documented"):
+                continue
+            suffix = f"#{occurrences[name]}" if occurrences[name] > 1 else ""
+            missing.append({"key": f"{analysis['file']}::{name}{suffix}", "file": analysis["file"],
+                            "name": name, "line": symbol.get("line", 0)})
+    return missing
+
+
+def write_baseline(path: Path, file_analysis: List[Dict[str, Any]], generator: str) -> int:
+    """Record all currently undocumented symbols, grouped by file; returns how many were recorded"""
+    files: Dict[str, List[str]] = {}
+    for entry in undocumented_symbols(file_analysis):
+        files.setdefault(entry["file"], []).append(entry["key"].split("::", 1)[1])
+    data = {
+        "_generator": f"Generated by {generator} - Baseline of pre-existing undocumented symbols",
+        "generated_at": datetime.datetime.now().isoformat() + "Z",
+        "count": sum(len(names) for names in files.values()),
+        "files": files,
+    }
+    path.parent.mkdir(parents=True, exist_ok=True)
+    with open(path, "w", encoding="utf-8") as f:
+        json.dump(data, f, indent=2, sort_keys=True)
+    return data["count"]
+
+
+def load_baseline(path: Path) -> Optional[Set[str]]:
+    """Baselined symbol keys, or None when there is no (readable) baseline file"""
+    try:
+        with open(path, "r", encoding="utf-8") as f:
+            data = json.load(f)
+        return {f"{file}::{name}" for file, names in data.get("files", {}).items() for name in names}
+    except (OSError, ValueError, AttributeError) as e:
+        if path.exists():
+            print(f"⚠️ Could not read baseline {path}: {e}")
+        return None
+
+
+def compare_to_baseline(file_analysis: List[Dict[str, Any]], baselined: Set[str]) -> Tuple[List[Dict[str, Any]], int, int]:
+    """
+    # @codebase-summary: Baseline comparison
+    - Returns (new undocumented symbols, baselined symbols still undocumented, baselined symbols
+      resolved) - resolved ones were documented or removed, so the baseline can be tightened
+    """
+    missing = undocumented_symbols(file_analysis)
+    keys = {entry["key"] for entry in missing}
+    new = [entry for entry in missing if entry["key"] not in baselined]
+    remaining = len(keys & baselined)
+    return new, remaining, len(baselined - keys)
@@ -0,0 +1,91 @@
+#!/usr/bin/env python3
+"""
+Breadcrumb Styles - Per-language documentation conventions for the coverage checker
+Teams configure in workflow_config.json which markers count as a breadcrumb ("scanner":
+{"breadcrumbs": {...}}), whether native doc comments count, and the stub template 'annotate' writes
+"""
+
+import json
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+DEFAULT_MARKER = '@codebase-summary:'
+
+# Style used when nothing is configured: only the default marker and native doc comments count
+DEFAULT_STYLE = {"patterns": [], "default_marker": True, "native_docs": True, "template": None}
+
+
+def _compile_style(definition: Any) -> Dict[str, Any]:
+    """One configured style; a bare list is shorthand for {"patterns": [...]}"""
+    if isinstance(definition, list):
+        definition = {"patterns": definition}
+    if not isinstance(definition, dict):
+        raise ValueError("style must be an object or a list of patterns")
+    patterns = []
+    for pattern in definition.get("patterns", []):
+        try:
+            patterns.append(re.compile(pattern))
+        except (re.error, TypeError) as e:
+            raise ValueError(f"invalid breadcrumb pattern {pattern!r}: {e}")
+    template = definition.get("template")
+    if isinstance(template, str):
+        template = [template]
+    if template is not None and (not isinstance(template, list) or not all(isinstance(t, str) for t in template)):
+        raise ValueError("'template' must be a string or a list of strings")
+    style = {
+        "patterns": patterns,
+        "default_marker": bool(definition.get("default_marker", True)),
+        "native_docs": bool(definition.get("native_docs", True)),
+        "template": template,
+    }
+    if not style["patterns"] and not style["default_marker"] and not style["native_docs"]:
+        raise ValueError("nothing would count as documented - add patterns or keep the default marker")
+    return style
+
+
+def load_breadcrumb_styles(config_path: Path) -> Dict[str, Dict[str, Any]]:
+    """
+    # @codebase-summary: Breadcrumb styles from workflow_config.json
+    - Reads "scanner": {"breadcrumbs": {"<language>" | "*": {...}}}; "*" applies to every
+      language without its own entry
+    - patterns: regexes that mark a breadcrumb near a declaration (same window as the default
+      marker); default_marker / native_docs (default true) keep '@codebase-summary:' and native
+      doc comments (Javadoc, docstrings...) counting; template: lines 'annotate' inserts
+    - Invalid styles are reported and skipped; returns the compiled styles by language key
+    """
+    try:
+        with open(config_path, "r", encoding="utf-8") as f:
+            config = json.load(f)
+    except (OSError, ValueError):
+        return {}
+    definitions = ((config.get("scanner") or {}).get("breadcrumbs") or {}) if isinstance(config, dict) else {}
+    return compile_breadcrumb_styles(definitions)
+
+
+def compile_breadcrumb_styles(definitions: Dict[str, Any]) -> Dict[str, Dict[str, Any]]:
+    """Compiled styles by language key from {"<language>" | "*": definition}; invalid ones are reported and skipped"""
+    styles = {}
+    for language, definition in sorted(definitions.items()):
+        try:
+            styles[language] = _compile_style(definition)
+        except ValueError as e:
+            print(f"⚠️ Skipping breadcrumb style '{language}': {e}")
+            continue
+        styles[language]["definition"] = definition
+    return styles
+
+
+def style_for(styles: Dict[str, Dict[str, Any]], language: Optional[str]) -> Dict[str, Any]:
+    """The style for a language key: its own entry, else "*", else the default convention"""
+    return styles.get(language) or styles.get("*") or DEFAULT_STYLE
+
+
+def has_breadcrumb(lines: List[str], index: int, style: Dict[str, Any]) -> bool:
+    """Check a style's markers in the search window around a declaration line (5 above, 2 below)"""
+    for j in range(max(0, index - 5), min(len(lines), index + 3)):
+        if style["default_marker"] and DEFAULT_MARKER in lines[j]:
+            return True
+        if any(pattern.search(lines[j]) for pattern in style["patterns"]):
+            return True
+    return False
@@ -0,0 +1,57 @@
+#!/usr/bin/env python3
+"""
+Build Inventory - Bazel rules, macros, and BUILD targets across the scan
+Aggregates the Starlark extractor's build symbols into a summary section
+"""
+
+from collections import Counter
+from pathlib import Path
+from typing import Dict, Any, List
+
+from memory_budget import in_file_order
+
+_RULE_KINDS = ("rule", "repository_rule", "aspect", "provider", "module_extension")
+
+
+def build_build_inventory(file_analysis: List[Dict[str, Any]], max_listed: int = 50) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Bazel build summary for codebase_summary.json
+    - Lists rule, repository_rule, aspect, provider, and module_extension definitions from .bzl
+      files with their implementation function and attrs, plus public macros
+    - Counts BUILD targets per rule and lists the packages (directories) that declare them
+    - Returns an empty dict when the scan contains no Starlark build symbols
+    """
+    rules = []
+    macros = []
+    targets_by_rule = Counter()
+    packages = set()
+    files = set()
+
+    for analysis in in_file_order(file_analysis):
+        for symbol in analysis.get("symbols", []):
+            if not symbol.get("build"):
+                continue
+            kind = symbol.get("kind")
+            files.add(analysis["file"])
+            if kind in _RULE_KINDS:
+                entry = {"name": symbol["name"], "kind": kind, "file": analysis["file"], "line": symbol.get("line", 0)}
+                for key in ("implementation", "attrs", "fields"):
+                    if key in symbol:
+                        entry[key] = symbol[key]
+                rules.append(entry)
+            elif kind == "macro":
+                macros.append({"name": symbol["name"], "file": analysis["file"], "line": symbol.get("line", 0)})
+            elif kind == "target":
+                targets_by_rule[symbol["rule"]] += 1
+                packages.add(Path(analysis["file"]).parent.as_posix())
+
+    if not files:
+        return {}
+    return {
+        "files": len(files),
+        "rules": rules[:max_listed],
+        "macros": macros[:max_listed],
+        "targets": sum(targets_by_rule.values()),
+        "targets_by_rule": dict(sorted(targets_by_rule.items(), key=lambda t: (-t[1], t[0]))),
+        "packages": sorted(packages)[:max_listed]
+    }
@@ -0,0 +1,151 @@
+#!/usr/bin/env python3
+"""
+Call Graph - Intra-package call graphs built from the go/ast helper's call sites
+Resolves calls across the files of each Go package and exports the graph as JSON or DOT
+"""
+
+import json
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List
+
+from memory_budget import in_file_order
+
+# Functions the runtime or toolchain calls, so they are never orphaned
+_GO_ENTRY_POINTS = {"main", "init"}
+_GO_TEST_PREFIXES = ("Test", "Benchmark", "Example", "Fuzz")
+
+
+def _go_node_id(symbol: Dict[str, Any]) -> str:
+    """'Func' for functions, 'Type.Method' for methods (pointer and type params stripped)"""
+    if symbol.get("kind") == "method":
+        receiver = symbol.get("receiver", "").lstrip("*").split("[")[0]
+        return f"{receiver}.{symbol['name']}"
+    return symbol["name"]
+
+
+def _is_go_orphan(node: Dict[str, Any]) -> bool:
+    """Unexported, never called within its package, and not an entry point"""
+    name = node["name"]
+    return (not node["calls_in"] and not name[:1].isupper()
+            and name not in _GO_ENTRY_POINTS and not name.startswith(_GO_TEST_PREFIXES))
+
+
+def build_go_call_graph(file_analysis: List[Dict[str, Any]]) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Intra-package Go call graph builder
+    - Groups Go files by directory and package clause, then resolves each call site
+      against the package's functions (plain calls) and methods (calls on the receiver)
+    - Marks unexported functions with no callers as orphaned helpers
+    - Files parsed by the regex fallback carry no call data and are listed as unresolved
+    """
+    packages = defaultdict(lambda: {"files": [], "symbols": []})
+    unresolved_files = []
+    for analysis in in_file_order(file_analysis):
+        if analysis.get("language") != ".go":
+            continue
+        functions = [s for s in analysis.get("symbols", []) if s.get("kind") in ("function", "method")]
+        if functions and not any("package" in s for s in functions):
+            unresolved_files.append(analysis["file"])
+            continue
+        for symbol in functions:
+            key = (Path(analysis["file"]).parent.as_posix(), symbol.get("package", ""))
+            packages[key]["symbols"].append((analysis["file"], symbol))
+        if functions:
+            key = (Path(analysis["file"]).parent.as_posix(), functions[0].get("package", ""))
+            packages[key]["files"].append(analysis["file"])
+
+    result = []
+    for (directory, package), data in sorted(packages.items()):
+        nodes = {}
+        for file_path, symbol in data["symbols"]:
+            node_id = _go_node_id(symbol)
+            nodes[node_id] = {
+                "id": node_id, "name": symbol["name"], "kind": symbol["kind"], "file": file_path,
+                "line": symbol.get("line", 0), "exported": symbol["name"][:1].isupper(),
+                "calls_out": 0, "calls_in": 0
+            }
+
+        edges = set()
+        for file_path, symbol in data["symbols"]:
+            caller = _go_node_id(symbol)
+            for call in symbol.get("calls", []):
+                callee = f"{call['recv']}.{call['name']}" if call.get("recv") else call["name"]
+                if callee in nodes and nodes[callee]["kind"] == ("method" if call.get("recv") else "function"):
+                    edges.add((caller, callee))
+
+        for caller, callee in edges:
+            nodes[caller]["calls_out"] += 1
+            nodes[callee]["calls_in"] += 1
+
+        node_list = sorted(nodes.values(), key=lambda n: (n["file"], n["line"]))
+        result.append({
+            "directory": directory,
+            "package": package,
+            "files": data["files"],
+            "nodes": node_list,
+            "edges": [{"from": caller, "to": callee} for caller, callee in sorted(edges)],
+            "orphans": [n["id"] for n in node_list if _is_go_orphan(n)]
+        })
+
+    return {"packages": result, "unresolved_files": unresolved_files}
+
+
+def summarize_call_graph(graph: Dict[str, Any], max_orphans: int = 20) -> Dict[str, Any]:
+    """Compact statistics for codebase_summary.json"""
+    orphans = [
+        f"{pkg['directory']}:{orphan}" for pkg in graph["packages"] for orphan in pkg["orphans"]
+    ]
+    return {
+        "packages": len(graph["packages"]),
+        "functions": sum(len(pkg["nodes"]) for pkg in graph["packages"]),
+        "edges": sum(len(pkg["edges"]) for pkg in graph["packages"]),
+        "orphaned_helpers": orphans[:max_orphans],
+        "orphaned_count": len(orphans),
+        "unresolved_files": len(graph["unresolved_files"])
+    }
+
+
+def _dot_quote(text: str) -> str:
+    """Quote an identifier for DOT"""
+    escaped = text.replace('\\', '\\\\').replace('"', '\\"')
+    return f'"{escaped}"'
+
+
+def build_call_graph_dot(graph: Dict[str, Any]) -> str:
+    """
+    # @codebase-summary: Graphviz DOT rendering of the Go call graph
+    - One cluster per package; node ids are qualified by directory so packages never collide
+    - Exported functions are drawn bold, orphaned helpers dashed
+    """
+    lines = ["digraph go_call_graph {", "  rankdir=LR;", '  node [shape=box, fontname="Helvetica"];']
+    for index, pkg in enumerate(graph["packages"]):
+        orphans = set(pkg["orphans"])
+        label = f"{pkg['package']} ({pkg['directory']})"
+        lines.append(f"  subgraph cluster_{index} {{")
+        lines.append(f"    label={_dot_quote(label)};")
+        for node in pkg["nodes"]:
+            style = "dashed" if node["id"] in orphans else "bold" if node["exported"] else "solid"
+            qualified = _dot_quote(f"{pkg['directory']}:{node['id']}")
+            lines.append(f"    {qualified} [label={_dot_quote(node['id'])}, style={style}];")
+        lines.append("  }")
+        for edge in pkg["edges"]:
+            lines.append(f"  {_dot_quote(pkg['directory'] + ':' + edge['from'])} -> "
+                         f"{_dot_quote(pkg['directory'] + ':' + edge['to'])};")
+    lines.append("}")
+    return "\n".join(lines) + "\n"
+
+
+def write_call_graph(output_dir: Path, graph: Dict[str, Any]) -> List[Path]:
+    """Write go_call_graph.json and go_call_graph.dot, returning the written paths"""
+    output_dir.mkdir(parents=True, exist_ok=True)
+    json_path = output_dir / "go_call_graph.json"
+    dot_path = output_dir / "go_call_graph.dot"
+    with open(json_path, 'w', encoding='utf-8') as f:
+        json.dump({
+            "_generator": "Generated by codebase_summary/call_graph.py - Go intra-package call graph",
+            **graph
+        }, f, indent=2)
+    with open(dot_path, 'w', encoding='utf-8') as f:
+        f.write(build_call_graph_dot(graph))
+    return [json_path, dot_path]
@@ -0,0 +1,121 @@
+#!/usr/bin/env python3
+"""
+Churn - Per-file and per-function edit history from git, combined with complexity into hotspots (--churn)
+Files get commit counts from one git log pass; the most-edited files are then blamed so their functions
+get the number of commits behind their current lines. Frequently edited, complex, undocumented
+functions rank highest
+"""
+
+import datetime
+import subprocess
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from todo_comments import blame_file
+
+DEFAULT_WINDOW_DAYS = 90
+# Files blamed for per-function churn (the most-committed ones); blame is the expensive part
+BLAMED_FILES = 50
+# Undocumented functions weigh this much more in the hotspot score
+UNDOCUMENTED_WEIGHT = 2
+
+
+def file_churn(project_root: Path, files: List[str], window_days: int,
+               now: Optional[datetime.datetime] = None) -> Dict[str, Dict[str, Any]]:
+    """
+    # @codebase-summary: Commit counts and line changes per scanned file from git log --numstat
+    - recent_commits counts commits in the last window_days; merges are skipped and renames are
+      not followed, so a renamed file's history starts at the rename
+    - Raises RuntimeError when git log fails (not a repository, git missing)
+    """
+    now = now or datetime.datetime.now(datetime.timezone.utc)
+    cutoff = (now - datetime.timedelta(days=window_days)).timestamp()
+    try:
+        result = subprocess.run(["git", "log", "--no-merges", "--no-renames", "--relative", "--format=@%H %at",
+                                 "--numstat"], cwd=project_root, capture_output=True, text=True, timeout=300)
+    except (OSError, subprocess.SubprocessError) as e:
+        raise RuntimeError(str(e))
+    if result.returncode != 0:
+        raise RuntimeError(result.stderr.strip() or "git log failed")
+    wanted = set(files)
+    churn: Dict[str, Dict[str, Any]] = {}
+    timestamp = 0
+    for line in result.stdout.splitlines():
+        if line.startswith("@"):
+            timestamp = int(line.split()[1])
+            continue
+        parts = line.split("\t")
+        if len(parts) != 3 or parts[2] not in wanted:
+            continue
+        added, deleted, file = parts
+        entry = churn.setdefault(file, {"file": file, "commits": 0, "recent_commits": 0, "lines_added": 0,
+                                        "lines_deleted": 0, "last_modified": timestamp})
+        entry["commits"] += 1
+        entry["recent_commits"] += timestamp >= cutoff
+        entry["lines_added"] += int(added) if added.isdigit() else 0
+        entry["lines_deleted"] += int(deleted) if deleted.isdigit() else 0
+        entry["last_modified"] = max(entry["last_modified"], timestamp)
+    for entry in churn.values():
+        entry["last_modified"] = datetime.datetime.fromtimestamp(
+            entry["last_modified"], datetime.timezone.utc).strftime("%Y-%m-%d")
+    return churn
+
+
+def function_churn(project_root: Path, analysis: Dict[str, Any], cutoff: float) -> List[Dict[str, Any]]:
+    """(commits, recent_commits) behind each function's current lines, from one git blame of the file"""
+    blamed = blame_file(project_root, analysis["file"], None)
+    functions = []
+    for symbol in analysis.get("symbols", []):
+        start, end = symbol.get("line", 0), symbol.get("end_line") or symbol.get("line", 0) + symbol.get("length", 1) - 1
+        if "complexity" not in symbol or symbol.get("doc_exempt") or not start:
+            continue
+        lines = [blamed[number] for number in range(start, end + 1) if number in blamed]
+        commits = {line["sha"] for line in lines if not line["uncommitted"]}
+        recent = {line["sha"] for line in lines if not line["uncommitted"] and line.get("time", 0) >= cutoff}
+        functions.append({"file": analysis["file"], "name": symbol["name"], "line": start,
+                          "complexity": symbol["complexity"], "documented": bool(symbol.get("documented")),
+                          "commits": len(commits), "recent_commits": len(recent)})
+    return functions
+
+
+def hotspot_score(function: Dict[str, Any]) -> int:
+    """(commits + recent commits) x complexity, doubled for undocumented functions"""
+    weight = 1 if function["documented"] else UNDOCUMENTED_WEIGHT
+    return (function["commits"] + function["recent_commits"]) * function["complexity"] * weight
+
+
+def analyze_churn(project_root: Path, file_analysis: List[Dict[str, Any]], files: List[str],
+                  window_days: int = DEFAULT_WINDOW_DAYS, top: int = 20) -> Tuple[Optional[Dict[str, Any]], Optional[str]]:
+    """
+    # @codebase-summary: Churn section: most-edited files and per-function hotspots
+    - files are the scanned source files; the BLAMED_FILES most-committed ones with functions are blamed
+    - Hotspots rank functions by hotspot_score; undocumented_hotspots counts the undocumented ones among them
+    - Returns (section, None), or (None, error message) when git history is unavailable
+    """
+    now = datetime.datetime.now(datetime.timezone.utc)
+    try:
+        churn = file_churn(project_root, files, window_days, now)
+    except RuntimeError as e:
+        return None, f"git log unavailable: {e}"
+    cutoff = (now - datetime.timedelta(days=window_days)).timestamp()
+    ranked_files = sorted(churn.values(), key=lambda f: (-f["commits"], -f["recent_commits"], f["file"]))
+    analyses = {analysis["file"]: analysis for analysis in file_analysis}
+    blamed = [entry["file"] for entry in ranked_files if entry["file"] in analyses][:BLAMED_FILES]
+    functions = []
+    for file in blamed:
+        try:
+            functions.extend(function_churn(project_root, analyses[file], cutoff))
+        except (RuntimeError, OSError, subprocess.SubprocessError, ValueError):
+            continue
+    for function in functions:
+        function["hotspot_score"] = hotspot_score(function)
+    hotspots = sorted((f for f in functions if f["hotspot_score"]),
+                      key=lambda f: (-f["hotspot_score"], f["file"], f["line"]))[:top]
+    return {
+        "window_days": window_days,
+        "files_with_history": len(churn),
+        "blamed_files": len(blamed),
+        "files": ranked_files[:top],
+        "hotspots": hotspots,
+        "undocumented_hotspots": sum(1 for f in hotspots if not f["documented"]),
+    }, None
@@ -0,0 +1,127 @@
+#!/usr/bin/env python3
+"""
+Code Owners - CODEOWNERS (GitHub and GitLab formats) ownership for files, symbols, and coverage gaps
+Every scanned file is attributed to its owners, coverage is rolled up per owner, and owners can be
+given their own --min-coverage threshold ('--min-coverage 70,@acme/payments=90')
+"""
+
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from ignore_files import glob_to_regex
+from text_encoding import is_file
+
+# Where GitHub and GitLab look for the file, in their order of precedence
+CODEOWNERS_LOCATIONS = (".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS")
+UNOWNED = "(unowned)"
+
+# GitLab section headers: '[Section]', '^[Optional]', '[Section][2]', optionally followed by default owners
+_SECTION = re.compile(r"^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$")
+_OWNER = re.compile(r"^(?:@[\w.\-/]+|[\w.+\-]+@[\w\-]+(?:\.[\w\-]+)+)$")
+
+# (section, path regex, owners)
+Rule = Tuple[str, "re.Pattern", List[str]]
+
+
+def _pattern_regex(pattern: str) -> "re.Pattern":
+    """
+    Regex for a CODEOWNERS path pattern on a file path relative to the repository root.
+    Like gitignore, a pattern without an inner '/' matches at any depth and a match on a directory owns
+    everything below it; 'docs/*' owns only the files directly in docs/
+    """
+    directories_only = pattern.endswith("/")
+    pattern = pattern.rstrip("/")
+    anchored = "/" in pattern
+    body = glob_to_regex(pattern.lstrip("/"))
+    if pattern.endswith("/*"):
+        suffix = "$"
+    elif directories_only:
+        suffix = "/.*$"
+    else:
+        suffix = "(?:/.*)?$"
+    return re.compile(("" if anchored else "(?:.*/)?") + body + suffix)
+
+
+def _split(line: str) -> List[str]:
+    """Whitespace-separated fields, keeping '\\ ' escaped spaces inside a pattern"""
+    return [field.replace("\\ ", " ") for field in re.split(r"(?<!\\)\s+", line.strip()) if field]
+
+
+def parse_codeowners(text: str) -> List[Rule]:
+    """
+    # @codebase-summary: CODEOWNERS rules in file order
+    - '#' starts a comment (escape a leading '#' in a pattern as '\\#'); owners are @user, @org/team,
+      or email addresses
+    - GitLab sections ('[Docs] @docs-team') give their default owners to entries listing none; entries
+      before the first section belong to the unnamed section, as in GitHub's single-section files
+    """
+    rules: List[Rule] = []
+    section, defaults = "", []
+    for raw in text.split("\n"):
+        line = raw.strip()
+        if not line or line.startswith("#"):
+            continue
+        line = re.sub(r"\s+#.*$", "", line)
+        header = _SECTION.match(line)
+        if header:
+            section = header.group(1).strip().lower()
+            defaults = [owner for owner in header.group(2).split() if _OWNER.match(owner)]
+            continue
+        fields = _split(line)
+        pattern = fields[0][1:] if fields[0].startswith("\\#") else fields[0]
+        owners = [owner for owner in fields[1:] if _OWNER.match(owner)]
+        rules.append((section, _pattern_regex(pattern), (owners or list(defaults)) if section else owners))
+    return rules
+
+
+def find_codeowners(project_root: Path) -> Optional[Path]:
+    """The CODEOWNERS file GitHub/GitLab would use for the project, or None"""
+    return next((project_root / location for location in CODEOWNERS_LOCATIONS
+                 if is_file(project_root / location)), None)
+
+
+def owners_of(rules: List[Rule], file: str) -> List[str]:
+    """
+    Owners of a file: the last matching rule of each section (GitHub has one), combined across
+    sections in file order. A last match without owners leaves the file unowned in that section
+    """
+    matched: Dict[str, List[str]] = {}
+    for section, regex, owners in rules:
+        if regex.match(file):
+            matched[section] = owners
+    combined: List[str] = []
+    for owners in matched.values():
+        combined.extend(owner for owner in owners if owner not in combined)
+    return combined
+
+
+def summarize_ownership(file_analysis: List[Dict[str, Any]], source_files: List[Dict[str, Any]],
+                        owners_by_file: Dict[str, List[str]], codeowners_file: str, rule_count: int) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Ownership section: per-owner coverage rollup and every file's owners
+    - A file with several owners counts toward each of them; files no rule owns roll up as '(unowned)'
+    - by_owner is sorted by coverage, lowest first, so the least documented team leads
+    """
+    counts = {analysis["file"]: analysis for analysis in file_analysis}
+    by_owner: Dict[str, Dict[str, Any]] = {}
+    for source in source_files:
+        analysis = counts.get(source["file"], {})
+        for owner in owners_by_file.get(source["file"]) or [UNOWNED]:
+            totals = by_owner.setdefault(owner, {"files": 0, "functions": 0, "documented": 0})
+            totals["files"] += 1
+            totals["functions"] += analysis.get("function_count", 0)
+            totals["documented"] += analysis.get("documented_count", 0)
+    for totals in by_owner.values():
+        totals["undocumented"] = totals["functions"] - totals["documented"]
+        totals["coverage_percentage"] = round(totals["documented"] / totals["functions"] * 100, 2) \
+            if totals["functions"] else 100.0
+    return {
+        "codeowners_file": codeowners_file,
+        "rules": rule_count,
+        "owners": len([owner for owner in by_owner if owner != UNOWNED]),
+        "unowned_files": by_owner.get(UNOWNED, {}).get("files", 0),
+        "by_owner": dict(sorted(by_owner.items(), key=lambda item: (item[1]["coverage_percentage"],
+                                                                    -item[1]["undocumented"], item[0]))),
+        "files": {file: owners for file, owners in sorted(owners_by_file.items())},
+    }
@@ -0,0 +1,307 @@
+#!/usr/bin/env python3
+"""
+Complexity Analyzer - Cyclomatic and cognitive complexity for detected functions
+Locates each function body (braces or indentation) and counts its decision points, length, and nesting
+"""
+
+import re
+from typing import Dict, Any, List, Optional, Tuple
+
+from language_extractors import (
+    BraceScopeTracker, _CHAR_LITERAL, _STRING_LITERAL, _SINGLE_QUOTED, _TEMPLATE_LITERAL
+)
+
+# Symbol kinds that have a body worth scoring
+FUNCTION_KINDS = {
+    "function", "method", "constructor", "destructor", "operator", "property", "indexer", "getter", "setter",
+    "initializer", "deinitializer", "subscript", "extension_function", "trait_method", "component", "hook",
+    "computed", "composable"
+}
+
+# Declaration lines the regex fallback reports as "function" but that declare types
+_TYPE_DECLARATION = re.compile(r'\b(?:class|struct|interface|enum|trait|protocol|object|record)\s+[A-Za-z_]')
+
+_INDENTED_LANGUAGES = {"python", "ruby", "lua", "elixir"}
+
+# Control structures: each adds one path (cyclomatic) and is a nesting-aware increment (cognitive)
+_CONTROL_KEYWORDS = {
+    "python": r'\b(?:if|elif|for|while|except|case)\b',
+    "ruby": r'\b(?:if|elsif|unless|while|until|for|when|rescue)\b',
+    "elixir": r'\b(?:if|unless|case|cond|with|rescue|catch)\b',
+    "lua": r'\b(?:if|elseif|for|while|until)\b',
+    "shell": r'\b(?:if|elif|for|while|until)\b',
+    "powershell": r'(?i)\b(?:if|elseif|for|foreach|while|until|catch|switch)\b',
+    "kotlin": r'\b(?:if|for|while|catch|when)\b',
+    "swift": r'\b(?:if|for|while|case|catch|guard)\b',
+    "default": r'\b(?:if|for|foreach|while|case|catch)\b',
+}
+_LOGICAL_OPERATORS = {
+    "python": r'\b(?:and|or)\b',
+    "ruby": r'&&|\|\||\b(?:and|or)\b',
+    "elixir": r'&&|\|\||\b(?:and|or)\b',
+    "lua": r'\b(?:and|or)\b',
+    "powershell": r'(?i)-(?:and|or)\b',
+    "default": r'&&|\|\|',
+}
+# '?' used as a conditional operator - not '?.', '??', '?:' (optional params) or Rust's 'expr?'
+_TERNARY = re.compile(r'(?<![?\w)\]])\s\?\s')
+_ELSE_BRANCH = re.compile(r'\belse\b')
+
+_LITERALS = {
+    "rust": (_CHAR_LITERAL, _STRING_LITERAL),
+    "go": (_CHAR_LITERAL, _STRING_LITERAL, _TEMPLATE_LITERAL),
+    "c": (_CHAR_LITERAL, _STRING_LITERAL),
+    "cpp": (_CHAR_LITERAL, _STRING_LITERAL),
+    "objc": (_CHAR_LITERAL, _STRING_LITERAL),
+    "default": (_STRING_LITERAL, _SINGLE_QUOTED, _TEMPLATE_LITERAL),
+}
+_LINE_COMMENTS = {"python": '#', "ruby": '#', "elixir": '#', "shell": '#', "powershell": '#', "r": '#', "lua": '--'}
+
+
+def clean_lines(lines: List[str], language: str) -> List[str]:
+    """Lines with comments and string literals removed, including Python triple-quoted blocks"""
+    if language == "python":
+        cleaned = []
+        in_docstring = None
+        for line in lines:
+            text = line
+            if in_docstring:
+                end = text.find(in_docstring)
+                if end == -1:
+                    cleaned.append('')
+                    continue
+                text = text[end + 3:]
+                in_docstring = None
+            text = re.sub(r'("""|\'\'\')(?:.*?)\1', '""', text)
+            start = re.search(r'"""|\'\'\'', text)
+            if start:
+                in_docstring = start.group(0)
+                text = text[:start.start()]
+            text = _SINGLE_QUOTED.sub("''", _STRING_LITERAL.sub('""', text))
+            cleaned.append(text.split('#', 1)[0])
+        return cleaned
+
+    block = None if language in _LINE_COMMENTS else ('/*', '*/')
+    tracker = BraceScopeTracker(
+        line_comment=_LINE_COMMENTS.get(language, '//'),
+        block_comment=block,
+        literals=_LITERALS.get(language, _LITERALS["default"])
+    )
+    return [tracker.clean(line) for line in lines]
+
+
+def indent_width(line: str) -> int:
+    """Leading whitespace characters of a line"""
+    return len(line) - len(line.lstrip())
+
+
+def _brace_body(cleaned: List[str], start: int) -> Optional[Tuple[int, int]]:
+    """(first, last) line indexes of the brace-delimited body starting at a declaration"""
+    depth = 0
+    opened = None
+    for i in range(start, min(len(cleaned), start + 2000)):
+        for ch in cleaned[i]:
+            if ch == '{':
+                depth += 1
+                if opened is None:
+                    opened = i
+            elif ch == '}' and opened is not None:
+                depth -= 1
+                if depth == 0:
+                    return opened, i
+            elif ch == ';' and opened is None:
+                return None  # Prototype or abstract declaration without a body
+        if opened is None and i - start > 10:
+            return None
+    return (opened, len(cleaned) - 1) if opened is not None else None
+
+
+def _indented_body(cleaned: List[str], start: int) -> Optional[Tuple[int, int]]:
+    """(first, last) line indexes of an indentation-delimited body"""
+    base = indent_width(cleaned[start])
+    header_end = start
+    # Python signatures may span lines - the body starts after the line closing the parameters
+    depth = 0
+    for i in range(start, min(len(cleaned), start + 30)):
+        depth += cleaned[i].count('(') - cleaned[i].count(')')
+        header_end = i
+        if depth <= 0:
+            break
+    last = header_end
+    for i in range(header_end + 1, len(cleaned)):
+        if not cleaned[i].strip():
+            continue
+        if indent_width(cleaned[i]) <= base:
+            break
+        last = i
+    return (header_end, last)
+
+
+def function_body(cleaned: List[str], start: int, language: str) -> Optional[Tuple[int, int]]:
+    """(first, last) line indexes of the body of the function declared at cleaned[start] (see clean_lines), or None"""
+    return _indented_body(cleaned, start) if language in _INDENTED_LANGUAGES else _brace_body(cleaned, start)
+
+
+def score_function(cleaned: List[str], index: int, language: str) -> Optional[Dict[str, int]]:
+    """
+    # @codebase-summary: Per-function cyclomatic and cognitive complexity
+    - Cyclomatic: 1 + control structures + logical operators + conditional expressions
+    - Cognitive (approximate): control structures cost 1 + their nesting depth inside the
+      function, else branches and logical operators cost 1 without nesting penalty
+    - length is the line span from declaration to body end; max_nesting the deepest control
+      structure (1 for an if directly in the body, 2 for a loop inside it)
+    - Returns None when no body can be located (prototypes, abstract or interface methods)
+    """
+    indented = language in _INDENTED_LANGUAGES
+    body = function_body(cleaned, index, language)
+    if body is None:
+        return None
+    first, last = body
+
+    control = re.compile(_CONTROL_KEYWORDS.get(language, _CONTROL_KEYWORDS["default"]))
+    logical = re.compile(_LOGICAL_OPERATORS.get(language, _LOGICAL_OPERATORS["default"]))
+
+    cyclomatic = 1
+    cognitive = 0
+    max_nesting = 0
+    # Indentation of the enclosing control blocks (indented languages), so continuation lines don't nest
+    blocks: List[int] = []
+    depth = 0
+    body_indent = None
+    indent_unit = None
+    for i in range(first, last + 1):
+        text = cleaned[i]
+        # The declaration line itself contributes no decisions
+        scan = text.split('{', 1)[1] if (i == first and not indented and '{' in text) else text
+        if i == first and indented:
+            scan = ''
+
+        if indented:
+            if text.strip() and i > first:
+                if body_indent is None:
+                    body_indent = indent_width(text)
+                extra = indent_width(text) - body_indent
+                if extra > 0 and indent_unit is None:
+                    indent_unit = extra
+                nesting = extra // indent_unit if indent_unit else 0
+            else:
+                nesting = 0
+        else:
+            nesting = max(0, depth - 1)
+
+        controls = len(control.findall(scan))
+        branches = len(_ELSE_BRANCH.findall(scan))
+        logicals = len(logical.findall(scan))
+        ternaries = len(_TERNARY.findall(scan)) if language not in ("python", "lua", "shell") else 0
+
+        cyclomatic += controls + logicals + ternaries
+        if indented and i > first and text.strip():
+            while blocks and blocks[-1] >= indent_width(text):
+                blocks.pop()
+            if control.match(text.lstrip()):
+                blocks.append(indent_width(text))
+                max_nesting = max(max_nesting, len(blocks))
+        elif controls and not indented:
+            max_nesting = max(max_nesting, nesting + 1)
+        # 'else if' already counts as a control structure; plain else adds a flat increment
+        cognitive += controls * (1 + nesting) + max(0, branches - controls) + logicals + ternaries * (1 + nesting)
+
+        if not indented:
+            depth += text.count('{') - text.count('}')
+
+    return {"complexity": cyclomatic, "cognitive_complexity": cognitive, "length": last - index + 1,
+            "max_nesting": max_nesting}
+
+
+def annotate_complexity(symbols: List[Dict[str, Any]], lines: List[str], language: str):
+    """
+    Add complexity, cognitive_complexity, length, and max_nesting to function-like symbols in place.
+    Symbols that already carry scores (e.g. from the go/ast helper) keep them; their length comes from end_line.
+    """
+    if language in ("css", "sql", "clojure"):
+        return
+    cleaned = None
+    for symbol in symbols:
+        if "complexity" in symbol and symbol.get("end_line") and "length" not in symbol:
+            symbol["length"] = symbol["end_line"] - symbol.get("line", symbol["end_line"]) + 1
+            symbol.setdefault("max_nesting", 0)
+        # Single-file component wrappers (Vue/Svelte) have no function body of their own
+        if symbol.get("kind") not in FUNCTION_KINDS or "complexity" in symbol or symbol.get("sfc"):
+            continue
+        index = symbol.get("line", 0) - 1
+        if not 0 <= index < len(lines) or _TYPE_DECLARATION.search(lines[index]):
+            continue
+        if cleaned is None:
+            cleaned = clean_lines(lines, language)
+        scores = score_function(cleaned, index, language)
+        if scores:
+            symbol.update(scores)
+
+
+def summarize_complexity(file_analysis: List[Dict[str, Any]], max_complexity: Optional[int] = None,
+                         top: int = 10) -> Dict[str, Any]:
+    """Summary section: distribution, the most complex functions, and --max-complexity offenders"""
+    scored = [
+        {"file": a["file"], "name": s["name"], "line": s.get("line", 0),
+         "complexity": s["complexity"], "cognitive_complexity": s.get("cognitive_complexity", 0)}
+        for a in file_analysis for s in a.get("symbols", []) if "complexity" in s
+    ]
+    scored.sort(key=lambda f: (-f["complexity"], -f["cognitive_complexity"], f["file"], f["line"]))
+    summary = {
+        "functions_scored": len(scored),
+        "average_complexity": round(sum(f["complexity"] for f in scored) / len(scored), 2) if scored else 0,
+        "max_complexity": scored[0]["complexity"] if scored else 0,
+        "most_complex": scored[:top]
+    }
+    if max_complexity is not None:
+        offenders = [f for f in scored if f["complexity"] > max_complexity]
+        summary["threshold"] = max_complexity
+        summary["offender_count"] = len(offenders)
+        summary["offenders"] = offenders[:50]
+    return summary
+
+
+# Per-function limits: metric -> (symbol field, CLI option)
+FUNCTION_LIMITS = {
+    "length": ("length", "--max-function-length"),
+    "params": ("param_count", "--max-params"),
+    "nesting": ("max_nesting", "--max-nesting"),
+}
+
+
+def summarize_function_metrics(file_analysis: List[Dict[str, Any]], limits: Dict[str, int],
+                               top: int = 10) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Summary section for function length, parameter count, and nesting depth
+    - Averages and maxima over functions with a located body, plus the longest functions
+    - With limits (--max-function-length, --max-params, --max-nesting), one warning per function and
+      exceeded metric; warnings never fail the scan
+    """
+    measured = []
+    for analysis in file_analysis:
+        for symbol in analysis.get("symbols", []):
+            if "length" not in symbol or symbol.get("doc_exempt"):
+                continue
+            measured.append({"file": analysis["file"], "name": symbol["name"], "line": symbol.get("line", 0),
+                             "length": symbol["length"], "param_count": len(symbol.get("params") or []),
+                             "max_nesting": symbol.get("max_nesting", 0)})
+
+    def stats(field: str) -> Dict[str, Any]:
+        values = [m[field] for m in measured]
+        return {"average": round(sum(values) / len(values), 2) if values else 0, "max": max(values, default=0)}
+
+    summary = {
+        "functions_measured": len(measured),
+        "length": stats("length"),
+        "params": stats("param_count"),
+        "nesting": stats("max_nesting"),
+        "longest": sorted(measured, key=lambda m: (-m["length"], m["file"], m["line"]))[:top],
+    }
+    if limits:
+        warnings = [{"file": m["file"], "name": m["name"], "line": m["line"], "metric": metric,
+                     "value": m[FUNCTION_LIMITS[metric][0]], "limit": limit}
+                    for m in measured for metric, limit in limits.items() if m[FUNCTION_LIMITS[metric][0]] > limit]
+        summary["limits"] = limits
+        summary["warning_count"] = len(warnings)
+        summary["warnings"] = warnings[:50]
+    return summary
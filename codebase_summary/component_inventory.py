@@ -0,0 +1,49 @@
+#!/usr/bin/env python3
+"""
+Component Inventory - Single-file UI components (Vue, Svelte) with their public interface
+Summarizes the component symbols the SFC extractors attach to each scanned file
+"""
+
+from typing import Dict, Any, List
+
+from memory_budget import in_file_order
+
+# Extension -> framework reported for single-file components
+SFC_FRAMEWORKS = {".vue": "vue", ".svelte": "svelte"}
+
+
+def build_component_inventory(file_analysis: List[Dict[str, Any]], max_listed: int = 100) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Single-file component summary for codebase_summary.json
+    - One entry per component file with its name (declared, or the file name), props, emits,
+      and composables used, plus counts of computed/reactive values and functions/methods
+    - Returns an empty dict when the scan contains no single-file components
+    """
+    components = []
+    for analysis in in_file_order(file_analysis):
+        framework = SFC_FRAMEWORKS.get(analysis.get("language"))
+        symbols = analysis.get("symbols", [])
+        component = next((s for s in symbols if s.get("sfc")), None)
+        if framework is None or component is None:
+            continue
+        entry = {
+            "file": analysis["file"],
+            "framework": framework,
+            "name": component["name"],
+            "props": component.get("props", []),
+            "computed": sum(1 for s in symbols if s.get("kind") in ("computed", "reactive")),
+            "functions": sum(1 for s in symbols if s.get("kind") in ("function", "method", "composable")),
+        }
+        for key in ("emits", "composables"):
+            if component.get(key):
+                entry[key] = component[key]
+        components.append(entry)
+
+    if not components:
+        return {}
+    return {
+        "total": len(components),
+        "by_framework": {fw: sum(1 for c in components if c["framework"] == fw)
+                         for fw in sorted({c["framework"] for c in components})},
+        "components": components[:max_listed]
+    }
@@ -0,0 +1,122 @@
+#!/usr/bin/env python3
+"""
+Coverage Gate - Breadcrumb coverage thresholds for CI
+Compares documentation coverage (globally and per language) against --min-coverage thresholds
+"""
+
+from collections import defaultdict
+from typing import Dict, Any, List, Callable
+
+GLOBAL_KEY = "*"
+# Per-file keys evaluate_coverage() uses
+EVALUATED_FIELDS = ("file", "language", "owners", "function_count", "documented_count", "missing_breadcrumbs")
+
+
+def parse_coverage_thresholds(spec: str) -> Dict[str, float]:
+    """
+    # @codebase-summary: --min-coverage specification parser
+    - "80" sets the global threshold; "python=90,go=70" sets per-language thresholds
+    - Both forms combine: "75,python=90"; language keys may be names or extensions (.py),
+      and CODEOWNERS owners ("@acme/payments=90") hold a team to its own threshold
+    - Raises ValueError for malformed entries or percentages outside 0-100
+    """
+    thresholds = {}
+    for entry in (spec or "").split(","):
+        entry = entry.strip()
+        if not entry:
+            continue
+        key, sep, value = entry.partition("=")
+        if not sep:
+            key, value = GLOBAL_KEY, entry
+        key = key.strip().lower()
+        try:
+            percentage = float(value.strip().rstrip("%"))
+        except ValueError:
+            raise ValueError(f"invalid coverage threshold '{entry}'")
+        if not 0 <= percentage <= 100:
+            raise ValueError(f"coverage threshold '{entry}' must be between 0 and 100")
+        thresholds[key] = percentage
+    return thresholds
+
+
+def _coverage(documented: int, total: int) -> float:
+    """Coverage percentage rounded like the summary's coverage_percentage"""
+    return round(documented / total * 100, 2) if total else 100.0
+
+
+def scope_label(key: str, scope: str = "") -> str:
+    """Display name of a threshold ('overall', 'python'), qualified by a policy directory when scoped"""
+    label = "overall" if key == GLOBAL_KEY else key
+    return f"{label} in {scope}/" if scope else label
+
+
+def evaluate_coverage(file_analysis: List[Dict[str, Any]], thresholds: Dict[str, float],
+                      language_of: Callable[[str], str], scope: str = "") -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Coverage threshold evaluation
+    - Groups per-file counts by language (via language_of(extension)), by CODEOWNERS owner, and overall
+    - scope names the .arkival-policy directory the files and thresholds belong to, if any
+    - Returns one failure record per threshold that is not met, with the files responsible; a language or
+      owner key no file matches (a typo like 'pyhton') fails with coverage None instead of passing at 100%
+    """
+    groups = defaultdict(list)
+    for analysis in file_analysis:
+        ext = analysis.get("language", "")
+        # A set, so an unmapped extension (language_of returns it unchanged) counts the file once
+        keys = {GLOBAL_KEY, ext.lower(), language_of(ext)} | {owner.lower() for owner in analysis.get("owners", [])}
+        for key in keys:
+            groups[key].append(analysis)
+
+    failures = []
+    for key, threshold in sorted(thresholds.items()):
+        files = groups.get(key, [])
+        if not files and key != GLOBAL_KEY:
+            failures.append({"scope": scope_label(key, scope), "threshold": threshold, "coverage": None,
+                             "total_functions": 0, "documented_functions": 0, "offenders": []})
+            continue
+        total = sum(a["function_count"] for a in files)
+        documented = sum(a["documented_count"] for a in files)
+        coverage = _coverage(documented, total)
+        if coverage >= threshold:
+            continue
+        offenders = sorted(
+            ({"file": a["file"], "missing": a["missing_breadcrumbs"]} for a in files if a["missing_breadcrumbs"]),
+            key=lambda o: (-len(o["missing"]), o["file"])
+        )
+        failures.append({
+            "scope": scope_label(key, scope),
+            "threshold": threshold,
+            "coverage": coverage,
+            "total_functions": total,
+            "documented_functions": documented,
+            "offenders": offenders
+        })
+    return failures
+
+
+def failure_message(failure: Dict[str, Any]) -> str:
+    """One-line reason a threshold failed ('61.5% < 80% (8/13 functions documented)')"""
+    if failure["coverage"] is None:
+        return "no scanned functions match this key (misspelled, or not in this scan)"
+    return (f"{failure['coverage']}% < {failure['threshold']:g}% "
+            f"({failure['documented_functions']}/{failure['total_functions']} functions documented)")
+
+
+def print_coverage_report(failures: List[Dict[str, Any]], thresholds: Dict[str, float],
+                          max_files: int = 10, max_names: int = 8):
+    """Print pass/fail status and the files/functions responsible for each failing threshold"""
+    if not failures:
+        checked = ", ".join(f"{'overall' if k == GLOBAL_KEY else k} ≥ {v:g}%" for k, v in sorted(thresholds.items()))
+        print(f"✅ COVERAGE GATE PASSED: {checked}")
+        return
+
+    print("❌ COVERAGE GATE FAILED")
+    for failure in failures:
+        print(f"   {failure['scope']}: {failure_message(failure)}")
+        for offender in failure["offenders"][:max_files]:
+            names = offender["missing"]
+            listed = ", ".join(names[:max_names]) + (f", +{len(names) - max_names} more" if len(names) > max_names else "")
+            print(f"      - {offender['file']} ({len(names)} missing): {listed}")
+        remaining = len(failure["offenders"]) - max_files
+        if remaining > 0:
+            print(f"      ... and {remaining} more file(s) - see missing_breadcrumbs.json")
@@ -0,0 +1,150 @@
+#!/usr/bin/env python3
+"""
+Database Inventory - Stored routines, triggers, and migration ordering from scanned .sql files
+Aggregates the SQL extractor's symbols and parses versioned migration file names per directory
+"""
+
+import re
+from collections import Counter, defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+from memory_budget import in_file_order
+
+_FLYWAY = re.compile(r'^[VU](\d+(?:[._]\d+)*)__(.+)\.sql$', re.IGNORECASE)
+_FLYWAY_REPEATABLE = re.compile(r'^R__(.+)\.sql$', re.IGNORECASE)
+_UP_DOWN = re.compile(r'^(\d+)[_-](.+?)\.(up|down)\.sql$', re.IGNORECASE)
+_NUMBERED = re.compile(r'^(\d+)[_-](.+)\.sql$', re.IGNORECASE)
+
+# Versions at least this long are timestamps (20240115093000_...), not a gap-free sequence
+_TIMESTAMP_DIGITS = 8
+
+
+def parse_migration_name(filename: str) -> Optional[Dict[str, Any]]:
+    """
+    # @codebase-summary: Versioned migration file name parser
+    - Flyway 'V2_1__add_index.sql' (and repeatable 'R__views.sql'), golang-migrate/dbmate
+      '000003_users.up.sql', and plain numbered or timestamped '0004_users.sql'
+    - Returns scheme, version (tuple of ints, None for repeatables), description, and direction
+    """
+    match = _FLYWAY.match(filename)
+    if match:
+        version = tuple(int(part) for part in re.split(r'[._]', match.group(1)))
+        return {"scheme": "flyway", "version": version, "description": match.group(2), "direction": "up"}
+    match = _FLYWAY_REPEATABLE.match(filename)
+    if match:
+        return {"scheme": "flyway", "version": None, "description": match.group(1), "direction": "up"}
+    match = _UP_DOWN.match(filename)
+    if match:
+        return {"scheme": "up_down", "version": (int(match.group(1)),), "description": match.group(2),
+                "direction": match.group(3).lower()}
+    match = _NUMBERED.match(filename)
+    if match:
+        scheme = "timestamp" if len(match.group(1)) >= _TIMESTAMP_DIGITS else "numbered"
+        return {"scheme": scheme, "version": (int(match.group(1)),), "description": match.group(2), "direction": "up"}
+    return None
+
+
+def _is_migration_directory(directory: str, scheme: str) -> bool:
+    """Flyway names are unambiguous; other numbered .sql files only count inside a migrations directory"""
+    return scheme == "flyway" or any("migrat" in part.lower() for part in Path(directory).parts)
+
+
+def _format_version(version: tuple) -> str:
+    return ".".join(str(part) for part in version)
+
+
+def analyze_migrations(sql_files: List[str], max_listed: int = 50) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Migration ordering per directory
+    - Orders each directory's migrations by parsed version, the order migration tools apply them
+    - Reports duplicate versions, gaps in sequential (non-timestamp) numbering, and up
+      migrations without a matching down migration
+    """
+    directories = defaultdict(list)
+    for file_path in sql_files:
+        path = Path(file_path)
+        parsed = parse_migration_name(path.name)
+        if parsed and _is_migration_directory(path.parent.as_posix(), parsed["scheme"]):
+            directories[path.parent.as_posix()].append({**parsed, "file": path.name})
+
+    results = []
+    for directory, migrations in sorted(directories.items()):
+        versioned = sorted((m for m in migrations if m["version"] is not None),
+                           key=lambda m: (m["version"], m["direction"] != "up", m["file"]))
+        ups = [m for m in versioned if m["direction"] == "up"]
+        schemes = Counter(m["scheme"] for m in migrations)
+        entry = {
+            "directory": directory,
+            "scheme": schemes.most_common(1)[0][0],
+            "count": len(migrations),
+            "order": [m["file"] for m in versioned][:max_listed],
+        }
+        if ups:
+            entry["first"] = ups[0]["file"]
+            entry["latest"] = ups[-1]["file"]
+            entry["latest_version"] = _format_version(ups[-1]["version"])
+
+        version_counts = Counter(m["version"] for m in ups)
+        duplicates = sorted(v for v, count in version_counts.items() if count > 1)
+        if duplicates:
+            entry["duplicate_versions"] = [_format_version(v) for v in duplicates]
+
+        if entry["scheme"] != "timestamp":
+            majors = sorted({m["version"][0] for m in ups})
+            if majors and majors[-1] - majors[0] < 10000:
+                gaps = sorted(set(range(majors[0], majors[-1] + 1)) - set(majors))
+                if gaps:
+                    entry["gaps"] = gaps[:max_listed]
+
+        downs = {m["version"] for m in versioned if m["direction"] == "down"}
+        if downs or entry["scheme"] == "up_down":
+            missing = [m["file"] for m in ups if m["version"] not in downs]
+            if missing:
+                entry["missing_down"] = missing[:max_listed]
+
+        repeatable = [m["file"] for m in migrations if m["version"] is None]
+        if repeatable:
+            entry["repeatable"] = sorted(repeatable)
+        results.append(entry)
+    return results
+
+
+def build_database_inventory(file_analysis: List[Dict[str, Any]], all_files: List[str],
+                             max_listed: int = 50) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Database-side symbol summary for codebase_summary.json
+    - Counts SQL functions, procedures, and triggers; lists triggers with their table and events
+    - Adds per-directory migration ordering from every scanned .sql file name, including
+      migrations that define no routines (all_files, not just files with symbols)
+    - Returns an empty dict when the scan contains no .sql files
+    """
+    sql_files = sorted(f for f in all_files if f.lower().endswith(".sql"))
+    if not sql_files:
+        return {}
+
+    routines = Counter()
+    triggers = []
+    for analysis in in_file_order(file_analysis):
+        if analysis.get("language") != ".sql":
+            continue
+        for symbol in analysis.get("symbols", []):
+            kind = symbol.get("kind")
+            if kind not in ("function", "procedure", "trigger"):
+                continue
+            routines[kind] += 1
+            if kind == "trigger":
+                triggers.append({
+                    "name": symbol["name"], "file": analysis["file"], "line": symbol.get("line", 0),
+                    **{key: symbol[key] for key in ("table", "timing", "events", "executes") if key in symbol}
+                })
+
+    inventory = {
+        "sql_files": len(sql_files),
+        "routines": {kind: routines[kind] for kind in ("function", "procedure", "trigger")},
+        "triggers": triggers[:max_listed],
+    }
+    migrations = analyze_migrations(sql_files, max_listed)
+    if migrations:
+        inventory["migrations"] = migrations
+    return inventory
@@ -0,0 +1,154 @@
+#!/usr/bin/env python3
+"""
+Dead Code - Functions whose name never appears anywhere else in the repository
+Declarations come from the scanned symbols; references are identifier occurrences across all code and
+config files, so any mention (call, callback registration, config entry) keeps a function alive.
+Entry points, tests, decorated functions, library overrides, and exported symbols are never candidates
+"""
+
+import re
+from collections import Counter
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set
+
+from annotate import DECORATOR_PREFIXES
+from text_encoding import file_size, read_text
+
+FUNCTION_KINDS = ("function", "method")
+# Names runtimes, frameworks, and toolchains call without a reference in the code
+ENTRY_POINT_NAMES = {"main", "init", "__init__", "__main__", "setup", "teardown", "setUp", "tearDown", "constructor",
+                     "handler", "lambda_handler"}
+# Config files whose identifiers count as references (handler names in serverless.yml, entry points in setup.cfg);
+# HTML/XML/JSON are left out because the scanner's own reports list every symbol name
+CONFIG_EXTENSIONS = {".yaml", ".yml", ".toml", ".cfg", ".ini"}
+_IDENTIFIER = re.compile(r"[A-Za-z_$][\w$]*")
+_TEST_FILE = re.compile(r"(?:^test_.*|.*_test\.\w+|.*[._](?:test|spec)\.\w+|conftest\.py)$")
+_TEST_DIRS = {"test", "tests", "__tests__", "spec", "specs", "testdata"}
+_TEST_NAME = re.compile(r"^(?:test_?|Test|Benchmark|Fuzz|Example)")
+_PYTHON_ALL = re.compile(r"^__all__\s*[:=+]")
+# Visibility keywords that publish a declaration outside its module
+_EXPORT_KEYWORDS = re.compile(r"^\s*(?:export\b|pub\b|pub\(|public\b|open\b)")
+MAX_FILE_BYTES = 1024 * 1024
+
+
+def is_test_path(file: str) -> bool:
+    """Test code by the usual conventions: test_*.py, *_test.go, *.spec.ts, tests/ and __tests__/ directories"""
+    path = Path(file)
+    return bool(_TEST_FILE.match(path.name)) or any(part in _TEST_DIRS for part in path.parts[:-1])
+
+
+def python_all_names(lines: List[str]) -> Set[str]:
+    """Names listed in a module's __all__ (their public API)"""
+    names: Set[str] = set()
+    for index, line in enumerate(lines):
+        if _PYTHON_ALL.match(line):
+            text = "\n".join(lines[index:index + 50])
+            end = text.find("]") if "[" in text.split("\n")[0] else text.find(")")
+            names.update(re.findall(r"['\"]([\w.]+)['\"]", text[:end if end > 0 else len(text)]))
+    return names
+
+
+def _library_classes(file_analysis: List[Dict[str, Any]]) -> Set[str]:
+    """Classes extending a base the repository doesn't declare - their methods may be framework callbacks"""
+    declared = {s["name"] for a in file_analysis for s in a.get("symbols", []) if s.get("kind") == "class"}
+    return {s["name"] for a in file_analysis for s in a.get("symbols", [])
+            if s.get("kind") == "class" and any(base.split(".")[-1].split("[")[0] not in declared
+                                                 for base in s.get("bases", []) if base != "object")}
+
+
+def exclusion_reason(symbol: Dict[str, Any], analysis: Dict[str, Any], lines: List[str],
+                     public: Set[str], language: str, library_classes: Set[str]) -> Optional[str]:
+    """
+    # @codebase-summary: Why a function can't be a dead-code candidate, or None
+    - "entry_point" (main, init, lifecycle hooks, dunder methods), "test" (test files and test functions),
+      "decorated" (registered by a decorator or annotation), "override" (methods of classes extending a
+      library base, like do_GET), or "exported" - Go upper-case names, export/pub/public declarations,
+      and names in a Python module's __all__
+    """
+    name = symbol["name"]
+    if name in ENTRY_POINT_NAMES or (name.startswith("__") and name.endswith("__")):
+        return "entry_point"
+    if analysis.get("go_test_file") or is_test_path(analysis["file"]) or _TEST_NAME.match(name):
+        return "test"
+    index = symbol.get("line", 0) - 1
+    declaration = lines[index] if 0 <= index < len(lines) else ""
+    decorator = DECORATOR_PREFIXES.get(language)
+    if symbol.get("decorators") or (decorator and index > 0 and lines[index - 1].lstrip().startswith(decorator)):
+        return "decorated"
+    if symbol.get("kind") == "method" and symbol.get("parent", "").split(".")[-1] in library_classes:
+        return "override"
+    if (language == "go" and name[:1].isupper()) or name in public or _EXPORT_KEYWORDS.match(declaration):
+        return "exported"
+    return None
+
+
+def _read(path: Path) -> Optional[str]:
+    """File text, or None for unreadable or very large files"""
+    try:
+        if file_size(path) > MAX_FILE_BYTES:
+            return None
+        return read_text(path)
+    except OSError:
+        return None
+
+
+def find_dead_code(file_analysis: List[Dict[str, Any]], reference_files: List[str], project_root: Path,
+                   language_of, limit: int = 50) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Dead-code candidates: functions whose name occurs nowhere but its declarations
+    - reference_files are every code and config file of the scan; mentions in comments count too,
+      which keeps the list to likely-dead code rather than every function without a call
+    - Name-based across the repository: a name declared twice stays alive while either is used
+    """
+    declared: Counter = Counter()
+    examined = []
+    excluded: Dict[str, int] = {}
+    # Identifier counts of the files read here, summed as they are read rather than kept per file
+    mentions: Counter = Counter()
+    counted = set()
+    library_classes = _library_classes(file_analysis)
+    for analysis in file_analysis:
+        functions = [s for s in analysis.get("symbols", [])
+                     if s.get("kind", "function") in FUNCTION_KINDS and not s.get("doc_exempt")]
+        text = _read(project_root / analysis["file"]) if functions else None
+        if text is None:
+            continue
+        mentions.update(_IDENTIFIER.findall(text))
+        counted.add(analysis["file"])
+        lines = text.split("\n")
+        language = language_of(analysis["language"])
+        public = python_all_names(lines) if language == "python" else set()
+        for symbol in functions:
+            declared[symbol["name"]] += 1
+            reason = exclusion_reason(symbol, analysis, lines, public, language, library_classes)
+            if reason:
+                excluded[reason] = excluded.get(reason, 0) + 1
+            else:
+                examined.append({"file": analysis["file"], "line": symbol.get("line", 0), "name": symbol["name"],
+                                 "kind": symbol.get("kind", "function"), "language": analysis["language"]})
+
+    wanted = {entry["name"] for entry in examined}
+    references: Counter = Counter({name: count for name, count in mentions.items() if name in wanted})
+    del mentions
+    for file in reference_files:
+        if file in counted:
+            counted.discard(file)
+            continue
+        text = None if Path(file).suffix.lower() == ".ipynb" else _read(project_root / file)
+        if text:
+            references.update(name for name in _IDENTIFIER.findall(text) if name in wanted)
+    # Only mentions in reference files count; take back those of analyzed files that are not one
+    for file in counted:
+        references.subtract(name for name in _IDENTIFIER.findall(_read(project_root / file) or "") if name in wanted)
+
+    candidates = [entry for entry in examined if references[entry["name"]] <= declared[entry["name"]]]
+    by_language: Dict[str, int] = {}
+    for candidate in candidates:
+        by_language[candidate["language"]] = by_language.get(candidate["language"], 0) + 1
+    return {
+        "candidates": len(candidates),
+        "examined_functions": len(examined),
+        "excluded": dict(sorted(excluded.items())),
+        "by_language": dict(sorted(by_language.items(), key=lambda item: (-item[1], item[0]))),
+        "items": candidates[:limit],
+    }
@@ -0,0 +1,307 @@
+#!/usr/bin/env python3
+"""
+Dependency Graph - Cross-file import graph for Python, JavaScript/TypeScript, and Go
+Extracts import specifiers while files are analyzed, then resolves them against the scanned files
+(Python modules, relative JS/TS paths, Go packages of the go.mod/go.work modules) to build file and module edges
+"""
+
+import json
+import posixpath
+import re
+import sys
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from go_workspace import GoWorkspace
+
+_PY_IMPORT = re.compile(r"^\s*import\s+(.+)")
+_PY_FROM = re.compile(r"^\s*from\s+(\.*[\w.]*)\s+import\s+(.+)")
+
+_JS_LANGUAGES = {"javascript", "typescript", "tsx", "vue", "svelte"}
+_JS_IMPORT = re.compile(
+    r"""\bimport\s*['"]([^'"\n]+)['"]"""                                  # import './side-effect'
+    r"""|\b(?:import|export)\s[^'";]*?\bfrom\s*['"]([^'"\n]+)['"]"""       # import x from 'y' / export * from 'y'
+    r"""|\b(?:require|import)\s*\(\s*['"]([^'"\n]+)['"]\s*\)"""            # require('x') / import('x')
+)
+_JS_EXTENSIONS = ("", ".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".vue", ".svelte")
+
+_GO_IMPORT = re.compile(r'^\s*import\s+(?:[\w.]+\s+)?"([^"]+)"')
+_GO_BLOCK_LINE = re.compile(r'^\s*(?:[\w.]+\s+)?"([^"]+)"')
+
+# Standard libraries are not dependencies worth listing (sys.stdlib_module_names needs Python 3.10+)
+_PY_STDLIB = set(getattr(sys, "stdlib_module_names", ())) | {"__future__"}
+
+
+def _python_imports(lines: List[str]) -> List[str]:
+    """Dotted module names; 'from . import a, b' records '.a' and '.b' since the names are likely modules"""
+    imports = []
+    i = 0
+    while i < len(lines):
+        line = lines[i].split("#", 1)[0]
+        match = _PY_FROM.match(line)
+        if match:
+            module, names = match.group(1), match.group(2)
+            # Parenthesized name lists may continue on the following lines
+            while "(" in names and ")" not in names and i + 1 < len(lines):
+                i += 1
+                names += " " + lines[i].split("#", 1)[0]
+            if module.strip("."):
+                imports.append(module)
+            else:
+                for name in names.strip().strip("()").split(","):
+                    name = name.split(" as ")[0].strip()
+                    if name and name != "*":
+                        imports.append(module + name)
+        else:
+            match = _PY_IMPORT.match(line)
+            if match:
+                imports += [part.split(" as ")[0].strip() for part in match.group(1).split(",")
+                            if re.fullmatch(r"[\w.]+", part.split(" as ")[0].strip())]
+        i += 1
+    return imports
+
+
+def _go_imports(lines: List[str]) -> List[str]:
+    """Import paths from single imports and import ( ... ) blocks"""
+    imports = []
+    in_block = False
+    for line in lines:
+        stripped = line.strip()
+        if in_block:
+            if stripped.startswith(")"):
+                in_block = False
+                continue
+            match = _GO_BLOCK_LINE.match(line)
+            if match:
+                imports.append(match.group(1))
+        elif re.match(r"^import\s*\($", stripped):
+            in_block = True
+        else:
+            match = _GO_IMPORT.match(line)
+            if match:
+                imports.append(match.group(1))
+    return imports
+
+
+def extract_imports(lines: List[str], language: str) -> List[str]:
+    """
+    # @codebase-summary: Raw import specifiers of one source file, in order and deduplicated
+    - Python: dotted module names, relative ones keep their leading dots
+    - JavaScript/TypeScript (and Vue/Svelte scripts): import/export-from/require/import() specifiers
+    - Go: import paths; other languages return []
+    """
+    if language == "python":
+        imports = _python_imports(lines)
+    elif language in _JS_LANGUAGES:
+        imports = [next(group for group in match.groups() if group) for match in _JS_IMPORT.finditer("\n".join(lines))]
+    elif language == "go":
+        imports = _go_imports(lines)
+    else:
+        imports = []
+    return list(dict.fromkeys(imports))
+
+
+def _python_index(files: List[str]) -> Tuple[Dict[str, str], Dict[str, List[str]]]:
+    """Python files by dotted path from the root, and by every shorter dotted suffix (src layouts, sys.path dirs)"""
+    exact, suffixes = {}, defaultdict(list)
+    for file in files:
+        parts = list(Path(file).with_suffix("").parts)
+        if parts and parts[-1] == "__init__":
+            parts = parts[:-1]
+        if not parts:
+            continue
+        exact[".".join(parts)] = file
+        for start in range(1, len(parts)):
+            suffixes[".".join(parts[start:])].append(file)
+    return exact, suffixes
+
+
+def _resolve_python(spec: str, file: str, exact: Dict[str, str], suffixes: Dict[str, List[str]]) -> Optional[str]:
+    """Scanned file a Python import refers to: relative to the package, the importing file's directory, or the root"""
+    directory = Path(file).parent
+    if spec.startswith("."):
+        level = len(spec) - len(spec.lstrip("."))
+        base = directory
+        for _ in range(level - 1):
+            base = base.parent
+        parts = [p for p in base.parts if p] + [p for p in spec.lstrip(".").split(".") if p]
+        return exact.get(".".join(parts))
+    sibling = exact.get(".".join(list(directory.parts) + [spec])) if directory.parts else None
+    if sibling:
+        return sibling
+    if spec in exact:
+        return exact[spec]
+    candidates = suffixes.get(spec, [])
+    return candidates[0] if len(candidates) == 1 else None
+
+
+def _resolve_js(spec: str, file: str, known: set) -> Optional[str]:
+    """Scanned file a relative JS/TS specifier refers to (extension and /index resolution); packages are external"""
+    if not spec.startswith("."):
+        return None
+    base = posixpath.normpath(posixpath.join(Path(file).parent.as_posix(), spec))
+    for candidate in [base + ext for ext in _JS_EXTENSIONS] + [f"{base}/index{ext}" for ext in _JS_EXTENSIONS[1:]]:
+        if candidate in known:
+            return candidate
+    return None
+
+
+def _is_standard_library(package: str, language: str) -> bool:
+    """Python stdlib modules, Go std packages (no dot in the first path element), Node 'node:' builtins"""
+    if language == "go":
+        return "." not in package.split("/")[0]
+    if language == "python":
+        return package in _PY_STDLIB
+    return package.startswith("node:")
+
+
+def package_name(spec: str, language: str) -> str:
+    """External package an import belongs to ('numpy', '@scope/pkg', 'github.com/x/y/sub')"""
+    if language == "go":
+        return spec
+    if language == "python":
+        return spec.split(".")[0]
+    parts = spec.split("/")
+    return "/".join(parts[:2]) if spec.startswith("@") else parts[0]
+
+
+def build_dependency_graph(module_files: List[Dict[str, Any]], project_root: Path,
+                           workspace: Optional[GoWorkspace] = None) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Resolve recorded imports into internal file edges and external packages
+    - module_files: {file, language, imports} for every analyzed file, including files without functions
+    - Python: relative imports, modules next to the importing file, from the root, or a unique suffix
+    - JS/TS: relative specifiers with extension and index-file resolution
+    - Go: paths under the importing module, a go.work sibling module, or a local replace target link to
+      every non-test file of that package (see go_workspace)
+    - Returns {"edges": [(from, to)], "external_edges": [(from, package)], "external": {package: import count}};
+      standard libraries are left out
+    """
+    imports_of = {a["file"]: a for a in module_files if a.get("imports")}
+    by_language = defaultdict(list)
+    for analysis in module_files:
+        by_language[analysis.get("language", "")].append(Path(analysis["file"]).as_posix())
+    exact, suffixes = _python_index(by_language[".py"])
+    known = {Path(a["file"]).as_posix() for a in module_files}
+    go_files = by_language[".go"]
+    workspace = workspace or GoWorkspace(project_root)
+    go_packages = defaultdict(list)
+    for file in go_files:
+        if not file.endswith("_test.go"):
+            go_packages[Path(file).parent.as_posix()].append(file)
+
+    edges = set()
+    external_edges = set()
+    external = defaultdict(int)
+    for file, analysis in sorted(imports_of.items()):
+        source = Path(file).as_posix()
+        ext = analysis.get("language", "")
+        for spec in analysis["imports"]:
+            if ext == ".py":
+                targets = [t for t in [_resolve_python(spec, source, exact, suffixes)] if t]
+                language = "python"
+            elif ext == ".go":
+                package_dir = workspace.resolve(spec, Path(source).parent.as_posix()) if go_files else None
+                targets = go_packages.get(package_dir, []) if package_dir else []
+                language = "go"
+            else:
+                targets = [t for t in [_resolve_js(spec, source, known)] if t]
+                language = "javascript"
+            if targets:
+                edges.update((source, target) for target in targets if target != source)
+            elif not spec.startswith("."):
+                package = package_name(spec, language)
+                if not _is_standard_library(package, language):
+                    external[package] += 1
+                    external_edges.add((source, package))
+    return {"edges": sorted(edges), "external_edges": sorted(external_edges), "external": dict(external)}
+
+
+def module_of(file: str, depth: int = 1) -> str:
+    """Directory prefix of depth components a file is grouped under ('(root)' for top-level files)"""
+    parts = Path(file).parent.parts
+    return "/".join(parts[:depth]) if parts else "(root)"
+
+
+def collapse_edges(edges: List[Tuple[str, str]], depth: int = 1) -> List[Dict[str, Any]]:
+    """File edges aggregated into directory edges with the number of file imports behind each"""
+    counts = defaultdict(int)
+    for source, target in edges:
+        pair = (module_of(source, depth), module_of(target, depth))
+        if pair[0] != pair[1]:
+            counts[pair] += 1
+    return [{"from": a, "to": b, "imports": n} for (a, b), n in sorted(counts.items())]
+
+
+def summarize_dependencies(graph: Dict[str, Any], limit: int = 20) -> Dict[str, Any]:
+    """Summary section: internal import count, top-level module edges, and the most used external packages"""
+    external = sorted(graph["external"].items(), key=lambda item: (-item[1], item[0]))
+    return {
+        "internal_imports": len(graph["edges"]),
+        "module_edges": collapse_edges(graph["edges"]),
+        "external_packages": [{"name": name, "imports": count} for name, count in external[:limit]],
+    }
+
+
+def _dot_quote(text: str) -> str:
+    """Quote an identifier for DOT"""
+    escaped = text.replace('\\', '\\\\').replace('"', '\\"')
+    return f'"{escaped}"'
+
+
+def build_dependency_dot(graph: Dict[str, Any], collapse: int = 0, include_external: bool = False) -> str:
+    """
+    # @codebase-summary: Graphviz DOT rendering of the import graph
+    - collapse=0: one node per file, clustered by directory
+    - collapse=N: one node per directory prefix of N components; edge width and label count the file imports
+    - include_external: third-party packages as grey ellipses
+    """
+    lines = ["digraph dependencies {", "  rankdir=LR;", '  node [shape=box, fontname="Helvetica"];']
+    if collapse > 0:
+        edges = collapse_edges(graph["edges"], collapse)
+        modules = sorted({e["from"] for e in edges} | {e["to"] for e in edges})
+        lines += [f"  {_dot_quote(module)};" for module in modules]
+        for edge in edges:
+            width = min(1 + edge["imports"] // 5, 6)
+            lines.append(f"  {_dot_quote(edge['from'])} -> {_dot_quote(edge['to'])} "
+                         f"[label={edge['imports']}, penwidth={width}];")
+        external_edges = sorted({(module_of(source, collapse), package) for source, package in graph.get("external_edges", [])})
+    else:
+        files = sorted({f for edge in graph["edges"] for f in edge}
+                       | ({source for source, _ in graph.get("external_edges", [])} if include_external else set()))
+        by_directory = defaultdict(list)
+        for file in files:
+            by_directory[Path(file).parent.as_posix()].append(file)
+        for index, (directory, members) in enumerate(sorted(by_directory.items())):
+            lines.append(f"  subgraph cluster_{index} {{")
+            lines.append(f"    label={_dot_quote(directory + '/' if directory != '.' else '(root)')};")
+            lines += [f"    {_dot_quote(file)} [label={_dot_quote(Path(file).name)}];" for file in members]
+            lines.append("  }")
+        lines += [f"  {_dot_quote(source)} -> {_dot_quote(target)};" for source, target in graph["edges"]]
+        external_edges = graph.get("external_edges", [])
+    if include_external:
+        packages = sorted({package for _, package in external_edges})
+        lines += [f'  {_dot_quote("pkg:" + package)} [label={_dot_quote(package)}, shape=ellipse, color=grey, fontcolor=grey];'
+                  for package in packages]
+        lines += [f'  {_dot_quote(source)} -> {_dot_quote("pkg:" + package)} [color=grey];' for source, package in external_edges]
+    lines.append("}")
+    return "\n".join(lines) + "\n"
+
+
+def write_dependency_graph(output_dir: Path, graph: Dict[str, Any], collapse: int = 0,
+                           include_external: bool = False) -> List[Path]:
+    """Write dependency_graph.json (file edges) and dependency_graph.dot, returning the written paths"""
+    output_dir.mkdir(parents=True, exist_ok=True)
+    json_path = output_dir / "dependency_graph.json"
+    dot_path = output_dir / "dependency_graph.dot"
+    with open(json_path, 'w', encoding='utf-8') as f:
+        json.dump({
+            "_generator": "Generated by codebase_summary/dependency_graph.py - Cross-file import graph",
+            "edges": [{"from": source, "to": target} for source, target in graph["edges"]],
+            "external": [{"from": source, "package": package} for source, package in graph.get("external_edges", [])],
+            "modules": collapse_edges(graph["edges"], max(collapse, 1)),
+        }, f, indent=2)
+    with open(dot_path, 'w', encoding='utf-8') as f:
+        f.write(build_dependency_dot(graph, collapse, include_external))
+    return [json_path, dot_path]
@@ -0,0 +1,466 @@
+#!/usr/bin/env python3
+"""
+Dependency Inventory - Direct dependencies declared in go.mod, package.json, requirements*.txt, pyproject.toml,
+Cargo.toml, and pom.xml, with their version constraints and scope, per manifest directory
+The summary then describes both the scanned code and what it depends on; packages a monorepo's projects pin
+to different versions are listed as conflicts
+"""
+
+import json
+import posixpath
+import re
+import xml.etree.ElementTree as ElementTree
+from pathlib import Path
+from typing import Dict, Any, Iterable, List, Optional, Tuple
+
+from go_workspace import GoWorkspace
+from text_encoding import read_bytes, read_text
+
+try:
+    import tomllib
+    TOMLLIB_AVAILABLE = True
+except ImportError:
+    try:
+        import tomli as tomllib
+        TOMLLIB_AVAILABLE = True
+    except ImportError:
+        TOMLLIB_AVAILABLE = False
+
+ECOSYSTEMS = {"go.mod": "go", "package.json": "npm", "pyproject.toml": "pypi", "Cargo.toml": "cargo", "pom.xml": "maven"}
+# requirements.txt, requirements-dev.txt, requirements_test.txt, ...; dev/test/lint/doc files are dev scope
+_REQUIREMENTS = re.compile(r"^requirements(?:[-_.]([\w.-]+))?\.txt$", re.IGNORECASE)
+_DEV_REQUIREMENTS = re.compile(r"dev|test|lint|doc|ci", re.IGNORECASE)
+# PEP 508: name[extras] (constraint | @ url) ; markers
+_PEP508 = re.compile(r"^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(@\s*\S+|[^;#]*)")
+_NPM_SCOPES = (("dependencies", "runtime"), ("devDependencies", "dev"), ("peerDependencies", "peer"),
+               ("optionalDependencies", "optional"))
+_CARGO_SCOPES = (("dependencies", "runtime"), ("dev-dependencies", "dev"), ("build-dependencies", "build"))
+_MAVEN_SCOPES = {"compile": "runtime", "runtime": "runtime", "test": "test", "provided": "provided",
+                 "system": "provided", "import": "import"}
+_MAVEN_PROPERTY = re.compile(r"\$\{([^}]+)\}")
+_TOML_BARE_KEY = re.compile(r"[A-Za-z0-9_-]+")
+_TOML_BASIC_STRING = re.compile(r'"((?:[^"\\\n]|\\.)*)"')
+_TOML_TOKEN = re.compile(r"[^,\]\}\s#]+")
+
+
+def _dependency(name: str, version: Optional[str], scope: str, **extra) -> Dict[str, Any]:
+    """One inventory entry; empty version constraints become None (any version)"""
+    return {"name": name, "version": (version or "").strip() or None, "scope": scope,
+            **{key: value for key, value in extra.items() if value}}
+
+
+# ====== TOML (tomllib on Python 3.11+, else tomli, else the subset below) ======
+
+def _toml_skip(text: str, i: int, newlines: bool = True) -> int:
+    """Index of the next significant character, past spaces, '#' comments, and (optionally) newlines"""
+    while i < len(text):
+        if text[i] in " \t\r" or (newlines and text[i] == "\n"):
+            i += 1
+        elif text[i] == "#":
+            while i < len(text) and text[i] != "\n":
+                i += 1
+        else:
+            break
+    return i
+
+
+def _toml_key(text: str, i: int) -> Tuple[List[str], int]:
+    """Dotted key ('a.b', '"x.y".z') as its parts"""
+    parts = []
+    while True:
+        i = _toml_skip(text, i, newlines=False)
+        if text[i] in "\"'":
+            value, i = _toml_value(text, i)
+            parts.append(value)
+        else:
+            match = _TOML_BARE_KEY.match(text, i)
+            if not match:
+                raise ValueError(f"invalid key at offset {i}")
+            parts.append(match.group(0))
+            i = match.end()
+        i = _toml_skip(text, i, newlines=False)
+        if i < len(text) and text[i] == ".":
+            i += 1
+            continue
+        return parts, i
+
+
+def _toml_value(text: str, i: int) -> Tuple[Any, int]:
+    """Value starting at text[i]: strings, arrays, inline tables, booleans, numbers (dates stay strings)"""
+    for quote in ('"""', "'''"):
+        if text.startswith(quote, i):
+            start = i + 3 + (text[i + 3:i + 4] == "\n")  # a newline right after the quotes is trimmed
+            end = text.index(quote, start)
+            return text[start:end], end + 3
+    if text[i] == '"':
+        match = _TOML_BASIC_STRING.match(text, i)
+        return json.loads(match.group(0)), match.end()
+    if text[i] == "'":
+        end = text.index("'", i + 1)
+        return text[i + 1:end], end + 1
+    if text[i] == "[":
+        items, i = [], i + 1
+        while True:
+            i = _toml_skip(text, i)
+            if text[i] == "]":
+                return items, i + 1
+            item, i = _toml_value(text, i)
+            items.append(item)
+            i = _toml_skip(text, i)
+            if text[i] == ",":
+                i += 1
+    if text[i] == "{":
+        table, i = {}, i + 1
+        while True:
+            i = _toml_skip(text, i, newlines=False)
+            if text[i] == "}":
+                return table, i + 1
+            keys, i = _toml_key(text, i)
+            i = _toml_skip(text, i + 1, newlines=False)  # past '='
+            value, i = _toml_value(text, i)
+            _toml_set(table, keys, value)
+            i = _toml_skip(text, i, newlines=False)
+            if text[i] == ",":
+                i += 1
+    match = _TOML_TOKEN.match(text, i)
+    token = match.group(0)
+    if token in ("true", "false"):
+        return token == "true", match.end()
+    try:
+        return int(token.replace("_", ""), 0), match.end()
+    except ValueError:
+        pass
+    try:
+        return float(token.replace("_", "")), match.end()
+    except ValueError:
+        return token, match.end()
+
+
+def _toml_set(table: Dict[str, Any], keys: List[str], value: Any):
+    for key in keys[:-1]:
+        table = table.setdefault(key, {})
+    table[keys[-1]] = value
+
+
+def parse_simple_toml(text: str) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Fallback parser for the TOML manifests need (Python before 3.11 without tomli)
+    - [table], [[array of tables]], dotted and quoted keys, basic/literal/multi-line strings, arrays,
+      inline tables, booleans, and numbers; dates and times are kept as strings, and escapes inside
+      multi-line strings are left as written
+    """
+    root: Dict[str, Any] = {}
+    table, i = root, _toml_skip(text, 0)
+    while i < len(text):
+        if text[i] == "[":
+            array = text.startswith("[[", i)
+            keys, i = _toml_key(text, i + (2 if array else 1))
+            i += 2 if array else 1
+            parent = root
+            for key in keys[:-1]:
+                parent = parent.setdefault(key, {})
+                parent = parent[-1] if isinstance(parent, list) else parent
+            if array:
+                parent.setdefault(keys[-1], []).append({})
+                table = parent[keys[-1]][-1]
+            else:
+                table = parent.setdefault(keys[-1], {})
+        else:
+            keys, i = _toml_key(text, i)
+            if text[i] != "=":
+                raise ValueError(f"expected '=' at offset {i}")
+            value, i = _toml_value(text, _toml_skip(text, i + 1, newlines=False))
+            _toml_set(table, keys, value)
+        i = _toml_skip(text, i)
+    return root
+
+
+def load_toml(text: str) -> Dict[str, Any]:
+    """Parsed TOML document; raises ValueError on syntax errors"""
+    if TOMLLIB_AVAILABLE:
+        try:
+            return tomllib.loads(text)
+        except tomllib.TOMLDecodeError as e:
+            raise ValueError(str(e))
+    try:
+        return parse_simple_toml(text)
+    except (IndexError, AttributeError, json.JSONDecodeError) as e:
+        raise ValueError(f"unsupported TOML: {e}")
+
+
+# ====== MANIFESTS ======
+
+def _requirement(spec: str, scope: str, **extra) -> Optional[Dict[str, Any]]:
+    """Entry for a PEP 508 requirement string ('requests>=2.31', 'pkg @ git+https://...')"""
+    match = _PEP508.match(spec)
+    if not match:
+        return None
+    version = match.group(2).strip()
+    if version.startswith("@"):
+        return _dependency(match.group(1), None, scope, source=version[1:].strip(), **extra)
+    return _dependency(match.group(1), version, scope, **extra)
+
+
+def parse_go_mod(text: str, local: Optional[Dict[str, str]] = None) -> Tuple[List[Dict[str, Any]], int]:
+    """Direct requires of a go.mod and the count of '// indirect' ones; local maps module paths in the tree to their source"""
+    local = local or {}
+    dependencies, indirect, block = [], 0, False
+    for raw in text.splitlines():
+        line, _, comment = raw.partition("//")
+        line = line.strip()
+        if block:
+            if line == ")":
+                block = False
+                continue
+            args = line.split()
+        elif re.match(r"^require\b", line):
+            rest = line[len("require"):].strip()
+            if rest == "(":
+                block = True
+                continue
+            args = rest.split()
+        else:
+            continue
+        if len(args) < 2:
+            continue
+        if comment.strip() == "indirect":
+            indirect += 1
+            continue
+        name = args[0].strip('"`')
+        dependencies.append(_dependency(name, args[1], "runtime", source=local.get(name)))
+    return dependencies, indirect
+
+
+def _npm_source(spec: str) -> Optional[str]:
+    """Where an npm version spec points when it is not the registry: workspace, path, git, or url"""
+    if spec.startswith("workspace:"):
+        return "workspace"
+    if spec.startswith(("file:", "link:", "./", "../")):
+        return "path"
+    if spec.startswith(("git", "github:", "gitlab:", "bitbucket:")) or re.match(r"^[\w-]+/[\w.-]+(#.*)?$", spec):
+        return "git"
+    if spec.startswith(("http://", "https://")):
+        return "url"
+    return None
+
+
+def parse_package_json(text: str) -> List[Dict[str, Any]]:
+    data = json.loads(text)
+    dependencies = []
+    for key, scope in _NPM_SCOPES:
+        section = data.get(key) if isinstance(data, dict) else None
+        if not isinstance(section, dict):
+            continue
+        for name, spec in sorted(section.items()):
+            spec = spec if isinstance(spec, str) else None
+            dependencies.append(_dependency(name, spec, scope, source=_npm_source(spec or "")))
+    return dependencies
+
+
+def parse_requirements(text: str, filename: str) -> List[Dict[str, Any]]:
+    """requirements*.txt lines; options (-r, -e, --index-url) and comments are skipped"""
+    suffix = _REQUIREMENTS.match(filename)
+    scope = "dev" if suffix and suffix.group(1) and _DEV_REQUIREMENTS.search(suffix.group(1)) else "runtime"
+    dependencies = []
+    for line in text.replace("\\\n", " ").splitlines():
+        line = re.split(r"\s+(?:#|--)", line, 1)[0].strip()
+        if not line or line.startswith(("#", "-")):
+            continue
+        entry = _requirement(line, scope)
+        if entry:
+            dependencies.append(entry)
+    return dependencies
+
+
+def _poetry_dependency(name: str, spec: Any, scope: str, group: Optional[str] = None) -> Dict[str, Any]:
+    """Entry for a Poetry dependency: "^1.2" or {version, path, git, optional}"""
+    if isinstance(spec, dict):
+        source = "path" if "path" in spec else "git" if "git" in spec else "url" if "url" in spec else None
+        return _dependency(name, spec.get("version"), "optional" if spec.get("optional") else scope,
+                           group=group, source=source)
+    return _dependency(name, spec if isinstance(spec, str) and spec != "*" else None, scope, group=group)
+
+
+def parse_pyproject(text: str) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Dependencies of a pyproject.toml in every common layout
+    - PEP 621 [project] dependencies and optional-dependencies (scope optional, group = extra name)
+    - PEP 735 [dependency-groups] and uv dev-dependencies (scope dev), [build-system] requires (build)
+    - Poetry [tool.poetry.dependencies], dev-dependencies, and group.<name>.dependencies (python itself left out)
+    """
+    data = load_toml(text)
+    project = data.get("project", {})
+    dependencies = [_requirement(spec, "runtime") for spec in project.get("dependencies", [])]
+    for group, specs in sorted(project.get("optional-dependencies", {}).items()):
+        dependencies += [_requirement(spec, "optional", group=group) for spec in specs]
+    for group, specs in sorted(data.get("dependency-groups", {}).items()):
+        dependencies += [_requirement(spec, "dev", group=group) for spec in specs if isinstance(spec, str)]
+    dependencies += [_requirement(spec, "dev") for spec in data.get("tool", {}).get("uv", {}).get("dev-dependencies", [])]
+    dependencies += [_requirement(spec, "build") for spec in data.get("build-system", {}).get("requires", [])]
+    poetry = data.get("tool", {}).get("poetry", {})
+    dependencies += [_poetry_dependency(name, spec, "runtime")
+                     for name, spec in poetry.get("dependencies", {}).items() if name.lower() != "python"]
+    dependencies += [_poetry_dependency(name, spec, "dev") for name, spec in poetry.get("dev-dependencies", {}).items()]
+    for group, section in sorted(poetry.get("group", {}).items()):
+        dependencies += [_poetry_dependency(name, spec, "dev", group)
+                         for name, spec in section.get("dependencies", {}).items()]
+    return [entry for entry in dependencies if entry]
+
+
+def _cargo_dependency(name: str, spec: Any, scope: str, target: Optional[str] = None) -> Dict[str, Any]:
+    """Entry for a Cargo dependency: "1.0" or {version, path, git, workspace, optional, package}"""
+    if not isinstance(spec, dict):
+        return _dependency(name, spec if isinstance(spec, str) else None, scope, target=target)
+    source = ("workspace" if spec.get("workspace") else "path" if "path" in spec
+              else "git" if "git" in spec else None)
+    return _dependency(spec.get("package", name), spec.get("version"), scope, target=target, source=source,
+                       optional=bool(spec.get("optional")))
+
+
+def parse_cargo_toml(text: str) -> List[Dict[str, Any]]:
+    """[dependencies], [dev-dependencies], [build-dependencies], their [target.<cfg>.*] forms, and [workspace.dependencies]"""
+    data = load_toml(text)
+    dependencies = []
+    for key, scope in _CARGO_SCOPES:
+        dependencies += [_cargo_dependency(name, spec, scope) for name, spec in data.get(key, {}).items()]
+        for target, section in sorted(data.get("target", {}).items()):
+            dependencies += [_cargo_dependency(name, spec, scope, target)
+                             for name, spec in section.get(key, {}).items()]
+    dependencies += [_cargo_dependency(name, spec, "workspace")
+                     for name, spec in data.get("workspace", {}).get("dependencies", {}).items()]
+    return dependencies
+
+
+def parse_pom(raw: bytes) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: <dependencies> of a Maven pom.xml as groupId:artifactId entries
+    - ${property} versions resolve from <properties> and the project's own version
+    - Versions left to a parent's <dependencyManagement> stay None; managed and plugin dependencies are not listed
+    """
+    root = ElementTree.fromstring(raw)
+    namespace = root.tag[:root.tag.index("}") + 1] if root.tag.startswith("{") else ""
+
+    def child(element, name: str) -> Optional[str]:
+        found = element.find(namespace + name)
+        return found.text.strip() if found is not None and found.text else None
+
+    properties = {element.tag[len(namespace):]: (element.text or "").strip()
+                  for element in root.findall(f"{namespace}properties/*")}
+    properties["project.version"] = child(root, "version") or ""
+
+    def resolve(value: Optional[str]) -> Optional[str]:
+        return _MAVEN_PROPERTY.sub(lambda m: properties.get(m.group(1), m.group(0)), value) if value else value
+
+    dependencies = []
+    for element in root.findall(f"{namespace}dependencies/{namespace}dependency"):
+        group, artifact = child(element, "groupId"), child(element, "artifactId")
+        if not artifact:
+            continue
+        scope = _MAVEN_SCOPES.get(child(element, "scope") or "compile", "runtime")
+        dependencies.append(_dependency(f"{resolve(group)}:{artifact}" if group else artifact,
+                                        resolve(child(element, "version")), scope,
+                                        optional=child(element, "optional") == "true"))
+    return dependencies
+
+
+def _comparable_name(name: str, ecosystem: str) -> str:
+    """Package name as its registry compares it: PyPI folds case and runs of '-', '_', '.' (PEP 503)"""
+    return re.sub(r"[-_.]+", "-", name).lower() if ecosystem == "pypi" else name
+
+
+def is_manifest(file: str) -> bool:
+    """Whether a scanned file is a manifest the inventory reads"""
+    name = Path(file).name
+    return name in ECOSYSTEMS or bool(_REQUIREMENTS.match(name))
+
+
+def _go_local_modules(workspace: GoWorkspace, directory: str) -> Dict[str, str]:
+    """Module path -> 'path' (local replace) or 'workspace' (go.work sibling) for modules a go.mod resolves in the tree"""
+    module = workspace.module_at(directory)
+    if module is None:
+        return {}
+    local = {path: "workspace" for path, _ in workspace.visible_modules(module) if path != module["module"]}
+    local.update({path: "path" for path in module["replaces"]})
+    return local
+
+
+def _parse_manifest(path: Path, file: str, workspace: GoWorkspace) -> Tuple[List[Dict[str, Any]], int]:
+    """(dependencies, indirect count) of one manifest; raises ValueError/OSError/ElementTree.ParseError"""
+    name = Path(file).name
+    if name == "go.mod":
+        return parse_go_mod(read_text(path), _go_local_modules(workspace, Path(file).parent.as_posix()))
+    if name == "package.json":
+        return parse_package_json(read_text(path)), 0
+    if name == "pyproject.toml":
+        return parse_pyproject(read_text(path)), 0
+    if name == "Cargo.toml":
+        return parse_cargo_toml(read_text(path)), 0
+    if name == "pom.xml":
+        return parse_pom(read_bytes(path)), 0
+    return parse_requirements(read_text(path), name), 0
+
+
+def build_inventory(all_files: Iterable[str], project_root: Path, workspace: Optional[GoWorkspace] = None,
+                    limit: int = 20) -> Optional[Dict[str, Any]]:
+    """
+    # @codebase-summary: Top-level 'dependencies' section, or None when no manifest was scanned
+    - projects: one entry per manifest directory with each manifest's direct dependencies
+      ({name, version, scope, [group, source, target, optional]}); go.mod '// indirect' requires are only counted
+    - by_ecosystem / by_scope count direct dependency declarations
+    - version_conflicts: registry packages that projects in different directories constrain differently
+      (^4.17.0 vs ^4.17.21); a pin and a range in one directory (requirements.txt next to pyproject.toml)
+      are not a conflict, and peer and build requirements are ranges by design and are left out
+    - all_files are the scanned paths, so vendored and node_modules manifests are never read
+    """
+    manifests = sorted((file for file in all_files if is_manifest(file)),
+                       key=lambda file: (Path(file).parent.as_posix(), Path(file).name))
+    if not manifests:
+        return None
+    workspace = workspace or GoWorkspace(project_root)
+    projects: Dict[str, Dict[str, Any]] = {}
+    unparsed = []
+    by_ecosystem: Dict[str, int] = {}
+    by_scope: Dict[str, int] = {}
+    versions: Dict[Tuple[str, str], Dict[str, List[str]]] = {}
+    for file in manifests:
+        file = Path(file).as_posix()
+        ecosystem = ECOSYSTEMS.get(Path(file).name, "pypi")
+        try:
+            dependencies, indirect = _parse_manifest(Path(project_root) / file, file, workspace)
+        except (OSError, ValueError, TypeError, AttributeError, ElementTree.ParseError) as e:
+            # TypeError/AttributeError: a manifest whose sections have the wrong types (a list where a table belongs)
+            unparsed.append({"file": file, "error": str(e).splitlines()[0] if str(e) else type(e).__name__})
+            continue
+        directory = posixpath.dirname(file) or "."
+        entry = {"file": file, "ecosystem": ecosystem, "dependencies": dependencies}
+        if indirect:
+            entry["indirect"] = indirect
+        projects.setdefault(directory, {"path": directory, "manifests": []})["manifests"].append(entry)
+        by_ecosystem[ecosystem] = by_ecosystem.get(ecosystem, 0) + len(dependencies)
+        for dependency in dependencies:
+            by_scope[dependency["scope"]] = by_scope.get(dependency["scope"], 0) + 1
+            if dependency["version"] and not dependency.get("source") and dependency["scope"] not in ("peer", "build"):
+                key = (ecosystem, _comparable_name(dependency["name"], ecosystem))
+                versions.setdefault(key, {}).setdefault(dependency["version"], []).append(file)
+
+    conflicts = [{"ecosystem": ecosystem, "name": name,
+                  "versions": [{"version": version, "manifests": files} for version, files in sorted(specs.items())]}
+                 for (ecosystem, name), specs in sorted(versions.items())
+                 if len({frozenset(posixpath.dirname(f) for f in files) for files in specs.values()}) > 1]
+    return {
+        "manifests": len(manifests) - len(unparsed),
+        "direct_dependencies": sum(by_ecosystem.values()),
+        "by_ecosystem": dict(sorted(by_ecosystem.items())),
+        "by_scope": dict(sorted(by_scope.items())),
+        "projects": [projects[directory] for directory in sorted(projects)],
+        "version_conflicts": conflicts[:limit],
+        **({"unparsed": unparsed} if unparsed else {}),
+    }
+
+
+def project_dependencies(inventory: Optional[Dict[str, Any]], path: str) -> List[Dict[str, Any]]:
+    """Manifests (with their dependencies) declared in one project directory, for its sub-project report"""
+    for project in (inventory or {}).get("projects", []):
+        if project["path"] == path:
+            return project["manifests"]
+    return []
@@ -0,0 +1,235 @@
+#!/usr/bin/env python3
+"""
+Deprecations - Symbols marked deprecated in any language, and the call sites that still use them
+Markers come from doc comments (Go 'Deprecated:', JSDoc/Javadoc '@deprecated', Sphinx '.. deprecated::'),
+annotations and attributes (@Deprecated, @warnings.deprecated, #[deprecated], [Obsolete]), and Python
+DeprecationWarning calls; references are compared with deprecations.json from the previous scan
+"""
+
+import datetime
+import itertools
+import json
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+import doc_drift
+from text_encoding import read_text
+
+# (marker kind, pattern) in precedence order; group 1, when present, holds the message
+MARKERS = (
+    ("annotation", re.compile(r"@Deprecated\b(?:\s*\((.*)\))?")),
+    ("decorator", re.compile(r"^@(?:[\w.]+\.)?deprecated\b(?:\s*\((.*)\))?\s*$")),
+    ("attribute", re.compile(r"#\[\\?[Dd]eprecated\b(?:\s*\((.*)\))?\s*\]")),
+    ("attribute", re.compile(r"\[Obsolete\b(?:\s*\((.*)\))?\s*\]")),
+    ("attribute", re.compile(r"@available\s*\((.*\bdeprecated\b.*)\)")),
+    ("doc_tag", re.compile(r"@deprecated\b[:\s]*(.*)")),
+    ("doc_comment", re.compile(r"^(?:Deprecated:|\.\.\s+deprecated::)\s*(.*)")),
+)
+_WARNING = re.compile(r"\bwarnings\.warn\s*\((.*\b(?:Pending)?DeprecationWarning\b.*)")
+_COMMENT_PREFIX = re.compile(r"^\s*(?:///?|#(?!\[)|--|;|/\*\*?|\*|'{3}|\"{3})\s?")
+_LEADING_LINE = re.compile(r"^\s*(?://|#|--|;|/\*|\*|@|\[)")
+_STRING = re.compile(r'"((?:[^"\\]|\\.)*)"|\'((?:[^\'\\]|\\.)*)\'')
+_KEYWORD_STRING = re.compile(r'\b(?:message|note|reason)\s*[=:]\s*(?:"((?:[^"\\]|\\.)*)"|\'((?:[^\'\\]|\\.)*)\')')
+_COMMENT_ONLY = re.compile(r"^\s*(?://|#|--|/\*|\*)")
+# Per-file keys (and symbol keys) summarize_deprecations() uses
+SUMMARIZED_FIELDS = ("file", "language", "symbols")
+SYMBOL_FIELDS = ("name", "line", "kind", "deprecated", "deprecation_marker", "deprecation_message")
+# Names this short match too much unrelated code to count references by name
+MIN_REFERENCE_NAME = 3
+
+
+def _message(raw: Optional[str]) -> str:
+    """
+    Message among annotation arguments: '"use bar", ReplaceWith(...)' -> 'use bar'; note=/message:
+    arguments win over the first string, and other keyword arguments alone ('since = "2.0"') carry none
+    """
+    text = (raw or "").strip()
+    match = _KEYWORD_STRING.search(text)
+    if not match and re.match(r"^\s*\w+\s*[=:]", text):
+        return ""
+    match = match or _STRING.search(text)
+    return next(g for g in match.groups() if g is not None).strip() if match else ""
+
+
+def _leading_lines(lines: List[str], index: int) -> List[str]:
+    """Comments, decorators, and annotations directly above lines[index], nearest last"""
+    block = []
+    j = index - 1
+    while j >= 0 and index - j <= 40 and _LEADING_LINE.match(lines[j]):
+        block.append(lines[j])
+        j -= 1
+    return list(reversed(block))
+
+
+def _body_warning(lines: List[str], index: int) -> Optional[str]:
+    """Message of a warnings.warn(..., DeprecationWarning) near the top of a Python function body"""
+    indent = len(lines[index]) - len(lines[index].lstrip())
+    for line in lines[index + 1:index + 16]:
+        stripped = line.strip()
+        if stripped and len(line) - len(line.lstrip()) <= indent:
+            break
+        match = _WARNING.search(line)
+        if match:
+            return _message(match.group(1))
+    return None
+
+
+def deprecation_marker(lines: List[str], index: int, language: str) -> Optional[Tuple[str, str]]:
+    """
+    # @codebase-summary: (marker kind, message) when the declaration on lines[index] is deprecated
+    - Markers must open a line (after any comment marker), so prose that mentions them doesn't count
+    - Looks at the comment/annotation block above, annotations on the declaration line itself
+      ('@Deprecated public void f()'), and for Python the docstring and DeprecationWarning calls
+    - A Go 'Deprecated:' marker must start a line of the doc comment, as go doc expects
+    """
+    candidates = [(line, False) for line in _leading_lines(lines, index) + [lines[index]]]
+    if language in ("python", "starlark"):
+        # Docstrings use the Sphinx/Google forms only; '@...' there is prose
+        candidates += [(line, True) for line in doc_drift.doc_text(lines, index, language).split("\n")]
+    for line, docstring in candidates:
+        text = _COMMENT_PREFIX.sub("", line.strip(), count=1).strip()
+        comment = text != line.strip()
+        for kind, pattern in MARKERS:
+            if (kind == "decorator" and comment) or (docstring and kind != "doc_comment"):
+                continue
+            match = pattern.match(text if kind != "doc_comment" else text.lstrip("*/ "))
+            if match:
+                raw = match.group(1) if match.groups() else None
+                return kind, (raw or "").rstrip("*/").strip() if kind.startswith("doc_") else _message(raw)
+    if language == "python":
+        warning = _body_warning(lines, index)
+        if warning is not None:
+            return "warning", warning
+    return None
+
+
+def annotate_deprecations(symbols: List[Dict[str, Any]], lines: List[str], language: str) -> int:
+    """
+    # @codebase-summary: Mark deprecated symbols and return how many there are
+    - Sets deprecated, deprecation_marker (the marker kind), and deprecation_message when there is one
+    - Symbols an extractor already flagged (Java/Dart annotations, C#/PHP attributes, proto options,
+      GraphQL directives) keep the flag and get the marker kind "annotation" when none is found here
+    """
+    count = 0
+    for symbol in symbols:
+        if not 0 < symbol.get("line", 0) <= len(lines):
+            continue
+        marker = deprecation_marker(lines, symbol["line"] - 1, language)
+        if marker:
+            symbol["deprecated"] = True
+            symbol["deprecation_marker"], message = marker
+            if message:
+                symbol["deprecation_message"] = message
+        elif symbol.get("deprecated"):
+            symbol["deprecation_marker"] = "annotation"
+        count += bool(symbol.get("deprecated"))
+    return count
+
+
+def find_references(project_root: Path, module_files: List[Dict[str, Any]], names: List[str],
+                    declarations: set) -> Dict[str, Dict[str, List[int]]]:
+    """
+    # @codebase-summary: {name: {file: [lines]}} of calls ('name(') and qualified uses ('.name') of names
+    - Declaration lines, comment lines, and string literals are not references
+    - Text-based: a name declared deprecated in one place and alive in another can't be told apart,
+      so callers pass only unambiguous names
+    """
+    if not names:
+        return {}
+    alternation = "|".join(re.escape(name) for name in sorted(names, key=len, reverse=True))
+    pattern = re.compile(rf"(?<![\w$])(?:{alternation})(?=\s*\()|(?<=\.)(?:{alternation})\b")
+    references: Dict[str, Dict[str, List[int]]] = {}
+    for module in module_files:
+        if module["file"].endswith(".ipynb"):
+            continue
+        try:
+            text = read_text(project_root / module["file"])
+        except OSError:
+            continue
+        for number, line in enumerate(text.split("\n"), 1):
+            if (module["file"], number) in declarations or _COMMENT_ONLY.match(line):
+                continue
+            for name in {match.group(0) for match in pattern.finditer(_STRING.sub('""', line))}:
+                references.setdefault(name, {}).setdefault(module["file"], []).append(number)
+    return references
+
+
+def load_deprecation_snapshot(path: Path) -> Optional[Dict[str, Any]]:
+    """A saved deprecations.json ({"symbols", "references"}), or None when there is none yet"""
+    try:
+        with open(path, "r", encoding="utf-8") as f:
+            data = json.load(f)
+    except (OSError, ValueError):
+        return None
+    return data if isinstance(data, dict) and isinstance(data.get("references"), dict) else None
+
+
+def write_deprecation_snapshot(path: Path, snapshot: Dict[str, Any], generator: str):
+    """Save deprecated names and their reference counts per file for the next scan's comparison"""
+    path.parent.mkdir(parents=True, exist_ok=True)
+    with open(path, "w", encoding="utf-8") as f:
+        json.dump({
+            "_generator": f"Generated by {generator} - Deprecated symbols and their references",
+            "generated_at": datetime.datetime.now().isoformat() + "Z",
+            **snapshot,
+        }, f, indent=2, sort_keys=True)
+
+
+def summarize_deprecations(analyses: List[Dict[str, Any]], file_analysis: List[Dict[str, Any]],
+                           module_files: List[Dict[str, Any]], project_root: Path, previous: Optional[Dict[str, Any]],
+                           limit: int = 20) -> Tuple[Dict[str, Any], Dict[str, Any]]:
+    """
+    # @codebase-summary: (summary section, snapshot) for deprecated symbols and their references
+    - Every deprecated symbol with its marker, message, and how many references remain
+    - new_references: files that reference a deprecated name more often than in the previous
+      snapshot; symbols deprecated since then start from their current references
+    - Names that are also declared without a deprecation marker (in analyses or file_analysis) are
+      listed as ambiguous and not counted
+    """
+    deprecated, declarations = [], set()
+    for analysis in analyses:
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("deprecated"):
+                deprecated.append({"file": analysis["file"], "line": symbol["line"], "name": symbol["name"],
+                                   "kind": symbol.get("kind", "function"), "language": analysis["language"],
+                                   "marker": symbol["deprecation_marker"], "message": symbol.get("deprecation_message", "")})
+                declarations.add((analysis["file"], symbol["line"]))
+    live_names = {symbol["name"] for analysis in itertools.chain(analyses, file_analysis)
+                  for symbol in analysis.get("symbols", []) if not symbol.get("deprecated")}
+    names = {entry["name"] for entry in deprecated}
+    ambiguous = sorted(names & live_names)
+    counted = sorted(name for name in names - live_names if len(name) >= MIN_REFERENCE_NAME)
+    references = find_references(project_root, module_files, counted, declarations)
+
+    by_language: Dict[str, int] = {}
+    by_marker: Dict[str, int] = {}
+    for entry in deprecated:
+        by_language[entry["language"]] = by_language.get(entry["language"], 0) + 1
+        by_marker[entry["marker"]] = by_marker.get(entry["marker"], 0) + 1
+        if entry["name"] in counted:
+            entry["references"] = sum(len(sites) for sites in references.get(entry["name"], {}).values())
+
+    section: Dict[str, Any] = {
+        "deprecated_symbols": len(deprecated),
+        "by_language": dict(sorted(by_language.items())),
+        "by_marker": dict(sorted(by_marker.items())),
+        "reference_count": sum(len(sites) for files in references.values() for sites in files.values()),
+        "symbols": sorted(deprecated, key=lambda e: (-e.get("references", 0), e["file"], e["line"]))[:limit],
+        "ambiguous_names": ambiguous[:limit],
+    }
+    if previous is not None:
+        known = set(previous.get("symbols", []))
+        new = []
+        for name in counted:
+            if name not in known:
+                continue
+            before = previous["references"].get(name, {})
+            for file, sites in sorted(references.get(name, {}).items()):
+                if len(sites) > before.get(file, 0):
+                    new.append({"name": name, "file": file, "lines": sites, "previous": before.get(file, 0)})
+        section["new_reference_count"] = sum(len(e["lines"]) - e["previous"] for e in new)
+        section["new_references"] = new[:limit]
+    snapshot = {"symbols": counted,
+                "references": {name: {file: len(sites) for file, sites in files.items()} for name, files in references.items()}}
+    return section, snapshot
@@ -0,0 +1,130 @@
+#!/usr/bin/env python3
+"""
+Doc Drift - Flags documentation that predates its function's current signature
+Each scan records, per documented function, a hash of its doc comment and the parameters it had
+when that comment last changed; a later parameter change with an unchanged comment is likely stale
+"""
+
+import hashlib
+import json
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from annotate import DECORATOR_PREFIXES, symbol_parameters
+from complexity import FUNCTION_KINDS
+
+# Line starts that continue a comment block above a declaration (line, block, and doc comment syntaxes)
+_COMMENT_LINE = re.compile(r"^\s*(?://|#|--|;|/\*|\*|\(\*|\{-|-\}|%|')")
+_DOCSTRING_OPEN = re.compile(r'^\s*[rRuUbB]?("""|\'\'\')')
+
+
+def _comment_block_above(lines: List[str], index: int, language: str) -> List[str]:
+    """Comment lines directly above a declaration, skipping its decorators/annotations"""
+    decorator = DECORATOR_PREFIXES.get(language)
+    j = index - 1
+    while decorator and j >= 0 and lines[j].lstrip().startswith(decorator):
+        j -= 1
+    block = []
+    while j >= 0 and _COMMENT_LINE.match(lines[j]):
+        block.append(lines[j].strip())
+        j -= 1
+    return list(reversed(block))
+
+
+def _docstring_below(lines: List[str], index: int) -> List[str]:
+    """A Python/Starlark docstring opening the body of the def/class declared on lines[index]"""
+    end = next((j for j in range(index, min(len(lines), index + 10)) if lines[j].rstrip().endswith(':')), None)
+    if end is None:
+        return []
+    start = next((j for j in range(end + 1, len(lines)) if lines[j].strip()), None)
+    if start is None:
+        return []
+    opened = _DOCSTRING_OPEN.match(lines[start])
+    if not opened:
+        return []
+    quote = opened.group(1)
+    rest = lines[start][opened.end():]
+    for j in range(start, min(len(lines), start + 200)):
+        if quote in (rest if j == start else lines[j]):
+            return [line.strip() for line in lines[start:j + 1]]
+    return []
+
+
+def doc_text(lines: List[str], index: int, language: str) -> str:
+    """The documentation attached to a declaration: the comment block above plus any docstring"""
+    block = _comment_block_above(lines, index, language)
+    if language in ("python", "starlark"):
+        block += _docstring_below(lines, index)
+    return "\n".join(block)
+
+
+def annotate_signatures(symbols: List[Dict[str, Any]], lines: List[str], language: str):
+    """Record params on function-like symbols, and a doc_hash on documented ones (drift needs both)"""
+    for symbol in symbols:
+        if symbol.get("kind", "function") not in FUNCTION_KINDS:
+            continue
+        symbol["params"] = symbol_parameters(lines, symbol, language)
+        text = doc_text(lines, symbol["line"] - 1, language) if symbol.get("documented") else ""
+        if text:
+            symbol["doc_hash"] = hashlib.sha256(text.encode()).hexdigest()[:16]
+
+
+def qualified_name(symbol: Dict[str, Any]) -> str:
+    """Symbol name prefixed by its parent scope when it has one ('Class.method')"""
+    parent = symbol.get("parent")
+    return f"{parent}.{symbol['name']}" if isinstance(parent, str) and parent else symbol["name"]
+
+
+def load_snapshot(path: Path) -> Dict[str, Any]:
+    """Previous scan's signature snapshot, or {} when there is none yet"""
+    try:
+        with open(path, "r", encoding="utf-8") as f:
+            return json.load(f).get("symbols", {})
+    except (OSError, ValueError, AttributeError):
+        return {}
+
+
+def build_doc_drift(file_analysis: List[Dict[str, Any]], previous: Dict[str, Any],
+                    limit: int = 50) -> Tuple[Dict[str, Any], Dict[str, Any]]:
+    """
+    # @codebase-summary: Stale-documentation detection against the previous scan
+    - Symbols are keyed by file and (parent-qualified) name; repeated names (overloads) by occurrence
+    - A doc comment whose hash is unchanged keeps the parameters recorded when it was written, so a
+      drifted function stays flagged until its documentation is updated
+    - Returns the summary section and the snapshot to save for the next scan
+    """
+    snapshot = {}
+    drifted = []
+    for analysis in file_analysis:
+        occurrences: Dict[str, int] = {}
+        for symbol in analysis.get("symbols", []):
+            if "doc_hash" not in symbol:
+                continue
+            key = f"{analysis['file']}::{qualified_name(symbol)}"
+            occurrences[key] = occurrences.get(key, 0) + 1
+            if occurrences[key] > 1:
+                key = f"{key}#{occurrences[key]}"
+
+            documented_params = symbol["params"]
+            before = previous.get(key)
+            if isinstance(before, dict) and before.get("doc_hash") == symbol["doc_hash"]:
+                documented_params = before.get("documented_params", symbol["params"])
+                if documented_params != symbol["params"]:
+                    drifted.append({
+                        "file": analysis["file"],
+                        "name": symbol["name"],
+                        "line": symbol.get("line", 0),
+                        "documented_params": documented_params,
+                        "params": symbol["params"],
+                        "added": [p for p in symbol["params"] if p not in documented_params],
+                        "removed": [p for p in documented_params if p not in symbol["params"]],
+                    })
+            snapshot[key] = {"doc_hash": symbol["doc_hash"], "documented_params": documented_params}
+
+    section = {
+        "symbols_tracked": len(snapshot),
+        "drift_count": len(drifted),
+        "drifted": drifted[:limit],
+    }
+    return section, snapshot
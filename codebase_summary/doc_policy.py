@@ -0,0 +1,116 @@
+#!/usr/bin/env python3
+"""
+Doc Policy - Per-directory documentation rules from .arkival-policy files
+A policy file applies to its directory's subtree and can override the coverage thresholds, require
+breadcrumb fields, and exclude paths - relaxed rules for generated/vendor code, strict ones for core
+"""
+
+import hashlib
+import json
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+import coverage_gate
+from project_config import glob_matches
+from text_encoding import is_file, read_text
+
+POLICY_FILENAME = ".arkival-policy"
+
+
+def _parse_policy(path: Path) -> Dict[str, Any]:
+    """
+    Validate one policy file: min_coverage ("75,python=90" or a number), required_fields (strings
+    that must appear in a symbol's documentation), and exclude (globs relative to the policy's directory)
+    """
+    data = json.loads(read_text(path))
+    if not isinstance(data, dict):
+        raise ValueError("policy must be a JSON object")
+    policy = {}
+    if "min_coverage" in data:
+        policy["min_coverage"] = coverage_gate.parse_coverage_thresholds(str(data["min_coverage"]))
+    if "required_fields" in data:
+        fields = data["required_fields"]
+        if not isinstance(fields, list) or not all(isinstance(field, str) for field in fields):
+            raise ValueError("'required_fields' must be a list of strings")
+        policy["required_fields"] = fields
+    if "exclude" in data:
+        patterns = data["exclude"]
+        if not isinstance(patterns, list) or not all(isinstance(pattern, str) for pattern in patterns):
+            raise ValueError("'exclude' must be a list of glob patterns")
+        policy["exclude"] = patterns
+    policy["source"] = data
+    return policy
+
+
+class PolicyResolver:
+    """
+    # @codebase-summary: .arkival-policy lookup for any path in the project
+    - Loads each directory's policy file once; policies nest, so a subtree inherits its ancestors' rules
+    - The deepest policy setting min_coverage / required_fields wins; exclude patterns accumulate
+    - Invalid policy files are reported once and ignored
+    """
+
+    def __init__(self, project_root: Path):
+        self.project_root = Path(project_root)
+        self._policies: Dict[Path, Optional[Dict[str, Any]]] = {}
+        self._chains: Dict[Path, List[Tuple[Path, Dict[str, Any]]]] = {}
+
+    def _policy(self, directory: Path) -> Optional[Dict[str, Any]]:
+        """The directory's own policy, or None"""
+        if directory not in self._policies:
+            path = directory / POLICY_FILENAME
+            policy = None
+            if is_file(path):
+                try:
+                    policy = _parse_policy(path)
+                except (OSError, ValueError) as e:
+                    print(f"⚠️ Ignoring invalid policy {path}: {e}")
+            self._policies[directory] = policy
+        return self._policies[directory]
+
+    def chain(self, directory: Path) -> List[Tuple[Path, Dict[str, Any]]]:
+        """(directory, policy) pairs from the project root down to directory"""
+        directory = Path(directory)
+        if directory not in self._chains:
+            if directory == self.project_root or self.project_root not in directory.parents:
+                parent_chain = []
+            else:
+                parent_chain = self.chain(directory.parent)
+            policy = self._policy(directory)
+            self._chains[directory] = parent_chain + ([(directory, policy)] if policy else [])
+        return self._chains[directory]
+
+    def is_excluded(self, path: Path) -> bool:
+        """True when an ancestor directory's policy excludes path (a file or directory)"""
+        for base, policy in self.chain(Path(path).parent):
+            rel_path = Path(path).relative_to(base).as_posix()
+            if any(glob_matches(rel_path, pattern) for pattern in policy.get("exclude", [])):
+                return True
+        return False
+
+    def effective(self, directory: Path) -> Dict[str, Any]:
+        """
+        Merged rules for files in directory: min_coverage with the relative path of the policy
+        directory that set it (its "scope"), required_fields, and a digest of the policies applied
+        """
+        merged: Dict[str, Any] = {}
+        for base, policy in self.chain(directory):
+            if "min_coverage" in policy:
+                merged["min_coverage"] = policy["min_coverage"]
+                merged["scope"] = base.relative_to(self.project_root).as_posix()
+            if "required_fields" in policy:
+                merged["required_fields"] = policy["required_fields"]
+        if merged.get("required_fields"):
+            applied = [policy["source"] for _, policy in self.chain(directory)]
+            merged["digest"] = hashlib.sha256(json.dumps(applied, sort_keys=True).encode()).hexdigest()[:16]
+        return merged
+
+
+def missing_fields(doc: str, required: List[str]) -> List[str]:
+    """
+    Required fields missing from a symbol's documentation. A field is present when a doc line starts
+    with it (case-insensitive) after comment and bullet markers: '- Parameters:', '@param', 'Returns'
+    """
+    return [field for field in required
+            if not re.search(rf"(?im)^[\s#/*;'\-]*{re.escape(field)}\b", doc)]
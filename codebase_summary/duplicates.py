@@ -0,0 +1,200 @@
+#!/usr/bin/env python3
+"""
+Duplicates - Clusters of structurally similar functions across files and languages (--duplicates)
+Function bodies are reduced to language-neutral token streams (identifiers, literals, and keywords
+normalized), fingerprinted by winnowing, and compared by the share of fingerprints they have in common
+"""
+
+import re
+import zlib
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from complexity import FUNCTION_KINDS, clean_lines, function_body
+from text_encoding import file_size, read_text
+
+DEFAULT_THRESHOLD = 0.85
+# Functions shorter than this many tokens (getters, one-line wrappers) are never reported
+MIN_TOKENS = 40
+# Winnowing: k-gram length in tokens and window size in k-grams
+KGRAM = 5
+WINDOW = 4
+# Fingerprints shared by more functions than this are boilerplate, not evidence of copying
+MAX_POSTING = 50
+MAX_FILE_BYTES = 1024 * 1024
+
+_TOKEN = re.compile(r"[A-Za-z_$][\w$]*|\d[\w.]*|\"\"|''|``|===?|!==?|<=|>=|&&|\|\||->|=>|\+\+|--|[-+*/%<>=!&|^~.\[\]]")
+# Keywords that carry structure, mapped to one spelling so Python and C-family code compare
+_KEYWORDS = {
+    "if": "if", "elif": "elif", "else": "else", "for": "for", "foreach": "for", "while": "while",
+    "return": "return", "break": "break", "continue": "continue", "yield": "yield", "await": "await",
+    "try": "try", "catch": "catch", "except": "catch", "rescue": "catch", "finally": "finally",
+    "throw": "raise", "raise": "raise", "panic": "raise", "switch": "switch", "match": "switch",
+    "case": "case", "when": "case", "in": "in", "of": "in", "range": "in", "new": "new", "lambda": "fn",
+    "def": "fn", "func": "fn", "function": "fn", "fn": "fn", "fun": "fn", "sub": "fn",
+    "and": "&&", "or": "||", "not": "!", "is": "==",
+    "None": "null", "null": "null", "nil": "null", "undefined": "null",
+    "True": "true", "true": "true", "False": "false", "false": "false",
+    "===": "==", "!==": "!=",
+}
+# Tokens with no structural meaning (declaration keywords, receivers)
+_IGNORED = {"const", "let", "var", "auto", "val", "self", "this", "async", "static", "public", "private",
+            "protected", "final", "pub", "mut", "export"}
+
+
+def normalize_tokens(text: str) -> List[str]:
+    """
+    # @codebase-summary: Language-neutral token stream of cleaned source text
+    - Identifiers become 'v', numbers 'n', and (already emptied) strings 's'; structural keywords keep
+      one shared spelling (def/func/function -> fn, except/catch -> catch, None/null/nil -> null)
+    - Punctuation that only delimits (braces, parentheses, commas, colons) is dropped, and so are
+      lines holding nothing but a string (docstrings)
+    """
+    tokens = []
+    text = "\n".join(line for line in text.split("\n") if line.strip().rstrip(";") not in ('""', "''", "``"))
+    for token in _TOKEN.findall(text):
+        if token in _IGNORED:
+            continue
+        if token in _KEYWORDS:
+            tokens.append(_KEYWORDS[token])
+        elif token[0].isdigit():
+            tokens.append("n")
+        elif token[0] in "\"'`":
+            tokens.append("s")
+        elif token[0].isalpha() or token[0] in "_$":
+            tokens.append("v")
+        else:
+            tokens.append(token)
+    return tokens
+
+
+def winnow(tokens: List[str]) -> set:
+    """Winnowing fingerprints: the minimum k-gram hash of every window of WINDOW consecutive k-grams"""
+    hashes = [zlib.crc32(" ".join(tokens[i:i + KGRAM]).encode()) for i in range(len(tokens) - KGRAM + 1)]
+    if len(hashes) <= WINDOW:
+        return set(hashes)
+    return {min(hashes[i:i + WINDOW]) for i in range(len(hashes) - WINDOW + 1)}
+
+
+def _function_bodies(symbols: List[Dict[str, Any]], lines: List[str],
+                     language: str) -> List[Tuple[Dict[str, Any], int, str]]:
+    """(symbol, last line, cleaned declaration and body text) for each function-like symbol with a locatable body"""
+    cleaned = clean_lines(lines, language)
+    bodies = []
+    for symbol in symbols:
+        index = symbol.get("line", 0) - 1
+        if symbol.get("kind", "function") not in FUNCTION_KINDS or not 0 <= index < len(lines):
+            continue
+        body = function_body(cleaned, index, language)
+        if body:
+            bodies.append((symbol, body[1] + 1, "\n".join(cleaned[index:body[1] + 1])))
+    return bodies
+
+
+def fingerprint_functions(file_analysis: List[Dict[str, Any]], project_root: Path,
+                          language_of) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Winnowing fingerprints of every function long enough to compare
+    - Each entry has file, name, line, end_line, language, tokens (body length), and fingerprints
+    - Notebooks, files over MAX_FILE_BYTES, and languages without function bodies are skipped
+    """
+    functions = []
+    for analysis in file_analysis:
+        language = language_of(analysis["language"])
+        if analysis["language"] == ".ipynb" or language in ("css", "sql", "clojure", "proto", "graphql"):
+            continue
+        path = project_root / analysis["file"]
+        try:
+            if file_size(path) > MAX_FILE_BYTES:
+                continue
+            lines = read_text(path).split("\n")
+        except OSError:
+            continue
+        for symbol, end_line, text in _function_bodies(analysis.get("symbols", []), lines, language):
+            tokens = normalize_tokens(text)
+            if len(tokens) >= MIN_TOKENS:
+                functions.append({"file": analysis["file"], "name": symbol["name"], "line": symbol.get("line", 0),
+                                  "end_line": end_line, "language": analysis["language"], "tokens": len(tokens),
+                                  "fingerprints": winnow(tokens)})
+    return functions
+
+
+def _nested(a: Dict[str, Any], b: Dict[str, Any]) -> bool:
+    """Whether one function is defined inside the other (an outer body contains its inner functions)"""
+    return a["file"] == b["file"] and a["line"] <= b["end_line"] and b["line"] <= a["end_line"]
+
+
+def find_duplicates(functions: List[Dict[str, Any]], threshold: float = DEFAULT_THRESHOLD,
+                    limit: int = 25) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Cluster functions whose fingerprint similarity reaches threshold
+    - Similarity is the Jaccard index of two functions' fingerprint sets, leaving out boilerplate
+      fingerprints (shared by more than MAX_POSTING functions); candidate pairs come from an inverted
+      fingerprint index, so only functions sharing fingerprints are compared
+    - Nested functions are never compared with their enclosing function
+    - Pairs above the threshold are joined into clusters (union-find); clusters are listed largest first
+      with the lowest similarity that links them
+    """
+    postings: Dict[int, List[int]] = {}
+    for index, function in enumerate(functions):
+        for fingerprint in function["fingerprints"]:
+            postings.setdefault(fingerprint, []).append(index)
+    sizes = [0] * len(functions)
+    shared: Dict[Tuple[int, int], int] = {}
+    for members in postings.values():
+        if len(members) > MAX_POSTING:
+            continue
+        for member in members:
+            sizes[member] += 1
+        for i, a in enumerate(members):
+            for b in members[i + 1:]:
+                shared[(a, b)] = shared.get((a, b), 0) + 1
+
+    parent = list(range(len(functions)))
+
+    def root(i: int) -> int:
+        while parent[i] != i:
+            parent[i] = parent[parent[i]]
+            i = parent[i]
+        return i
+
+    links: List[Tuple[int, int, float]] = []
+    for (a, b), count in shared.items():
+        if _nested(functions[a], functions[b]):
+            continue
+        similarity = count / (sizes[a] + sizes[b] - count)
+        if similarity >= threshold:
+            links.append((a, b, similarity))
+            parent[root(a)] = root(b)
+
+    clusters: Dict[int, Dict[str, Any]] = {}
+    for a, b, similarity in links:
+        cluster = clusters.setdefault(root(a), {"members": set(), "similarity": 1.0})
+        cluster["members"].update((a, b))
+        cluster["similarity"] = min(cluster["similarity"], similarity)
+    items = []
+    for cluster in clusters.values():
+        members = sorted(cluster["members"], key=lambda i: (functions[i]["file"], functions[i]["line"]))
+        items.append({
+            "similarity": round(cluster["similarity"], 3),
+            "tokens": max(functions[i]["tokens"] for i in members),
+            "cross_language": len({functions[i]["language"] for i in members}) > 1,
+            "functions": [{key: functions[i][key] for key in ("file", "name", "line", "language")} for i in members],
+        })
+    items.sort(key=lambda c: (-len(c["functions"]), -c["tokens"], c["functions"][0]["file"], c["functions"][0]["line"]))
+    return {
+        "threshold": threshold,
+        "functions_compared": len(functions),
+        "clusters": len(items),
+        "duplicated_functions": sum(len(c["functions"]) for c in items),
+        "items": items[:limit],
+    }
+
+
+def parse_threshold(value: Optional[str]) -> float:
+    """--duplicate-threshold as a fraction (0.8) or percentage (80); raises ValueError outside (0, 1]"""
+    threshold = DEFAULT_THRESHOLD if value is None else float(value)
+    threshold = threshold / 100 if threshold > 1 else threshold
+    if not 0 < threshold <= 1:
+        raise ValueError(f"{value} is not a similarity between 0 and 1 (or 1-100%)")
+    return threshold
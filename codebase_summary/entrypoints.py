@@ -0,0 +1,457 @@
+#!/usr/bin/env python3
+"""
+Entrypoints - Where a project starts: main functions, HTTP routes, CLI commands, and container commands
+Heuristics over the scanned sources and imports: frameworks are recognized from imports (Flask, FastAPI,
+Django, Express, Gin, Echo, chi, Cobra, Click, Spring, ...), routes and commands from the registration
+idioms of each framework, and container starts from Dockerfile CMD/ENTRYPOINT and Procfile entries
+"""
+
+import re
+import json
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from memory_budget import in_file_order
+from text_encoding import file_size, read_text
+
+MAX_FILE_BYTES = 1024 * 1024
+MAX_LISTED = 100
+
+# Import specifier (exact, or prefix followed by '.', '/' or '@') -> (framework, category)
+FRAMEWORK_IMPORTS = {
+    "python": {
+        "flask": ("Flask", "web"), "fastapi": ("FastAPI", "web"), "django": ("Django", "web"),
+        "starlette": ("Starlette", "web"), "aiohttp": ("aiohttp", "web"), "tornado": ("Tornado", "web"),
+        "click": ("Click", "cli"), "typer": ("Typer", "cli"), "argparse": ("argparse", "cli"),
+    },
+    "javascript": {
+        "express": ("Express", "web"), "fastify": ("Fastify", "web"), "koa": ("Koa", "web"),
+        "@koa/router": ("Koa", "web"), "@nestjs/core": ("NestJS", "web"), "hono": ("Hono", "web"),
+        "next": ("Next.js", "web"), "commander": ("Commander", "cli"), "yargs": ("yargs", "cli"),
+    },
+    "go": {
+        "github.com/gin-gonic/gin": ("Gin", "web"), "github.com/labstack/echo": ("Echo", "web"),
+        "github.com/gofiber/fiber": ("Fiber", "web"), "github.com/go-chi/chi": ("chi", "web"),
+        "github.com/gorilla/mux": ("gorilla/mux", "web"), "net/http": ("net/http", "web"),
+        "github.com/spf13/cobra": ("Cobra", "cli"), "github.com/urfave/cli": ("urfave/cli", "cli"),
+    },
+}
+_IMPORT_LANGUAGE = {"python": "python", "javascript": "javascript", "typescript": "javascript",
+                    "vue": "javascript", "svelte": "javascript", "go": "go"}
+# JVM frameworks are recognized from import lines in the source text
+_JVM_FRAMEWORKS = (("org.springframework", "Spring", "web"), ("io.micronaut", "Micronaut", "web"),
+                   ("io.quarkus", "Quarkus", "web"), ("io.ktor", "Ktor", "web"), ("picocli", "picocli", "cli"))
+_IMPORT_LINE = re.compile(r"^\s*import\s+(?:static\s+)?([\w.]+)", re.M)
+
+_PY_ROUTE = re.compile(r"^\s*@(\w+(?:\.\w+)*)\.(route|get|post|put|patch|delete|head|options|websocket|api_route)"
+                       r"\(\s*[rRuU]?['\"]([^'\"]*)['\"](.*)$")
+_PY_METHODS = re.compile(r"methods\s*=\s*[\[(]([^\])]*)[\])]")
+_DJANGO_PATH = re.compile(r"\b(?:re_)?path\(\s*r?['\"]([^'\"]*)['\"]\s*,\s*([\w.]+)")
+_JS_ROUTE = re.compile(r"\b(\w+)\.(get|post|put|patch|delete|all|head|options)\(\s*['\"`](/[^'\"`]*)['\"`]"
+                       r"(?:\s*,\s*([\w.]+))?")
+_GO_ROUTE = re.compile(r"\b(\w+)\.(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS|Any|Get|Post|Put|Patch|Delete|Handle|HandleFunc)"
+                       r"\(\s*\"([^\"]*)\"\s*,\s*([\w.]+)?")
+_GO_METHODS = re.compile(r"\.Methods\(\s*\"(\w+)\"")
+# Router groups and mounts whose prefix applies to the routes registered on them
+_GO_GROUP = re.compile(r"(\w+)\s*:?=\s*(\w+)\.(?:Group|Route|PathPrefix)\(\s*\"([^\"]*)\"")
+_JS_MOUNT = re.compile(r"\b(\w+)\.(?:use|register)\(\s*['\"`](/[^'\"`]*)['\"`]\s*,\s*(\w+)")
+_PY_BLUEPRINT = re.compile(r"(\w+)\s*=\s*(?:Blueprint|APIRouter)\(.*?\b(?:url_)?prefix\s*=\s*['\"]([^'\"]*)['\"]")
+_SPRING_MAPPING = re.compile(r"@(Get|Post|Put|Patch|Delete|Request)Mapping\b(?:\s*\(([^)]*)\))?")
+_SPRING_PATH = re.compile(r"(?:(?:value|path)\s*=\s*)?\{?\s*\"([^\"]*)\"")
+_SPRING_METHOD = re.compile(r"RequestMethod\.(\w+)")
+_JVM_HANDLER = re.compile(r"\b(?:fun\s+)?(\w+)\s*\(")
+
+_PY_MAIN = re.compile(r"^if\s+__name__\s*==\s*['\"]__main__['\"]\s*:", re.M)
+_PY_COMMAND = re.compile(r"^\s*@(\w+)\.(command|group)\(\s*(?:name\s*=\s*)?(?:['\"]([\w:-]+)['\"])?")
+_PY_ADD_PARSER = re.compile(r"\.add_parser\(\s*['\"]([\w:-]+)['\"]")
+_PY_ARGV_DISPATCH = re.compile(r"sys\.argv\[1\]\s*==\s*['\"]([\w:-]+)['\"]")
+_PY_DEF = re.compile(r"^\s*(?:async\s+)?def\s+(\w+)")
+_JS_COMMAND = re.compile(r"\.command\(\s*['\"`]([\w:-]+)")
+_COBRA_COMMAND = re.compile(r"(\w+)\s*(?::=|=)\s*&(cobra|cli)\.Command\s*\{")
+_COBRA_FIELD = re.compile(r"\b(?:Use|Name):\s*\"([\w:-]+)")
+_COBRA_ADD = re.compile(r"(\w+)\.AddCommand\(([\w\s,]+)\)")
+
+_DOCKER_INSTRUCTION = re.compile(r"^\s*(CMD|ENTRYPOINT|FROM)\s+(.*)$", re.I)
+_PROCFILE_ENTRY = re.compile(r"^([\w-]+):\s*(.+)$")
+
+# Symbol kinds a 'main' symbol is reported for; Go mains are found through the package name instead
+_MAIN_KINDS = ("function", "method")
+_MAIN_NAMES = ("main", "Main")
+
+
+def _read_lines(project_root: Path, file: str) -> Optional[List[str]]:
+    """File lines, or None when unreadable or over MAX_FILE_BYTES"""
+    path = project_root / file
+    try:
+        if file_size(path) > MAX_FILE_BYTES:
+            return None
+        return read_text(path).split("\n")
+    except OSError:
+        return None
+
+
+def _line_of(text: str, offset: int) -> int:
+    """1-based line number of a character offset"""
+    return text.count("\n", 0, offset) + 1
+
+
+def _route_prefixes(text: str, language: str) -> Dict[str, str]:
+    """Path prefix of each router variable: Go r.Group("/api"), Express app.use('/api', router), Flask/FastAPI url_prefix"""
+    prefixes: Dict[str, str] = {}
+    if language == "go":
+        for match in _GO_GROUP.finditer(text):
+            prefixes[match.group(1)] = prefixes.get(match.group(2), "") + match.group(3)
+    elif language == "python":
+        for match in _PY_BLUEPRINT.finditer(text):
+            prefixes[match.group(1)] = match.group(2)
+    else:
+        for match in _JS_MOUNT.finditer(text):
+            prefixes[match.group(3)] = prefixes.get(match.group(1), "") + match.group(2)
+    return {name: prefix.rstrip("/") for name, prefix in prefixes.items()}
+
+
+def _handler(name: Optional[str]) -> Optional[str]:
+    """Named handler of a registration; inline functions and nil have none"""
+    return None if name in (None, "func", "function", "nil", "async") else name
+
+
+def match_frameworks(imports: List[str], language: str) -> List[Tuple[str, str]]:
+    """(framework, category) pairs named by one file's import specifiers"""
+    table = FRAMEWORK_IMPORTS.get(_IMPORT_LANGUAGE.get(language, ""), {})
+    found = []
+    for specifier in imports:
+        for prefix, framework in table.items():
+            if (specifier == prefix or specifier.startswith((prefix + ".", prefix + "/", prefix + "@"))) \
+                    and framework not in found:
+                found.append(framework)
+    return found
+
+
+def _python_routes(lines: List[str], file: str, frameworks: List[str]) -> List[Dict[str, Any]]:
+    """Flask/FastAPI-style decorator routes, plus Django path() entries in files importing Django"""
+    prefixes = _route_prefixes("\n".join(lines), "python")
+    framework = "FastAPI" if "FastAPI" in frameworks else "Flask" if "Flask" in frameworks else \
+        "Starlette" if "Starlette" in frameworks else None
+    routes = []
+    for i, line in enumerate(lines):
+        match = _PY_ROUTE.match(line)
+        if match and framework:
+            verb, path, rest = match.group(2), prefixes.get(match.group(1), "") + match.group(3), match.group(4)
+            listed = _PY_METHODS.search(rest)
+            methods = re.findall(r"\w+", listed.group(1)) if listed else None
+            if verb in ("route", "api_route"):
+                methods = [m.upper() for m in methods] if methods else ["GET"]
+            else:
+                methods = ["WS" if verb == "websocket" else verb.upper()]
+            handler = next((m.group(1) for m in map(_PY_DEF.match, lines[i + 1:i + 10]) if m), None)
+            routes.extend({"framework": framework, "method": method, "path": path, "file": file, "line": i + 1,
+                           "handler": handler} for method in methods)
+        elif "Django" in frameworks:
+            for path in _DJANGO_PATH.finditer(line):
+                routes.append({"framework": "Django", "method": "ANY", "path": path.group(1), "file": file,
+                               "line": i + 1, "handler": path.group(2)})
+    return routes
+
+
+def _javascript_routes(lines: List[str], file: str, frameworks: List[str]) -> List[Dict[str, Any]]:
+    """app.get('/path', handler) / router.post(...) registrations (Express, Fastify, Koa, Hono)"""
+    web = [name for name, category in frameworks if category == "web" and name not in ("Next.js", "NestJS")]
+    if not web:
+        return []
+    prefixes = _route_prefixes("\n".join(lines), "javascript")
+    routes = []
+    for i, line in enumerate(lines):
+        for match in _JS_ROUTE.finditer(line):
+            routes.append({"framework": web[0], "method": match.group(2).upper().replace("ALL", "ANY"),
+                           "path": prefixes.get(match.group(1), "") + match.group(3), "file": file, "line": i + 1,
+                           "handler": _handler(match.group(4))})
+    return routes
+
+
+def _go_routes(lines: List[str], file: str, frameworks: List[str]) -> List[Dict[str, Any]]:
+    """Gin/Echo/Fiber/chi GET(...)-style calls and Handle/HandleFunc (gorilla/mux, net/http, Go 1.22 'GET /x')"""
+    web = [name for name, category in frameworks if category == "web"]
+    if not web:
+        return []
+    # Prefer the router package over net/http, which routers import too
+    framework = next((name for name in web if name != "net/http"), web[0])
+    prefixes = _route_prefixes("\n".join(lines), "go")
+    routes = []
+    for i, line in enumerate(lines):
+        for match in _GO_ROUTE.finditer(line):
+            verb, path, handler = match.group(2), match.group(3), match.group(4)
+            if verb in ("Handle", "HandleFunc"):
+                pattern_method, _, pattern_path = path.partition(" ")
+                if pattern_path and pattern_method.isupper():
+                    method, path = pattern_method, pattern_path.strip()
+                else:
+                    explicit = _GO_METHODS.search(line[match.end():])
+                    method = explicit.group(1).upper() if explicit else "ANY"
+                owner = "net/http" if match.group(1) == "http" else framework
+            else:
+                method, owner = ("ANY" if verb == "Any" else verb.upper()), framework
+            if path.startswith("/") or path == "":
+                routes.append({"framework": owner, "method": method, "path": prefixes.get(match.group(1), "") + path,
+                               "file": file, "line": i + 1, "handler": _handler(handler)})
+    return routes
+
+
+def _spring_routes(lines: List[str], file: str) -> List[Dict[str, Any]]:
+    """@GetMapping/@RequestMapping handler methods, prefixed by the class-level @RequestMapping"""
+    prefix, seen_class, routes = "", False, []
+    for i, line in enumerate(lines):
+        if re.search(r"\b(class|interface|object)\s+\w+", line) and not line.strip().startswith(("//", "*", "@")):
+            seen_class = True
+        match = _SPRING_MAPPING.search(line)
+        if not match:
+            continue
+        arguments = match.group(2) or ""
+        path_match = _SPRING_PATH.search(arguments)
+        path = path_match.group(1) if path_match else ""
+        if not seen_class:
+            prefix = path.rstrip("/")
+            continue
+        if match.group(1) == "Request":
+            methods = [m.upper() for m in _SPRING_METHOD.findall(arguments)] or ["ANY"]
+        else:
+            methods = [match.group(1).upper()]
+        handler = None
+        for following in lines[i + 1:i + 6]:
+            if following.strip().startswith("@"):
+                continue
+            name = _JVM_HANDLER.findall(following)
+            handler = name[-1] if name else None
+            break
+        full = prefix + ("/" + path.lstrip("/") if path else "")
+        routes.extend({"framework": "Spring", "method": method, "path": full or "/", "file": file, "line": i + 1,
+                       "handler": handler} for method in methods)
+    return routes
+
+
+def _python_commands(lines: List[str], file: str, frameworks: List[str]) -> List[Dict[str, Any]]:
+    """Click/Typer @x.command()/@x.group(), argparse add_parser(), and sys.argv[1] == '...' dispatch"""
+    program = Path(file).stem
+    groups: Dict[str, str] = {}
+    commands = []
+    for i, line in enumerate(lines):
+        decorator = _PY_COMMAND.match(line)
+        if decorator and {"Click", "Typer"} & set(frameworks):
+            owner, kind, explicit = decorator.groups()
+            function = next((m.group(1) for m in map(_PY_DEF.match, lines[i + 1:i + 10]) if m), None)
+            name = explicit or (function or "").replace("_", "-")
+            parent = groups.get(owner)
+            if kind == "group" and function:
+                groups[function] = name
+            if name:
+                commands.append({"framework": "Typer" if "Typer" in frameworks else "Click",
+                                 "command": " ".join(p for p in (program, parent, name) if p),
+                                 "file": file, "line": i + 1, "handler": function})
+            continue
+        for pattern, framework in ((_PY_ADD_PARSER, "argparse"), (_PY_ARGV_DISPATCH, "sys.argv")):
+            for match in pattern.finditer(line):
+                commands.append({"framework": framework, "command": f"{program} {match.group(1)}",
+                                 "file": file, "line": i + 1, "handler": None})
+    return commands
+
+
+def _go_commands(text: str, file: str) -> List[Dict[str, Any]]:
+    """Cobra/urfave &Command{Use/Name: ...} literals, nested through AddCommand calls"""
+    declared: Dict[str, Tuple[str, int, str]] = {}
+    for match in _COBRA_COMMAND.finditer(text):
+        field = _COBRA_FIELD.search(text, match.end(), match.end() + 600)
+        if field:
+            framework = "Cobra" if match.group(2) == "cobra" else "urfave/cli"
+            declared[match.group(1)] = (field.group(1), _line_of(text, match.start()), framework)
+    parents: Dict[str, str] = {}
+    for match in _COBRA_ADD.finditer(text):
+        for child in re.findall(r"\w+", match.group(2)):
+            parents[child] = match.group(1)
+    commands = []
+    for variable, (name, line, framework) in declared.items():
+        path, current, hops = [name], variable, 0
+        while current in parents and hops < 10:
+            current = parents[current]
+            path.insert(0, declared[current][0] if current in declared else current)
+            hops += 1
+        commands.append({"framework": framework, "command": " ".join(path), "file": file, "line": line,
+                         "handler": None})
+    return commands
+
+
+def _javascript_commands(lines: List[str], file: str, frameworks: List[str]) -> List[Dict[str, Any]]:
+    """program.command('name') registrations (Commander, yargs)"""
+    cli = [name for name, category in frameworks if category == "cli"]
+    if not cli:
+        return []
+    return [{"framework": cli[0], "command": match.group(1), "file": file, "line": i + 1, "handler": None}
+            for i, line in enumerate(lines) for match in _JS_COMMAND.finditer(line)]
+
+
+def _docker_commands(lines: List[str], file: str) -> List[Dict[str, Any]]:
+    """CMD/ENTRYPOINT of each build stage (exec-form arrays joined with spaces), continuation lines joined"""
+    logical: List[Tuple[int, str]] = []
+    pending, start = "", 0
+    for i, line in enumerate(lines):
+        if not pending:
+            start = i
+        if line.rstrip().endswith("\\"):
+            pending += line.rstrip()[:-1] + " "
+            continue
+        logical.append((start + 1, pending + line))
+        pending = ""
+    stage, entries = None, []
+    for number, line in logical:
+        match = _DOCKER_INSTRUCTION.match(line)
+        if not match:
+            continue
+        instruction, argument = match.group(1).upper(), match.group(2).strip()
+        if instruction == "FROM":
+            alias = re.search(r"\s+as\s+(\S+)", argument, re.I)
+            stage = alias.group(1) if alias else argument.split()[0] if argument.split() else None
+            continue
+        try:
+            parsed = json.loads(argument)
+            command = " ".join(str(part) for part in parsed) if isinstance(parsed, list) else argument
+        except ValueError:
+            command = argument
+        entries.append({"file": file, "line": number, "instruction": instruction, "command": command,
+                        "stage": stage})
+    return entries
+
+
+def _package_scripts(lines: List[str], file: str) -> List[Dict[str, Any]]:
+    """package.json bin/main entries and the start script"""
+    try:
+        package = json.loads("\n".join(lines))
+    except ValueError:
+        return []
+    if not isinstance(package, dict):
+        return []
+    entries = []
+    bin_field = package.get("bin")
+    if isinstance(bin_field, str):
+        entries.append({"file": file, "kind": "bin", "name": package.get("name", ""), "target": bin_field})
+    elif isinstance(bin_field, dict):
+        entries.extend({"file": file, "kind": "bin", "name": name, "target": target}
+                       for name, target in bin_field.items() if isinstance(target, str))
+    if isinstance(package.get("main"), str):
+        entries.append({"file": file, "kind": "main", "name": package.get("name", ""), "target": package["main"]})
+    start = (package.get("scripts") or {}).get("start") if isinstance(package.get("scripts"), dict) else None
+    if isinstance(start, str):
+        entries.append({"file": file, "kind": "start", "name": "start", "target": start})
+    return entries
+
+
+def _pyproject_scripts(lines: List[str], file: str) -> List[Dict[str, Any]]:
+    """Console scripts from [project.scripts] and [tool.poetry.scripts]"""
+    entries, in_scripts = [], False
+    for line in lines:
+        stripped = line.strip()
+        if stripped.startswith("["):
+            in_scripts = stripped in ("[project.scripts]", "[tool.poetry.scripts]")
+            continue
+        match = re.match(r"""^["']?([\w.-]+)["']?\s*=\s*["']([^"']+)["']""", stripped) if in_scripts else None
+        if match:
+            entries.append({"file": file, "kind": "console_script", "name": match.group(1), "target": match.group(2)})
+    return entries
+
+
+def _is_dockerfile(file: str) -> bool:
+    """Dockerfile, Containerfile, Dockerfile.dev, api.Dockerfile"""
+    name = Path(file).name.lower()
+    return name in ("dockerfile", "containerfile") or name.startswith("dockerfile.") or name.endswith(".dockerfile")
+
+
+def detect_entrypoints(file_analysis: List[Dict[str, Any]], module_files: List[Dict[str, Any]],
+                       all_files: List[str], project_root: Path, language_of) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Entrypoints section: frameworks, main functions, HTTP routes, CLI commands, container starts
+    - Frameworks come from each file's imports (module_files) and JVM import lines; routes and commands
+      are only looked for in files that import a matching framework, so unrelated .get('/x') calls stay out
+    - mains: Go func main in package main, Python __main__ guards, and main/Main functions elsewhere
+    - containers: Dockerfile/Containerfile CMD and ENTRYPOINT per stage; processes: Procfile entries;
+      scripts: package.json bin/main/start and pyproject console scripts
+    - Mains, routes, and commands are capped at MAX_LISTED each; route and command totals are always exact
+    """
+    imports = {entry["file"]: entry.get("imports") or [] for entry in module_files}
+    frameworks: Dict[Tuple[str, str], Dict[str, Any]] = {}
+    mains, routes, commands = [], [], []
+
+    def note(framework: Tuple[str, str], language: str, file: str):
+        entry = frameworks.setdefault(framework, {"name": framework[0], "category": framework[1],
+                                                  "language": language, "files": 0, "example": file})
+        entry["files"] += 1
+
+    for analysis in in_file_order(file_analysis):
+        file, language = analysis["file"], language_of(analysis["language"])
+        symbols = analysis.get("symbols", [])
+        for symbol in symbols:
+            if symbol.get("name") in _MAIN_NAMES and symbol.get("kind", "function") in _MAIN_KINDS:
+                if language == "go" and symbol.get("package", "main") != "main":
+                    continue
+                mains.append({"kind": "go_main_package" if language == "go" else "main", "language": language,
+                              "file": file, "line": symbol.get("line", 0),
+                              **({"package_dir": Path(file).parent.as_posix()} if language == "go" else {})})
+        if language not in ("python", "javascript", "typescript", "go", "java", "kotlin", "vue", "svelte"):
+            continue
+        lines = _read_lines(project_root, file)
+        if lines is None:
+            continue
+        text = "\n".join(lines)
+        if language in ("java", "kotlin"):
+            named = [(name, category) for prefix, name, category in _JVM_FRAMEWORKS
+                     if any(i.startswith(prefix) for i in _IMPORT_LINE.findall(text))]
+        else:
+            named = match_frameworks(imports.get(file, []), language)
+        for framework in named:
+            note(framework, language, file)
+        names = [name for name, _ in named]
+        if language == "python":
+            for match in _PY_MAIN.finditer(text):
+                mains.append({"kind": "python_main_guard", "language": language, "file": file,
+                              "line": _line_of(text, match.start())})
+            routes.extend(_python_routes(lines, file, names))
+            commands.extend(_python_commands(lines, file, names))
+        elif language == "go":
+            routes.extend(_go_routes(lines, file, named))
+            if "Cobra" in names or "urfave/cli" in names:
+                commands.extend(_go_commands(text, file))
+        elif language in ("java", "kotlin"):
+            if "Spring" in names:
+                routes.extend(_spring_routes(lines, file))
+        else:
+            routes.extend(_javascript_routes(lines, file, named))
+            commands.extend(_javascript_commands(lines, file, named))
+
+    containers, processes, scripts = [], [], []
+    for file in sorted(all_files):
+        name = Path(file).name
+        if not (_is_dockerfile(file) or name in ("Procfile", "package.json", "pyproject.toml")):
+            continue
+        if "node_modules/" in file:
+            continue
+        lines = _read_lines(project_root, file)
+        if lines is None:
+            continue
+        if _is_dockerfile(file):
+            containers.extend(_docker_commands(lines, file))
+        elif name == "Procfile":
+            processes.extend({"file": file, "line": i + 1, "process": m.group(1), "command": m.group(2).strip()}
+                             for i, m in enumerate(map(_PROCFILE_ENTRY.match, lines)) if m)
+        elif name == "package.json":
+            scripts.extend(_package_scripts(lines, file))
+        else:
+            scripts.extend(_pyproject_scripts(lines, file))
+
+    by_framework: Dict[str, int] = {}
+    for route in routes:
+        by_framework[route["framework"]] = by_framework.get(route["framework"], 0) + 1
+    return {
+        "frameworks": sorted(frameworks.values(), key=lambda f: (-f["files"], f["name"])),
+        "mains": mains[:MAX_LISTED],
+        "http_routes": {"total": len(routes), "by_framework": by_framework, "routes": routes[:MAX_LISTED]},
+        "cli_commands": {"total": len(commands), "commands": commands[:MAX_LISTED]},
+        "containers": containers,
+        "processes": processes,
+        "scripts": scripts,
+    }
@@ -0,0 +1,261 @@
+#!/usr/bin/env python3
+"""
+Extractor Plugins - User-supplied language extractors loaded without modifying the scanner
+Command plugins (any executable: a Go binary, a WASM module run by wasmtime, a script) are
+declared by JSON manifests; Python plugins are modules defining LANGUAGE, EXTENSIONS, and extract().
+Simpler languages can be defined in workflow_config.json as regexes plus comment syntax.
+"""
+
+import importlib.util
+import json
+import re
+import subprocess
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+import language_extractors
+
+DEFAULT_TIMEOUT = 30
+
+# String literal syntax a config-defined language may declare (string_delimiters)
+_LITERALS = {'"': re.compile(r'"(?:\\.|[^"\\])*"'), "'": re.compile(r"'(?:\\.|[^'\\])*'"),
+             "`": re.compile(r'`(?:\\.|[^`\\])*`')}
+
+
+def _apply_doc_prefix(symbols: List[Dict[str, Any]], lines: List[str], prefix: Optional[str]) -> List[Dict[str, Any]]:
+    """Mark symbols preceded by the plugin's doc-comment prefix line as documented (doc_comment="plugin")"""
+    if not prefix:
+        return symbols
+    for symbol in symbols:
+        index = symbol.get("line", 0) - 1
+        if "doc_comment" not in symbol and 0 < index < len(lines) and \
+                language_extractors.has_line_doc_comment(lines, index, prefix):
+            symbol["doc_comment"] = "plugin"
+    return symbols
+
+
+def _validate_symbols(symbols: Any, plugin: str) -> List[Dict[str, Any]]:
+    """Keep well-formed symbols (name + positive line); the scanner relies on both"""
+    if not isinstance(symbols, list):
+        raise ValueError(f"plugin '{plugin}' returned {type(symbols).__name__}, expected a list of symbols")
+    valid = []
+    for symbol in symbols:
+        if isinstance(symbol, dict) and isinstance(symbol.get("name"), str) and \
+                isinstance(symbol.get("line"), int) and symbol["line"] > 0:
+            valid.append({"kind": "function", **symbol})
+    return valid
+
+
+def _command_executable(manifest: Dict[str, Any], base_dir: Path) -> Optional[Path]:
+    """The command's program when it is a file relative to the manifest (not a PATH lookup)"""
+    executable = base_dir / str(manifest["command"][0])
+    return executable if executable.is_file() else None
+
+
+def _command_extractor(manifest: Dict[str, Any], base_dir: Path):
+    """
+    Extractor that runs the manifest's command with the file's source on stdin.
+    The command prints {"symbols": [...]} (or {"error": "..."}) as JSON on stdout.
+    """
+    command = [str(part) for part in manifest["command"]]
+    executable = _command_executable(manifest, base_dir)
+    if executable:
+        command[0] = str(executable)
+    timeout = manifest.get("timeout", DEFAULT_TIMEOUT)
+    name = manifest["name"]
+
+    def extract(lines: List[str]) -> List[Dict[str, Any]]:
+        result = subprocess.run(command, input="\n".join(lines), capture_output=True, text=True,
+                                timeout=timeout, cwd=base_dir)
+        if result.returncode != 0:
+            raise RuntimeError(f"plugin '{name}' exited {result.returncode}: {result.stderr.strip()[:200]}")
+        output = json.loads(result.stdout)
+        if output.get("error"):
+            raise RuntimeError(f"plugin '{name}': {output['error']}")
+        symbols = _validate_symbols(output.get("symbols", []), name)
+        return _apply_doc_prefix(symbols, lines, manifest.get("doc_prefix"))
+
+    return extract
+
+
+def _python_plugin(path: Path) -> Dict[str, Any]:
+    """Load a Python plugin module into a manifest-shaped descriptor"""
+    spec = importlib.util.spec_from_file_location(f"arkival_plugin_{path.stem}", path)
+    module = importlib.util.module_from_spec(spec)
+    spec.loader.exec_module(module)
+    manifest = {
+        "name": getattr(module, "NAME", path.stem),
+        "language": getattr(module, "LANGUAGE", None),
+        "extensions": list(getattr(module, "EXTENSIONS", [])),
+        "filenames": list(getattr(module, "FILENAMES", [])),
+        "patterns": list(getattr(module, "PATTERNS", [])),
+        "doc_prefix": getattr(module, "DOC_PREFIX", None),
+    }
+    extract = getattr(module, "extract", None)
+    if not callable(extract):
+        raise ValueError("Python plugins must define extract(lines)")
+    name = manifest["name"]
+    manifest["extract"] = lambda lines: _apply_doc_prefix(
+        _validate_symbols(extract(lines), name), lines, manifest["doc_prefix"])
+    return manifest
+
+
+def load_extractor_plugins(directory: Path) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Extractor plugin discovery and registration
+    - Reads every *.json manifest (command plugins) and *.py module (Python plugins) in directory
+    - Each plugin names a language key, the extensions and/or exact file names it handles, and
+      optional regex fallback patterns and a doc-comment line prefix (e.g. '##')
+    - Registers the plugin's extractor under its language; invalid plugins are reported and skipped
+    - Returns the loaded plugin descriptors (without their extract callables)
+    """
+    if not directory.is_dir():
+        return []
+
+    plugins = []
+    for path in sorted(directory.iterdir()):
+        if path.suffix not in (".json", ".py") or path.name.startswith(("_", ".")):
+            continue
+        try:
+            if path.suffix == ".json":
+                with open(path, "r", encoding="utf-8") as f:
+                    manifest = json.load(f)
+                manifest.setdefault("name", path.stem)
+                if not isinstance(manifest.get("command"), list) or not manifest["command"]:
+                    raise ValueError("'command' must be a non-empty list")
+                manifest["extract"] = _command_extractor(manifest, path.parent)
+                executable = _command_executable(manifest, path.parent)
+                manifest["files"] = [str(path)] + ([str(executable)] if executable else [])
+            else:
+                manifest = _python_plugin(path)
+                manifest["files"] = [str(path)]
+
+            language = manifest.get("language")
+            extensions = [ext.lower() if ext.startswith(".") else f".{ext.lower()}" for ext in manifest.get("extensions", [])]
+            if not isinstance(language, str) or not language:
+                raise ValueError("'language' is required")
+            if not extensions and not manifest.get("filenames"):
+                raise ValueError("at least one of 'extensions' or 'filenames' is required")
+        except Exception as e:
+            print(f"⚠️ Skipping extractor plugin {path.name}: {e}")
+            continue
+
+        language_extractors.register_extractor(language)(manifest["extract"])
+        plugins.append({
+            "name": manifest["name"],
+            "language": language,
+            "extensions": extensions,
+            "filenames": list(manifest.get("filenames", [])),
+            "patterns": list(manifest.get("patterns", [])),
+            # Manifest/module and executable - their contents invalidate cached scan results
+            "files": manifest["files"],
+        })
+        print(f"🔌 Loaded extractor plugin '{manifest['name']}' for {language}")
+    return plugins
+
+
+def _has_config_block_doc(lines: List[str], index: int, block_comment: List[str], opener: str) -> bool:
+    """A block comment that starts with opener (e.g. '(**') and ends directly above the declaration"""
+    start, end = block_comment
+    j = index - 1
+    while j >= 0 and not lines[j].strip():
+        j -= 1
+    if j < 0 or not lines[j].rstrip().endswith(end):
+        return False
+    for k in range(j, max(-1, j - 500), -1):
+        position = lines[k].find(start)
+        if position != -1:
+            return lines[k].startswith(opener, position)
+    return False
+
+
+def compile_config_language(definition: Dict[str, Any]):
+    """
+    # @codebase-summary: Extractor compiled from a config-defined language
+    - function_patterns are regexes (or {"pattern": ..., "kind": ...} objects) whose last
+      non-empty group is the symbol name; they run on lines with comments and strings removed
+    - line_comment / block_comment give the comment syntax; doc_line_prefix (e.g. '##') and
+      doc_block_opener (e.g. '(**') mark doc comments directly above a symbol (doc_comment="config")
+    - Raises ValueError for invalid regexes or comment syntax
+    """
+    patterns = []
+    for entry in definition.get("function_patterns", []):
+        pattern, kind = (entry.get("pattern"), entry.get("kind", "function")) if isinstance(entry, dict) else (entry, "function")
+        try:
+            patterns.append((re.compile(pattern), kind))
+        except (re.error, TypeError) as e:
+            raise ValueError(f"invalid function pattern {pattern!r}: {e}")
+    if not patterns:
+        raise ValueError("'function_patterns' must contain at least one regex")
+    block_comment = definition.get("block_comment")
+    if block_comment is not None and (not isinstance(block_comment, list) or len(block_comment) != 2):
+        raise ValueError("'block_comment' must be a [start, end] pair")
+    line_comment = definition.get("line_comment")
+    doc_line_prefix = definition.get("doc_line_prefix")
+    doc_block_opener = definition.get("doc_block_opener")
+    if doc_block_opener and not block_comment:
+        raise ValueError("'doc_block_opener' requires 'block_comment'")
+    literals = tuple(_LITERALS[q] for q in definition.get("string_delimiters", ['"', "'"]) if q in _LITERALS)
+
+    def extract(lines: List[str]) -> List[Dict[str, Any]]:
+        cleaner = language_extractors.BraceScopeTracker(line_comment, tuple(block_comment) if block_comment else None, literals)
+        symbols = []
+        for i, line in enumerate(lines):
+            text = cleaner.clean(line)
+            for pattern, kind in patterns:
+                match = pattern.search(text)
+                if not match:
+                    continue
+                name = next((g for g in reversed(match.groups()) if g), None) if match.groups() else match.group(0)
+                if not name:
+                    continue
+                symbol = {"name": name, "kind": kind, "line": i + 1}
+                if (doc_line_prefix and language_extractors.has_line_doc_comment(lines, i, doc_line_prefix)) or \
+                        (doc_block_opener and _has_config_block_doc(lines, i, block_comment, doc_block_opener)):
+                    symbol["doc_comment"] = "config"
+                symbols.append(symbol)
+                break
+        return symbols
+
+    return extract
+
+
+
+def load_config_languages(config_path: Path) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Config-defined languages from workflow_config.json
+    - Reads "scanner": {"languages": {"<name>": {...}}} - extensions and/or filenames, function
+      patterns, comment syntax, and doc-comment markers per language
+    - Registers each compiled extractor like a plugin; invalid definitions are reported and skipped
+    - Returns plugin-shaped descriptors whose "definition" feeds the scan-cache fingerprint
+    """
+    try:
+        with open(config_path, "r", encoding="utf-8") as f:
+            config = json.load(f)
+    except (OSError, ValueError):
+        return []
+    languages = ((config.get("scanner") or {}).get("languages") or {}) if isinstance(config, dict) else {}
+
+    descriptors = []
+    for language, definition in sorted(languages.items()):
+        try:
+            if not isinstance(definition, dict):
+                raise ValueError("definition must be an object")
+            extensions = [ext.lower() if ext.startswith(".") else f".{ext.lower()}" for ext in definition.get("extensions", [])]
+            if not extensions and not definition.get("filenames"):
+                raise ValueError("at least one of 'extensions' or 'filenames' is required")
+            extract = compile_config_language(definition)
+        except Exception as e:
+            print(f"⚠️ Skipping config language '{language}': {e}")
+            continue
+        language_extractors.register_extractor(language)(extract)
+        descriptors.append({
+            "name": language,
+            "language": language,
+            "extensions": extensions,
+            "filenames": list(definition.get("filenames", [])),
+            "patterns": [p.get("pattern") if isinstance(p, dict) else p for p in definition["function_patterns"]],
+            "files": [],
+            "definition": definition,
+        })
+    return descriptors
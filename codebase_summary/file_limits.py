@@ -0,0 +1,84 @@
+#!/usr/bin/env python3
+"""
+File Limits - Skip rules for files not worth parsing (huge, binary, or minified)
+Such files are never run through the extractors: a multi-megabyte bundle or a data blob with a code
+extension only slows the scan and adds noise, so each one is listed with its reason instead
+"""
+
+from typing import Dict, Any, List, Optional
+
+from memory_budget import format_size, parse_size
+
+# Defaults for --max-file-size and --max-line-length; "0" or "off" turns a rule off
+DEFAULT_MAX_FILE_SIZE = "1M"
+DEFAULT_MAX_LINE_LENGTH = 1000
+# Bytes searched for a NUL byte, which text files do not contain
+BINARY_SNIFF = 8192
+# A file is minified when lines over the length limit hold at least this share of its characters
+# and have less than MINIFIED_WHITESPACE whitespace (hand-written code is indented and spaced)
+MINIFIED_SHARE = 0.5
+MINIFIED_WHITESPACE = 0.1
+_OFF = ("0", "off", "none", "false")
+
+
+def parse_limit(text: Optional[str], default: Any, size: bool = False) -> Optional[int]:
+    """
+    # @codebase-summary: Limit from a --max-file-size / --max-line-length value; None when turned off
+    - Sizes accept the --max-memory forms (512K, 2M, 1G); line lengths are plain character counts
+    - Raises ValueError for anything else
+    """
+    text = str(default) if text is None else text.strip()
+    if text.lower() in _OFF:
+        return None
+    if size:
+        return parse_size(text)
+    if not text.isdigit():
+        raise ValueError(f"'{text}' is not a line length in characters")
+    return int(text)
+
+
+def oversize_reason(size: int, max_size: Optional[int]) -> Optional[str]:
+    """Skip reason for a file larger than max_size bytes, checked before the file is read"""
+    if max_size is None or size <= max_size:
+        return None
+    return f"size: {format_size(size)} exceeds {format_size(max_size)}"
+
+
+def is_binary(content: str) -> bool:
+    """Whether decoded file content has a NUL character in its first BINARY_SNIFF characters"""
+    return "\x00" in content[:BINARY_SNIFF]
+
+
+def minified_reason(lines: List[str], max_line_length: Optional[int]) -> Optional[str]:
+    """
+    # @codebase-summary: Skip reason for minified or machine-packed text, or None
+    - Needs lines longer than max_line_length that carry most of the characters with hardly any
+      whitespace, so a long hand-written line (a URL, an SQL string) alone does not skip a file
+    """
+    if max_line_length is None:
+        return None
+    long_lines = [line for line in lines if len(line) > max_line_length]
+    if not long_lines:
+        return None
+    long_chars = sum(len(line) for line in long_lines)
+    if long_chars < MINIFIED_SHARE * sum(len(line) for line in lines):
+        return None
+    whitespace = sum(line.count(" ") + line.count("\t") for line in long_lines)
+    if whitespace >= MINIFIED_WHITESPACE * long_chars:
+        return None
+    return f"minified: {len(long_lines)} line(s) over {max_line_length} characters, longest {max(map(len, long_lines))}"
+
+
+def summarize_skipped(skipped: List[Dict[str, Any]], limits: Dict[str, Optional[int]],
+                      limit: int = 100) -> Dict[str, Any]:
+    """Summary section listing files left unparsed, with counts per reason (binary, minified, size)"""
+    by_reason: Dict[str, int] = {}
+    for entry in skipped:
+        kind = entry["reason"].split(":", 1)[0]
+        by_reason[kind] = by_reason.get(kind, 0) + 1
+    return {
+        "files": len(skipped),
+        "by_reason": dict(sorted(by_reason.items())),
+        "limits": limits,
+        "listed": skipped[:limit],
+    }
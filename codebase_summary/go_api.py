@@ -0,0 +1,165 @@
+#!/usr/bin/env python3
+"""
+Go API - Exported (public API) and unexported Go symbols per package, and breaking changes between runs
+Each scan saves the exported surface to go_api.json; the next scan, or 'api-diff old.json new.json',
+reports exported functions, methods, and types that were removed or whose signatures changed
+"""
+
+import datetime
+import json
+from pathlib import Path
+from typing import Dict, Any, Callable, List, Optional
+
+def is_exported(name: str) -> bool:
+    """Go's rule: an identifier is exported when it starts with an upper-case letter"""
+    return name[:1].isupper()
+
+
+def receiver_base(receiver: str) -> str:
+    """Type name of a method receiver: '*Stack[T]' -> 'Stack'"""
+    return receiver.lstrip("*").split("[", 1)[0]
+
+
+def _api_entry(symbol: Dict[str, Any]) -> Optional[tuple]:
+    """
+    # @codebase-summary: (key, declaration) of an exported symbol, or None for unexported ones
+    - Keys identify the symbol across runs ('func Sum', 'method Stack.Push', 'type Pair');
+      declarations render what callers depend on ('[N Number](...N) N', 'struct[K comparable, V any]')
+    - Methods are public API only when the receiver type is exported as well
+    - Interfaces include their method set, since adding a method breaks implementers
+    """
+    name, kind = symbol["name"], symbol.get("kind", "function")
+    if kind == "method":
+        receiver = receiver_base(symbol.get("receiver", ""))
+        if not (is_exported(name) and is_exported(receiver)):
+            return None
+        return f"method {receiver}.{name}", f"({symbol.get('receiver', '')}) {symbol.get('signature', '')}".rstrip()
+    if not is_exported(name):
+        return None
+    if kind == "function":
+        return f"func {name}", f"{symbol.get('type_params', '')}{symbol.get('signature', '')}"
+    declaration = f"{kind}{symbol.get('type_params', '')}"
+    if kind == "interface":
+        members = [f"{method}{symbol.get('method_signatures', {}).get(method, '')}" for method in sorted(symbol.get("methods", []))]
+        members += sorted(symbol.get("embeds", []))
+        if symbol.get("type_set"):
+            members.append(" | ".join(symbol["type_set"]))
+        declaration += "{" + "; ".join(members) + "}"
+    return f"type {name}", declaration
+
+
+def build_api_surface(file_analysis: List[Dict[str, Any]]) -> Dict[str, Dict[str, Any]]:
+    """
+    # @codebase-summary: Exported declarations and unexported counts per Go package directory
+    - _test.go files are left out; they are not part of a package's importable API
+    - Files in a directory that declare the same symbol for different platforms (build constraints)
+      collapse into one entry
+    """
+    packages: Dict[str, Dict[str, Any]] = {}
+    for analysis in file_analysis:
+        if analysis.get("language") != ".go" or analysis.get("go_test_file"):
+            continue
+        directory = Path(analysis["file"]).parent.as_posix()
+        for symbol in analysis.get("symbols", []):
+            package = packages.setdefault(directory, {"package": symbol.get("package", ""), "exported": {}, "unexported": 0})
+            package["package"] = package["package"] or symbol.get("package", "")
+            entry = _api_entry(symbol)
+            if entry is None:
+                package["unexported"] += 1
+            else:
+                package["exported"][entry[0]] = entry[1]
+    return {directory: {**package, "exported": dict(sorted(package["exported"].items()))}
+            for directory, package in sorted(packages.items())}
+
+
+def diff_api(old: Dict[str, Dict[str, Any]], new: Dict[str, Dict[str, Any]]) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Compare two API surfaces (build_api_surface results)
+    - removed and changed exported declarations are breaking; added ones are not
+    - A package directory that disappears entirely makes all of its exports removed
+    """
+    removed, changed, added = [], [], []
+    for directory in sorted(set(old) | set(new)):
+        before = old.get(directory, {}).get("exported", {})
+        after = new.get(directory, {}).get("exported", {})
+        for key in sorted(set(before) | set(after)):
+            if key not in after:
+                removed.append({"package": directory, "symbol": key, "before": before[key]})
+            elif key not in before:
+                added.append({"package": directory, "symbol": key, "after": after[key]})
+            elif before[key] != after[key]:
+                changed.append({"package": directory, "symbol": key, "before": before[key], "after": after[key]})
+    return {"breaking": len(removed) + len(changed), "removed": removed, "changed": changed, "added": added}
+
+
+def summarize_api(surface: Dict[str, Dict[str, Any]], changes: Optional[Dict[str, Any]], limit: int = 20,
+                  import_path: Optional[Callable[[str], Optional[str]]] = None) -> Dict[str, Any]:
+    """Summary section: exported/unexported counts per package (with its import path) and, after a previous run, breaking changes"""
+    packages = []
+    for directory, package in surface.items():
+        entry = {"package": directory, "name": package["package"], "exported": len(package["exported"]),
+                 "unexported": package["unexported"]}
+        path = import_path(directory) if import_path else None
+        if path:
+            entry["import_path"] = path
+        packages.append(entry)
+    section = {
+        "exported_symbols": sum(p["exported"] for p in packages),
+        "unexported_symbols": sum(p["unexported"] for p in packages),
+        "packages": sorted(packages, key=lambda p: (-p["exported"], p["package"]))[:limit],
+    }
+    if changes is not None:
+        section["changes_since_last_scan"] = {
+            "breaking": changes["breaking"],
+            "removed": changes["removed"][:limit],
+            "changed": changes["changed"][:limit],
+            "added": len(changes["added"]),
+        }
+    return section
+
+
+def load_api_snapshot(path: Path) -> Optional[Dict[str, Any]]:
+    """A saved go_api.json ({"parser", "packages"}), or None when there is no (readable) snapshot"""
+    try:
+        with open(path, "r", encoding="utf-8") as f:
+            data = json.load(f)
+    except (OSError, ValueError):
+        return None
+    if not isinstance(data, dict) or not isinstance(data.get("packages"), dict):
+        return None
+    return data
+
+
+def write_api_snapshot(path: Path, surface: Dict[str, Dict[str, Any]], parser: str, generator: str):
+    """
+    Save the public API listing that the next run (or api-diff) compares against. The parser is
+    recorded because regex scans see no signatures, so only same-parser snapshots compare cleanly
+    """
+    path.parent.mkdir(parents=True, exist_ok=True)
+    with open(path, "w", encoding="utf-8") as f:
+        json.dump({
+            "_generator": f"Generated by {generator} - Go public API surface for breaking-change detection",
+            "generated_at": datetime.datetime.now().isoformat() + "Z",
+            "parser": parser,
+            "packages": surface,
+        }, f, indent=2, sort_keys=True)
+
+
+def format_api_diff(changes: Dict[str, Any], max_listed: int = 25) -> str:
+    """Human-readable report for a diff_api() result"""
+    lines = []
+    for title, key, describe in (
+            ("➖ Removed exports", "removed", lambda e: f"{e['package']}: {e['symbol']} {e['before']}"),
+            ("✏️ Changed exports", "changed", lambda e: f"{e['package']}: {e['symbol']} {e['before']} → {e['after']}"),
+            ("➕ Added exports", "added", lambda e: f"{e['package']}: {e['symbol']} {e['after']}")):
+        entries = changes[key]
+        if entries:
+            lines.append(f"{title} ({len(entries)}):")
+            lines += [f"   {describe(e)}" for e in entries[:max_listed]]
+            if len(entries) > max_listed:
+                lines.append(f"   ... and {len(entries) - max_listed} more (use --json for the full list)")
+    if changes["breaking"]:
+        lines.append(f"❌ {changes['breaking']} breaking change(s) to the exported Go API")
+    else:
+        lines.append("✅ No breaking changes to the exported Go API")
+    return "\n".join(lines)
@@ -0,0 +1,3 @@
+module arkival/go_ast_parser
+
+go 1.21
@@ -0,0 +1,549 @@
+// Command go_ast_parser extracts Go symbols for the Arkival codebase scanner.
+//
+// It parses every file named on the command line with go/parser and prints a
+// single JSON document describing the functions, methods, structs, and
+// interfaces each file declares. Files that fail to parse are reported with an
+// error message so update_project_summary.py can fall back to its regex
+// patterns for that file only.
+//
+// Function and method symbols also list the calls they make to identifiers
+// and to methods on their own receiver. Resolution against the rest of the
+// package happens in the scanner, since a package spans several files.
+//
+// With -stdin the source is read from standard input instead, and the single
+// path argument only names the file (so _test.go detection and error messages
+// still work). The scanner uses this for text that is not on disk: unsaved
+// editor buffers, staged and committed git blobs, and archive members.
+//
+// Usage:
+//
+//	go_ast_parser file.go [file.go ...]
+//	go_ast_parser -stdin virtual/path.go < source.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// symbol is a single declaration found in a Go file. Signature holds a
+// function's or method's parameter and result types, e.g. "(string) error";
+// interfaces carry the same for each method in MethodSignatures, plus the
+// names of any embedded interfaces in Embeds. TestKind is set for the
+// functions go test runs: "test", "benchmark", "fuzz", or "example".
+//
+// Generic declarations carry their type parameter list in TypeParams, e.g.
+// "[K comparable, V any]", and constraint interfaces list their union terms
+// ("~int", "~float64") in TypeSet. Instantiations holds the expressions that
+// may instantiate a generic, such as "Map[int, string]"; without type
+// information an index into a slice looks the same, so the scanner keeps only
+// those naming a generic it knows.
+type symbol struct {
+	Name             string            `json:"name"`
+	Kind             string            `json:"kind"`
+	Line             int               `json:"line"`
+	EndLine          int               `json:"end_line"`
+	Receiver         string            `json:"receiver,omitempty"`
+	Methods          []string          `json:"methods,omitempty"`
+	Signature        string            `json:"signature,omitempty"`
+	MethodSignatures map[string]string `json:"method_signatures,omitempty"`
+	Embeds           []string          `json:"embeds,omitempty"`
+	Calls            []call            `json:"calls,omitempty"`
+	Complexity       int               `json:"complexity,omitempty"`
+	Cognitive        int               `json:"cognitive_complexity,omitempty"`
+	MaxNesting       int               `json:"max_nesting,omitempty"`
+	Doc              string            `json:"doc,omitempty"`
+	TestKind         string            `json:"test_kind,omitempty"`
+	TypeParams       string            `json:"type_params,omitempty"`
+	TypeSet          []string          `json:"type_set,omitempty"`
+	Instantiations   []string          `json:"instantiations,omitempty"`
+}
+
+// call is a call site inside a function body. Recv is set when the callee is
+// a method invoked on the enclosing method's receiver variable.
+type call struct {
+	Name string `json:"name"`
+	Recv string `json:"recv,omitempty"`
+}
+
+// fileResult is the parse outcome for one input file.
+type fileResult struct {
+	Path    string   `json:"path"`
+	Package string   `json:"package,omitempty"`
+	Symbols []symbol `json:"symbols"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] == "-stdin" && len(os.Args) != 3) {
+		fmt.Fprintln(os.Stderr, "usage: go_ast_parser file.go [file.go ...] | go_ast_parser -stdin path.go")
+		os.Exit(2)
+	}
+
+	var results []fileResult
+	if os.Args[1] == "-stdin" {
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		results = []fileResult{parseSource(os.Args[2], src)}
+	} else {
+		results = make([]fileResult, 0, len(os.Args)-1)
+		for _, path := range os.Args[1:] {
+			results = append(results, parseSource(path, nil))
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	if err := enc.Encode(map[string]any{"files": results}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// parseSource parses a single file and collects its top-level declarations.
+// A nil src reads the file at path; otherwise src is the file's content.
+func parseSource(path string, src []byte) fileResult {
+	result := fileResult{Path: path, Symbols: []symbol{}}
+
+	fset := token.NewFileSet()
+	var source any
+	if src != nil {
+		source = src
+	}
+	file, err := parser.ParseFile(fset, path, source, parser.ParseComments)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Package = file.Name.Name
+	isTestFile := strings.HasSuffix(path, "_test.go")
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			s := funcSymbol(fset, d)
+			if isTestFile {
+				s.TestKind = testKind(d)
+			}
+			result.Symbols = append(result.Symbols, s)
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				if s, ok := typeSymbol(fset, d, spec.(*ast.TypeSpec)); ok {
+					result.Symbols = append(result.Symbols, s)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// funcSymbol describes a function or method declaration.
+func funcSymbol(fset *token.FileSet, d *ast.FuncDecl) symbol {
+	s := symbol{
+		Name:    d.Name.Name,
+		Kind:    "function",
+		Line:    fset.Position(d.Pos()).Line,
+		EndLine: fset.Position(d.End()).Line,
+		Doc:     d.Doc.Text(),
+	}
+	s.TypeParams = typeParamList(d.Type.TypeParams)
+	nodes := []ast.Node{d.Type}
+	if d.Body != nil {
+		nodes = append(nodes, d.Body)
+	}
+	s.Instantiations = collectInstantiations(nodes...)
+	recvName := ""
+	if d.Recv != nil && len(d.Recv.List) > 0 {
+		s.Kind = "method"
+		s.Receiver = types.ExprString(d.Recv.List[0].Type)
+		if names := d.Recv.List[0].Names; len(names) > 0 {
+			recvName = names[0].Name
+		}
+	}
+	s.Signature = signature(d.Type)
+	if d.Body != nil {
+		s.Calls = collectCalls(d.Body, recvName, receiverBase(s.Receiver))
+		score := &complexityScore{cyclomatic: 1}
+		ast.Walk(complexityVisitor{score: score}, d.Body)
+		s.Complexity, s.Cognitive, s.MaxNesting = score.cyclomatic, score.cognitive, score.maxNesting
+	}
+	return s
+}
+
+// testPrefixes maps go test's function name prefixes to the test kind and
+// the *testing type the single parameter must have ("" for no parameters).
+var testPrefixes = []struct{ prefix, kind, param string }{
+	{"Test", "test", "T"},
+	{"Benchmark", "benchmark", "B"},
+	{"Fuzz", "fuzz", "F"},
+	{"Example", "example", ""},
+}
+
+// testKind classifies a _test.go function the way go test does: the name
+// starts with a prefix not followed by a lowercase letter, and the signature
+// matches (func(*testing.T), or no parameters and results for examples).
+// Methods, TestMain, and helpers return "".
+func testKind(d *ast.FuncDecl) string {
+	if d.Recv != nil || d.Type.TypeParams != nil || d.Type.Results != nil {
+		return ""
+	}
+	name := d.Name.Name
+	for _, p := range testPrefixes {
+		if !strings.HasPrefix(name, p.prefix) {
+			continue
+		}
+		if rest := name[len(p.prefix):]; rest != "" {
+			if r, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(r) {
+				return ""
+			}
+		}
+		params := fieldTypes(d.Type.Params)
+		if p.param == "" {
+			if len(params) == 0 {
+				return p.kind
+			}
+			return ""
+		}
+		// The testing package may be imported under another name
+		if len(params) == 1 && strings.HasPrefix(params[0], "*") && strings.HasSuffix(params[0], "."+p.param) {
+			return p.kind
+		}
+		return ""
+	}
+	return ""
+}
+
+// collectCalls lists the distinct calls in body that may target declarations
+// in the same package: plain identifiers other than builtins, and methods
+// called on the receiver variable recvName (reported with type recvType).
+// Identifiers passed as call arguments are included too, so a function handed
+// to a registration call (a handler, a callback) counts as used.
+func collectCalls(body *ast.BlockStmt, recvName, recvType string) []call {
+	var calls []call
+	seen := map[call]bool{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		expr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		fun := expr.Fun
+		for paren, ok := fun.(*ast.ParenExpr); ok; paren, ok = fun.(*ast.ParenExpr) {
+			fun = paren.X
+		}
+		var c call
+		switch fn := fun.(type) {
+		case *ast.Ident:
+			if types.Universe.Lookup(fn.Name) != nil {
+				return true
+			}
+			c = call{Name: fn.Name}
+		case *ast.IndexExpr:
+			// Explicit instantiation of a generic function: f[T](x)
+			if id, ok := fn.X.(*ast.Ident); ok {
+				c = call{Name: id.Name}
+			}
+		case *ast.SelectorExpr:
+			if id, ok := fn.X.(*ast.Ident); ok && recvName != "" && id.Name == recvName {
+				c = call{Name: fn.Sel.Name, Recv: recvType}
+			}
+		}
+		candidates := []call{c}
+		for _, arg := range expr.Args {
+			if id, ok := arg.(*ast.Ident); ok && types.Universe.Lookup(id.Name) == nil {
+				candidates = append(candidates, call{Name: id.Name})
+			}
+		}
+		for _, c := range candidates {
+			if c.Name != "" && !seen[c] {
+				seen[c] = true
+				calls = append(calls, c)
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// complexityScore accumulates the complexity of one function body.
+type complexityScore struct {
+	cyclomatic int
+	cognitive  int
+	maxNesting int
+}
+
+// control records a control structure at the given nesting depth, keeping
+// the deepest one (1 for an if directly in the function body).
+func (s *complexityScore) control(nesting int) {
+	if nesting+1 > s.maxNesting {
+		s.maxNesting = nesting + 1
+	}
+}
+
+// complexityVisitor scores a function body. Cyclomatic complexity counts
+// branches (if, loops, non-default cases, && and ||). Cognitive complexity
+// charges control structures 1 plus their nesting depth, else branches and
+// logical operators 1, and nests function literals.
+type complexityVisitor struct {
+	score   *complexityScore
+	nesting int
+}
+
+func (v complexityVisitor) nested() complexityVisitor {
+	return complexityVisitor{score: v.score, nesting: v.nesting + 1}
+}
+
+// Visit implements ast.Visitor.
+func (v complexityVisitor) Visit(n ast.Node) ast.Visitor {
+	switch x := n.(type) {
+	case *ast.IfStmt:
+		v.score.cyclomatic++
+		v.score.cognitive += 1 + v.nesting
+		v.walkIf(x)
+		return nil
+	case *ast.ForStmt, *ast.RangeStmt:
+		v.score.cyclomatic++
+		v.score.cognitive += 1 + v.nesting
+		v.score.control(v.nesting)
+		return v.nested()
+	case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+		v.score.cognitive += 1 + v.nesting
+		v.score.control(v.nesting)
+		return v.nested()
+	case *ast.CaseClause:
+		if x.List != nil {
+			v.score.cyclomatic++
+		}
+	case *ast.CommClause:
+		if x.Comm != nil {
+			v.score.cyclomatic++
+		}
+	case *ast.BinaryExpr:
+		if x.Op == token.LAND || x.Op == token.LOR {
+			v.score.cyclomatic++
+			v.score.cognitive++
+		}
+	case *ast.FuncLit:
+		return v.nested()
+	}
+	return v
+}
+
+// walkIf scores an if statement's parts, treating an else-if chain as flat
+// increments rather than deeper nesting.
+func (v complexityVisitor) walkIf(x *ast.IfStmt) {
+	v.score.control(v.nesting)
+	if x.Init != nil {
+		ast.Walk(v, x.Init)
+	}
+	ast.Walk(v, x.Cond)
+	ast.Walk(v.nested(), x.Body)
+	switch e := x.Else.(type) {
+	case *ast.IfStmt:
+		v.score.cyclomatic++
+		v.score.cognitive++
+		v.walkIf(e)
+	case *ast.BlockStmt:
+		v.score.cognitive++
+		ast.Walk(v.nested(), e)
+	}
+}
+
+// receiverBase strips pointers and type parameters from a receiver type
+// expression, so "*Stack[T]" becomes "Stack".
+func receiverBase(recv string) string {
+	recv = strings.TrimLeft(recv, "*")
+	if i := strings.IndexByte(recv, '['); i >= 0 {
+		recv = recv[:i]
+	}
+	return recv
+}
+
+// typeSymbol describes struct and interface type declarations. Other named
+// types are skipped to match the regex scanner's notion of a symbol.
+func typeSymbol(fset *token.FileSet, d *ast.GenDecl, spec *ast.TypeSpec) (symbol, bool) {
+	doc := spec.Doc
+	if doc == nil && len(d.Specs) == 1 {
+		doc = d.Doc
+	}
+	s := symbol{
+		Name:    spec.Name.Name,
+		Line:    fset.Position(spec.Pos()).Line,
+		EndLine: fset.Position(spec.End()).Line,
+		Doc:     doc.Text(),
+	}
+	s.TypeParams = typeParamList(spec.TypeParams)
+
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		s.Kind = "struct"
+		s.Instantiations = collectInstantiations(t)
+	case *ast.InterfaceType:
+		s.Kind = "interface"
+		for _, field := range t.Methods.List {
+			ft, isMethod := field.Type.(*ast.FuncType)
+			if !isMethod {
+				if terms := typeSetTerms(field.Type); terms != nil {
+					s.TypeSet = append(s.TypeSet, terms...)
+					continue
+				}
+				s.Embeds = append(s.Embeds, types.ExprString(field.Type))
+				continue
+			}
+			for _, name := range field.Names {
+				s.Methods = append(s.Methods, name.Name)
+				if s.MethodSignatures == nil {
+					s.MethodSignatures = map[string]string{}
+				}
+				s.MethodSignatures[name.Name] = signature(ft)
+			}
+		}
+	default:
+		return s, false
+	}
+	return s, true
+}
+
+// typeParamList renders a type parameter list as written, with grouped names
+// kept together: "[K comparable, V any]", or "[S ~[]E, E any]".
+func typeParamList(fields *ast.FieldList) string {
+	if fields == nil || len(fields.List) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(fields.List))
+	for _, field := range fields.List {
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+types.ExprString(field.Type))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// typeSetTerms splits an interface element that is a type set, such as
+// "~int | ~float64" or "~string", into its terms. It returns nil for embedded
+// interfaces, which are reported as embeds instead.
+func typeSetTerms(expr ast.Expr) []string {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.OR {
+			return unionTerms(e)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.TILDE {
+			return []string{types.ExprString(e)}
+		}
+	case *ast.Ident:
+		// A predeclared type (int, string...) is a term; other names are embedded interfaces
+		if types.Universe.Lookup(e.Name) != nil && e.Name != "any" && e.Name != "comparable" && e.Name != "error" {
+			return []string{e.Name}
+		}
+	}
+	return nil
+}
+
+// unionTerms flattens "A | B | ~C" into its terms.
+func unionTerms(expr ast.Expr) []string {
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.OR {
+		return append(unionTerms(b.X), unionTerms(b.Y)...)
+	}
+	return []string{types.ExprString(expr)}
+}
+
+// collectInstantiations lists the distinct index expressions in nodes that
+// look like generic instantiations: any multi-argument index ("Pair[K, V]"),
+// and single-argument ones on a plain name whose argument looks like a type.
+func collectInstantiations(nodes ...ast.Node) []string {
+	var found []string
+	seen := map[string]bool{}
+	add := func(expr ast.Expr) {
+		text := types.ExprString(expr)
+		if !seen[text] {
+			seen[text] = true
+			found = append(found, text)
+		}
+	}
+	visit := func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.IndexListExpr:
+			switch x.X.(type) {
+			case *ast.Ident, *ast.SelectorExpr:
+				add(x)
+			}
+		case *ast.IndexExpr:
+			if _, named := x.X.(*ast.Ident); named && typeLike(x.Index) {
+				add(x)
+			}
+		}
+		return true
+	}
+	for _, node := range nodes {
+		ast.Inspect(node, visit)
+	}
+	return found
+}
+
+// typeLike reports whether expr could be a type argument.
+func typeLike(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.ArrayType, *ast.MapType, *ast.FuncType,
+		*ast.ChanType, *ast.InterfaceType, *ast.StructType:
+		return true
+	case *ast.StarExpr:
+		return typeLike(e.X)
+	case *ast.IndexExpr:
+		return typeLike(e.Index)
+	case *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+// signature renders a function type's parameter and result types without
+// parameter names, so "(a, b int) (err error)" becomes "(int, int) error".
+func signature(ft *ast.FuncType) string {
+	params := fieldTypes(ft.Params)
+	results := fieldTypes(ft.Results)
+	sig := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// fieldTypes expands a field list into one type string per declared name.
+func fieldTypes(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+	var out []string
+	for _, field := range fields.List {
+		typ := types.ExprString(field.Type)
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			out = append(out, typ)
+		}
+	}
+	return out
+}
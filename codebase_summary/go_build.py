@@ -0,0 +1,194 @@
+#!/usr/bin/env python3
+"""
+Go Build - Build constraints from //go:build lines, legacy +build lines, and _GOOS/_GOARCH file names
+By default every platform's files are scanned and merged, with their symbols tagged by constraint;
+--go-target GOOS/GOARCH (plus --go-tags) scans only the files go build would compile for that target
+"""
+
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set, Tuple
+
+# go/build's known operating systems and architectures (file-name suffixes only count for these)
+KNOWN_OS = {"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js", "linux", "nacl",
+            "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos"}
+KNOWN_ARCH = {"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64", "mips", "mipsle", "mips64",
+              "mips64le", "mips64p32", "mips64p32le", "ppc", "ppc64", "ppc64le", "riscv", "riscv64", "s390", "s390x",
+              "sparc", "sparc64", "wasm"}
+UNIX_OS = {"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "linux", "netbsd",
+           "openbsd", "solaris"}
+# Operating systems that also satisfy another OS's constraints
+IMPLIED_OS = {"android": "linux", "illumos": "solaris", "ios": "darwin"}
+# Per-file keys (and symbol keys) summarize_build_constraints() uses
+SUMMARIZED_FIELDS = ("file", "build_constraint", "build_excluded", "symbols")
+SYMBOL_FIELDS = ("name", "receiver")
+
+_TOKEN = re.compile(r"\s*(\(|\)|!|&&|\|\||[\w.]+)")
+
+
+def filename_constraint(file_name: str) -> Optional[str]:
+    """Constraint implied by a *_GOOS, *_GOARCH, or *_GOOS_GOARCH file name (the _test suffix is ignored)"""
+    stem = file_name[:-3] if file_name.endswith(".go") else file_name
+    if stem.endswith("_test"):
+        stem = stem[:-5]
+    parts = stem.split("_")
+    if len(parts) >= 3 and parts[-2] in KNOWN_OS and parts[-1] in KNOWN_ARCH:
+        return f"{parts[-2]} && {parts[-1]}"
+    if len(parts) >= 2 and (parts[-1] in KNOWN_OS or parts[-1] in KNOWN_ARCH):
+        return parts[-1]
+    return None
+
+
+def _plus_build_expression(lines: List[str]) -> str:
+    """'// +build' lines as a //go:build expression: spaces are OR, commas AND, and lines AND together"""
+    clauses = []
+    for line in lines:
+        options = [" && ".join(option.split(",")) for option in line.split()]
+        clauses.append(options[0] if len(options) == 1 else "(" + " || ".join(options) + ")")
+    return " && ".join(clauses)
+
+
+def header_constraint(lines: List[str]) -> Optional[str]:
+    """
+    # @codebase-summary: The //go:build expression in a Go file's header, or None
+    - Only comments before the package clause count, as in go build; //go:build wins over '+build' lines
+    - Legacy '// +build' lines are translated to the equivalent //go:build expression
+    """
+    go_build = None
+    plus_build = []
+    in_block = False
+    for raw in lines:
+        line = raw.strip()
+        if in_block:
+            in_block = "*/" not in line
+            continue
+        if line.startswith("/*"):
+            in_block = "*/" not in line[2:]
+            continue
+        if not line:
+            continue
+        if not line.startswith("//"):
+            break
+        if line.startswith("//go:build ") and go_build is None:
+            go_build = line[len("//go:build "):].strip()
+        elif re.match(r"//\s*\+build\s", line):
+            plus_build.append(line.split("+build", 1)[1].strip())
+    if go_build:
+        return go_build
+    return _plus_build_expression(plus_build) if plus_build else None
+
+
+def build_constraint(file_name: str, lines: List[str]) -> Optional[str]:
+    """Combined constraint of a Go file's header and file name, or None when it builds everywhere"""
+    parts = [c for c in (header_constraint(lines), filename_constraint(file_name)) if c]
+    if len(parts) < 2:
+        return parts[0] if parts else None
+    return " && ".join(f"({c})" if "||" in c else c for c in parts)
+
+
+def _parse(tokens: List[str], position: int, tags: Set[str]) -> Tuple[bool, int]:
+    """Recursive-descent evaluation of an || expression starting at position"""
+    def unary(i: int) -> Tuple[bool, int]:
+        if i >= len(tokens):
+            raise ValueError("unexpected end of expression")
+        if tokens[i] == "!":
+            value, i = unary(i + 1)
+            return not value, i
+        if tokens[i] == "(":
+            value, i = _parse(tokens, i + 1, tags)
+            if i >= len(tokens) or tokens[i] != ")":
+                raise ValueError("missing ')'")
+            return value, i + 1
+        if tokens[i] in (")", "&&", "||"):
+            raise ValueError(f"unexpected '{tokens[i]}'")
+        return _satisfied(tokens[i], tags), i + 1
+
+    def conjunction(i: int) -> Tuple[bool, int]:
+        value, i = unary(i)
+        while i < len(tokens) and tokens[i] == "&&":
+            right, i = unary(i + 1)
+            value = value and right
+        return value, i
+
+    value, position = conjunction(position)
+    while position < len(tokens) and tokens[position] == "||":
+        right, position = conjunction(position + 1)
+        value = value or right
+    return value, position
+
+
+def _satisfied(tag: str, tags: Set[str]) -> bool:
+    """Whether a single build tag holds; release tags (go1.21) are assumed to be met"""
+    return tag in tags or bool(re.fullmatch(r"go1\.\d+", tag))
+
+
+def target_tags(goos: str, goarch: str, extra: List[str]) -> Set[str]:
+    """Tags go build sets for a target: GOOS (and the OS it implies), GOARCH, unix, gc, plus --go-tags"""
+    tags = {goos, goarch, "gc", *extra}
+    if goos in IMPLIED_OS:
+        tags.add(IMPLIED_OS[goos])
+    if goos in UNIX_OS:
+        tags.add("unix")
+    return tags
+
+
+def evaluate(expression: str, tags: Set[str]) -> bool:
+    """Whether a //go:build expression holds for the given tags; raises ValueError when malformed"""
+    tokens = []
+    position = 0
+    expression = expression.strip()
+    while position < len(expression):
+        match = _TOKEN.match(expression, position)
+        if not match:
+            raise ValueError(f"invalid character at '{expression[position:]}'")
+        tokens.append(match.group(1))
+        position = match.end()
+    value, end = _parse(tokens, 0, tags)
+    if end != len(tokens):
+        raise ValueError(f"unexpected '{tokens[end]}'")
+    return value
+
+
+def parse_target(target: str) -> Tuple[str, str]:
+    """(GOOS, GOARCH) from --go-target 'linux/amd64' or 'linux' (GOARCH defaults to amd64)"""
+    goos, _, goarch = target.lower().partition("/")
+    goarch = goarch or "amd64"
+    if goos not in KNOWN_OS or goarch not in KNOWN_ARCH:
+        raise ValueError(f"unknown Go target '{target}' (expected GOOS/GOARCH, e.g. linux/amd64)")
+    return goos, goarch
+
+
+def summarize_build_constraints(analyses: List[Dict[str, Any]], target: Optional[str], tags: List[str],
+                                limit: int = 20) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Summary section for Go files with build constraints
+    - Files per constraint, and symbols declared in several platform variants (e.g. openFile for
+      linux and windows) which the merged view lists once per file
+    - With a target, the files go build would skip for it, which are left out of every other section
+    """
+    by_constraint: Dict[str, int] = {}
+    variants: Dict[Tuple[str, str], Set[str]] = {}
+    excluded = []
+    for analysis in analyses:
+        constraint = analysis["build_constraint"]
+        by_constraint[constraint] = by_constraint.get(constraint, 0) + 1
+        if analysis.get("build_excluded"):
+            excluded.append(analysis["file"])
+        package = Path(analysis["file"]).parent.as_posix()
+        for symbol in analysis.get("symbols", []):
+            name = f"{symbol['receiver'].lstrip('*')}.{symbol['name']}" if symbol.get("receiver") else symbol["name"]
+            variants.setdefault((package, name), set()).add(constraint)
+    multi_platform = [{"package": package, "name": name, "constraints": sorted(constraints)}
+                      for (package, name), constraints in sorted(variants.items()) if len(constraints) > 1]
+    section = {
+        "mode": "target" if target else "merged",
+        "constrained_files": len(analyses),
+        "by_constraint": dict(sorted(by_constraint.items(), key=lambda item: (-item[1], item[0]))[:limit]),
+        "platform_variants": multi_platform[:limit],
+    }
+    if target:
+        section["target"] = target
+        section["tags"] = tags
+        section["excluded_files"] = len(excluded)
+        section["excluded_sample"] = excluded[:limit]
+    return section
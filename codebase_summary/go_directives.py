@@ -0,0 +1,136 @@
+#!/usr/bin/env python3
+"""
+Go Directives - //go:generate, //go:embed, compiler directives, and cgo preambles in Go files
+Directives are read from the source lines, so go/ast and regex scans record the same metadata;
+the summary lists code generation commands, embedded assets, and cgo usage across the project
+"""
+
+import re
+from typing import Dict, Any, List, Optional
+
+# Directives that apply to the declaration that follows them
+DECLARATION_DIRECTIVES = {"noinline", "nosplit", "noescape", "norace", "nocheckptr", "linkname", "uintptrescapes",
+                          "nowritebarrier", "nowritebarrierrec", "registerparams", "wasmimport", "wasmexport"}
+# Per-file keys summarize_directives() uses
+SUMMARIZED_FIELDS = ("file", "go_directives")
+
+_DIRECTIVE = re.compile(r"^\s*//go:(\w+)(?:\s+(.*))?$")
+_EXPORT = re.compile(r"^\s*//export\s+(\w+)")
+_EMBED_PATTERN = re.compile(r'"((?:[^"\\]|\\.)*)"|`([^`]*)`|(\S+)')
+_VAR_NAME = re.compile(r"^\s*(?:var\s+)?([A-Za-z_]\w*)\b")
+_FUNC = re.compile(r"^\s*func\s")
+
+
+def _cgo_preamble(lines: List[str], import_index: int) -> List[str]:
+    """The comment block directly above an 'import "C"' line, without comment markers"""
+    preamble = []
+    i = import_index - 1
+    if i >= 0 and lines[i].strip().endswith("*/"):
+        while i >= 0:
+            preamble.insert(0, lines[i])
+            if "/*" in lines[i]:
+                break
+            i -= 1
+        text = "\n".join(preamble)
+        return text[text.find("/*") + 2:text.rfind("*/")].split("\n")
+    while i >= 0 and lines[i].strip().startswith("//"):
+        preamble.insert(0, lines[i].strip()[2:])
+        i -= 1
+    return preamble
+
+
+def extract_directives(lines: List[str], symbols: List[Dict[str, Any]]) -> Optional[Dict[str, Any]]:
+    """
+    # @codebase-summary: Directive metadata for one Go file, or None when it has none
+    - generate: //go:generate commands; embed: //go:embed patterns with the variable they fill
+    - compiler: //go:noinline, //go:linkname, //export... - when they precede a function, that
+      symbol also gets a "directives" list
+    - cgo: the includes and #cgo flags of the preamble above import "C"
+    """
+    result: Dict[str, Any] = {"generate": [], "embed": [], "compiler": []}
+    by_line = {symbol.get("line"): symbol for symbol in symbols if symbol.get("kind", "function") in ("function", "method")}
+    pending: List[Dict[str, Any]] = []
+    for index, line in enumerate(lines):
+        number = index + 1
+        directive = _DIRECTIVE.match(line)
+        export = _EXPORT.match(line)
+        if directive:
+            name, args = directive.group(1), (directive.group(2) or "").strip()
+            if name == "generate":
+                result["generate"].append({"line": number, "command": args})
+            elif name == "embed":
+                patterns = [next(g for g in match.groups() if g is not None) for match in _EMBED_PATTERN.finditer(args)]
+                target = next((l for l in lines[index + 1:] if l.strip() and not l.strip().startswith("//")), "")
+                var = _VAR_NAME.match(target)
+                result["embed"].append({"line": number, "var": var.group(1) if var else None, "patterns": patterns})
+            elif name in DECLARATION_DIRECTIVES:
+                entry = {"line": number, "directive": f"go:{name}", **({"args": args} if args else {})}
+                result["compiler"].append(entry)
+                pending.append(entry)
+            continue
+        if export:
+            entry = {"line": number, "directive": "export", "args": export.group(1)}
+            result["compiler"].append(entry)
+            pending.append(entry)
+            continue
+        stripped = line.strip()
+        if stripped.startswith("//") or not stripped:
+            continue
+        if _FUNC.match(line) and number in by_line and pending:
+            by_line[number]["directives"] = [entry["directive"] for entry in pending]
+            for entry in pending:
+                entry["symbol"] = by_line[number]["name"]
+        pending = []
+
+    for index, line in enumerate(lines):
+        if re.match(r'^\s*import\s+"C"\s*$', line):
+            preamble = _cgo_preamble(lines, index)
+            result["cgo"] = {
+                "line": index + 1,
+                "includes": [m.group(1) for m in (re.match(r'\s*#\s*include\s+([<"][^>"]+[>"])', l) for l in preamble) if m],
+                "flags": [l.strip()[len("#cgo"):].strip() for l in preamble if l.strip().startswith("#cgo")],
+            }
+            break
+
+    result = {key: value for key, value in result.items() if value}
+    return result or None
+
+
+def _tool(command: str) -> str:
+    """Generator a //go:generate command runs ('go run golang.org/x/tools/cmd/stringer' -> 'stringer')"""
+    words = command.split()
+    if len(words) >= 3 and words[0] == "go" and words[1] == "run":
+        words = [w for w in words[2:] if not w.startswith("-")] or words[2:]
+        return words[0].rsplit("@", 1)[0].rstrip("/").rsplit("/", 1)[-1]
+    return words[0].rsplit("/", 1)[-1] if words else ""
+
+
+def summarize_directives(analyses: List[Dict[str, Any]], limit: int = 20) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Summary section for Go directives across the scanned files
+    - Code generation commands (with a count per generator tool), embedded assets, cgo files,
+      and how often each compiler directive is used
+    """
+    generate, embedded, cgo = [], [], []
+    tools: Dict[str, int] = {}
+    compiler: Dict[str, int] = {}
+    for analysis in analyses:
+        directives = analysis["go_directives"]
+        for entry in directives.get("generate", []):
+            generate.append({"file": analysis["file"], "line": entry["line"], "command": entry["command"]})
+            tool = _tool(entry["command"])
+            tools[tool] = tools.get(tool, 0) + 1
+        for entry in directives.get("embed", []):
+            embedded.append({"file": analysis["file"], "line": entry["line"], "var": entry["var"], "patterns": entry["patterns"]})
+        for entry in directives.get("compiler", []):
+            compiler[entry["directive"]] = compiler.get(entry["directive"], 0) + 1
+        if "cgo" in directives:
+            cgo.append({"file": analysis["file"], "includes": directives["cgo"]["includes"], "flags": directives["cgo"]["flags"]})
+    return {
+        "generate_commands": len(generate),
+        "generators": dict(sorted(tools.items(), key=lambda item: (-item[1], item[0]))),
+        "generate": generate[:limit],
+        "embedded_assets": embedded[:limit],
+        "cgo_files": cgo[:limit],
+        "compiler_directives": dict(sorted(compiler.items())),
+    }
@@ -0,0 +1,151 @@
+#!/usr/bin/env python3
+"""
+Go Generics - Type parameters, constraints, and instantiations of generic Go functions and types
+Declarations come from the go/ast helper (type_params, type_set, instantiations) or, for regex-scanned
+files, from the declaration line; instantiations are resolved against the generics of the same package
+"""
+
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+FUNCTION_KINDS = ("function", "method")
+
+
+def split_type_params(type_params: str) -> List[Dict[str, str]]:
+    """
+    # @codebase-summary: '[K comparable, V any]' as [{"name": "K", "constraint": "comparable"}, ...]
+    - Grouped names share the constraint that follows them ('[K, V comparable]')
+    - Commas inside constraints ('~map[K]V', 'interface{ f(a, b int) }') are not split on
+    """
+    body = type_params.strip()
+    if body.startswith("[") and body.endswith("]"):
+        body = body[1:-1]
+    parts, depth, current = [], 0, ""
+    for char in body:
+        if char in "[({":
+            depth += 1
+        elif char in "])}":
+            depth -= 1
+        if char == "," and depth == 0:
+            parts.append(current.strip())
+            current = ""
+        else:
+            current += char
+    if current.strip():
+        parts.append(current.strip())
+
+    params, pending = [], []
+    for part in parts:
+        name, _, constraint = part.partition(" ")
+        pending.append(name)
+        if constraint.strip():
+            params += [{"name": n, "constraint": constraint.strip()} for n in pending]
+            pending = []
+    return params + [{"name": n, "constraint": ""} for n in pending]
+
+
+def _bracketed(text: str, start: int) -> Optional[str]:
+    """The balanced '[...]' starting at text[start], or None"""
+    if start >= len(text) or text[start] != "[":
+        return None
+    depth = 0
+    for i in range(start, len(text)):
+        depth += {"[": 1, "]": -1}.get(text[i], 0)
+        if depth == 0:
+            return text[start:i + 1]
+    return None
+
+
+def declaration_type_params(name: str, line: str) -> Optional[str]:
+    """Type parameter list written after name on a Go declaration line ('func Map[T any](' -> '[T any]')"""
+    match = re.search(rf"\b(?:func|type)\s+(?:\([^)]*\)\s*)?{re.escape(name)}\s*(?=\[)", line)
+    if not match:
+        return None
+    params = _bracketed(line, match.end())
+    # 'type Grid [4]int' is an array type, not a parameter list
+    return params if params and re.match(r"\[\s*[A-Za-z_]\w*", params) else None
+
+
+def receiver_type_params(receiver: str) -> List[str]:
+    """Type parameter names of a generic receiver ('*Stack[T]' -> ['T'], 'Pair[K, V]' -> ['K', 'V'])"""
+    params = _bracketed(receiver, receiver.find("[")) if "[" in receiver else None
+    return [p.strip() for p in params[1:-1].split(",") if p.strip()] if params else []
+
+
+def annotate_type_params(candidates: List[Dict[str, Any]], lines: List[str]):
+    """Set type_params on generic functions and types found by the regex patterns (go/ast symbols arrive annotated)"""
+    for candidate in candidates:
+        if "type_params" in candidate or not 0 < candidate.get("line", 0) <= len(lines):
+            continue
+        params = declaration_type_params(candidate["name"], lines[candidate["line"] - 1])
+        if params:
+            candidate["type_params"] = params
+
+
+def _base_name(expression: str) -> str:
+    """Generic being instantiated: 'Pair[K, V]' -> 'Pair', 'maps.Keys[M]' -> 'maps.Keys'"""
+    return expression.split("[", 1)[0].strip()
+
+
+def summarize_generics(file_analysis: List[Dict[str, Any]], limit: int = 20) -> Optional[Dict[str, Any]]:
+    """
+    # @codebase-summary: Summary section for Go generics, or None when the Go code declares none
+    - Counts generic functions, types, and methods on generic receivers, and how often each
+      constraint is used; constraint interfaces are listed with their type sets
+    - Instantiations ('Stack[int]') are matched to generics declared in the same package
+      directory, with the distinct type arguments seen; other packages' generics are counted apart
+    """
+    declarations, constraint_interfaces = [], []
+    constraints: Dict[str, int] = {}
+    generic_methods = 0
+    package_generics: Dict[str, Dict[str, Dict[str, Any]]] = {}
+    for analysis in file_analysis:
+        if analysis.get("language") != ".go":
+            continue
+        package = Path(analysis["file"]).parent.as_posix()
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("type_set"):
+                constraint_interfaces.append({"file": analysis["file"], "name": symbol["name"], "type_set": symbol["type_set"]})
+            if symbol.get("kind") == "method" and receiver_type_params(symbol.get("receiver", "")):
+                generic_methods += 1
+            if not symbol.get("type_params"):
+                continue
+            params = split_type_params(symbol["type_params"])
+            for param in params:
+                constraints[param["constraint"] or "?"] = constraints.get(param["constraint"] or "?", 0) + 1
+            entry = {"file": analysis["file"], "name": symbol["name"], "kind": symbol.get("kind", "function"),
+                     "type_params": symbol["type_params"]}
+            declarations.append(entry)
+            package_generics.setdefault(package, {})[symbol["name"]] = {**entry, "type_args": set(), "sites": 0}
+    if not declarations and not constraint_interfaces:
+        return None
+
+    external = 0
+    for analysis in file_analysis:
+        if analysis.get("language") != ".go":
+            continue
+        generics = package_generics.get(Path(analysis["file"]).parent.as_posix(), {})
+        for symbol in analysis.get("symbols", []):
+            for expression in symbol.get("instantiations", []):
+                base = _base_name(expression)
+                if base in generics:
+                    generics[base]["sites"] += 1
+                    generics[base]["type_args"].add(expression[len(base):].strip()[1:-1])
+                elif "." in base:
+                    external += 1
+
+    instantiated = sorted((g for generics in package_generics.values() for g in generics.values() if g["sites"]),
+                          key=lambda g: (-g["sites"], g["file"], g["name"]))
+    functions = [d for d in declarations if d["kind"] in FUNCTION_KINDS]
+    return {
+        "generic_functions": len(functions),
+        "generic_types": len(declarations) - len(functions),
+        "generic_methods": generic_methods,
+        "constraints": dict(sorted(constraints.items(), key=lambda item: (-item[1], item[0]))[:limit]),
+        "constraint_interfaces": constraint_interfaces[:limit],
+        "declarations": declarations[:limit],
+        "instantiations": [{"file": g["file"], "name": g["name"], "sites": g["sites"], "type_args": sorted(g["type_args"])}
+                           for g in instantiated[:limit]],
+        "external_instantiations": external,
+    }
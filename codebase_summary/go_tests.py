@@ -0,0 +1,110 @@
+#!/usr/bin/env python3
+"""
+Go Tests - Classification of Go test, benchmark, fuzz, and example functions in _test.go files
+Test code is left out of documentation coverage by default (--go-tests include counts it), and the
+summary reports how much of it there is next to the production code it exercises
+"""
+
+import re
+from pathlib import Path
+from typing import Dict, Any, Callable, List, Optional
+
+GO_TEST_MODES = ("exclude", "include")
+
+# go test's name prefixes, the test kind, and the *testing type of the single parameter (None: no parameters)
+TEST_PREFIXES = (("Test", "test", "T"), ("Benchmark", "benchmark", "B"), ("Fuzz", "fuzz", "F"), ("Example", "example", None))
+KIND_KEYS = {"test": "tests", "benchmark": "benchmarks", "fuzz": "fuzz_targets", "example": "examples"}
+FUNCTION_KINDS = ("function", "method")
+# Per-file keys (and symbol keys) summarize_go_tests() uses
+SUMMARIZED_FIELDS = ("file", "language", "go_test_file", "symbols")
+SYMBOL_FIELDS = ("kind", "test_kind")
+
+_FUNC_PARAMS = r"^\s*func\s+{name}\s*\((?P<params>[^)]*)\)\s*(?P<rest>.*)$"
+
+
+def is_go_test_file(file_name: str) -> bool:
+    """True for files go test compiles as test code (*_test.go)"""
+    return file_name.endswith("_test.go")
+
+
+def test_kind(name: str, declaration: str) -> Optional[str]:
+    """
+    # @codebase-summary: go test's classification of a top-level function from its declaration line
+    - The prefix must not be followed by a lowercase letter ('Testify' is a helper, 'Test_x' a test)
+    - Tests, benchmarks, and fuzz targets take one *testing.T/B/F (under any import name); examples take nothing
+    - Used for regex-scanned files; the go/ast helper reports the same kinds itself
+    """
+    for prefix, kind, param in TEST_PREFIXES:
+        if not name.startswith(prefix):
+            continue
+        rest = name[len(prefix):]
+        if rest[:1].islower():
+            return None
+        match = re.match(_FUNC_PARAMS.format(name=re.escape(name)), declaration)
+        if match is None:
+            return None
+        params, results = match.group("params").strip(), match.group("rest").strip()
+        if results and not results.startswith("{"):
+            return None
+        if param is None:
+            return kind if not params else None
+        return kind if re.fullmatch(rf"(?:\w+\s+)?\*\w+\.{param}", params) else None
+    return None
+
+
+def classify_test_functions(candidates: List[Dict[str, Any]], lines: List[str]):
+    """Set test_kind on the test functions among a regex-scanned _test.go file's candidates"""
+    for candidate in candidates:
+        if candidate.get("kind", "function") != "function" or not 0 < candidate.get("line", 0) <= len(lines):
+            continue
+        kind = test_kind(candidate["name"], lines[candidate["line"] - 1])
+        if kind:
+            candidate["test_kind"] = kind
+
+
+def _ratio(tests: int, production: int) -> Optional[float]:
+    """Test functions per production function (None without production code)"""
+    return round(tests / production, 2) if production else None
+
+
+def summarize_go_tests(analyses: List[Dict[str, Any]], mode: str, limit: int = 20,
+                       import_path: Optional[Callable[[str], Optional[str]]] = None) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Summary section comparing Go test code with production code
+    - Counts test files and their functions by kind, plus helpers (other functions in test files)
+    - test_to_production_ratio is TestXxx functions per production function/method, overall and
+      per package directory (the directories with the most tests first), with its import path when known
+    """
+    totals = {key: 0 for key in KIND_KEYS.values()}
+    helpers = production = test_files = 0
+    packages: Dict[str, Dict[str, int]] = {}
+    for analysis in analyses:
+        if analysis.get("language") != ".go":
+            continue
+        package = packages.setdefault(Path(analysis["file"]).parent.as_posix(), {"tests": 0, "production_functions": 0})
+        functions = [s for s in analysis.get("symbols", []) if s.get("kind", "function") in FUNCTION_KINDS]
+        if not analysis.get("go_test_file"):
+            package["production_functions"] += len(functions)
+            production += len(functions)
+            continue
+        test_files += 1
+        for symbol in functions:
+            if symbol.get("test_kind"):
+                totals[KIND_KEYS[symbol["test_kind"]]] += 1
+                package["tests"] += symbol["test_kind"] == "test"
+            else:
+                helpers += 1
+
+    by_package = sorted(((name, counts) for name, counts in packages.items() if counts["tests"]),
+                        key=lambda item: (-item[1]["tests"], item[0]))[:limit]
+    return {
+        "coverage_mode": mode,
+        "test_files": test_files,
+        **totals,
+        "helpers": helpers,
+        "production_functions": production,
+        "test_to_production_ratio": _ratio(totals["tests"], production),
+        "by_package": [{"package": name, **({"import_path": import_path(name)} if import_path and import_path(name) else {}),
+                        **counts, "test_to_production_ratio": _ratio(counts["tests"], counts["production_functions"])}
+                       for name, counts in by_package],
+    }
@@ -0,0 +1,224 @@
+#!/usr/bin/env python3
+"""
+Go Workspace - go.work and multi-module resolution of Go import paths
+A repository can hold several Go modules tied together by a go.work file or by local replace directives;
+imports between them resolve to the sibling module's package directory instead of an external dependency,
+and every package directory gets its full import path (module path + directory within the module)
+"""
+
+import posixpath
+import re
+from pathlib import Path
+from typing import Dict, Any, Iterable, List, Optional, Tuple
+
+from text_encoding import is_file, read_text
+
+_DIRECTIVE = re.compile(r"^(\w+)\s*(.*)$")
+
+
+def _tokens(text: str) -> List[str]:
+    """Whitespace-separated arguments of a directive, with Go string quotes removed"""
+    return [token.strip('"`') for token in text.split()]
+
+
+def parse_directives(text: str) -> List[Tuple[str, List[str]]]:
+    """(verb, arguments) for each directive of a go.mod or go.work file, with 'verb ( ... )' blocks expanded"""
+    directives, block = [], None
+    for line in text.splitlines():
+        line = line.split("//", 1)[0].strip()
+        if not line:
+            continue
+        if block is not None:
+            if line == ")":
+                block = None
+            else:
+                directives.append((block, _tokens(line)))
+            continue
+        match = _DIRECTIVE.match(line)
+        if not match:
+            continue
+        verb, rest = match.groups()
+        if rest.strip() == "(":
+            block = verb
+        else:
+            directives.append((verb, _tokens(rest)))
+    return directives
+
+
+def _local_replaces(directives: List[Tuple[str, List[str]]], directory: str) -> Dict[str, str]:
+    """Module path -> directory for 'replace old [version] => ./path' directives pointing inside the repository"""
+    replaces = {}
+    for verb, args in directives:
+        if verb != "replace" or "=>" not in args:
+            continue
+        arrow = args.index("=>")
+        old, new = args[:1], args[arrow + 1:arrow + 2]
+        if old and new and new[0].startswith(("./", "../")):
+            target = posixpath.normpath(posixpath.join(directory, new[0]))
+            if target != ".." and not target.startswith("../"):
+                replaces[old[0]] = target
+    return replaces
+
+
+def _parent(directory: str) -> Optional[str]:
+    """Parent of a root-relative directory ('a/b' -> 'a', 'a' -> '.'), None above the root"""
+    if directory == ".":
+        return None
+    return directory.rsplit("/", 1)[0] if "/" in directory else "."
+
+
+class GoWorkspace:
+    """
+    # @codebase-summary: Go modules of a scanned tree and the import paths each package can resolve
+    - A directory belongs to the nearest go.mod at or above it, as the go command decides
+    - An importing module sees its own packages, the modules its go.mod replaces with local paths, and,
+      when a go.work above it lists the module in 'use', every module of that workspace (plus the
+      workspace's local replaces)
+    - go.mod and go.work files are read lazily through text_encoding, so archive scans work unchanged
+    """
+
+    def __init__(self, project_root: Path):
+        self.project_root = Path(project_root)
+        self._module_at: Dict[str, Optional[Dict[str, Any]]] = {}
+        self._work_at: Dict[str, Optional[Dict[str, Any]]] = {}
+        self._nearest: Dict[str, Optional[Dict[str, Any]]] = {}
+
+    def _read(self, directory: str, name: str) -> Optional[str]:
+        path = self.project_root / directory / name
+        try:
+            return read_text(path) if is_file(path) else None
+        except OSError:
+            return None
+
+    def module_at(self, directory: str) -> Optional[Dict[str, Any]]:
+        """Module declared by directory/go.mod: {module, directory, go, replaces}, or None"""
+        if directory not in self._module_at:
+            text = self._read(directory, "go.mod")
+            directives = parse_directives(text) if text is not None else []
+            module = next((args[0] for verb, args in directives if verb == "module" and args), None)
+            self._module_at[directory] = None if module is None else {
+                "module": module,
+                "directory": directory,
+                "go": next((args[0] for verb, args in directives if verb == "go" and args), None),
+                "replaces": _local_replaces(directives, directory),
+            }
+        return self._module_at[directory]
+
+    def workspace_at(self, directory: str) -> Optional[Dict[str, Any]]:
+        """Workspace declared by directory/go.work: {file, directory, go, use, replaces}, or None"""
+        if directory not in self._work_at:
+            text = self._read(directory, "go.work")
+            directives = parse_directives(text) if text is not None else []
+            self._work_at[directory] = None if text is None else {
+                "file": "go.work" if directory == "." else f"{directory}/go.work",
+                "directory": directory,
+                "go": next((args[0] for verb, args in directives if verb == "go" and args), None),
+                "use": sorted({posixpath.normpath(posixpath.join(directory, args[0]))
+                               for verb, args in directives if verb == "use" and args}),
+                "replaces": _local_replaces(directives, directory),
+            }
+        return self._work_at[directory]
+
+    def module_of(self, directory: str) -> Optional[Dict[str, Any]]:
+        """Nearest module at or above a root-relative directory, or None outside every module"""
+        if directory not in self._nearest:
+            module = self.module_at(directory)
+            if module is None and _parent(directory) is not None:
+                module = self.module_of(_parent(directory))
+            self._nearest[directory] = module
+        return self._nearest[directory]
+
+    def workspace_of(self, module: Dict[str, Any]) -> Optional[Dict[str, Any]]:
+        """Nearest go.work at or above a module that uses it, or None"""
+        directory = module["directory"]
+        while directory is not None:
+            workspace = self.workspace_at(directory)
+            if workspace is not None:
+                return workspace if module["directory"] in workspace["use"] else None
+            directory = _parent(directory)
+        return None
+
+    def visible_modules(self, module: Dict[str, Any]) -> List[Tuple[str, str]]:
+        """(module path, directory) of every module an importing module resolves inside the repository"""
+        visible = {module["module"]: module["directory"]}
+        workspace = self.workspace_of(module)
+        if workspace is not None:
+            for directory in workspace["use"]:
+                member = self.module_at(directory)
+                if member is not None:
+                    visible.setdefault(member["module"], member["directory"])
+        visible.update(module["replaces"])
+        if workspace is not None:
+            # go.work replace directives override the same module's replaces in its member go.mod files
+            visible.update(workspace["replaces"])
+        return sorted(visible.items())
+
+    def resolve(self, spec: str, directory: str) -> Optional[str]:
+        """Package directory an import path names from a file in directory, or None when it is not in the tree"""
+        module = self.module_of(directory)
+        if module is None:
+            return None
+        matches = [(path, target) for path, target in self.visible_modules(module)
+                   if spec == path or spec.startswith(path + "/")]
+        if not matches:
+            return None
+        path, target = max(matches, key=lambda match: len(match[0]))
+        return posixpath.normpath(posixpath.join(target, spec[len(path):].lstrip("/")))
+
+    def import_path(self, directory: str) -> Optional[str]:
+        """Full import path of the package in a directory ('example.com/acme/text/strings'), or None"""
+        module = self.module_of(directory)
+        if module is None:
+            return None
+        relative = posixpath.relpath(directory, module["directory"])
+        return module["module"] if relative == "." else f"{module['module']}/{relative}"
+
+    def summarize(self, go_files: Iterable[str], edges: Iterable[Tuple[str, str]]) -> Optional[Dict[str, Any]]:
+        """
+        # @codebase-summary: code_analysis 'go_modules' section, or None for a single module without go.work
+        - modules: every go.mod the scanned Go files belong to, with its package count and workspace
+        - workspaces: go.work files, their 'use' directories, and uses that have no go.mod
+        - cross_module_imports: resolved Go import edges between module directories, with the imported module path
+        """
+        modules: Dict[str, Dict[str, Any]] = {}
+        outside = 0
+        packages = {Path(file).parent.as_posix() for file in go_files}
+        for directory in sorted(packages):
+            module = self.module_of(directory)
+            if module is None:
+                outside += 1
+                continue
+            entry = modules.setdefault(module["directory"], {
+                "module": module["module"], "directory": module["directory"], "go": module["go"], "packages": 0})
+            entry["packages"] += 1
+            workspace = self.workspace_of(module)
+            if workspace is not None:
+                entry["workspace"] = workspace["file"]
+            if module["replaces"]:
+                entry["local_replaces"] = module["replaces"]
+        workspaces = {}
+        for entry in modules.values():
+            if "workspace" in entry:
+                workspace = self.workspace_of(self.module_at(entry["directory"]))
+                workspaces[workspace["file"]] = {
+                    "file": workspace["file"], "go": workspace["go"], "use": workspace["use"],
+                    "missing": [d for d in workspace["use"] if self.module_at(d) is None],
+                }
+        if len(modules) < 2 and not workspaces:
+            return None
+        crossings: Dict[Tuple[str, str, str], int] = {}
+        for source, target in edges:
+            if not (source.endswith(".go") and target.endswith(".go")):
+                continue
+            source_module = self.module_of(Path(source).parent.as_posix())
+            target_module = self.module_of(Path(target).parent.as_posix())
+            if source_module and target_module and source_module["directory"] != target_module["directory"]:
+                key = (source_module["directory"], target_module["directory"], target_module["module"])
+                crossings[key] = crossings.get(key, 0) + 1
+        return {
+            "modules": sorted(modules.values(), key=lambda entry: entry["directory"]),
+            "workspaces": sorted(workspaces.values(), key=lambda entry: entry["file"]),
+            "packages_outside_modules": outside,
+            "cross_module_imports": [{"from": source, "to": target, "module": module, "file_edges": count}
+                                     for (source, target, module), count in sorted(crossings.items())],
+        }
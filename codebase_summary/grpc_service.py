@@ -0,0 +1,139 @@
+#!/usr/bin/env python3
+"""
+gRPC Service - The ArkivalScan service from schemas/arkival_scan.proto over the serve-mode index
+Message classes are generated from the .proto at startup (grpcio + grpcio-tools), so there are no
+checked-in stubs to keep in sync; both packages are optional and only needed for --grpc-port
+"""
+
+import hmac
+import json
+import time
+from concurrent import futures
+from typing import Dict, Any, Iterator, Optional
+
+from doc_drift import qualified_name
+
+PROTO_PATH = "schemas/arkival_scan.proto"
+SERVICE_NAME = "arkival.v1.ArkivalScan"
+
+try:
+    import grpc
+    GRPC_AVAILABLE = True
+except ImportError:
+    GRPC_AVAILABLE = False
+
+
+def _load_protos():
+    """(messages, services) modules generated from PROTO_PATH (resolved against sys.path)"""
+    return grpc.protos_and_services(PROTO_PATH)
+
+
+def iter_findings(summary: Dict[str, Any], files: Dict[str, Dict[str, Any]], path_prefix: str = "",
+                  rule_ids=()) -> Iterator[Dict[str, Any]]:
+    """
+    # @codebase-summary: Findings streamed by StreamFindings, in file then line order
+    - ARK001: undocumented symbol; ARK002: documented but missing .arkival-policy required fields
+    - ARK003: documented function whose parameters changed since its doc comment was written
+    """
+    wanted = set(rule_ids)
+    for file, analysis in sorted(files.items()):
+        if not file.startswith(path_prefix):
+            continue
+        for symbol in sorted(analysis.get("symbols", []), key=lambda s: s.get("line", 0)):
+            if symbol.get("documented") or symbol.get("doc_exempt"):
+                continue
+            name = qualified_name(symbol)
+            if symbol.get("missing_fields"):
+                rule, message = "ARK002", f"'{name}' documentation is missing: {', '.join(symbol['missing_fields'])}"
+            else:
+                rule, message = "ARK001", f"'{name}' is missing a @codebase-summary breadcrumb"
+            if not wanted or rule in wanted:
+                yield {"rule_id": rule, "severity": "warning", "file": file, "line": symbol.get("line", 0),
+                       "symbol": name, "kind": symbol.get("kind", "function"), "message": message}
+    if wanted and "ARK003" not in wanted:
+        return
+    for entry in summary.get("code_analysis", {}).get("doc_drift", {}).get("drifted", []):
+        if entry["file"].startswith(path_prefix):
+            yield {"rule_id": "ARK003", "severity": "warning", "file": entry["file"], "line": entry["line"],
+                   "symbol": entry["name"], "kind": "function",
+                   "message": f"parameters of '{entry['name']}' changed since its documentation was written"}
+
+
+def _totals(messages, index) -> Any:
+    """ScanTotals message for the index's current snapshot"""
+    summary, files = index.snapshot()
+    code = summary.get("code_analysis", {})
+    file_counts: Dict[str, int] = {}
+    for analysis in files.values():
+        language = index.generator.language_map.get(analysis.get("language", ""), analysis.get("language", ""))
+        file_counts[language] = file_counts.get(language, 0) + 1
+    languages = [
+        messages.LanguageCoverage(language=language, files=file_counts.get(language, 0), functions=entry["total"],
+                                  documented=entry["documented"], coverage_percentage=entry["coverage"] or 0.0)
+        for language, entry in index.coverage()["languages"].items()
+    ]
+    return messages.ScanTotals(
+        project_name=summary.get("project_name", ""), version=summary.get("version", ""),
+        files_analyzed=code.get("total_files_analyzed", 0), total_functions=code.get("total_functions", 0),
+        documented_functions=code.get("documented_functions", 0),
+        coverage_percentage=code.get("coverage_percentage", 0.0), languages=languages)
+
+
+def start_grpc_server(index, port: int, host: str = "127.0.0.1", certfile: Optional[str] = None,
+                      keyfile: Optional[str] = None, token: Optional[str] = None, workers: int = 4):
+    """
+    # @codebase-summary: Start the ArkivalScan gRPC server and return it (None without grpcio)
+    - Shares serve mode's WorkspaceIndex, so HTTP and gRPC clients see the same results
+    - certfile/keyfile enable TLS; token requires 'authorization: Bearer <token>' metadata
+    """
+    if not GRPC_AVAILABLE:
+        print("❌ gRPC needs the grpcio and grpcio-tools packages: pip install grpcio grpcio-tools")
+        return None
+    try:
+        messages, services = _load_protos()
+    except Exception as e:  # grpcio-tools missing or the .proto failed to compile
+        print(f"❌ Could not load {PROTO_PATH}: {e} - is grpcio-tools installed?")
+        return None
+
+    def authorize(context):
+        if not token:
+            return
+        supplied = dict(context.invocation_metadata()).get("authorization", "")
+        if not hmac.compare_digest(supplied.encode(), f"Bearer {token}".encode()):
+            context.abort(grpc.StatusCode.UNAUTHENTICATED, "missing or invalid bearer token")
+
+    class ArkivalScanServicer(services.ArkivalScanServicer):
+        def Scan(self, request, context):
+            authorize(context)
+            started = time.monotonic()
+            index.refresh(force=request.force)
+            return messages.ScanResponse(totals=_totals(messages, index),
+                                         duration_ms=int((time.monotonic() - started) * 1000))
+
+        def GetSummary(self, request, context):
+            authorize(context)
+            summary, _ = index.snapshot()
+            return messages.GetSummaryResponse(totals=_totals(messages, index), summary_json=json.dumps(summary))
+
+        def StreamFindings(self, request, context):
+            authorize(context)
+            summary, files = index.snapshot()
+            for finding in iter_findings(summary, files, request.path_prefix, list(request.rule_ids)):
+                if not context.is_active():
+                    return
+                yield messages.Finding(**finding)
+
+    server = grpc.server(futures.ThreadPoolExecutor(max_workers=workers))
+    services.add_ArkivalScanServicer_to_server(ArkivalScanServicer(), server)
+    address = f"{host}:{port}"
+    if certfile:
+        with open(certfile, "rb") as f:
+            certificate = f.read()
+        with open(keyfile or certfile, "rb") as f:
+            key = f.read()
+        server.add_secure_port(address, grpc.ssl_server_credentials([(key, certificate)]))
+    else:
+        server.add_insecure_port(address)
+    server.start()
+    print(f"✅ gRPC {SERVICE_NAME} listening on {address}{' (TLS)' if certfile else ''}")
+    return server
@@ -0,0 +1,138 @@
+#!/usr/bin/env python3
+"""
+Ignore Files - gitignore-style .arkivalignore files at any directory level
+Rules are checked while the project is walked, so an ignored directory is pruned before the scanner
+descends into it; deeper files refine their ancestors' rules and '!' re-includes a path
+"""
+
+import re
+from pathlib import Path
+from typing import Dict, List, Optional, Tuple
+
+from text_encoding import is_file, read_text
+
+IGNORE_FILENAME = ".arkivalignore"
+
+# (regex on the path relative to the ignore file's directory, negated, directories only)
+Rule = Tuple[re.Pattern, bool, bool]
+
+
+def glob_to_regex(pattern: str) -> str:
+    """Unanchored regex body for a gitignore glob: '*' and '?' stay within a path segment, '**' spans segments"""
+    out = []
+    i = 0
+    while i < len(pattern):
+        if pattern.startswith("**/", i):
+            out.append("(?:.*/)?")
+            i += 3
+        elif pattern.startswith("**", i):
+            out.append(".*")
+            i += 2
+        elif pattern[i] == "*":
+            out.append("[^/]*")
+            i += 1
+        elif pattern[i] == "?":
+            out.append("[^/]")
+            i += 1
+        elif pattern[i] == "[":
+            end = pattern.find("]", i + 2)
+            if end == -1:
+                out.append(re.escape("["))
+                i += 1
+                continue
+            body = pattern[i + 1:end]
+            if body[0] in "!^":
+                body = "^" + body[1:]
+            out.append("[" + body.replace("\\", "\\\\") + "]")
+            i = end + 1
+        elif pattern[i] == "\\" and i + 1 < len(pattern):
+            out.append(re.escape(pattern[i + 1]))
+            i += 2
+        else:
+            out.append(re.escape(pattern[i]))
+            i += 1
+    return "".join(out)
+
+
+def parse_rule(line: str) -> Optional[Rule]:
+    """
+    # @codebase-summary: One .arkivalignore line, with git's semantics
+    - '#' comments and blank lines are skipped; '\\#' and '\\!' escape a leading '#' or '!'
+    - A leading '!' re-includes; a trailing '/' matches directories only
+    - A pattern containing '/' (other than a trailing one) is anchored to the file's directory;
+      otherwise it matches a name at any depth below it
+    """
+    line = line.rstrip("\n")
+    if not line.startswith("\\ "):
+        line = re.sub(r"(?<!\\) +$", "", line)
+    if not line or line.startswith("#"):
+        return None
+    negated = line.startswith("!")
+    if negated:
+        line = line[1:]
+    elif line.startswith(("\\#", "\\!")):
+        line = line[1:]
+    directories_only = line.endswith("/")
+    line = line.rstrip("/")
+    if not line:
+        return None
+    anchored = "/" in line
+    body = glob_to_regex(line.lstrip("/"))
+    return re.compile(("" if anchored else "(?:.*/)?") + body + "$"), negated, directories_only
+
+
+class IgnoreResolver:
+    """
+    # @codebase-summary: .arkivalignore lookup for paths under the project root
+    - Each directory's file is read once; a path's rules are those of every directory from the
+      root down to its parent, evaluated in order so the last matching rule wins
+    - A path below an ignored directory is ignored even when checked on its own (watch mode, hooks)
+    """
+
+    def __init__(self, project_root: Path, filename: str = IGNORE_FILENAME):
+        self.project_root = Path(project_root)
+        self.filename = filename
+        self._rules: Dict[Path, List[Rule]] = {}
+        self._directories: Dict[Path, bool] = {}
+
+    def rules(self, directory: Path) -> List[Rule]:
+        """Compiled rules from directory's own ignore file (empty when it has none)"""
+        if directory not in self._rules:
+            rules = []
+            path = directory / self.filename
+            if is_file(path):
+                try:
+                    rules = [rule for rule in (parse_rule(line) for line in read_text(path).split("\n")) if rule]
+                except OSError as e:
+                    print(f"⚠️ Could not read {path}: {e}")
+            self._rules[directory] = rules
+        return self._rules[directory]
+
+    def _matches(self, path: Path, is_dir: bool) -> bool:
+        """Whether the rules of path's ancestor directories ignore path itself"""
+        ignored = False
+        base = path.parent
+        bases = [base] + list(base.parents)
+        for directory in reversed(bases[:bases.index(self.project_root) + 1]):
+            rel_path = path.relative_to(directory).as_posix()
+            for regex, negated, directories_only in self.rules(directory):
+                if (is_dir or not directories_only) and regex.match(rel_path):
+                    ignored = not negated
+        return ignored
+
+    def _directory_ignored(self, directory: Path) -> bool:
+        """Whether directory or any directory above it (up to the root) is ignored"""
+        if directory == self.project_root:
+            return False
+        if directory not in self._directories:
+            self._directories[directory] = self._directory_ignored(directory.parent) or self._matches(directory, True)
+        return self._directories[directory]
+
+    def is_ignored(self, path: Path) -> bool:
+        """True when an .arkivalignore rule excludes path (a file or directory) or one of its parent directories"""
+        path = Path(path)
+        if path == self.project_root or self.project_root not in path.parents:
+            return False
+        if self._directory_ignored(path.parent):
+            return True
+        return self._matches(path, path.is_dir())
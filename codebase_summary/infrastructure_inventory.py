@@ -0,0 +1,57 @@
+#!/usr/bin/env python3
+"""
+Infrastructure Inventory - Terraform resources, modules, variables, and outputs across the scan
+Aggregates the HCL extractor's infrastructure symbols into a summary section
+"""
+
+from collections import Counter
+from typing import Dict, Any, List
+
+from memory_budget import in_file_order
+
+_INFRASTRUCTURE_KINDS = ("resource", "data", "module", "variable", "output")
+
+
+def build_infrastructure_inventory(file_analysis: List[Dict[str, Any]], max_listed: int = 50) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Terraform inventory summary for codebase_summary.json
+    - Counts blocks by kind and resources by type and provider across every scanned .tf file
+    - Lists module calls with their source, and variables or outputs missing a description
+    - Returns an empty dict when the scan contains no infrastructure symbols
+    """
+    counts = Counter()
+    resource_types = Counter()
+    providers = Counter()
+    modules = []
+    undescribed = []
+    files = set()
+
+    for analysis in in_file_order(file_analysis):
+        for symbol in analysis.get("symbols", []):
+            if not symbol.get("infrastructure"):
+                continue
+            kind = symbol.get("kind")
+            files.add(analysis["file"])
+            counts[kind] += 1
+            if kind in ("resource", "data"):
+                resource_types[symbol["resource_type"]] += 1
+                providers[symbol["provider"]] += 1
+            elif kind == "module":
+                modules.append({
+                    "name": symbol["name"], "source": symbol.get("source", ""),
+                    "file": analysis["file"], "line": symbol.get("line", 0)
+                })
+            if kind in ("variable", "output") and not symbol.get("documented"):
+                undescribed.append(f"{analysis['file']}:{symbol['name']}")
+
+    if not files:
+        return {}
+    return {
+        "files": len(files),
+        "counts": {kind: counts[kind] for kind in _INFRASTRUCTURE_KINDS},
+        "resource_types": dict(sorted(resource_types.items(), key=lambda t: (-t[1], t[0]))),
+        "providers": dict(sorted(providers.items(), key=lambda p: (-p[1], p[0]))),
+        "modules": modules[:max_listed],
+        "undescribed": undescribed[:max_listed],
+        "undescribed_count": len(undescribed)
+    }
@@ -0,0 +1,103 @@
+#!/usr/bin/env python3
+"""
+Interface Map - Go interface-to-implementation analysis
+Matches the method sets of scanned struct types against scanned interfaces using go/ast signatures
+"""
+
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+from memory_budget import in_file_order
+
+
+def _base_type(receiver: str) -> str:
+    """'*Stack[T]' -> 'Stack'"""
+    return receiver.lstrip("*").split("[")[0]
+
+
+def _find_interface(directory: str, embedded: str, interfaces: Dict[tuple, Dict[str, Any]]) -> Optional[tuple]:
+    """
+    Locate an embedded interface: same package for 'Name', or a unique scanned
+    interface with that name for a qualified 'pkg.Name'
+    """
+    if "." not in embedded:
+        return (directory, embedded) if (directory, embedded) in interfaces else None
+    bare = embedded.rsplit(".", 1)[1]
+    matches = [key for key in interfaces if key[1] == bare]
+    return matches[0] if len(matches) == 1 else None
+
+
+def _resolve_interface_methods(key: tuple, interfaces: Dict[tuple, Dict[str, Any]],
+                               visiting: Optional[set] = None) -> Optional[Dict[str, str]]:
+    """
+    Full method set of an interface including embedded interfaces found in the scan.
+    Returns None when an embedded interface is outside the scanned module (e.g. io.Reader),
+    because the complete method set is then unknown.
+    """
+    visiting = visiting or set()
+    if key in visiting:
+        return {}
+    iface = interfaces[key]
+    methods = dict(iface.get("method_signatures", {}))
+    for embedded in iface.get("embeds", []):
+        inner_key = _find_interface(key[0], embedded, interfaces)
+        inner = _resolve_interface_methods(inner_key, interfaces, visiting | {key}) if inner_key else None
+        if inner is None:
+            return None
+        methods.update(inner)
+    return methods
+
+
+def build_interface_map(file_analysis: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Go interface satisfaction mapping across the scanned module
+    - Collects struct method sets (value vs pointer receivers) and interface method signatures
+    - A struct implements an interface when every method matches by name and signature
+    - pointer_receiver marks implementations where only *T (not T) has the full method set
+    - Interfaces with no methods, or that embed interfaces from outside the scan, are skipped
+    """
+    interfaces: Dict[tuple, Dict[str, Any]] = {}
+    structs: Dict[tuple, Dict[str, Any]] = {}
+    methods = defaultdict(dict)  # (directory, type) -> {name: (signature, pointer)}
+
+    for analysis in in_file_order(file_analysis):
+        if analysis.get("language") != ".go":
+            continue
+        directory = Path(analysis["file"]).parent.as_posix()
+        for symbol in analysis.get("symbols", []):
+            kind = symbol.get("kind")
+            if kind == "interface" and ("method_signatures" in symbol or symbol.get("embeds")):
+                interfaces[(directory, symbol["name"])] = {**symbol, "file": analysis["file"]}
+            elif kind == "struct" and "package" in symbol:
+                structs[(directory, symbol["name"])] = {**symbol, "file": analysis["file"]}
+            elif kind == "method" and "signature" in symbol:
+                receiver = symbol.get("receiver", "")
+                methods[(directory, _base_type(receiver))][symbol["name"]] = (
+                    symbol["signature"], receiver.startswith("*")
+                )
+
+    mapping = []
+    for key, iface in sorted(interfaces.items()):
+        required = _resolve_interface_methods(key, interfaces)
+        if not required:
+            continue
+        implementations = []
+        for struct_key, struct in sorted(structs.items()):
+            method_set = methods.get(struct_key, {})
+            if not all(method_set.get(m, (None,))[0] == sig for m, sig in required.items()):
+                continue
+            implementations.append({
+                "type": struct["name"],
+                "file": struct["file"],
+                "line": struct.get("line", 0),
+                "pointer_receiver": any(method_set[m][1] for m in required)
+            })
+        mapping.append({
+            "interface": iface["name"],
+            "file": iface["file"],
+            "line": iface.get("line", 0),
+            "methods": sorted(required),
+            "implementations": implementations
+        })
+    return mapping
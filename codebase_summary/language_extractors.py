@@ -0,0 +1,3850 @@
+#!/usr/bin/env python3
+"""
+Language Extractors - Structure-aware symbol extraction for individual languages
+Used by update_project_summary.py for languages that need more than per-line regex matching
+"""
+
+import re
+import ast
+from typing import Dict, Any, List, Optional, Callable
+
+# Each extractor takes the file content split into lines and returns symbol candidates:
+#   {"name": str, "kind": str, "line": int (1-based), ...language-specific metadata}
+# A "doc_comment" entry names the language's native doc format (e.g. "javadoc") when one
+# precedes the declaration; the scanner counts such symbols as documented.
+Extractor = Callable[[List[str]], List[Dict[str, Any]]]
+
+EXTRACTORS: Dict[str, Extractor] = {}
+
+
+def register_extractor(language: str):
+    """Decorator registering an extractor function for a language key"""
+    def decorator(func: Extractor) -> Extractor:
+        EXTRACTORS[language] = func
+        return func
+    return decorator
+
+
+def get_extractor(language: str) -> Optional[Extractor]:
+    """Return the dedicated extractor for a language, or None to use regex patterns"""
+    return EXTRACTORS.get(language)
+
+
+_CHAR_LITERAL = re.compile(r"'(?:\\.|[^'\\])'")
+_STRING_LITERAL = re.compile(r'"(?:\\.|[^"\\])*"')
+_SINGLE_QUOTED = re.compile(r"'(?:\\.|[^'\\])*'")
+_TEMPLATE_LITERAL = re.compile(r'`(?:\\.|[^`\\])*`')
+
+
+class BraceScopeTracker:
+    """
+    # @codebase-summary: Brace depth and enclosing-scope tracker for C-family languages
+    - Ignores braces inside string/char literals and line or block comments
+    - Scopes registered with open_scope() attach to the next opening brace
+    - A pending scope is dropped if a ';' ends the declaration before any brace
+    """
+
+    def __init__(self, line_comment: str = '//', block_comment=('/*', '*/'), literals=(_CHAR_LITERAL, _STRING_LITERAL)):
+        self.line_comment = line_comment
+        self.block_comment = block_comment
+        self.literals = literals
+        self.depth = 0
+        self.scopes: List[Dict[str, Any]] = []
+        self._pending: Optional[Dict[str, Any]] = None
+        self._in_block_comment = False
+
+    def clean(self, line: str) -> str:
+        """Strip literals and comments so only structural characters remain"""
+        text = line
+        if self.block_comment:
+            start, end = self.block_comment
+            result = ''
+            while text:
+                if self._in_block_comment:
+                    idx = text.find(end)
+                    if idx == -1:
+                        text = ''
+                    else:
+                        text = text[idx + len(end):]
+                        self._in_block_comment = False
+                else:
+                    idx = text.find(start)
+                    if idx == -1:
+                        result += text
+                        text = ''
+                    else:
+                        result += text[:idx]
+                        text = text[idx + len(start):]
+                        self._in_block_comment = True
+            text = result
+        for literal in self.literals:
+            text = literal.sub('""', text)
+        if self.line_comment and self.line_comment in text:
+            text = text[:text.index(self.line_comment)]
+        return text
+
+    def current_scope(self) -> Optional[Dict[str, Any]]:
+        """Innermost scope whose body the tracker is directly inside"""
+        if self.scopes and self.scopes[-1]["depth"] == self.depth:
+            return self.scopes[-1]
+        return None
+
+    def in_scope(self, kind: str) -> bool:
+        """True when any enclosing scope (at any depth) has the given kind"""
+        return any(scope["kind"] == kind for scope in self.scopes)
+
+    def open_scope(self, kind: str, name: str, **metadata):
+        """Register a scope (class, impl, trait...) that begins at the next '{'"""
+        self._pending = {"kind": kind, "name": name, **metadata}
+
+    def feed(self, line: str):
+        """Advance the tracker past one source line"""
+        for ch in self.clean(line):
+            if ch == '{':
+                self.depth += 1
+                if self._pending is not None:
+                    self.scopes.append({**self._pending, "depth": self.depth})
+                    self._pending = None
+            elif ch == '}':
+                if self.scopes and self.scopes[-1]["depth"] == self.depth:
+                    self.scopes.pop()
+                self.depth = max(0, self.depth - 1)
+            elif ch == ';' and self._pending is not None:
+                self._pending = None
+
+
+def _strip_angle_brackets(text: str) -> str:
+    """Remove balanced <...> groups so 'Map<K, List<V>> f(' reads as 'Map f(' (C++, Java, C#...)"""
+    previous = None
+    while previous != text:
+        previous = text
+        text = re.sub(r'<[^<>;{}]*>', '', text)
+    return text
+
+
+def _join_declaration_head(first: str, cleaned: List[str], start: int, limit: int = 8):
+    """
+    Join a declaration whose first line is 'first' (cleaned[start] minus any template prefix)
+    until its body '{' or terminating ';' at parenthesis depth 0.
+    Returns (text, end_index, has_body) or None if neither is found within limit lines.
+    """
+    depth = 0
+    parts = []
+    for i in range(start, min(len(cleaned), start + limit)):
+        line = first if i == start else cleaned[i]
+        for pos, ch in enumerate(line):
+            if ch == '(':
+                depth += 1
+            elif ch == ')':
+                depth -= 1
+            elif depth == 0 and ch in '{;':
+                parts.append(line[:pos])
+                return ' '.join(parts), i, ch == '{'
+        parts.append(line)
+    return None
+
+
+def has_block_doc_comment(lines: List[str], index: int, opener: str = '/**',
+                          skip: Optional[re.Pattern] = None) -> bool:
+    """
+    True when the declaration at lines[index] is directly preceded by a doc block such as
+    Javadoc's '/** ... */'. Blank lines and lines matching skip (annotations, attributes)
+    may sit between the block and the declaration.
+    """
+    j = index - 1
+    while j >= 0 and (not lines[j].strip() or (skip is not None and skip.match(lines[j]))):
+        j -= 1
+    if j < 0 or not lines[j].rstrip().endswith('*/'):
+        return False
+    for k in range(j, max(-1, j - 500), -1):
+        start = lines[k].find('/*')
+        if start != -1:
+            return lines[k].startswith(opener, start) and not lines[k].startswith(opener + '/', start)
+    return False
+
+
+def has_line_doc_comment(lines: List[str], index: int, prefix: str = '///',
+                         skip: Optional[re.Pattern] = None) -> bool:
+    """
+    True when the declaration at lines[index] is directly preceded by line doc comments such as
+    C#'s '/// <summary>'. Lines matching skip (attributes) may sit in between; blank lines may not.
+    """
+    j = index - 1
+    while j >= 0 and skip is not None and lines[j].strip() and skip.match(lines[j]):
+        j -= 1
+    return j >= 0 and lines[j].lstrip().startswith(prefix) and not lines[j].lstrip().startswith(prefix + '/')
+
+
+def _drop_declared_prototypes(symbols: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+    """
+    Drop body-less prototypes whose definition appears in the same file.
+    A doc comment on the dropped prototype (e.g. in an @interface) carries over to the definition.
+    """
+    defined = {(s.get("parent"), s["name"]) for s in symbols if not s.get("declaration_only")}
+    prototype_docs = {(s.get("parent"), s["name"]): s["doc_comment"]
+                      for s in symbols if s.get("declaration_only") and s.get("doc_comment")}
+    kept = []
+    for s in symbols:
+        key = (s.get("parent"), s["name"])
+        if s.get("declaration_only") and key in defined:
+            continue
+        symbol = {k: v for k, v in s.items() if k != "declaration_only"}
+        if not s.get("declaration_only") and "doc_comment" not in symbol and key in prototype_docs:
+            symbol["doc_comment"] = prototype_docs[key]
+        kept.append(symbol)
+    return kept
+
+
+# ====== RUST ======
+
+_RUST_FN = re.compile(
+    r'^\s*(?:pub(?:\s*\([^)]*\))?\s+)?(?:default\s+)?(?:(const)\s+)?(?:(async)\s+)?'
+    r'(?:unsafe\s+)?(?:extern\s+"[^"]*"\s+)?fn\s+([A-Za-z_]\w*)'
+)
+_RUST_IMPL = re.compile(r'^\s*(?:unsafe\s+)?impl\b(?:\s*<.*?>(?=\s))?\s+([^{]+?)\s*(?:\bwhere\b.*)?\{?\s*$')
+_RUST_TRAIT = re.compile(r'^\s*(?:pub(?:\s*\([^)]*\))?\s+)?(?:unsafe\s+)?trait\s+([A-Za-z_]\w*)')
+_RUST_MACRO = re.compile(r'^\s*macro_rules!\s*([A-Za-z_]\w*)')
+_RUST_TYPE = re.compile(r'^\s*(?:pub(?:\s*\([^)]*\))?\s+)?(struct|enum)\s+([A-Za-z_]\w*)')
+
+
+def _rust_base_type(type_expr: str) -> str:
+    """Reduce an impl target like '&Wrapper<T>' to its type name 'Wrapper'"""
+    match = re.search(r'([A-Za-z_]\w*)\s*(?:<.*)?$', type_expr.strip())
+    return match.group(1) if match else type_expr.strip()
+
+
+@register_extractor('rust')
+def extract_rust_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Rust symbol extraction with impl/trait awareness
+    - Detects fn/pub fn/async fn/const fn, structs, enums, traits, and macro_rules! macros
+    - Records impl blocks and attributes methods to their impl target or trait; impl blocks are
+      doc_exempt, since their documentation lives on the type, trait, and methods
+    - Trait method signatures without bodies are reported as trait_method symbols
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+
+    for i, line in enumerate(lines):
+        line_no = i + 1
+        scope = tracker.current_scope()
+
+        fn_match = _RUST_FN.match(line)
+        if fn_match:
+            symbol = {"name": fn_match.group(3), "kind": "function", "line": line_no}
+            if fn_match.group(2):
+                symbol["async"] = True
+            if fn_match.group(1):
+                symbol["const"] = True
+            if scope and scope["kind"] == "impl":
+                symbol["kind"] = "method"
+                symbol["parent"] = scope["target"]
+                if scope.get("trait"):
+                    symbol["trait"] = scope["trait"]
+            elif scope and scope["kind"] == "trait":
+                symbol["kind"] = "trait_method"
+                symbol["parent"] = scope["name"]
+            symbols.append(symbol)
+        elif _RUST_MACRO.match(line):
+            symbols.append({"name": _RUST_MACRO.match(line).group(1), "kind": "macro", "line": line_no})
+        elif _RUST_TRAIT.match(line):
+            name = _RUST_TRAIT.match(line).group(1)
+            symbols.append({"name": name, "kind": "trait", "line": line_no})
+            tracker.open_scope("trait", name)
+        elif _RUST_IMPL.match(line):
+            header = _RUST_IMPL.match(line).group(1)
+            if ' for ' in f' {header} ':
+                trait_part, target_part = re.split(r'\s+for\s+', header, maxsplit=1)
+                scope_meta = {"target": _rust_base_type(target_part), "trait": _rust_base_type(trait_part)}
+                name = f"{scope_meta['trait']} for {scope_meta['target']}"
+            else:
+                scope_meta = {"target": _rust_base_type(header)}
+                name = scope_meta["target"]
+            symbols.append({"name": name, "kind": "impl", "line": line_no, "doc_exempt": True, **scope_meta})
+            tracker.open_scope("impl", name, **scope_meta)
+        else:
+            type_match = _RUST_TYPE.match(line)
+            if type_match:
+                symbols.append({"name": type_match.group(2), "kind": type_match.group(1), "line": line_no})
+
+        tracker.feed(line)
+
+    return symbols
+
+
+# ====== TYPESCRIPT / TSX ======
+
+_TS_MODIFIERS = r'(?:(?:public|private|protected|static|readonly|abstract|async|override|declare|accessor)\s+)*'
+_TS_FUNCTION = re.compile(
+    r'^\s*(export\s+)?(default\s+)?(declare\s+)?(async\s+)?function\s*(\*)?\s*([A-Za-z_$][\w$]*)?\s*(<[^(]*>)?\s*\('
+)
+_TS_VARIABLE = re.compile(
+    r'^\s*(export\s+)?(declare\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*(?::\s*([^=]+?))?\s*=(?![=>])\s*(.*)$'
+)
+_TS_DEFAULT_ARROW = re.compile(r'^\s*export\s+default\s+(async\s+)?(<[^(]*>\s*)?\(')
+_TS_CLASS = re.compile(r'^\s*(export\s+)?(default\s+)?(declare\s+)?(abstract\s+)?class\s+([A-Za-z_$][\w$]*)\s*(<[^{]*?>)?')
+_TS_AMBIENT = re.compile(r'^\s*(?:export\s+)?declare\s+(?:module|global|namespace)\b')
+_TS_NAMESPACE = re.compile(r'^\s*(?:export\s+)?(?:namespace|module)\s+([A-Za-z_$][\w$.]*)\s*\{?')
+_TS_DECORATOR = re.compile(r'^\s*@([A-Za-z_$][\w$.]*)')
+_TS_MEMBER = re.compile(
+    r'^\s*(' + _TS_MODIFIERS + r')(?:(get|set)\s+)?(\*\s*)?(#?[A-Za-z_$][\w$]*)\s*\??\s*(<[^(]*>)?\s*\('
+)
+_TS_MEMBER_PROPERTY = re.compile(
+    r'^\s*(' + _TS_MODIFIERS + r')(#?[A-Za-z_$][\w$]*)\s*(?::\s*([^=]+?))?\s*=(?![=>])\s*(.*)$'
+)
+_TS_COMPONENT_TYPE = re.compile(r'\b(?:React\.)?(?:FC|FunctionComponent|VFC)\b')
+# JSX evidence that a PascalCase TSX function renders: 'return <div>', '=> (<>', or a JSX.Element return type
+_TSX_JSX_RETURN = re.compile(r'(?:\breturn\b|=>)\s*\(?\s*<(?:[A-Za-z>])')
+_TSX_JSX_RETURN_TYPE = re.compile(r'\)\s*:\s*(?:React\.)?(?:JSX\.Element|ReactElement|ReactNode)\b')
+_TS_NOT_MEMBERS = {'if', 'for', 'while', 'switch', 'catch', 'return', 'function', 'new', 'super', 'this', 'else', 'do', 'typeof'}
+
+
+def _strip_ts_decorators(line: str):
+    """Split leading '@decorator' tokens off a line, returning (names, remainder)"""
+    names = []
+    rest = line
+    while True:
+        match = re.match(r'^\s*@([A-Za-z_$][\w$.]*)\s*(\((?:[^()]|\([^()]*\))*\))?', rest)
+        if not match:
+            return names, rest
+        names.append(match.group(1))
+        rest = rest[match.end():]
+
+
+def _balanced_arrow_follows(lines: List[str], index: int, text: str) -> Optional[str]:
+    """
+    Decide whether an initializer starting with '(' (possibly multi-line) is an arrow function.
+    Returns the generic parameter list if present ('' if none), or None when it is not an arrow.
+    """
+    joined = ' '.join([text] + [l.strip() for l in lines[index + 1:index + 15]])
+    type_params = ''
+    generic = re.match(r'^(<[^(]*>)\s*', joined)
+    if generic:
+        type_params = generic.group(1)
+        joined = joined[generic.end():]
+    if not joined.startswith('('):
+        return None
+    depth = 0
+    for pos, ch in enumerate(joined):
+        if ch == '(':
+            depth += 1
+        elif ch == ')':
+            depth -= 1
+            if depth == 0:
+                tail = joined[pos + 1:]
+                statement_end = min([p for p in (tail.find(';'), tail.find('{')) if p != -1] or [len(tail)])
+                return type_params if '=>' in tail[:statement_end + 2] else None
+    return None
+
+
+def _classify_ts_initializer(lines: List[str], index: int, rest: str, type_annotation: Optional[str]):
+    """
+    Classify the right-hand side of a variable declaration.
+    Returns (kind, metadata) for function-valued initializers, or None for plain values.
+    """
+    metadata = {}
+    text = rest.strip()
+    if text.startswith('async'):
+        metadata["async"] = True
+        text = text[5:].strip()
+    if text.startswith('function'):
+        return "function", metadata
+    if re.match(r'^(?:React\.)?(?:memo|forwardRef)\b', text):
+        return "component", metadata
+    if re.match(r'^[A-Za-z_$][\w$]*\s*=>', text):
+        return "function", metadata
+    type_params = _balanced_arrow_follows(lines, index, text)
+    if type_params is not None:
+        if type_params:
+            metadata["type_params"] = type_params
+        return "function", metadata
+    if type_annotation and _TS_COMPONENT_TYPE.search(type_annotation):
+        return "component", metadata
+    return None
+
+
+def _renders_jsx(lines: List[str], index: int, type_annotation: Optional[str] = None) -> bool:
+    """Whether the function declared at lines[index] is typed as a component or returns JSX"""
+    if type_annotation and _TS_COMPONENT_TYPE.search(type_annotation):
+        return True
+    depth, opened, body = 0, False, []
+    for line in lines[index:index + 200]:
+        body.append(line.strip())
+        depth += line.count('{') - line.count('}')
+        opened = opened or '{' in line
+        # The body ends at its closing brace, or at the ';' of an expression-bodied arrow
+        if (opened and depth <= 0) or (not opened and line.rstrip().endswith(';')):
+            break
+    text = ' '.join(body)
+    return bool(_TSX_JSX_RETURN.search(text) or _TSX_JSX_RETURN_TYPE.search(text))
+
+
+def _refine_ts_function_kind(name: str, kind: str, jsx: bool, lines: List[str], index: int,
+                             type_annotation: Optional[str] = None) -> str:
+    """Promote functions to hook/component kinds: use* names, and PascalCase TSX functions that render JSX"""
+    if kind != "function":
+        return kind
+    if re.match(r'^use[A-Z]', name):
+        return "hook"
+    if jsx and name[:1].isupper() and _renders_jsx(lines, index, type_annotation):
+        return "component"
+    return kind
+
+
+def _extract_typescript(lines: List[str], jsx: bool) -> List[Dict[str, Any]]:
+    """Shared TypeScript/TSX extraction - see extract_typescript_symbols"""
+    symbols = []
+    tracker = BraceScopeTracker(literals=(_STRING_LITERAL, _SINGLE_QUOTED, _TEMPLATE_LITERAL))
+    pending_decorators: List[str] = []
+
+    for i, raw_line in enumerate(lines):
+        line_no = i + 1
+        scope = tracker.current_scope()
+        decorators, line = _strip_ts_decorators(raw_line)
+        pending_decorators.extend(decorators)
+        stripped = line.strip()
+
+        if not stripped or stripped.startswith(('//', '/*', '*')):
+            tracker.feed(raw_line)
+            continue
+
+        ambient = tracker.in_scope("ambient")
+        symbol = None
+
+        if _TS_AMBIENT.match(line):
+            tracker.open_scope("ambient", "declare")
+        elif scope and scope["kind"] == "class":
+            member = _TS_MEMBER.match(line)
+            prop = _TS_MEMBER_PROPERTY.match(line)
+            if member and member.group(4) not in _TS_NOT_MEMBERS:
+                name = member.group(4)
+                modifiers = member.group(1).split()
+                symbol = {"name": name, "kind": "constructor" if name == "constructor" else "method", "line": line_no}
+                if member.group(2):
+                    symbol["accessor"] = member.group(2)
+                if member.group(5):
+                    symbol["type_params"] = member.group(5)
+                for flag in ("static", "abstract", "async"):
+                    if flag in modifiers:
+                        symbol[flag] = True
+            elif prop and _classify_ts_initializer(lines, i, prop.group(4), prop.group(3)):
+                kind, metadata = _classify_ts_initializer(lines, i, prop.group(4), prop.group(3))
+                symbol = {"name": prop.group(2), "kind": "method", "line": line_no, **metadata}
+            if symbol:
+                symbol["parent"] = scope["name"]
+        else:
+            func = _TS_FUNCTION.match(line)
+            var = _TS_VARIABLE.match(line)
+            cls = _TS_CLASS.match(line)
+            namespace = _TS_NAMESPACE.match(line)
+            default_arrow = _TS_DEFAULT_ARROW.match(line)
+
+            if func:
+                name = func.group(6) or "default"
+                symbol = {"name": name, "kind": _refine_ts_function_kind(name, "function", jsx, lines, i), "line": line_no}
+                if func.group(4):
+                    symbol["async"] = True
+                if func.group(5):
+                    symbol["generator"] = True
+                if func.group(7):
+                    symbol["type_params"] = func.group(7)
+                if func.group(3):
+                    ambient = True
+                # Overload signatures end with ';' and carry no body
+                if stripped.endswith(';') and '{' not in stripped:
+                    symbol["signature_only"] = True
+                if func.group(1):
+                    symbol["exported"] = True
+                if func.group(2):
+                    symbol["default_export"] = True
+            elif var:
+                classified = _classify_ts_initializer(lines, i, var.group(5), var.group(4))
+                if classified:
+                    kind, metadata = classified
+                    name = var.group(3)
+                    symbol = {"name": name, "kind": _refine_ts_function_kind(name, kind, jsx, lines, i, var.group(4)), "line": line_no, **metadata}
+                    if var.group(1):
+                        symbol["exported"] = True
+                    if var.group(2):
+                        ambient = True
+            elif default_arrow and _balanced_arrow_follows(lines, i, line[line.index('default') + 7:].strip().lstrip('async').strip()) is not None:
+                symbol = {"name": "default", "kind": "component" if jsx and _renders_jsx(lines, i) else "function", "line": line_no,
+                          "exported": True, "default_export": True}
+            elif cls:
+                name = cls.group(5)
+                symbol = {"name": name, "kind": "class", "line": line_no}
+                if cls.group(6):
+                    symbol["type_params"] = cls.group(6)
+                if cls.group(4):
+                    symbol["abstract"] = True
+                if cls.group(1):
+                    symbol["exported"] = True
+                if cls.group(2):
+                    symbol["default_export"] = True
+                if cls.group(3):
+                    ambient = True
+                tracker.open_scope("class", name)
+            elif namespace:
+                tracker.open_scope("namespace", namespace.group(1))
+
+            if symbol:
+                enclosing_namespace = [s["name"] for s in tracker.scopes if s["kind"] == "namespace"]
+                if enclosing_namespace:
+                    symbol["namespace"] = ".".join(enclosing_namespace)
+
+        if symbol and not ambient:
+            if pending_decorators:
+                symbol["decorators"] = pending_decorators
+            symbols.append(symbol)
+        if symbol or (stripped and not decorators):
+            pending_decorators = []
+
+        tracker.feed(raw_line)
+
+    # Drop overload signatures that have an implementation with the same name
+    implemented = {(s["name"], s.get("parent")) for s in symbols if not s.get("signature_only")}
+    return [
+        {k: v for k, v in s.items() if k != "signature_only"}
+        for s in symbols
+        if not (s.get("signature_only") and (s["name"], s.get("parent")) in implemented)
+    ]
+
+
+@register_extractor('typescript')
+def extract_typescript_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: TypeScript symbol extraction with decorator and generic support
+    - Detects function declarations, arrow functions assigned to const/let/var, classes and class members
+    - Captures decorators, generic type parameter lists, async/static/abstract flags, and default exports
+    - Skips ambient 'declare' blocks and collapses overload signatures into their implementation
+    """
+    return _extract_typescript(lines, jsx=False)
+
+
+@register_extractor('tsx')
+def extract_tsx_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: TSX symbol extraction with React component detection
+    - Same rules as TypeScript, plus memo/forwardRef wrappers and PascalCase functions/arrows that return
+      JSX (or are typed React.FC / JSX.Element) become components
+    """
+    return _extract_typescript(lines, jsx=True)
+
+
+# ====== KOTLIN ======
+
+_KOTLIN_MODIFIERS = (
+    r'(?:(?:public|private|protected|internal|open|final|abstract|override|inline|suspend|operator|infix|'
+    r'tailrec|external|actual|expect|data|sealed|enum|inner|value|annotation|lateinit|const)\s+)*'
+)
+_KOTLIN_FUN = re.compile(
+    r'^\s*(?:@[\w.]+(?:\([^)]*\))?\s+)*(' + _KOTLIN_MODIFIERS + r')fun\s+(?:<[^>]*(?:<[^>]*>[^>]*)*>\s*)?'
+    r'(?:([\w.]+(?:<[^>]*(?:<[^>]*>[^>]*)*>)?\??)\.)?(`[^`]+`|[A-Za-z_]\w*)\s*\('
+)
+_KOTLIN_TYPE = re.compile(
+    r'^\s*(?:@[\w.]+(?:\([^)]*\))?\s+)*(' + _KOTLIN_MODIFIERS + r')(fun\s+interface|class|interface|object)\s+([A-Za-z_]\w*)'
+)
+_KOTLIN_COMPANION = re.compile(r'^\s*(?:(?:private|internal|public)\s+)?companion\s+object\b\s*([A-Za-z_]\w*)?')
+_KOTLIN_CLASS_FLAGS = ("data", "sealed", "enum", "abstract", "open", "inner", "value", "annotation")
+
+
+def _kotlin_header_has_body(lines: List[str], index: int, tracker: BraceScopeTracker) -> bool:
+    """
+    Decide whether a class/object header starting at lines[index] opens a '{' body.
+    Kotlin has no terminating ';', so a header ends at a line break outside parentheses
+    unless the next line continues it (supertypes, where clauses, or the opening brace).
+    """
+    depth = 0
+    for offset, raw in enumerate(lines[index:index + 30]):
+        text = BraceScopeTracker(literals=tracker.literals).clean(raw)
+        for ch in text:
+            if ch in '(<':
+                depth += 1
+            elif ch in ')>':
+                depth = max(0, depth - 1)
+            elif ch == '{' and depth == 0:
+                return True
+        if depth == 0:
+            following = next((l.strip() for l in lines[index + offset + 1:] if l.strip()), '')
+            if not (text.rstrip().endswith((':', ',')) or following.startswith((':', '{', 'where', ','))):
+                return False
+    return False
+
+
+@register_extractor('kotlin')
+def extract_kotlin_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Kotlin symbol extraction with extension and companion awareness
+    - Detects top-level, member, suspend, generic, and extension functions (receiver recorded)
+    - Detects classes (data/sealed/enum/abstract flags), interfaces, and named objects
+    - Attributes companion object members to the enclosing class with a companion flag
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+
+    for i, line in enumerate(lines):
+        line_no = i + 1
+        scope = tracker.current_scope()
+
+        fun_match = _KOTLIN_FUN.match(line)
+        type_match = _KOTLIN_TYPE.match(line)
+        companion_match = _KOTLIN_COMPANION.match(line)
+
+        if fun_match and not (type_match and type_match.group(2).startswith('fun')):
+            modifiers = fun_match.group(1).split()
+            symbol = {"name": fun_match.group(3).strip('`'), "kind": "function", "line": line_no}
+            if fun_match.group(2):
+                symbol["kind"] = "extension_function"
+                symbol["receiver"] = fun_match.group(2)
+            if "suspend" in modifiers:
+                symbol["suspend"] = True
+            if scope and scope["kind"] in ("class", "object", "companion"):
+                if symbol["kind"] == "function":
+                    symbol["kind"] = "method"
+                symbol["parent"] = scope["name"]
+                if scope["kind"] == "companion":
+                    symbol["companion"] = True
+            symbols.append(symbol)
+        elif companion_match:
+            parent = scope["name"] if scope else (companion_match.group(1) or "Companion")
+            if _kotlin_header_has_body(lines, i, tracker):
+                tracker.open_scope("companion", parent)
+        elif type_match:
+            modifiers = type_match.group(1).split()
+            keyword = type_match.group(2)
+            name = type_match.group(3)
+            kind = "interface" if keyword.endswith("interface") else keyword
+            symbol = {"name": name, "kind": kind, "line": line_no}
+            for flag in _KOTLIN_CLASS_FLAGS:
+                if flag in modifiers:
+                    symbol[flag] = True
+            if scope and scope["kind"] in ("class", "object", "companion"):
+                symbol["parent"] = scope["name"]
+            symbols.append(symbol)
+            if _kotlin_header_has_body(lines, i, tracker):
+                tracker.open_scope("object" if kind == "object" else "class", name)
+
+        tracker.feed(line)
+
+    return symbols
+
+
+# ====== PYTHON ======
+
+_PROPERTY_DECORATORS = {"property", "cached_property", "functools.cached_property", "abc.abstractproperty"}
+
+
+def _python_decorator_name(decorator: ast.expr) -> str:
+    """Dotted name of a decorator, without call arguments: @app.route("/x") -> app.route"""
+    target = decorator.func if isinstance(decorator, ast.Call) else decorator
+    try:
+        return ast.unparse(target)
+    except Exception:
+        return "?"
+
+
+def _python_method_type(decorators: List[str]) -> Optional[str]:
+    """property / classmethod / staticmethod classification from decorator names"""
+    for name in decorators:
+        if name in _PROPERTY_DECORATORS or name.endswith((".setter", ".getter", ".deleter")):
+            return "property"
+        if name in ("classmethod", "staticmethod"):
+            return name
+    return None
+
+
+@register_extractor('python')
+def extract_python_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Python symbol extraction using the ast module
+    - Detects functions, async functions, classes, and methods at any nesting depth
+    - Records decorators, property/classmethod/staticmethod method types, and abstract methods
+    - Nested definitions carry their dotted parent scope (e.g. "Outer.method") and a nested flag
+    - Raises SyntaxError for unparsable files so the caller falls back to regex patterns
+    """
+    tree = ast.parse("\n".join(lines))
+    symbols = []
+
+    def visit(body, scope: List[str], parent_kind: Optional[str]):
+        for node in body:
+            if isinstance(node, (ast.FunctionDef, ast.AsyncFunctionDef)):
+                decorators = [_python_decorator_name(d) for d in node.decorator_list]
+                symbol = {
+                    "name": node.name,
+                    "kind": "method" if parent_kind == "class" else "function",
+                    "line": node.lineno,
+                    "end_line": getattr(node, "end_lineno", node.lineno)
+                }
+                if isinstance(node, ast.AsyncFunctionDef):
+                    symbol["async"] = True
+                if decorators:
+                    symbol["decorators"] = decorators
+                method_type = _python_method_type(decorators) if parent_kind == "class" else None
+                if method_type:
+                    symbol["method_type"] = method_type
+                if any(d.split(".")[-1] == "abstractmethod" for d in decorators):
+                    symbol["abstract"] = True
+                if scope:
+                    symbol["parent"] = ".".join(scope)
+                if parent_kind == "function":
+                    symbol["nested"] = True
+                symbols.append(symbol)
+                visit(node.body, scope + [node.name], "function")
+            elif isinstance(node, ast.ClassDef):
+                symbol = {"name": node.name, "kind": "class", "line": node.lineno,
+                          "end_line": getattr(node, "end_lineno", node.lineno)}
+                decorators = [_python_decorator_name(d) for d in node.decorator_list]
+                if decorators:
+                    symbol["decorators"] = decorators
+                bases = [ast.unparse(b) for b in node.bases]
+                if bases:
+                    symbol["bases"] = bases
+                if scope:
+                    symbol["parent"] = ".".join(scope)
+                if parent_kind == "function":
+                    symbol["nested"] = True
+                symbols.append(symbol)
+                visit(node.body, scope + [node.name], "class")
+            else:
+                # Definitions inside if/try/with/for blocks belong to the enclosing scope
+                for field in ("body", "orelse", "finalbody", "handlers"):
+                    block = getattr(node, field, None)
+                    if isinstance(block, list):
+                        visit(block, scope, parent_kind)
+                for case in getattr(node, "cases", []) or []:
+                    visit(case.body, scope, parent_kind)
+
+    visit(tree.body, [], None)
+    return symbols
+
+
+# ====== C++ ======
+
+_CPP_NOT_FUNCTIONS = {
+    'if', 'for', 'while', 'switch', 'return', 'sizeof', 'decltype', 'alignof', 'alignas', 'static_assert',
+    'catch', 'throw', 'new', 'delete', 'noexcept', 'typeid', 'defined', '__attribute__', '__declspec'
+}
+_CPP_SKIP_LINE = re.compile(
+    r'^\s*(?:#|(?:public|private|protected)\s*:|using\b|typedef\b|friend\b|return\b|case\b|default\s*:|'
+    r'else\b|do\b|goto\b|break\b|continue\b|}|\{)'
+)
+_CPP_TEMPLATE = re.compile(r'^\s*template\s*<')
+_CPP_NAMESPACE = re.compile(r'^\s*(?:inline\s+)?namespace\s*([A-Za-z_][\w:]*)?\s*(\{|$)')
+_CPP_LINKAGE = re.compile(r'^\s*extern\s+"[^"]*"\s*\{')
+_CPP_CLASS = re.compile(
+    r'^\s*(?:(?:export\s+)?template\s*<.*>\s*)?(class|struct|union)\s+(?:\[\[[^\]]*\]\]\s*)?(?:alignas\([^)]*\)\s*)?'
+    r'(?:[A-Z_][A-Z0-9_]*\s+)?([A-Za-z_]\w*)(?:\s*<[^{;]*>)?(?:\s+final)?\s*(?::[^;{]*)?(\{.*)?$'
+)
+_CPP_ENUM = re.compile(r'^\s*enum\s+(?:class\s+|struct\s+)?([A-Za-z_]\w*)[^;]*?(\{.*)?$')
+_CPP_OPERATOR = re.compile(
+    r'((?:[A-Za-z_]\w*(?:<[^<>]*>)?::)*)operator\s*(\(\)|\[\]|new\b(?:\[\])?|delete\b(?:\[\])?|""\s*\w+|[^\s(\w]+|[A-Za-z_][\w:<>*& ]*?)\s*\('
+)
+_CPP_FUNCTION_NAME = re.compile(r'((?:[A-Za-z_]\w*::)*)(~?[A-Za-z_]\w*)\s*\(')
+_CPP_PARAM_TYPES = re.compile(
+    r'^\s*(?:\)|void\s*\)|\.\.\.|(?:const\s+|volatile\s+|unsigned\s+|signed\s+|struct\s+|enum\s+)*'
+    r'[A-Za-z_][\w:]*(?:<.*>)?[\s*&]+[A-Za-z_*&]|(?:bool|char|short|int|long|float|double|auto|size_t)\b)'
+)
+
+
+@register_extractor('cpp')
+def extract_cpp_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: C++ symbol extraction with namespace, class, and template awareness
+    - Detects free functions, in-class and out-of-line (Class::method) methods, constructors,
+      destructors, and operator overloads, joining signatures that span several lines
+    - Attributes every symbol to its enclosing namespace chain and records template parameters
+    - In-class prototypes are dropped when the same file defines the method out-of-line
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+    cleaner = BraceScopeTracker()
+    cleaned = [cleaner.clean(line) for line in lines]
+    class_names = set()
+    namespace_names = set()
+    pending_template = None
+    in_macro = False
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        stripped = text.strip()
+        consumed = i
+
+        macro_line = in_macro or stripped.startswith('#')
+        in_macro = macro_line and lines[i].rstrip().endswith('\\')
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if macro_line or not stripped or not at_declaration_level or (scope and scope["kind"] == "enum"):
+            tracker.feed(lines[i])
+            i += 1
+            continue
+
+        namespaces = [s["name"] for s in tracker.scopes if s["kind"] == "namespace"]
+        enclosing_class = scope["name"] if scope and scope["kind"] == "class" else None
+
+        template_match = _CPP_TEMPLATE.match(text)
+        if template_match:
+            # template<...> may stand alone on its line or prefix the declaration
+            depth = 0
+            for pos in range(template_match.end() - 1, len(text)):
+                depth += {'<': 1, '>': -1}.get(text[pos], 0)
+                if depth == 0:
+                    pending_template = text[template_match.end() - 1:pos + 1]
+                    text = text[pos + 1:]
+                    break
+            if not text.strip():
+                tracker.feed(lines[i])
+                i += 1
+                continue
+
+        symbol = None
+        declaration = text.split('{', 1)[0]
+        namespace_match = _CPP_NAMESPACE.match(text)
+        class_match = _CPP_CLASS.match(text) if not text.rstrip().endswith(';') else None
+        enum_match = _CPP_ENUM.match(text) if not text.rstrip().endswith(';') else None
+
+        if namespace_match:
+            namespace_names.update((namespace_match.group(1) or "").split("::"))
+            tracker.open_scope("namespace", namespace_match.group(1) or "(anonymous)")
+        elif _CPP_LINKAGE.match(text):
+            tracker.open_scope("linkage", "extern")
+        elif class_match and '(' not in declaration.split(':')[0]:
+            name = class_match.group(2)
+            class_names.add(name)
+            symbol = {"name": name, "kind": class_match.group(1), "line": i + 1}
+            if enclosing_class:
+                symbol["parent"] = enclosing_class
+            tracker.open_scope("class", name)
+        elif enum_match:
+            symbol = {"name": enum_match.group(1), "kind": "enum", "line": i + 1}
+            tracker.open_scope("enum", enum_match.group(1))
+        elif '(' in text and not _CPP_SKIP_LINE.match(text):
+            head = _join_declaration_head(text, cleaned, i)
+            if head:
+                head_text, end_index, has_body = head
+                symbol = _cpp_function_symbol(head_text, has_body, enclosing_class, class_names, namespace_names)
+                if symbol:
+                    symbol["line"] = i + 1
+                    if not has_body:
+                        symbol["declaration_only"] = True
+                    consumed = end_index
+
+        if symbol:
+            if pending_template is not None:
+                symbol["template_params"] = pending_template
+            # 'ns::func() {}' defines a function of a namespace other than the enclosing one
+            namespaces += symbol.pop("qualified_namespace", [])
+            if namespaces:
+                symbol["namespace"] = "::".join(namespaces)
+            symbols.append(symbol)
+        pending_template = None
+
+        # Every line of a joined signature is fed so brace depth stays consistent
+        for j in range(i, consumed + 1):
+            tracker.feed(lines[j])
+        i = consumed + 1
+
+    return _drop_declared_prototypes(symbols)
+
+
+def _cpp_function_symbol(head: str, has_body: bool, enclosing_class: Optional[str], class_names: set,
+                         namespace_names: set):
+    """Build a function/method symbol from a joined declaration head, or None if it is not one"""
+    operator = _CPP_OPERATOR.search(head)
+    if operator:
+        qualifier = operator.group(1)
+        name = "operator" + re.sub(r'\s+', ' ', operator.group(2).strip())
+        prefix = head[:operator.start()]
+        params = head[operator.end():]
+        kind = "operator"
+    else:
+        stripped = _strip_angle_brackets(head)
+        match = _CPP_FUNCTION_NAME.search(stripped)
+        if not match or match.group(2).lstrip('~') in _CPP_NOT_FUNCTIONS:
+            return None
+        qualifier, name = match.group(1), match.group(2)
+        prefix = stripped[:match.start()]
+        params = stripped[match.end():]
+        kind = None
+
+    # 'int x = compute(3)' or 'auto f = [](...)' are initializations, not declarations
+    if '=' in prefix or '[' in prefix or '.' in prefix or '->' in prefix:
+        return None
+    qualifier_parts = [q for q in qualifier.split('::') if q]
+    qualified_namespace = []
+    while qualifier_parts and qualifier_parts[0] in namespace_names and qualifier_parts[0] not in class_names:
+        qualified_namespace.append(qualifier_parts.pop(0))
+    owner = qualifier_parts[-1] if qualifier_parts else enclosing_class
+    if not has_body:
+        # 'Widget w(1, 2);' constructs an object; prototypes name parameter types
+        if not _CPP_PARAM_TYPES.match(params) and not (owner and name in (owner, '~' + owner)):
+            return None
+        if enclosing_class is None and not qualifier_parts and not prefix.strip():
+            return None  # A bare call statement such as 'init();'
+
+    if kind is None:
+        if owner and name == owner:
+            kind = "constructor"
+        elif owner and name == '~' + owner:
+            kind = "destructor"
+        elif owner and (enclosing_class or owner in class_names or has_body):
+            kind = "method"
+        else:
+            kind = "function"
+
+    symbol = {"name": name, "kind": kind}
+    if qualified_namespace:
+        symbol["qualified_namespace"] = qualified_namespace
+    if owner and (enclosing_class or qualifier_parts):
+        symbol["parent"] = owner
+        if qualifier_parts and not enclosing_class:
+            symbol["out_of_line"] = True
+    return symbol
+
+
+# ====== JAVA ======
+
+_JAVA_ANNOTATION = re.compile(r'@(?!interface\b)([A-Za-z_][\w.]*)(?:\s*\((?:[^()]|\([^()]*\))*\))?')
+_JAVA_ANNOTATION_LINE = re.compile(r'^\s*@(?!interface\b)[A-Za-z_][\w.]*(?:\s*\(.*\))?\s*$')
+_JAVA_MODIFIERS = (
+    'public', 'protected', 'private', 'static', 'final', 'abstract', 'synchronized', 'native',
+    'default', 'strictfp', 'sealed', 'non-sealed', 'transient', 'volatile'
+)
+_JAVA_MODIFIER_PREFIX = r'((?:(?:' + '|'.join(re.escape(m) for m in _JAVA_MODIFIERS) + r')\s+)*)'
+_JAVA_TYPE = re.compile(
+    r'^\s*' + _JAVA_MODIFIER_PREFIX + r'(class|interface|enum|record|@interface)\s+([A-Za-z_$][\w$]*)'
+)
+_JAVA_METHOD = re.compile(
+    r'^\s*' + _JAVA_MODIFIER_PREFIX + r'(<(?:[^<>]|<[^<>]*>)*>\s*)?(?:([\w$.]+(?:\s*\[\s*\])*(?:\s*\.\.\.)?)\s+)?([A-Za-z_$][\w$]*)\s*\('
+)
+_JAVA_COMPACT_CONSTRUCTOR = re.compile(r'^\s*' + _JAVA_MODIFIER_PREFIX + r'([A-Z][\w$]*)\s*\{')
+_JAVA_ENUM_CONSTANT = re.compile(r'^\s*[A-Z][A-Z0-9_]*\s*(?:\(|,|;|\{|$)')
+_JAVA_NOT_METHODS = {'if', 'for', 'while', 'switch', 'catch', 'synchronized', 'return', 'new', 'throw', 'super', 'this'}
+_JAVA_TYPE_KINDS = {
+    'class': 'class', 'interface': 'interface', 'enum': 'enum', 'record': 'record', '@interface': 'annotation'
+}
+
+
+def _java_modifier_flags(modifiers: str) -> Dict[str, Any]:
+    """Visibility plus the boolean modifiers worth surfacing as metadata"""
+    words = modifiers.split()
+    flags = {}
+    visibility = next((w for w in ('public', 'protected', 'private') if w in words), None)
+    if visibility:
+        flags["visibility"] = visibility
+    for word in ('static', 'final', 'abstract', 'synchronized', 'native', 'default', 'sealed'):
+        if word in words:
+            flags[word] = True
+    return flags
+
+
+@register_extractor('java')
+def extract_java_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Java symbol extraction with annotations and Javadoc detection
+    - Detects classes, interfaces, enums, records, annotation types, methods, and constructors
+      (including compact record constructors), with nested types reported as Outer.Inner
+    - Captures annotations (@Override, @Deprecated...) and modifiers as metadata
+    - Marks symbols preceded by a /** ... */ block with doc_comment="javadoc"
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+    cleaner = BraceScopeTracker()
+    cleaned = [cleaner.clean(line) for line in lines]
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level or _JAVA_ANNOTATION_LINE.match(text):
+            tracker.feed(lines[i])
+            i += 1
+            continue
+
+        # Annotations may sit on preceding lines or prefix the declaration itself
+        annotation_lines = [text]
+        j = i - 1
+        while j >= 0 and (not cleaned[j].strip() or _JAVA_ANNOTATION_LINE.match(cleaned[j])):
+            annotation_lines.insert(0, cleaned[j])
+            j -= 1
+        annotations = ['@' + name for name in _JAVA_ANNOTATION.findall(' '.join(annotation_lines))]
+        declaration = _JAVA_ANNOTATION.sub('', text)
+
+        parents = [s["name"] for s in tracker.scopes if s["kind"] == "type"]
+        parent = ".".join(parents) if parents else None
+        type_kind = scope.get("type_kind") if scope else None
+
+        symbol = None
+        type_match = _JAVA_TYPE.match(declaration)
+        if type_match:
+            name = type_match.group(3)
+            symbol = {"name": name, "kind": _JAVA_TYPE_KINDS[type_match.group(2)], "line": i + 1,
+                      **_java_modifier_flags(type_match.group(1))}
+            tracker.open_scope("type", name, type_kind=symbol["kind"])
+        elif type_kind == "enum" and not scope.get("past_constants") and _JAVA_ENUM_CONSTANT.match(declaration):
+            # Enum constants (RED("r"), GREEN { ... }) precede the first ';' of the enum body
+            scope["past_constants"] = ';' in declaration
+        elif parent and '(' in declaration:
+            head = _join_declaration_head(declaration, cleaned, i)
+            method = _JAVA_METHOD.match(_strip_angle_brackets(head[0])) if head else None
+            generic = _JAVA_METHOD.match(head[0]) if head else None
+            if method and method.group(4) not in _JAVA_NOT_METHODS and '=' not in head[0].split('(')[0]:
+                name = method.group(4)
+                if method.group(3) or name == parents[-1]:
+                    _, end_index, has_body = head
+                    symbol = {"name": name, "kind": "method" if method.group(3) else "constructor",
+                              "line": i + 1, "parent": parent, **_java_modifier_flags(method.group(1))}
+                    if generic and generic.group(2):
+                        symbol["type_params"] = generic.group(2).strip()
+                    if not has_body and type_kind in ("interface", "annotation"):
+                        symbol["abstract"] = True
+                    consumed = end_index
+        elif type_kind == "record" and parents:
+            compact = _JAVA_COMPACT_CONSTRUCTOR.match(declaration)
+            if compact and compact.group(2) == parents[-1]:
+                symbol = {"name": compact.group(2), "kind": "constructor", "line": i + 1, "parent": parent,
+                          "compact": True, **_java_modifier_flags(compact.group(1))}
+
+        if symbol:
+            if parent and symbol["kind"] not in ("method", "constructor"):
+                symbol["parent"] = parent
+            if annotations:
+                symbol["annotations"] = annotations
+                if "@Deprecated" in annotations:
+                    symbol["deprecated"] = True
+            if has_block_doc_comment(lines, i, skip=_JAVA_ANNOTATION_LINE):
+                symbol["doc_comment"] = "javadoc"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(lines[k])
+        i = consumed + 1
+
+    return symbols
+
+
+# ====== C# ======
+
+_CS_MODIFIERS = (
+    'public', 'private', 'protected', 'internal', 'static', 'abstract', 'sealed', 'partial', 'readonly',
+    'unsafe', 'new', 'override', 'virtual', 'async', 'extern', 'file', 'required', 'const', 'volatile', 'ref'
+)
+_CS_MODIFIER_PREFIX = r'((?:(?:' + '|'.join(_CS_MODIFIERS) + r')\s+)*)'
+_CS_ATTRIBUTE = re.compile(r'^\s*\[([^\[\]]*(?:\[[^\]]*\][^\[\]]*)*)\]')
+_CS_ATTRIBUTE_LINE = re.compile(r'^\s*\[[^\]]*\]\s*$')
+_CS_NAMESPACE = re.compile(r'^\s*namespace\s+([\w.]+)\s*(;)?')
+_CS_TYPE = re.compile(
+    r'^\s*' + _CS_MODIFIER_PREFIX + r'(class|struct|interface|enum|record(?:\s+struct|\s+class)?|delegate)\s+'
+)
+_CS_TYPE_NAME = re.compile(r'([A-Za-z_]\w*)\s*(?:<[^>]*>\s*)?(?:\(|:|\{|where\b|;|$)')
+_CS_RETURN_TYPE = r'(?:[\w.]+(?:\[[,\s]*\])*\??)'
+_CS_METHOD = re.compile(
+    r'^\s*' + _CS_MODIFIER_PREFIX + r'(?:(' + _CS_RETURN_TYPE + r')\s+)?(~?[A-Za-z_]\w*)\s*\('
+)
+_CS_OPERATOR = re.compile(
+    r'^\s*' + _CS_MODIFIER_PREFIX + r'(?:(implicit|explicit)\s+operator\s+([\w.?]+)|'
+    + _CS_RETURN_TYPE + r'\s+operator\s*(true|false|[^\s(\w]+))\s*\('
+)
+_CS_INDEXER = re.compile(r'^\s*' + _CS_MODIFIER_PREFIX + _CS_RETURN_TYPE + r'\s+this\s*\[')
+_CS_PROPERTY = re.compile(
+    r'^\s*' + _CS_MODIFIER_PREFIX + r'(' + _CS_RETURN_TYPE + r')\s+([A-Za-z_]\w*)\s*(\{|=>|$)'
+)
+_CS_ACCESSOR_BODY = re.compile(r'\b(?:get|set|init)\s*(?:\{|=>)')
+_CS_NOT_METHODS = {
+    'if', 'for', 'foreach', 'while', 'switch', 'catch', 'using', 'lock', 'return', 'new', 'nameof',
+    'typeof', 'sizeof', 'default', 'checked', 'unchecked', 'fixed', 'throw', 'await', 'base', 'this'
+}
+
+
+def _cs_modifier_flags(modifiers: str) -> Dict[str, Any]:
+    """Visibility plus the boolean modifiers worth surfacing as metadata"""
+    words = modifiers.split()
+    flags = {}
+    visibility = " ".join(w for w in ('public', 'protected', 'private', 'internal', 'file') if w in words)
+    if visibility:
+        flags["visibility"] = visibility
+    for word in ('static', 'abstract', 'virtual', 'override', 'sealed', 'partial', 'async', 'readonly', 'extern'):
+        if word in words:
+            flags[word] = True
+    return flags
+
+
+def _cs_strip_attributes(text: str):
+    """Remove leading [Attribute(...)] groups, returning (remaining text, attribute names)"""
+    names = []
+    match = _CS_ATTRIBUTE.match(text)
+    while match:
+        content = re.sub(r'^\s*\w+\s*:', '', match.group(1))  # [return: NotNull], [assembly: ...]
+        names += re.findall(r'(?:^|,)\s*([A-Za-z_][\w.]*)', re.sub(r'\([^()]*\)', '', content))
+        text = text[match.end():]
+        match = _CS_ATTRIBUTE.match(text)
+    return text, names
+
+
+def _cs_strip_tuple_type(text: str) -> str:
+    """Replace a leading tuple return type '(string name, int value) Get()' with a plain type name"""
+    match = re.match(r'^(\s*' + _CS_MODIFIER_PREFIX + r')\(', text)
+    if not match:
+        return text
+    depth = 0
+    for pos in range(match.end() - 1, len(text)):
+        depth += {'(': 1, ')': -1}.get(text[pos], 0)
+        if depth == 0:
+            rest = text[pos + 1:]
+            # Only a tuple type when another identifier and '(' follow
+            return match.group(1) + 'ValueTuple' + rest if re.match(r'\??\s+[A-Za-z_]\w*\s*[(<]', rest) else text
+    return text
+
+
+@register_extractor('csharp')
+def extract_csharp_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: C# symbol extraction with XML doc comment detection
+    - Detects types (classes, partial classes, structs, records, interfaces, enums, delegates),
+      methods, constructors, finalizers, operators, indexers, and properties with accessor or
+      expression bodies - auto-properties ({ get; set; }) carry no logic and are skipped
+    - Records async, expression-bodied, extension-method, and modifier metadata plus attributes
+    - Marks symbols preceded by /// comments with doc_comment="xmldoc"; partial method
+      declarations are dropped when the implementing part is in the same file
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+    cleaner = BraceScopeTracker()
+    cleaned = [cleaner.clean(line) for line in lines]
+    file_namespace = None
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        skip_line = (not text.strip() or text.lstrip().startswith('#') or _CS_ATTRIBUTE_LINE.match(text)
+                     or (scope and scope.get("type_kind") == "enum"))
+        if skip_line or not at_declaration_level:
+            tracker.feed(lines[i])
+            i += 1
+            continue
+
+        # Attributes may sit on preceding lines or prefix the declaration itself
+        j = i - 1
+        while j >= 0 and _CS_ATTRIBUTE_LINE.match(cleaned[j]):
+            j -= 1
+        declaration, attributes = _cs_strip_attributes(' '.join(cleaned[j + 1:i] + [text]))
+
+        namespaces = ([file_namespace] if file_namespace else []) + [
+            s["name"] for s in tracker.scopes if s["kind"] == "namespace"
+        ]
+        parents = [s["name"] for s in tracker.scopes if s["kind"] == "type"]
+        parent = ".".join(parents) if parents else None
+        type_kind = scope.get("type_kind") if scope else None
+
+        symbol = None
+        namespace_match = _CS_NAMESPACE.match(declaration)
+        type_match = _CS_TYPE.match(declaration)
+        head = _join_declaration_head(text, cleaned, i)
+
+        if namespace_match:
+            if namespace_match.group(2):
+                file_namespace = namespace_match.group(1)
+            else:
+                tracker.open_scope("namespace", namespace_match.group(1))
+        elif type_match:
+            kind = type_match.group(2).split()[0]
+            rest = declaration[type_match.end():]
+            if kind == "delegate":
+                method = _CS_METHOD.match(_strip_angle_brackets(rest))
+                name = method.group(3) if method else None
+            else:
+                name_match = _CS_TYPE_NAME.match(rest)
+                name = name_match.group(1) if name_match else None
+            if name:
+                symbol = {"name": name, "kind": kind, "line": i + 1, **_cs_modifier_flags(type_match.group(1))}
+                if kind != "delegate":
+                    tracker.open_scope("type", name, type_kind=kind)
+        elif parent and head:
+            head_text, end_index, has_body = head
+            member = _cs_strip_tuple_type(_cs_strip_attributes(head_text)[0])
+            signature = _strip_angle_brackets(member)
+            operator = _CS_OPERATOR.match(signature)
+            indexer = _CS_INDEXER.match(signature)
+            method = _CS_METHOD.match(signature)
+            prop = _CS_PROPERTY.match(signature)
+            # The joined head stops at the body '{' or the final ';', so '=>' can only start an expression body
+            expression_bodied = '=>' in signature
+
+            if operator:
+                target = operator.group(3) or operator.group(4)
+                name = f"{operator.group(2)} operator {target}" if operator.group(2) else f"operator {target}"
+                symbol = {"name": name, "kind": "operator", **_cs_modifier_flags(operator.group(1))}
+            elif indexer:
+                if _CS_ACCESSOR_BODY.search(' '.join(cleaned[i:end_index + 3])) or expression_bodied:
+                    symbol = {"name": "this[]", "kind": "indexer", **_cs_modifier_flags(indexer.group(1))}
+            elif method and method.group(3) not in _CS_NOT_METHODS and '=' not in signature.split('(')[0]:
+                name = method.group(3)
+                if name == parents[-1] or name == '~' + parents[-1]:
+                    kind = "destructor" if name.startswith('~') else "constructor"
+                elif method.group(2):
+                    kind = "method"
+                else:
+                    kind = None
+                if kind:
+                    symbol = {"name": name, "kind": kind, **_cs_modifier_flags(method.group(1))}
+                    generic = re.match(r'[^(]*?\b' + re.escape(name) + r'\s*(<[^(]*>)\s*\(', member)
+                    if generic:
+                        symbol["type_params"] = generic.group(1)
+                    if re.search(r'\(\s*this\s', signature):
+                        symbol["extension"] = True
+                    if not has_body and not expression_bodied:
+                        if symbol.get("partial"):
+                            symbol["declaration_only"] = True
+                        elif type_kind == "interface" or symbol.get("abstract"):
+                            symbol["abstract"] = True
+                        elif not symbol.get("extern"):
+                            symbol = None
+            elif prop and prop.group(2) not in ('return', 'new', 'await', 'const'):
+                # Only properties whose accessors (or the property itself) have bodies
+                lookahead = ' '.join(cleaned[i:min(len(cleaned), end_index + 3)])
+                if prop.group(4) == '=>' or _CS_ACCESSOR_BODY.search(lookahead):
+                    symbol = {"name": prop.group(3), "kind": "property", **_cs_modifier_flags(prop.group(1))}
+
+            if symbol:
+                symbol["line"] = i + 1
+                symbol["parent"] = parent
+                if expression_bodied:
+                    symbol["expression_bodied"] = True
+                consumed = end_index
+
+        if symbol:
+            if parent and "parent" not in symbol:
+                symbol["parent"] = parent
+            if namespaces:
+                symbol["namespace"] = ".".join(namespaces)
+            if attributes:
+                symbol["attributes"] = attributes
+                if "Obsolete" in attributes or "ObsoleteAttribute" in attributes:
+                    symbol["deprecated"] = True
+            if has_line_doc_comment(lines, j + 1, skip=_CS_ATTRIBUTE_LINE) or \
+                    has_line_doc_comment(lines, i, skip=_CS_ATTRIBUTE_LINE):
+                symbol["doc_comment"] = "xmldoc"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(lines[k])
+        i = consumed + 1
+
+    return _drop_declared_prototypes(symbols)
+
+
+# ====== SWIFT ======
+
+_SWIFT_ATTRIBUTES = r'((?:@[A-Za-z_]\w*(?:\([^)]*\))?\s*)*)'
+_SWIFT_MODIFIERS = (
+    'public', 'private', 'fileprivate', 'internal', 'open', 'package', 'static', 'class', 'final', 'override',
+    'mutating', 'nonmutating', 'convenience', 'required', 'dynamic', 'lazy', 'nonisolated', 'indirect',
+    'prefix', 'postfix', 'infix', 'distributed', 'optional'
+)
+_SWIFT_MODIFIER_PREFIX = r'((?:(?:' + '|'.join(_SWIFT_MODIFIERS) + r')(?:\s*\(set\))?\s+)*)'
+_SWIFT_PREFIX = r'^\s*' + _SWIFT_ATTRIBUTES + _SWIFT_MODIFIER_PREFIX
+_SWIFT_ATTRIBUTE_LINE = re.compile(r'^\s*(?:@[A-Za-z_]\w*(?:\([^)]*\))?\s*)+$')
+_SWIFT_TYPE = re.compile(
+    _SWIFT_PREFIX + r'(class|struct|enum|actor|protocol|extension)\s+(?!func\b|var\b|let\b|subscript\b|init\b)'
+    r'([A-Za-z_][\w.]*)'
+)
+_SWIFT_FUNC = re.compile(_SWIFT_PREFIX + r'func\s+([A-Za-z_]\w*|`\w+`|[^\s(<\w`]+)\s*(<[^(]*>)?\s*\(')
+_SWIFT_INIT = re.compile(_SWIFT_PREFIX + r'init\s*([?!])?\s*(<[^(]*>)?\s*\(')
+_SWIFT_DEINIT = re.compile(r'^\s*deinit\s*\{')
+_SWIFT_SUBSCRIPT = re.compile(_SWIFT_PREFIX + r'subscript\s*(<[^(]*>)?\s*\(')
+_SWIFT_COMPUTED_VAR = re.compile(_SWIFT_PREFIX + r'var\s+([A-Za-z_]\w*|`\w+`)\s*:\s*[^={]+\{')
+
+
+def _swift_flags(attributes: str, modifiers: str) -> Dict[str, Any]:
+    """Attribute list plus visibility and the modifiers worth surfacing as metadata"""
+    flags = {}
+    names = re.findall(r'@([A-Za-z_]\w*)', attributes)
+    if names:
+        flags["attributes"] = ['@' + name for name in names]
+    if 'objc' in names or 'IBAction' in names:
+        flags["objc"] = True
+    words = re.sub(r'\(set\)', '', modifiers).split()
+    visibility = next((w for w in ('open', 'public', 'package', 'internal', 'fileprivate', 'private') if w in words), None)
+    if visibility:
+        flags["visibility"] = visibility
+    for word in ('static', 'class', 'final', 'override', 'mutating', 'convenience', 'required', 'optional'):
+        if word in words:
+            flags["class_method" if word == 'class' else word] = True
+    return flags
+
+
+def _swift_signature_end(cleaned: List[str], start: int, limit: int = 10):
+    """
+    Index of the line closing a declaration's parameter list, and whether a body follows
+    (a '{' after the parameters, or on the next line for brace-on-next-line styles)
+    """
+    depth = 0
+    for i in range(start, min(len(cleaned), start + limit)):
+        depth += cleaned[i].count('(') - cleaned[i].count(')')
+        if depth <= 0:
+            tail = cleaned[i].rsplit(')', 1)[-1] if ')' in cleaned[i] else cleaned[i]
+            has_body = '{' in tail or (i + 1 < len(cleaned) and cleaned[i + 1].strip().startswith('{'))
+            return i, has_body
+    return start, '{' in cleaned[start]
+
+
+@register_extractor('swift')
+def extract_swift_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Swift symbol extraction for types, extensions, and protocol requirements
+    - Detects funcs, operator funcs, initializers (including failable init?), deinit, subscripts,
+      and computed properties; members of an extension are attributed to the extended type
+    - Protocol members without bodies are reported with requirement=True
+    - Captures attributes (@objc, @MainActor...), modifiers, async/throws, and /// or /** */ docs
+    """
+    symbols = []
+    tracker = BraceScopeTracker(literals=(_STRING_LITERAL,))
+    cleaner = BraceScopeTracker(literals=(_STRING_LITERAL,))
+    cleaned = [cleaner.clean(line) for line in lines]
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level or _SWIFT_ATTRIBUTE_LINE.match(text):
+            tracker.feed(lines[i])
+            i += 1
+            continue
+
+        # Attributes on their own lines apply to the declaration that follows
+        j = i - 1
+        while j >= 0 and _SWIFT_ATTRIBUTE_LINE.match(cleaned[j]):
+            j -= 1
+        declaration = ' '.join(part.strip() for part in cleaned[j + 1:i] + [text])
+
+        parents = [s["name"] for s in tracker.scopes if s["kind"] == "type"]
+        parent = ".".join(parents) if parents else None
+        type_kind = scope.get("type_kind") if scope else None
+        in_extension = any(s.get("type_kind") == "extension" for s in tracker.scopes)
+
+        symbol = None
+        type_match = _SWIFT_TYPE.match(declaration)
+        func_match = _SWIFT_FUNC.match(declaration)
+        init_match = _SWIFT_INIT.match(declaration)
+        subscript_match = _SWIFT_SUBSCRIPT.match(declaration)
+        var_match = _SWIFT_COMPUTED_VAR.match(declaration)
+
+        if type_match:
+            kind, name = type_match.group(3), type_match.group(4)
+            if kind != "extension":
+                symbol = {"name": name, "kind": kind, **_swift_flags(type_match.group(1), type_match.group(2))}
+            tracker.open_scope("type", name, type_kind=kind)
+        elif func_match or init_match or subscript_match:
+            end_index, has_body = _swift_signature_end(cleaned, i)
+            signature_tail = ' '.join(cleaned[i:end_index + 1]).rsplit(')', 1)[-1]
+            if func_match:
+                name = func_match.group(3).strip('`')
+                operator = not re.match(r'\w', name)
+                kind = "operator" if operator else "method" if parent else "function"
+                symbol = {"name": name, "kind": kind, **_swift_flags(func_match.group(1), func_match.group(2))}
+                if func_match.group(4):
+                    symbol["type_params"] = func_match.group(4)
+            elif init_match:
+                symbol = {"name": "init", "kind": "initializer", **_swift_flags(init_match.group(1), init_match.group(2))}
+                if init_match.group(3):
+                    symbol["failable"] = True
+            else:
+                symbol = {"name": "subscript", "kind": "subscript",
+                          **_swift_flags(subscript_match.group(1), subscript_match.group(2))}
+            if re.search(r'\basync\b', signature_tail):
+                symbol["async"] = True
+            if re.search(r'\b(?:throws|rethrows)\b', signature_tail):
+                symbol["throws"] = True
+            if not has_body:
+                if type_kind != "protocol":
+                    symbol = None
+                else:
+                    symbol["requirement"] = True
+            consumed = end_index if symbol else i
+        elif _SWIFT_DEINIT.match(declaration) and parent:
+            symbol = {"name": "deinit", "kind": "deinitializer"}
+        elif var_match:
+            symbol = {"name": var_match.group(3).strip('`'), "kind": "property",
+                      **_swift_flags(var_match.group(1), var_match.group(2))}
+            if type_kind == "protocol":
+                # 'var name: String { get set }' declares a requirement, not a computed property
+                symbol["requirement"] = True
+                symbol["settable"] = bool(re.search(r'\bset\b', declaration[var_match.end():]))
+            else:
+                symbol["computed"] = True
+
+        if symbol:
+            symbol["line"] = i + 1
+            if parent:
+                symbol["parent"] = parent
+                if in_extension:
+                    symbol["in_extension"] = True
+            if has_line_doc_comment(lines, j + 1, skip=_SWIFT_ATTRIBUTE_LINE) or \
+                    has_block_doc_comment(lines, j + 1, skip=_SWIFT_ATTRIBUTE_LINE):
+                symbol["doc_comment"] = "swift_markup"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(lines[k])
+        i = consumed + 1
+
+    return symbols
+
+
+# ====== RUBY ======
+
+_RUBY_DEF = re.compile(
+    r'^\s*(?:(private|protected|public|module_function)\s+)?def\s+(?:(self|[A-Za-z_]\w*)\.)?'
+    r'([A-Za-z_]\w*[?!=]?|\[\]=?|[+\-*/%<>=!~^&|]+@?)\s*(\(|;|=(?!=)|$|\s)'
+)
+_RUBY_NAMESPACE = re.compile(r'^\s*(class|module)\s+(?!<<)([A-Z][\w:]*)(?:\s*<\s*([A-Z][\w:]*))?')
+_RUBY_SINGLETON_CLASS = re.compile(r'^\s*class\s*<<\s*self\b')
+_RUBY_OPENER = re.compile(r'^\s*(?:if|unless|while|until|case|begin|for)\b|=\s*(?:if|unless|case|begin|while)\b')
+_RUBY_DO_BLOCK = re.compile(r'\bdo\b\s*(?:\|[^|]*\|)?\s*$')
+_RUBY_END = re.compile(r'(?<![.:\w])end\b(?![?!])')
+_RUBY_VISIBILITY = re.compile(r'^\s*(private|protected|public|module_function)\s*$')
+_RUBY_ATTR = re.compile(r'^\s*attr_(accessor|reader|writer)\s+(.+)$')
+_RUBY_DEFINE_METHOD = re.compile(r'^\s*(?:self\.)?define_(singleton_)?method\s*\(?\s*[:"\']([A-Za-z_]\w*[?!=]?)')
+_RUBY_HEREDOC = re.compile(r'<<[~-]?([\'"]?)([A-Z_][A-Z0-9_]*)\1')
+
+
+@register_extractor('ruby')
+def extract_ruby_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Ruby symbol extraction with keyword/end block tracking
+    - Detects classes and modules (reported as Outer::Inner paths), def methods, def self.
+      class methods, class << self blocks, define_method calls, and attr_* accessors
+    - method_type distinguishes instance, class, singleton (def obj.name), and module_function methods
+    - Tracks private/protected/public sections; =begin/=end blocks and heredocs are skipped
+    """
+    symbols = []
+    cleaner = BraceScopeTracker(line_comment='#', block_comment=None, literals=(_STRING_LITERAL, _SINGLE_QUOTED))
+    # Each entry: {"kind": "class"|"module"|"singleton"|"def"|"block", "name", "visibility", "module_function"}
+    stack: List[Dict[str, Any]] = []
+    heredoc = None
+    in_block_comment = False
+
+    def namespace_path() -> Optional[str]:
+        names = [entry["name"] for entry in stack if entry["kind"] in ("class", "module")]
+        return "::".join(names) if names else None
+
+    def enclosing() -> Optional[Dict[str, Any]]:
+        return next((entry for entry in reversed(stack) if entry["kind"] in ("class", "module", "singleton")), None)
+
+    for i, line in enumerate(lines):
+        if in_block_comment:
+            in_block_comment = not line.startswith('=end')
+            continue
+        if line.startswith('=begin'):
+            in_block_comment = True
+            continue
+        if heredoc:
+            if line.strip() == heredoc:
+                heredoc = None
+            continue
+
+        text = cleaner.clean(line)
+        heredoc_match = _RUBY_HEREDOC.search(text)
+        if heredoc_match:
+            heredoc = heredoc_match.group(2)
+        if not text.strip():
+            continue
+
+        in_method = any(entry["kind"] in ("def", "block") for entry in stack)
+        scope = enclosing()
+        parent = namespace_path()
+        symbol = None
+        opened = None
+
+        namespace_match = _RUBY_NAMESPACE.match(text)
+        def_match = _RUBY_DEF.match(text)
+        visibility_match = _RUBY_VISIBILITY.match(text)
+
+        if _RUBY_SINGLETON_CLASS.match(text):
+            opened = {"kind": "singleton", "name": "self", "visibility": "public"}
+        elif namespace_match and not in_method:
+            kind, name = namespace_match.group(1), namespace_match.group(2)
+            symbol = {"name": name, "kind": kind}
+            if namespace_match.group(3):
+                symbol["superclass"] = namespace_match.group(3)
+            opened = {"kind": kind, "name": name, "visibility": "public", "module_function": False}
+        elif def_match:
+            visibility, receiver, name, follow = def_match.groups()
+            if scope and scope["kind"] == "singleton" or receiver == "self":
+                method_type = "class"
+            elif receiver:
+                method_type = "singleton"
+            elif scope and (scope.get("module_function") or visibility == "module_function"):
+                method_type = "module_function"
+            else:
+                method_type = "instance"
+            symbol = {"name": name, "kind": "method" if scope or receiver else "function"}
+            if scope or receiver:
+                symbol["method_type"] = method_type
+            if receiver and receiver != "self":
+                symbol["receiver"] = receiver
+            effective_visibility = visibility if visibility in ("private", "protected") else (
+                scope["visibility"] if scope and not receiver else "public")
+            if effective_visibility != "public":
+                symbol["visibility"] = effective_visibility
+            # Endless methods ('def area = w * h') have no block; one-liners ('def x; end') close it at once
+            endless = follow == '=' or bool(re.match(r'^[^#]*\)\s*=(?!=)', text[def_match.end():]))
+            if endless:
+                symbol["endless"] = True
+            else:
+                opened = {"kind": "def", "name": name}
+        elif visibility_match and scope:
+            if visibility_match.group(1) == "module_function":
+                scope["module_function"] = True
+            else:
+                scope["visibility"] = visibility_match.group(1)
+        elif not in_method:
+            attr_match = _RUBY_ATTR.match(text)
+            define_match = _RUBY_DEFINE_METHOD.match(text)
+            if attr_match and scope:
+                for attr_name in re.findall(r':([A-Za-z_]\w*)', attr_match.group(2)):
+                    accessor = {"name": attr_name, "kind": "accessor", "accessor": attr_match.group(1),
+                                "line": i + 1}
+                    if parent:
+                        accessor["parent"] = parent
+                    if scope["visibility"] != "public":
+                        accessor["visibility"] = scope["visibility"]
+                    symbols.append(accessor)
+            elif define_match:
+                symbol = {"name": define_match.group(2), "kind": "method" if scope else "function", "dynamic": True}
+                if scope:
+                    singleton = scope["kind"] == "singleton" or define_match.group(1)
+                    symbol["method_type"] = "class" if singleton else "instance"
+
+        if symbol:
+            symbol["line"] = i + 1
+            if parent:
+                symbol["parent"] = parent
+            symbols.append(symbol)
+
+        if opened is None and (_RUBY_OPENER.search(text) or _RUBY_DO_BLOCK.search(text)):
+            opened = {"kind": "block", "name": None}
+        if opened is not None:
+            stack.append(opened)
+        for _ in _RUBY_END.finditer(text):
+            if stack:
+                stack.pop()
+
+    return symbols
+
+
+# ====== PHP ======
+
+_PHP_MODIFIERS = r'((?:(?:public|protected|private|static|abstract|final|readonly)\s+)*)'
+_PHP_ATTRIBUTE = re.compile(r'^\s*#\[((?:[^\[\]]|\[[^\]]*\])*)\]')
+_PHP_ATTRIBUTE_LINE = re.compile(r'^\s*#\[.*\]\s*$')
+_PHP_NAMESPACE = re.compile(r'^\s*namespace\s+([\w\\]+)\s*(;)?')
+_PHP_TYPE = re.compile(r'^\s*' + _PHP_MODIFIERS + r'(class|interface|trait|enum)\s+([A-Za-z_]\w*)')
+_PHP_FUNCTION = re.compile(r'^\s*' + _PHP_MODIFIERS + r'function\s+&?\s*([A-Za-z_]\w*)\s*\(')
+_PHP_CLOSURE = re.compile(r'^\s*\$([A-Za-z_]\w*)\s*=\s*(?:static\s+)?(function|fn)\s*&?\s*\(')
+_PHP_USE_TRAITS = re.compile(r'^\s*use\s+([\w\\]+(?:\s*,\s*[\w\\]+)*)\s*[;{]')
+_PHP_HEREDOC = re.compile(r'<<<\s*[\'"]?([A-Za-z_]\w*)[\'"]?\s*$')
+_PHP_MAGIC_METHODS = {
+    '__construct', '__destruct', '__call', '__callStatic', '__get', '__set', '__isset', '__unset', '__sleep',
+    '__wakeup', '__serialize', '__unserialize', '__toString', '__invoke', '__set_state', '__clone', '__debugInfo'
+}
+
+
+def _php_clean(cleaner: BraceScopeTracker, line: str) -> str:
+    """Strip literals and // and /* */ comments, then '#' comments (but not #[Attribute] syntax)"""
+    return re.sub(r'#(?!\[).*', '', cleaner.clean(line))
+
+
+@register_extractor('php')
+def extract_php_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: PHP symbol extraction with trait, magic-method, and PHPDoc awareness
+    - Detects functions, class/interface/enum methods, trait methods, and closures or arrow
+      functions assigned to variables, attributed to their namespace and class-like parent
+    - Magic methods (__construct, __toString...) are flagged magic=True so coverage counts them
+    - Records the traits a class uses, #[Attribute] names, and PHPDoc blocks (doc_comment="phpdoc")
+    """
+    symbols = []
+    literals = (_STRING_LITERAL, _SINGLE_QUOTED)
+    tracker = BraceScopeTracker(literals=literals)
+    cleaner = BraceScopeTracker(literals=literals)
+    cleaned = []
+    heredoc = None
+    for line in lines:
+        # Heredoc/nowdoc bodies are blanked so their braces and keywords are ignored
+        if heredoc:
+            cleaned.append('')
+            if re.match(r'^\s*' + re.escape(heredoc) + r'\b', line):
+                heredoc = None
+            continue
+        text = _php_clean(cleaner, line)
+        heredoc_match = _PHP_HEREDOC.search(line)
+        if heredoc_match:
+            heredoc = heredoc_match.group(1)
+        cleaned.append(text)
+    file_namespace = None
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level or _PHP_ATTRIBUTE_LINE.match(text):
+            # tracker.feed() cleans raw lines itself, so blanked heredoc lines are fed as empty
+            tracker.feed(text)
+            i += 1
+            continue
+
+        j = i - 1
+        while j >= 0 and _PHP_ATTRIBUTE_LINE.match(cleaned[j]):
+            j -= 1
+        declaration = ' '.join(cleaned[j + 1:i] + [text])
+        attributes = []
+        match = _PHP_ATTRIBUTE.match(declaration)
+        while match:
+            attributes += re.findall(r'(?:^|,)\s*\\?([A-Za-z_][\w\\]*)', re.sub(r'\([^()]*\)', '', match.group(1)))
+            declaration = declaration[match.end():]
+            match = _PHP_ATTRIBUTE.match(declaration)
+
+        namespaces = ([file_namespace] if file_namespace else []) + [
+            s["name"] for s in tracker.scopes if s["kind"] == "namespace"
+        ]
+        class_scope = scope if scope and scope["kind"] == "type" else None
+
+        symbol = None
+        namespace_match = _PHP_NAMESPACE.match(declaration)
+        type_match = _PHP_TYPE.match(declaration)
+        function_match = _PHP_FUNCTION.match(declaration)
+        closure_match = _PHP_CLOSURE.match(declaration)
+        traits_match = _PHP_USE_TRAITS.match(declaration) if class_scope else None
+
+        if namespace_match:
+            if namespace_match.group(2):
+                file_namespace = namespace_match.group(1)
+            else:
+                tracker.open_scope("namespace", namespace_match.group(1))
+        elif type_match:
+            modifiers, kind, name = type_match.groups()
+            symbol = {"name": name, "kind": kind}
+            for word in ('abstract', 'final', 'readonly'):
+                if word in modifiers.split():
+                    symbol[word] = True
+            tracker.open_scope("type", name, type_kind=kind, symbol=symbol)
+        elif traits_match:
+            class_scope["symbol"].setdefault("traits", []).extend(
+                t.strip().lstrip('\\') for t in traits_match.group(1).split(','))
+        elif function_match:
+            modifiers, name = function_match.groups()
+            head = _join_declaration_head(text, cleaned, i)
+            has_body = head[2] if head else False
+            words = modifiers.split()
+            if class_scope:
+                kind = "trait_method" if class_scope["type_kind"] == "trait" else "method"
+                if name == "__construct":
+                    kind = "constructor"
+                symbol = {"name": name, "kind": kind, "parent": class_scope["name"]}
+                visibility = next((w for w in ('public', 'protected', 'private') if w in words), None)
+                if visibility:
+                    symbol["visibility"] = visibility
+                for word in ('static', 'final'):
+                    if word in words:
+                        symbol[word] = True
+                if not has_body and ('abstract' in words or class_scope["type_kind"] == "interface"):
+                    symbol["abstract"] = True
+                if name in _PHP_MAGIC_METHODS:
+                    symbol["magic"] = True
+            else:
+                symbol = {"name": name, "kind": "function"}
+            if head:
+                consumed = head[1]
+        elif closure_match and not class_scope:
+            symbol = {"name": closure_match.group(1), "kind": "closure", "arrow": closure_match.group(2) == "fn"}
+
+        if symbol:
+            symbol["line"] = i + 1
+            if namespaces:
+                symbol["namespace"] = "\\".join(namespaces)
+            if attributes:
+                symbol["attributes"] = attributes
+                if "Deprecated" in attributes:
+                    symbol["deprecated"] = True
+            if has_block_doc_comment(lines, j + 1, skip=_PHP_ATTRIBUTE_LINE):
+                symbol["doc_comment"] = "phpdoc"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(cleaned[k])
+        i = consumed + 1
+
+    return symbols
+
+
+# ====== SCALA ======
+
+_SCALA_ANNOTATIONS = r'((?:@[A-Za-z_][\w.]*(?:\([^)]*\))?\s*)*)'
+_SCALA_MODIFIERS = (
+    r'((?:(?:private|protected)(?:\[[\w.]*\])?\s+|'
+    r'(?:override|final|sealed|abstract|implicit|lazy|inline|transparent|open|opaque|case)\s+)*)'
+)
+_SCALA_PREFIX = r'^\s*' + _SCALA_ANNOTATIONS + _SCALA_MODIFIERS
+_SCALA_ANNOTATION_LINE = re.compile(r'^\s*(?:@[A-Za-z_][\w.]*(?:\([^)]*\))?\s*)+$')
+_SCALA_TYPE = re.compile(_SCALA_PREFIX + r'(class|trait|object|enum)\s+([A-Za-z_]\w*)')
+_SCALA_DEF = re.compile(_SCALA_PREFIX + r'def\s+([A-Za-z_]\w*|`[^`]+`|[^\s\w\[(:]+)')
+_SCALA_VAL = re.compile(_SCALA_PREFIX + r'(val|var)\s+([A-Za-z_]\w*)\s*(?::\s*((?:=>|[^=])+?))?\s*=(?!>)\s*(.*)$')
+_SCALA_GIVEN = re.compile(
+    _SCALA_PREFIX + r'given\s+(?:([a-z_]\w*)\s*(?:\[[^\]]*\])?\s*(?:\([^)]*\)\s*)*:\s*)?([^=]*?)\s*(?:\bwith\b|=|$)'
+)
+_SCALA_EXTENSION = re.compile(r'^\s*extension\s*(?:\[[^\]]*\]\s*)?\(')
+_SCALA_FUNCTION_TYPE = re.compile(r'=>|\b(?:PartialFunction|Function\d+)\b')
+_SCALA_LAMBDA = re.compile(r'^(?:\{\s*case\b|\{?\s*\([^()]*\)\s*=>|\{?\s*[a-z_]\w*\s*=>|\{\s*$)')
+_SCALA_CONTINUATION = re.compile(r'^\s*(?:[)\]]|extends\b|with\b|derives\b)')
+
+
+def _scala_flags(annotations: str, modifiers: str) -> Dict[str, Any]:
+    """Annotations plus the modifiers worth surfacing as metadata"""
+    flags = {}
+    names = re.findall(r'@([A-Za-z_][\w.]*)', annotations)
+    if names:
+        flags["annotations"] = ['@' + name for name in names]
+        if 'deprecated' in names:
+            flags["deprecated"] = True
+    visibility = re.search(r'\b(private|protected)\b', modifiers)
+    if visibility:
+        flags["visibility"] = visibility.group(1)
+    for word in ('override', 'final', 'sealed', 'abstract', 'implicit', 'lazy', 'inline'):
+        if re.search(r'\b' + word + r'\b', modifiers):
+            flags[word] = True
+    return flags
+
+
+@register_extractor('scala')
+def extract_scala_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Scala 2/3 symbol extraction using indentation-aware scopes
+    - Detects def methods, function-typed or lambda-valued val/var, classes, case classes (with
+      their constructor fields), traits, objects, enums, extension methods, and given instances
+    - Scopes follow indentation so brace and Scala 3 braceless syntax are handled alike;
+      definitions nested inside a def body are not reported
+    - Records implicit/override/lazy modifiers, annotations, and Scaladoc (doc_comment="scaladoc")
+    """
+    symbols = []
+    cleaner = BraceScopeTracker(literals=(_STRING_LITERAL, _CHAR_LITERAL))
+    # Each entry: {"indent", "kind": "type"|"def"|"extension", "name", "type_kind"}
+    stack: List[Dict[str, Any]] = []
+    cleaned: Dict[int, str] = {}
+    in_multiline_string = False
+
+    for i, line in enumerate(lines):
+        # Triple-quoted strings are blanked before literal cleaning
+        if in_multiline_string:
+            if '"""' in line:
+                in_multiline_string = False
+                line = line.split('"""', 1)[1]
+            else:
+                continue
+        line = re.sub(r'"""(?:.*?)"""', '""', line)
+        if '"""' in line:
+            in_multiline_string = True
+            line = line.split('"""', 1)[0]
+
+        text = cleaned[i] = cleaner.clean(line)
+        stripped = text.strip()
+        if not stripped or _SCALA_ANNOTATION_LINE.match(text) or _SCALA_CONTINUATION.match(text):
+            continue
+        indent = len(text) - len(text.lstrip())
+        while stack and stack[-1]["indent"] >= indent:
+            stack.pop()
+        if stripped.startswith('}') or any(entry["kind"] == "def" for entry in stack):
+            continue
+
+        types = [entry for entry in stack if entry["kind"] == "type"]
+        parent = ".".join(entry["name"] for entry in types) if types else None
+        type_kind = types[-1]["type_kind"] if types else None
+        in_extension = bool(stack) and stack[-1]["kind"] == "extension"
+
+        j = i - 1
+        while j >= 0 and _SCALA_ANNOTATION_LINE.match(cleaned.get(j, '')):
+            j -= 1
+        declaration = ' '.join([cleaned[k].strip() for k in range(j + 1, i)] + [stripped])
+
+        symbol = None
+        opened = None
+        type_match = _SCALA_TYPE.match(declaration)
+        def_match = _SCALA_DEF.match(declaration)
+        val_match = _SCALA_VAL.match(declaration)
+        given_match = _SCALA_GIVEN.match(declaration)
+
+        if type_match:
+            annotations, modifiers, kind, name = type_match.groups()
+            is_case = re.search(r'\bcase\b', modifiers) is not None
+            symbol = {"name": name, "kind": f"case_{kind}" if is_case else kind, **_scala_flags(annotations, modifiers)}
+            if is_case and kind == "class":
+                # The primary constructor's fields, e.g. case class User(name: String, age: Int)
+                params = re.search(re.escape(name) + r'\s*(?:\[[^\]]*\])?\s*\(([^)]*)\)?', declaration)
+                if params:
+                    symbol["fields"] = re.findall(r'(?:^|,)\s*(?:val\s+|var\s+)?([A-Za-z_]\w*)\s*:', params.group(1))
+            opened = {"kind": "type", "name": name, "type_kind": kind}
+        elif _SCALA_EXTENSION.match(declaration):
+            opened = {"kind": "extension", "name": "extension"}
+        elif def_match:
+            annotations, modifiers, name = def_match.groups()
+            name = name.strip('`')
+            kind = "method" if parent else "extension_function" if in_extension else "function"
+            symbol = {"name": name, "kind": kind, **_scala_flags(annotations, modifiers)}
+            generics = re.match(r'\s*(\[[^\]]*\])', declaration[def_match.end():])
+            if generics:
+                symbol["type_params"] = generics.group(1)
+            signature = declaration[def_match.end():]
+            if type_kind in ("trait", "class") and '=' not in signature.replace('=>', '') and '{' not in signature:
+                symbol["abstract"] = True
+            opened = {"kind": "def", "name": name}
+        elif val_match:
+            annotations, modifiers, keyword, name, type_annotation, value = val_match.groups()
+            if (type_annotation and _SCALA_FUNCTION_TYPE.search(type_annotation)) or _SCALA_LAMBDA.match(value.strip()):
+                symbol = {"name": name, "kind": "function_value", "mutable": keyword == "var",
+                          **_scala_flags(annotations, modifiers)}
+                opened = {"kind": "def", "name": name}
+        elif given_match and re.match(r'^\s*(?:@\S+\s*)*(?:\w+\s+)*given\b', declaration):
+            annotations, modifiers, name, given_type = given_match.groups()
+            if not name:
+                # Anonymous givens get the compiler's synthesized name: given Ordering[Int] -> given_Ordering_Int
+                name = "given_" + "_".join(re.findall(r'[A-Za-z_]\w*', given_type or "")[:3])
+            symbol = {"name": name, "kind": "given", "type": (given_type or "").strip(),
+                      **_scala_flags(annotations, modifiers)}
+            opened = {"kind": "type", "name": name, "type_kind": "given"}
+
+        if symbol:
+            symbol["line"] = i + 1
+            if parent:
+                symbol["parent"] = parent
+            if has_block_doc_comment(lines, j + 1, skip=_SCALA_ANNOTATION_LINE):
+                symbol["doc_comment"] = "scaladoc"
+            symbols.append(symbol)
+        if opened is not None:
+            stack.append({**opened, "indent": indent})
+
+    # Objects sharing a name with a class or trait in the same file are companions
+    type_names = {s["name"] for s in symbols if s["kind"] in ("class", "case_class", "trait")}
+    for symbol in symbols:
+        if symbol["kind"] == "object" and symbol["name"] in type_names:
+            symbol["companion"] = True
+    return symbols
+
+
+# ====== ELIXIR ======
+
+_ELIXIR_MODULE = re.compile(r'^\s*(defmodule|defprotocol|defimpl)\s+([A-Z][\w.]*)')
+_ELIXIR_DEF = re.compile(
+    r'^\s*(def|defp|defmacro|defmacrop|defguard|defguardp|defdelegate|defn|defnp)\s+'
+    r'(?:unquote\([^)]*\)|([a-z_]\w*[?!]?))\s*(\()?'
+)
+_ELIXIR_DOC = re.compile(r'^\s*@(doc|moduledoc)\s*(false\b|.*)')
+_ELIXIR_DO = re.compile(r'(?<![\w:])do\b(?!:)')
+_ELIXIR_FN = re.compile(r'(?<![\w:.])fn\b')
+_ELIXIR_END = re.compile(r'(?<![\w:.])end\b(?!:)')
+_ELIXIR_HEREDOC = re.compile(r'(?:~[a-zA-Z])?("""|\'\'\')')
+
+
+def _elixir_arity(text: str, start: int) -> int:
+    """Count top-level arguments of the parameter list opening at text[start] == '('"""
+    depth = 0
+    args = 0
+    seen = False
+    for ch in text[start:]:
+        if ch in '([{':
+            depth += 1
+        elif ch in ')]}':
+            depth -= 1
+            if depth == 0:
+                break
+        elif depth == 1 and ch == ',':
+            args += 1
+        elif depth >= 1 and not ch.isspace():
+            seen = True
+    return args + 1 if seen else 0
+
+
+@register_extractor('elixir')
+def extract_elixir_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Elixir symbol extraction with @doc/@moduledoc recognition
+    - Detects modules (defmodule/defprotocol/defimpl) and def, defp, defmacro, defguard, and
+      defdelegate definitions, collapsing consecutive clauses of the same name/arity
+    - A preceding @doc (or @moduledoc for modules) marks the symbol doc_comment="@doc";
+      @doc false marks it doc_exempt - intentionally undocumented, so excluded from coverage - as are
+      defp/defmacrop/defguardp, which the compiler gives no docs (it warns on their @doc)
+    - Tracks do/fn ... end nesting so definitions inside quote blocks or bodies are not reported
+    """
+    symbols = []
+    cleaner = BraceScopeTracker(line_comment='#', block_comment=None, literals=(_STRING_LITERAL, _CHAR_LITERAL))
+    # Each entry: {"kind": "module"|"def"|"block", "name"}
+    stack: List[Dict[str, Any]] = []
+    pending_doc = None
+    heredoc = None
+    last_clause = None
+
+    for i, line in enumerate(lines):
+        if heredoc:
+            if line.strip().startswith(heredoc):
+                heredoc = None
+            continue
+        heredoc_match = _ELIXIR_HEREDOC.search(line)
+        if heredoc_match and line.count(heredoc_match.group(1)) == 1:
+            heredoc = heredoc_match.group(1)
+            line = line[:heredoc_match.start()] + '""'
+
+        text = cleaner.clean(line)
+        if not text.strip():
+            continue
+
+        top = stack[-1] if stack else None
+        at_definition_level = top is None or top["kind"] == "module"
+        modules = [entry["name"] for entry in stack if entry["kind"] == "module"]
+        parent = ".".join(modules) if modules else None
+
+        doc_match = _ELIXIR_DOC.match(text) if at_definition_level else None
+        module_match = _ELIXIR_MODULE.match(text) if at_definition_level else None
+        def_match = _ELIXIR_DEF.match(text) if at_definition_level else None
+        opened = None
+
+        if doc_match:
+            kind, value = doc_match.groups()
+            if kind == "doc":
+                pending_doc = "false" if value.startswith("false") else "doc"
+            elif top is not None:
+                if value.startswith("false"):
+                    top["symbol"]["doc_exempt"] = True
+                else:
+                    top["symbol"]["doc_comment"] = "@moduledoc"
+        elif module_match:
+            keyword, name = module_match.groups()
+            symbol = {"name": name, "kind": "module" if keyword == "defmodule" else keyword[3:], "line": i + 1}
+            if parent:
+                symbol["parent"] = parent
+            symbols.append(symbol)
+            opened = {"kind": "module", "name": name, "symbol": symbol}
+            last_clause = None
+        elif def_match:
+            keyword, name = def_match.group(1), def_match.group(2)
+            arity = _elixir_arity(text, def_match.end() - 1) if def_match.group(3) else 0
+            key = (parent, keyword, name, arity)
+            if name and key == last_clause and pending_doc is None:
+                symbols[-1]["clauses"] = symbols[-1].get("clauses", 1) + 1
+            elif name:
+                kind = {"defmacro": "macro", "defmacrop": "macro", "defguard": "guard", "defguardp": "guard",
+                        "defdelegate": "delegate"}.get(keyword, "function")
+                symbol = {"name": name, "kind": kind, "arity": arity, "line": i + 1}
+                if keyword.endswith('p'):
+                    symbol["visibility"] = "private"
+                if parent:
+                    symbol["parent"] = parent
+                if keyword.endswith('p') or pending_doc == "false":
+                    symbol["doc_exempt"] = True
+                elif pending_doc == "doc":
+                    symbol["doc_comment"] = "@doc"
+                symbols.append(symbol)
+                last_clause = key
+            pending_doc = None
+            if _ELIXIR_DO.search(text):
+                opened = {"kind": "def", "name": name}
+
+        closers = len(_ELIXIR_END.findall(text))
+        openers = len(_ELIXIR_DO.findall(text)) + len(_ELIXIR_FN.findall(text))
+        if opened is not None:
+            stack.append(opened)
+            openers -= 1
+        for _ in range(max(0, openers)):
+            stack.append({"kind": "block", "name": None})
+        for _ in range(closers):
+            if stack:
+                stack.pop()
+
+    return symbols
+
+
+# ====== DART ======
+
+_DART_ANNOTATION = re.compile(r'^\s*@([A-Za-z_]\w*(?:\.\w+)?)(?:\s*\((?:[^()]|\([^()]*\))*\))?')
+_DART_ANNOTATION_LINE = re.compile(r'^\s*@[A-Za-z_][\w.]*(?:\s*\(.*\))?\s*$')
+_DART_TYPE = re.compile(
+    r'^\s*((?:(?:abstract|sealed|base|final|interface|mixin)\s+)*)(class|mixin|enum|extension(?:\s+type)?)\b'
+    r'\s*([A-Za-z_$][\w$]*)?'
+)
+_DART_MEMBER = re.compile(
+    r'^\s*((?:(?:static|external|abstract|factory|const|covariant)\s+)*)'
+    r'(?:([\w$.]+\??)\s+)?'
+    r'(operator\s*(?:\[\]=?|[^\s(\w]+)|[A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)?)\s*(\(|=>|\{)'
+)
+_DART_GETTER_SETTER = re.compile(
+    r'^\s*((?:(?:static|external|abstract)\s+)*)(?:([\w$.]+\??)\s+)?(get|set)\s+([A-Za-z_$][\w$]*)\s*(\(|=>|\{)'
+)
+_DART_NOT_FUNCTIONS = {'if', 'for', 'while', 'switch', 'catch', 'return', 'assert', 'super', 'this', 'await', 'throw'}
+_DART_WIDGET_BASE = re.compile(r'\bextends\s+(?:StatelessWidget|StatefulWidget|State|\w*Widget)\b')
+
+
+@register_extractor('dart')
+def extract_dart_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Dart/Flutter symbol extraction
+    - Detects top-level functions, classes, mixins, extensions, enums, methods, getters/setters,
+      operators, and unnamed, named (Class.name), const, and factory constructors
+    - Flags Flutter widget classes (extends StatelessWidget/StatefulWidget/State) and their
+      build(BuildContext) methods with widget / widget_build
+    - Captures annotations (@override, @Deprecated) and /// dartdoc comments (doc_comment="dartdoc")
+    """
+    symbols = []
+    literals = (_STRING_LITERAL, _SINGLE_QUOTED)
+    tracker = BraceScopeTracker(literals=literals)
+    cleaner = BraceScopeTracker(literals=literals)
+    cleaned = []
+    in_triple = None
+    for line in lines:
+        # Multi-line ''' / """ strings are blanked so their braces are ignored
+        text = line
+        if in_triple:
+            if in_triple not in text:
+                cleaned.append('')
+                continue
+            text = '""' + text.split(in_triple, 1)[1]
+            in_triple = None
+        text = re.sub(r'(\'\'\'|""")(?:.*?)\1', '""', text)
+        opener = re.search(r'\'\'\'|"""', text)
+        if opener:
+            in_triple = opener.group(0)
+            text = text[:opener.start()] + '""'
+        cleaned.append(cleaner.clean(text))
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level or _DART_ANNOTATION_LINE.match(text):
+            tracker.feed(text)
+            i += 1
+            continue
+
+        j = i - 1
+        while j >= 0 and _DART_ANNOTATION_LINE.match(cleaned[j]):
+            j -= 1
+        declaration = ' '.join(cleaned[j + 1:i] + [text])
+        annotations = []
+        match = _DART_ANNOTATION.match(declaration)
+        while match:
+            annotations.append('@' + match.group(1))
+            declaration = declaration[match.end():]
+            match = _DART_ANNOTATION.match(declaration)
+
+        type_scope = scope if scope and scope["kind"] == "type" else None
+        parent = type_scope["name"] if type_scope else None
+        symbol = None
+        type_match = _DART_TYPE.match(declaration)
+
+        if type_match:
+            modifiers, kind, name = type_match.groups()
+            kind = kind.split()[0]
+            on_type = re.search(r'\bon\s+([\w$.<>?]+)', declaration)
+            if not name or name == "on":
+                # Unnamed extensions ('extension on String') are named after the extended type
+                name = f"extension on {on_type.group(1)}" if on_type else kind
+            symbol = {"name": name, "kind": kind, "line": i + 1}
+            for word in ('abstract', 'sealed', 'base', 'final', 'interface'):
+                if word in modifiers.split():
+                    symbol[word] = True
+            if kind == "extension" and on_type:
+                symbol["on"] = on_type.group(1)
+            if _DART_WIDGET_BASE.search(declaration):
+                symbol["widget"] = True
+            tracker.open_scope("type", name, type_kind=kind, widget=bool(symbol.get("widget")))
+        elif type_scope and type_scope["type_kind"] == "enum" and not type_scope.get("past_values"):
+            # Enum values precede the first ';' (enhanced enums) or fill the whole body
+            type_scope["past_values"] = ';' in declaration
+        elif '(' in declaration or '=>' in declaration or '{' in declaration:
+            head = _join_declaration_head(declaration, cleaned, i)
+            signature = _strip_angle_brackets(head[0] if head else declaration)
+            accessor = _DART_GETTER_SETTER.match(signature)
+            member = _DART_MEMBER.match(signature)
+            prefix = signature.split('(')[0].split('=>')[0]
+            if accessor:
+                modifiers, _, kind, name, _ = accessor.groups()
+                symbol = {"name": name, "kind": "getter" if kind == "get" else "setter"}
+                if 'static' in modifiers.split():
+                    symbol["static"] = True
+            elif member and member.group(3) not in _DART_NOT_FUNCTIONS and (
+                    member.group(3).startswith('operator') or '=' not in prefix.replace('=>', '')):
+                modifiers, return_type, name, _ = member.groups()
+                words = modifiers.split()
+                base_name = name.split('.')[0]
+                if parent and base_name == parent and return_type is None:
+                    symbol = {"name": name, "kind": "constructor"}
+                    if '.' in name:
+                        symbol["named"] = True
+                    for word in ('factory', 'const'):
+                        if word in words:
+                            symbol[word] = True
+                elif return_type is not None or member.group(4) != '{':
+                    if name.startswith('operator'):
+                        kind = "operator"
+                    else:
+                        kind = "method" if parent else "function"
+                    symbol = {"name": re.sub(r'\s+', '', name) if kind == "operator" else name, "kind": kind}
+                    if 'static' in words:
+                        symbol["static"] = True
+                    if re.search(r'\)\s*async\b', signature):
+                        symbol["async"] = True
+                    if re.search(r'\)\s*(?:async|sync)\*', signature):
+                        symbol["generator"] = True
+                    if head and not head[2] and '=>' not in signature:
+                        if 'external' not in words and not parent:
+                            symbol = None
+                        elif parent:
+                            symbol["abstract"] = True
+                    if symbol and name == "build" and parent and 'BuildContext' in signature:
+                        symbol["widget_build"] = True
+            if symbol and head:
+                consumed = head[1]
+
+        if symbol:
+            symbol.setdefault("line", i + 1)
+            if parent and symbol["kind"] not in ("class", "mixin", "enum", "extension"):
+                symbol["parent"] = parent
+            if annotations:
+                symbol["annotations"] = annotations
+                if any(a.lower() == "@deprecated" for a in annotations):
+                    symbol["deprecated"] = True
+            if has_line_doc_comment(lines, j + 1, skip=_DART_ANNOTATION_LINE) or \
+                    has_block_doc_comment(lines, j + 1, skip=_DART_ANNOTATION_LINE):
+                symbol["doc_comment"] = "dartdoc"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(cleaned[k])
+        i = consumed + 1
+
+    return symbols
+
+
+# ====== ZIG ======
+
+_ZIG_FN = re.compile(
+    r'^\s*(pub\s+)?((?:(?:export|extern(?:\s+"[^"]*")?|inline|noinline)\s+)*)fn\s+([A-Za-z_]\w*|@"[^"]+")\s*\('
+)
+_ZIG_CONTAINER = re.compile(
+    r'^\s*(pub\s+)?const\s+([A-Za-z_]\w*)\s*(?::[^=]+)?=\s*(?:(?:packed|extern)\s+)?(struct|enum|union|opaque)\b'
+)
+_ZIG_RETURN_CONTAINER = re.compile(r'^\s*return\s+(?:(?:packed|extern)\s+)?(struct|enum|union|opaque)\b')
+
+
+def _zig_clean(cleaner: BraceScopeTracker, line: str) -> str:
+    """Strip literals and comments; '\\\\' multiline string lines carry no structure"""
+    return '' if line.lstrip().startswith('\\\\') else cleaner.clean(line)
+
+
+@register_extractor('zig')
+def extract_zig_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Zig symbol extraction for functions, containers, and comptime generics
+    - Detects pub/private fns, extern/export/inline fns, and struct/enum/union/opaque containers
+      declared as 'const Name = struct { ... }'
+    - Functions inside a container are methods when their first parameter is self-typed; fns
+      returning 'type' are comptime generics whose 'return struct { ... }' becomes their container
+    - Marks comptime parameters and /// doc comments (doc_comment="zig_doc")
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+    cleaner = BraceScopeTracker()
+    cleaned = [_zig_clean(cleaner, line) for line in lines]
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level:
+            tracker.feed(text)
+            i += 1
+            continue
+
+        containers = [s for s in tracker.scopes if s["kind"] == "container"]
+        parent = ".".join(s["name"] for s in containers) if containers else None
+        symbol = None
+
+        if scope and scope["kind"] == "type_fn":
+            # Inside a generic type function only the returned container matters
+            returned = _ZIG_RETURN_CONTAINER.match(text)
+            if returned:
+                tracker.open_scope("container", scope["name"], container=returned.group(1))
+        else:
+            container_match = _ZIG_CONTAINER.match(text)
+            fn_match = _ZIG_FN.match(text)
+            if container_match:
+                public, name, container = container_match.groups()
+                symbol = {"name": name, "kind": container}
+                if public:
+                    symbol["pub"] = True
+                tracker.open_scope("container", name, container=container)
+            elif fn_match:
+                public, qualifiers, name = fn_match.groups()
+                name = name.strip('@"')
+                head = _join_declaration_head(text, cleaned, i)
+                signature = head[0] if head else text
+                params = signature[fn_match.end():]
+                first_param = re.match(r'\s*([A-Za-z_]\w*)\s*:\s*([^,)]*)', params)
+                self_types = {"Self", "@This()"} | ({containers[-1]["name"]} if containers else set())
+                is_method = bool(containers and first_param and (
+                    first_param.group(1) == "self" or
+                    re.sub(r'^[*\[\]\s]*(?:const\s+)?', '', first_param.group(2)).strip() in self_types))
+                symbol = {"name": name, "kind": "method" if is_method else "function"}
+                if public:
+                    symbol["pub"] = True
+                for word in ('export', 'extern', 'inline'):
+                    if re.search(r'\b' + word + r'\b', qualifiers):
+                        symbol[word] = True
+                comptime_params = re.findall(r'\bcomptime\s+([A-Za-z_]\w*)\s*:', params)
+                if comptime_params:
+                    symbol["comptime_params"] = comptime_params
+                returns_type = re.search(r'\)\s*type\s*$', signature.rstrip())
+                if returns_type:
+                    symbol["comptime"] = True
+                if head:
+                    consumed = head[1]
+                    if head[2] and returns_type:
+                        tracker.open_scope("type_fn", name)
+
+        if symbol:
+            symbol["line"] = i + 1
+            if parent:
+                symbol["parent"] = parent
+            if has_line_doc_comment(lines, i):
+                symbol["doc_comment"] = "zig_doc"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(cleaned[k])
+        i = consumed + 1
+
+    return symbols
+
+
+# ====== HCL (TERRAFORM) ======
+
+_HCL_BLOCK = re.compile(r'^\s*(resource|data|module|variable|output)\s+"?([\w.-]+)"?(?:\s+"?([\w.-]+)"?)?\s*\{')
+_HCL_ATTRIBUTE = re.compile(r'^\s*([A-Za-z_][\w-]*)\s*=\s*(.*)$')
+_HCL_HEREDOC = re.compile(r'<<-?\s*([A-Za-z_]\w*)\s*$')
+_HCL_ADDRESS_PREFIX = {"variable": "var", "module": "module", "output": "output"}
+
+
+def _hcl_address(block: str, first: str, second: Optional[str]) -> str:
+    """Terraform address of a block: 'aws_instance.web', 'data.aws_ami.ubuntu', 'module.vpc', 'var.region'"""
+    if block == "resource":
+        return f"{first}.{second}"
+    if block == "data":
+        return f"data.{first}.{second}"
+    return f"{_HCL_ADDRESS_PREFIX[block]}.{first}"
+
+
+@register_extractor('hcl')
+def extract_hcl_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Terraform inventory of resources, data sources, modules, variables, and outputs
+    - Each top-level block becomes an infrastructure symbol named by its Terraform address
+    - Records resource types, module sources, count/for_each expansion, and variable types
+    - A description attribute (variables, outputs, modules) counts as documentation (doc_comment="description")
+    """
+    symbols = []
+    tracker = BraceScopeTracker(line_comment='//', literals=(_STRING_LITERAL,))
+    cleaner = BraceScopeTracker(line_comment='//', literals=(_STRING_LITERAL,))
+    current = None
+    heredoc = None
+
+    for i, line in enumerate(lines):
+        if heredoc:
+            if line.strip() == heredoc:
+                heredoc = None
+            continue
+
+        # Strings go first: '/*' is common inside them (ARNs, globs) and must not open a comment
+        text = re.sub(r'#.*', '', cleaner.clean(_STRING_LITERAL.sub('""', line)))
+        if tracker.depth == 0:
+            block = _HCL_BLOCK.match(line) if text.strip() else None
+            if block and (block.group(1) not in ("resource", "data") or block.group(3)):
+                kind, first, second = block.groups()
+                current = {"name": _hcl_address(kind, first, second), "kind": kind, "line": i + 1,
+                           "infrastructure": True}
+                if kind in ("resource", "data"):
+                    current["resource_type"] = first
+                    current["provider"] = first.split("_", 1)[0]
+                symbols.append(current)
+                # Single-line blocks such as 'variable "x" { description = "..." }'
+                if re.search(r'\{\s*description\s*=', line):
+                    current["doc_comment"] = "description"
+        elif tracker.depth == 1 and current is not None:
+            attribute = _HCL_ATTRIBUTE.match(line)
+            if attribute:
+                key, value = attribute.group(1), attribute.group(2).strip()
+                if key == "description" and value not in ('""', "''"):
+                    current["doc_comment"] = "description"
+                elif key == "source" and current["kind"] == "module":
+                    current["source"] = value.strip('"')
+                elif key == "version" and current["kind"] == "module":
+                    current["version"] = value.strip('"')
+                elif key in ("count", "for_each"):
+                    current["expansion"] = key
+                elif key == "type" and current["kind"] == "variable":
+                    current["type"] = re.sub(r'\s*[#/].*$', '', value)
+                elif key == "default" and current["kind"] == "variable":
+                    current["has_default"] = True
+                elif key == "sensitive" and value.startswith("true"):
+                    current["sensitive"] = True
+
+        opened = _HCL_HEREDOC.search(text)
+        if opened:
+            heredoc = opened.group(1)
+        tracker.feed(text)
+        if tracker.depth == 0:
+            current = None
+
+    return symbols
+
+
+# ====== PROTOBUF ======
+
+_PROTO_TYPE = re.compile(r'^\s*(message|enum|service)\s+([A-Za-z_]\w*)\s*\{?')
+_PROTO_BLOCK = re.compile(r'^\s*(oneof|extend)\s+([\w.]+)\s*\{?')
+_PROTO_RPC = re.compile(
+    r'^\s*rpc\s+([A-Za-z_]\w*)\s*\(\s*(stream\s+)?([\w.]+)\s*\)\s*returns\s*\(\s*(stream\s+)?([\w.]+)\s*\)'
+)
+
+
+@register_extractor('proto')
+def extract_proto_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Protocol Buffers extraction of services, RPC methods, messages, and enums
+    - RPCs record their request/response types and client/server streaming flags, parented to their service
+    - Nested messages and enums are parented to the enclosing message ('Outer.Inner')
+    - Every symbol carries the file's proto package and go_package option for stub cross-linking
+    - Leading // comments are the protobuf documentation convention (doc_comment="proto_comment")
+    """
+    symbols = []
+    tracker = BraceScopeTracker(literals=(_STRING_LITERAL, _SINGLE_QUOTED))
+    cleaner = BraceScopeTracker(literals=(_STRING_LITERAL, _SINGLE_QUOTED))
+    cleaned = [cleaner.clean(line) for line in lines]
+
+    package = None
+    go_package = None
+    for line in lines:
+        package_match = re.match(r'^\s*package\s+([\w.]+)\s*;', line)
+        if package_match and package is None:
+            package = package_match.group(1)
+        option = re.match(r'^\s*option\s+go_package\s*=\s*"([^"]+)"', line)
+        if option:
+            go_package = option.group(1)
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level:
+            tracker.feed(text)
+            i += 1
+            continue
+
+        types = [s["name"] for s in tracker.scopes if s["kind"] in ("message", "service")]
+        symbol = None
+        type_match = _PROTO_TYPE.match(text)
+        block_match = _PROTO_BLOCK.match(text)
+        if type_match:
+            kind, name = type_match.groups()
+            symbol = {"name": name, "kind": kind}
+            tracker.open_scope(kind, name, symbol=symbol)
+        elif scope and scope.get("symbol") and re.match(r'^\s*option\s+deprecated\s*=\s*true', text):
+            scope["symbol"]["deprecated"] = True
+        elif block_match:
+            tracker.open_scope(block_match.group(1), block_match.group(2))
+        elif scope and scope["kind"] == "service" and re.match(r'^\s*rpc\b', text):
+            head = _join_declaration_head(text, cleaned, i)
+            signature = head[0] if head else text
+            rpc = _PROTO_RPC.match(signature)
+            if rpc:
+                name, client_stream, request, server_stream, response = rpc.groups()
+                symbol = {"name": name, "kind": "rpc", "request": request, "response": response}
+                if client_stream:
+                    symbol["client_streaming"] = True
+                if server_stream:
+                    symbol["server_streaming"] = True
+            if head:
+                consumed = head[1]
+                if head[2]:
+                    # 'rpc X(...) returns (...) { option ... }' - options may mark the rpc deprecated
+                    tracker.open_scope("rpc_options", symbol["name"] if symbol else "rpc", symbol=symbol)
+
+        if symbol:
+            symbol["line"] = i + 1
+            if types:
+                symbol["parent"] = ".".join(types)
+            if package:
+                symbol["package"] = package
+            if go_package:
+                symbol["go_package"] = go_package
+            if has_line_doc_comment(lines, i, prefix='//'):
+                symbol["doc_comment"] = "proto_comment"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(cleaned[k])
+        i = consumed + 1
+    return symbols
+
+
+# ====== GRAPHQL ======
+
+_GRAPHQL_TYPE = re.compile(
+    r'^\s*(extend\s+)?(type|interface|input|enum|union|scalar)\s+([A-Za-z_]\w*)'
+    r'(?:\s+implements\s+&?\s*([\w\s&]+?))?\s*(?=[{=@]|$)'
+)
+_GRAPHQL_FIELD = re.compile(r'^\s*([A-Za-z_]\w*)\s*([(:])')
+_GRAPHQL_ROOT_KINDS = {"query": "query", "mutation": "mutation", "subscription": "subscription"}
+
+
+def _graphql_clean(lines: List[str]) -> List[str]:
+    """Blank string and block-string descriptions and '#' comments, keeping line positions"""
+    cleaned = []
+    in_block = False
+    for line in lines:
+        text = ''
+        rest = line
+        while rest:
+            if in_block:
+                end = rest.find('"""')
+                if end == -1:
+                    rest = ''
+                else:
+                    rest = rest[end + 3:]
+                    in_block = False
+                continue
+            start = rest.find('"""')
+            head = rest if start == -1 else rest[:start]
+            text += _STRING_LITERAL.sub('""', head)
+            if start == -1:
+                rest = ''
+            else:
+                rest = rest[start + 3:]
+                in_block = True
+        cleaned.append(text.split('#', 1)[0])
+    return cleaned
+
+
+def _graphql_has_description(lines: List[str], index: int) -> bool:
+    """A "string" or \"\"\"block\"\"\" description directly precedes (or opens) the definition"""
+    if lines[index].lstrip().startswith('"'):
+        return True
+    j = index - 1
+    while j >= 0 and not lines[j].strip():
+        j -= 1
+    if j < 0:
+        return False
+    previous = lines[j].strip()
+    return previous.endswith('"""') or (previous.startswith('"') and previous.endswith('"'))
+
+
+@register_extractor('graphql')
+def extract_graphql_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: GraphQL SDL extraction of types and root operation fields
+    - Detects type/interface/input/enum/union/scalar definitions (and 'extend type' additions)
+    - Fields of the Query, Mutation, and Subscription roots (or the names a schema { } block
+      assigns) become query/mutation/subscription symbols with their return type
+    - Schema description strings count as documentation (doc_comment="description");
+      @deprecated fields are flagged deprecated
+    """
+    symbols = []
+    cleaned = _graphql_clean(lines)
+
+    roots = {"Query": "query", "Mutation": "mutation", "Subscription": "subscription"}
+    schema_block = re.search(r'\bschema\s*(?:@\w+\s*)*\{([^}]*)\}', '\n'.join(cleaned))
+    if schema_block:
+        assigned = re.findall(r'\b(query|mutation|subscription)\s*:\s*([A-Za-z_]\w*)', schema_block.group(1))
+        if assigned:
+            roots = {type_name: _GRAPHQL_ROOT_KINDS[operation] for operation, type_name in assigned}
+
+    depth = 0
+    parens = 0
+    current = None  # (type name, operation kind or None) of the type body being read
+    for i, text in enumerate(cleaned):
+        if depth == 0 and parens == 0:
+            definition = _GRAPHQL_TYPE.match(text)
+            if definition:
+                extend, kind, name, implements = definition.groups()
+                symbol = {"name": name, "kind": kind, "line": i + 1}
+                if extend:
+                    symbol["extension"] = True
+                if implements:
+                    symbol["implements"] = [t for t in re.split(r'[\s&]+', implements) if t]
+                if kind == "union":
+                    members = re.search(r'=\s*\|?\s*(.+)$', text)
+                    if members:
+                        symbol["members"] = [m.strip() for m in members.group(1).split('|') if m.strip()]
+                if _graphql_has_description(lines, i):
+                    symbol["doc_comment"] = "description"
+                symbols.append(symbol)
+                current = (name, roots.get(name) if kind == "type" else None)
+        elif depth == 1 and parens == 0 and current and current[1]:
+            field = _GRAPHQL_FIELD.match(text)
+            if field:
+                symbol = {"name": field.group(1), "kind": current[1], "parent": current[0], "line": i + 1}
+                # The return type follows the closing ')' of the arguments, possibly lines later
+                signature = text
+                for k in range(i + 1, min(len(cleaned), i + 30)):
+                    if signature.count('(') <= signature.count(')'):
+                        break
+                    signature += ' ' + cleaned[k]
+                rest = signature[signature.rfind(')') + 1:] if field.group(2) == '(' else signature[field.end() - 1:]
+                returns = re.match(r'\s*:\s*([^@=]+)', rest)
+                if returns:
+                    symbol["returns"] = re.sub(r'\s+', '', returns.group(1))
+                if re.search(r'@deprecated\b', signature):
+                    symbol["deprecated"] = True
+                if field.group(2) == '(':
+                    symbol["arguments"] = re.findall(r'([A-Za-z_]\w*)\s*:', signature[signature.find('(') + 1:signature.rfind(')')])
+                if _graphql_has_description(lines, i):
+                    symbol["doc_comment"] = "description"
+                symbols.append(symbol)
+
+        depth += text.count('{') - text.count('}')
+        parens += text.count('(') - text.count(')')
+        depth, parens = max(0, depth), max(0, parens)
+        if depth == 0 and '}' in text:
+            current = None
+
+    return symbols
+
+
+# ====== SQL ======
+
+_SQL_NAME = r'((?:[`"\[]?[\w$]+[`"\]]?\.)?[`"\[]?[\w$]+[`"\]]?)'
+_SQL_ROUTINE = re.compile(
+    r'^\s*CREATE\s+(?:OR\s+(?:REPLACE|ALTER)\s+)?(?:DEFINER\s*=\s*\S+\s+)?(?:(?:TEMP|TEMPORARY|CONSTRAINT)\s+)?'
+    r'(FUNCTION|PROCEDURE|PROC|TRIGGER)\s+(?:IF\s+NOT\s+EXISTS\s+)?' + _SQL_NAME,
+    re.IGNORECASE
+)
+_SQL_COMMENT_ON = re.compile(r'^\s*COMMENT\s+ON\s+(FUNCTION|PROCEDURE|TRIGGER)\s+' + _SQL_NAME, re.IGNORECASE)
+_SQL_DOLLAR_QUOTE = re.compile(r'\$([A-Za-z_]\w*)?\$')
+
+
+def _sql_unquote(name: str) -> tuple:
+    """'"billing".calc_total' -> ('billing', 'calc_total')"""
+    parts = [re.sub(r'^[`"\[]|[`"\]]$', '', part) for part in name.split('.')]
+    return (parts[0], parts[1]) if len(parts) == 2 else (None, parts[0])
+
+
+@register_extractor('sql')
+def extract_sql_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: SQL extraction of functions, stored procedures, and triggers
+    - Matches CREATE [OR REPLACE] FUNCTION/PROCEDURE/TRIGGER statements (PostgreSQL, MySQL, T-SQL)
+      and records the schema, return type, routine language, and a trigger's table and events
+    - Ignores statements inside dollar-quoted bodies and /* */ comments
+    - 'COMMENT ON FUNCTION ... IS' counts as documentation (doc_comment="comment_on")
+    """
+    symbols = []
+    dollar_tag = None
+    in_block_comment = False
+    statement_lines = []
+
+    for i, line in enumerate(lines):
+        text = line
+        if in_block_comment:
+            end = text.find('*/')
+            if end == -1:
+                continue
+            text = text[end + 2:]
+            in_block_comment = False
+        text = _SINGLE_QUOTED.sub("''", re.sub(r'/\*.*?\*/', '', text)).split('--', 1)[0]
+        if '/*' in text:
+            text = text[:text.index('/*')]
+            in_block_comment = True
+
+        if dollar_tag is None:
+            routine = _SQL_ROUTINE.match(text)
+            if routine:
+                kind = routine.group(1).lower()
+                schema, name = _sql_unquote(routine.group(2))
+                symbol = {"name": name, "kind": "procedure" if kind == "proc" else kind, "line": i + 1}
+                if schema:
+                    symbol["schema"] = schema
+                symbols.append(symbol)
+                statement_lines.append((symbol, i))
+            comment = _SQL_COMMENT_ON.match(text)
+            if comment:
+                documented = _sql_unquote(comment.group(2))[1]
+                for symbol in symbols:
+                    if symbol["name"] == documented and symbol["kind"] == comment.group(1).lower():
+                        symbol["doc_comment"] = "comment_on"
+
+        # $$ / $tag$ bodies open and close on the same tag
+        for tag in _SQL_DOLLAR_QUOTE.finditer(text):
+            if dollar_tag is None:
+                dollar_tag = tag.group(0)
+            elif tag.group(0) == dollar_tag:
+                dollar_tag = None
+
+    # Statement headers: RETURNS, LANGUAGE, and trigger timing/table within the next lines
+    for n, (symbol, start) in enumerate(statement_lines):
+        end = statement_lines[n + 1][1] if n + 1 < len(statement_lines) else len(lines)
+        header = []
+        for k in range(start, min(len(lines), start + 15)):
+            header.append(lines[k].split('--', 1)[0])
+            if re.search(r'\$\w*\$|\bAS\b|\bBEGIN\b|;', lines[k], re.IGNORECASE) and k > start:
+                break
+        statement = ' '.join(header)
+        if symbol["kind"] == "trigger":
+            timing = re.search(r'\b(BEFORE|AFTER|INSTEAD\s+OF)\s+((?:INSERT|UPDATE|DELETE|TRUNCATE)'
+                               r'(?:\s+OF\s+[\w\s,]+?)?(?:\s+OR\s+(?:INSERT|UPDATE|DELETE|TRUNCATE))*)\s+ON\s+'
+                               + _SQL_NAME, statement, re.IGNORECASE)
+            if timing:
+                symbol["timing"] = re.sub(r'\s+', ' ', timing.group(1).upper())
+                symbol["events"] = re.findall(r'\b(INSERT|UPDATE|DELETE|TRUNCATE)\b', timing.group(2).upper())
+                symbol["table"] = ".".join(part for part in _sql_unquote(timing.group(3)) if part)
+            executes = re.search(r'EXECUTE\s+(?:FUNCTION|PROCEDURE)\s+' + _SQL_NAME, statement, re.IGNORECASE)
+            if executes:
+                symbol["executes"] = _sql_unquote(executes.group(1))[1]
+        else:
+            returns = re.search(r'\bRETURNS\s+(SETOF\s+\w+|TABLE\s*\(|[\w.\[\]]+(?:\s*\(\s*\d+(?:\s*,\s*\d+)?\s*\))?)',
+                                statement, re.IGNORECASE)
+            if returns:
+                symbol["returns"] = re.sub(r'\s*\($', '', returns.group(1)).upper()
+        # LANGUAGE may follow the body ('$$ LANGUAGE plpgsql;') - search up to the next statement
+        language = re.search(r'\bLANGUAGE\s+\'?(\w+)', ' '.join(lines[start:end]), re.IGNORECASE)
+        if language and symbol["kind"] != "trigger":
+            symbol["routine_language"] = language.group(1).lower()
+
+    return symbols
+
+
+# ====== SHELL ======
+
+_SHELL_FUNCTION = re.compile(
+    r'^\s*(?:(function)\s+([A-Za-z_][\w:.-]*)\s*(?:\(\s*\))?|([A-Za-z_][\w:.-]*)\s*\(\s*\))\s*([{(]|$)'
+)
+_SHELL_HEREDOC = re.compile(r'(?<!<)<<(-?)\s*([\'"]?)([A-Za-z_]\w*)\2')
+
+# Interpreter (after /usr/bin/env) -> language key for extensionless scripts
+SHEBANG_LANGUAGES = {
+    "sh": "shell", "bash": "shell", "zsh": "shell", "dash": "shell", "ksh": "shell", "ash": "shell",
+    "python": "python", "python3": "python", "node": "javascript", "ruby": "ruby", "lua": "lua",
+    "pwsh": "powershell", "Rscript": "r",
+}
+
+
+def shebang_interpreter(first_line: str) -> Optional[str]:
+    """'#!/usr/bin/env -S bash -e' -> 'bash'; None when the line is not a shebang"""
+    if not first_line.startswith('#!'):
+        return None
+    words = first_line[2:].split()
+    if words and words[0].endswith('/env'):
+        words = [w for w in words[1:] if not w.startswith('-') and '=' not in w]
+    if not words:
+        return None
+    interpreter = words[0].rsplit('/', 1)[-1]
+    # 'python3.11' and 'bash5' resolve like 'python3' and 'bash'
+    return re.sub(r'(?<=[a-z])[\d.]+$', '', interpreter) if interpreter not in SHEBANG_LANGUAGES else interpreter
+
+
+def shebang_language(first_line: str) -> Optional[str]:
+    """Language key for a script's shebang line, if the interpreter is one the scanner knows"""
+    interpreter = shebang_interpreter(first_line)
+    return SHEBANG_LANGUAGES.get(interpreter) if interpreter else None
+
+
+def _blank_literal(match: re.Match) -> str:
+    """A quoted literal with its contents replaced by spaces (same length, quotes kept)"""
+    literal = match.group(0)
+    return literal[0] + " " * (len(literal) - 2) + literal[-1]
+
+
+def _shell_mask(line: str) -> str:
+    """line with string literal contents and $(( )) arithmetic blanked out, keeping every column in place"""
+    masked = _SINGLE_QUOTED.sub(_blank_literal, _STRING_LITERAL.sub(_blank_literal, line))
+    return re.sub(r'\$\(\(.*?\)\)', lambda m: " " * len(m.group(0)), masked)
+
+
+@register_extractor('shell')
+def extract_shell_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Shell function extraction for sh, bash, and zsh scripts
+    - Detects POSIX 'name() {' and ksh-style 'function name {' definitions, including one-liners
+      and subshell bodies 'name() ( ... )'; heredoc bodies are skipped
+    - A contiguous '#' comment block directly above a function counts as documentation
+      (doc_comment="comment_block"); the shebang line itself does not
+    """
+    symbols = []
+    heredocs = []
+
+    for i, line in enumerate(lines):
+        if heredocs:
+            terminator, strip_tabs = heredocs[0]
+            if (line.lstrip('\t') if strip_tabs else line).rstrip() == terminator:
+                heredocs.pop(0)
+            continue
+
+        code = _SINGLE_QUOTED.sub("''", _STRING_LITERAL.sub('""', line))
+        code = re.sub(r'(^|\s)#.*', r'\1', code)
+        match = _SHELL_FUNCTION.match(code)
+        if match:
+            keyword, keyword_name, posix_name, body = match.groups()
+            symbol = {"name": keyword_name or posix_name, "kind": "function", "line": i + 1}
+            if keyword:
+                symbol["keyword"] = True
+            if body == '(':
+                symbol["subshell"] = True
+            j = i - 1
+            while j >= 0 and lines[j].lstrip().startswith('#') and not (j == 0 and lines[j].startswith('#!')):
+                j -= 1
+            if j < i - 1:
+                symbol["doc_comment"] = "comment_block"
+            symbols.append(symbol)
+
+        # Heredocs are matched on the raw line, since a quoted delimiter (<<-'USAGE') loses its name in
+        # 'code'; '<<' inside a string, a comment, or $(( )) arithmetic (a shift) starts none
+        masked = _shell_mask(line)
+        comment = re.search(r'(?:^|\s)#', masked)
+        end = comment.start() if comment else len(line)
+        for heredoc in _SHELL_HEREDOC.finditer(line):
+            if heredoc.start() < end and masked.startswith('<<', heredoc.start()):
+                heredocs.append((heredoc.group(3), heredoc.group(1) == '-'))
+
+    return symbols
+
+
+# ====== VUE ======
+
+_SFC_SCRIPT_OPEN = re.compile(r'<script\b([^>]*)>', re.IGNORECASE)
+_SFC_SCRIPT_CLOSE = re.compile(r'</script\s*>', re.IGNORECASE)
+_VUE_OPTIONS_OBJECT = re.compile(r'^\s*export\s+default\s+(?:defineComponent\s*\(\s*)?\{')
+_VUE_OPTION_KEY = re.compile(r'^\s*(async\s+)?([A-Za-z_$][\w$]*)\s*(\(|:)\s*(.*)$')
+_VUE_LIFECYCLE_HOOKS = {
+    "beforeCreate", "created", "beforeMount", "mounted", "beforeUpdate", "updated", "beforeUnmount",
+    "unmounted", "beforeDestroy", "destroyed", "activated", "deactivated", "errorCaptured",
+    "renderTracked", "renderTriggered", "serverPrefetch",
+}
+
+
+def _sfc_script_blocks(lines: List[str]) -> List[tuple]:
+    """(first_line, end_line, attributes) of each <script> block's content, as line indexes"""
+    blocks = []
+    i = 0
+    while i < len(lines):
+        opened = _SFC_SCRIPT_OPEN.search(lines[i])
+        if opened:
+            end = next((k for k in range(i + 1, len(lines)) if _SFC_SCRIPT_CLOSE.search(lines[k])), len(lines))
+            blocks.append((i + 1, end, opened.group(1)))
+            i = end
+        i += 1
+    return blocks
+
+
+def _object_literal_keys(text: str) -> List[str]:
+    """Top-level keys of the first {...} (or quoted items of the first [...]) in text"""
+    start = min([p for p in (text.find('{'), text.find('[')) if p != -1] or [-1])
+    if start == -1:
+        return []
+    closing = '}' if text[start] == '{' else ']'
+    depth = 0
+    top_level = []
+    for ch in text[start:]:
+        if ch in '{[(':
+            depth += 1
+        elif ch in '}])':
+            depth -= 1
+            if depth == 0:
+                break
+        top_level.append(ch if depth == 1 else ' ')
+    body = ''.join(top_level)[1:]
+    if closing == ']':
+        return re.findall(r'[\'"]([^\'"]+)[\'"]', body)
+    return re.findall(r'(?:^|[,;\n])\s*[\'"]?([A-Za-z_$][\w$-]*)[\'"]?\s*\??\s*[:(]', '\n' + body)
+
+
+def _vue_setup_metadata(lines: List[str], start: int, end: int, component: Dict[str, Any]) -> List[Dict[str, Any]]:
+    """<script setup> macros: computed refs, composables used, defineProps/defineEmits/defineOptions"""
+    symbols = []
+    block = '\n'.join(lines[start:end])
+    for i in range(start, end):
+        line = lines[i]
+        computed = re.match(r'^\s*(?:export\s+)?const\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*computed\s*(?:<[^(]*>)?\(', line)
+        if computed:
+            symbols.append({"name": computed.group(1), "kind": "computed", "line": i + 1})
+        for composable in re.findall(r'=\s*(?:await\s+)?(use[A-Z]\w*)\s*\(', line):
+            if composable not in component["composables"]:
+                component["composables"].append(composable)
+
+    props = re.search(r'defineProps\s*(<)?', block)
+    if props:
+        rest = block[props.end() - (1 if props.group(1) else 0):]
+        if props.group(1):
+            named = re.match(r'<\s*([A-Za-z_$][\w$]*)\s*>', rest)
+            if named:
+                declared = re.search(r'(?:interface\s+' + re.escape(named.group(1)) + r'\b[^{]*|type\s+'
+                                     + re.escape(named.group(1)) + r'\s*=\s*)(\{)', block)
+                component["props"] = _object_literal_keys(block[declared.start(1):]) if declared else []
+            else:
+                component["props"] = _object_literal_keys(rest[1:])
+        else:
+            component["props"] = _object_literal_keys(rest)
+    emits = re.search(r'defineEmits\s*(?:<|\()', block)
+    if emits:
+        component["emits"] = _object_literal_keys(block[emits.end():])
+    options = re.search(r'defineOptions\s*\(\s*\{[^}]*?\bname\s*:\s*[\'"]([^\'"]+)[\'"]', block)
+    if options:
+        component["name"] = options.group(1)
+    return symbols
+
+
+def _vue_options_api(lines: List[str], start: int, end: int, component: Dict[str, Any]) -> List[Dict[str, Any]]:
+    """Options API 'export default { name, props, computed: {...}, methods: {...}, hooks }'"""
+    symbols = []
+    opening = next((i for i in range(start, end) if _VUE_OPTIONS_OBJECT.match(lines[i])), None)
+    if opening is None:
+        return symbols
+    cleaner = BraceScopeTracker(literals=(_STRING_LITERAL, _SINGLE_QUOTED, _TEMPLATE_LITERAL))
+    depth = 0
+    section = None
+    for i in range(opening, end):
+        text = cleaner.clean(lines[i])
+        option = _VUE_OPTION_KEY.match(lines[i]) if i > opening else None
+        if option and depth == 1:
+            is_async, key, separator, rest = option.groups()
+            section = key if separator == ':' and rest.lstrip().startswith('{') else None
+            if key == "name" and separator == ':':
+                quoted = re.match(r'[\'"]([^\'"]+)[\'"]', rest.strip())
+                if quoted:
+                    component["name"] = quoted.group(1)
+            elif key in ("props", "emits") and separator == ':':
+                declared = _object_literal_keys('\n'.join(lines[i:end])[lines[i].index(':') + 1:])
+                existing = component.setdefault(key, [])
+                existing.extend(name for name in declared if name not in existing)
+            elif separator == '(' or re.match(r'(?:async\s+)?(?:function\b|\([^)]*\)\s*=>)', rest):
+                symbol = {"name": key, "kind": "method", "line": i + 1}
+                if key in _VUE_LIFECYCLE_HOOKS:
+                    symbol["lifecycle"] = True
+                if is_async:
+                    symbol["async"] = True
+                symbols.append(symbol)
+        elif option and depth == 2 and section in ("computed", "methods"):
+            is_async, key, separator, rest = option.groups()
+            if separator == '(' or re.match(r'(?:async\s+)?(?:function\b|\(?[\w\s,]*\)?\s*=>|\{)', rest):
+                symbol = {"name": key, "kind": "computed" if section == "computed" else "method", "line": i + 1}
+                if is_async or rest.lstrip().startswith('async'):
+                    symbol["async"] = True
+                symbols.append(symbol)
+        depth += text.count('{') - text.count('}')
+        if depth <= 0 and i > opening:
+            break
+    return symbols
+
+
+@register_extractor('vue')
+def extract_vue_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Vue single-file component extraction from <script> and <script setup>
+    - Script blocks go through the TypeScript extractor with every other line blanked, so line
+      numbers match the .vue file; use* functions are reported as composables
+    - <script setup>: computed() refs, composables used, defineProps/defineEmits/defineOptions
+    - Options API: name, props, emits, computed entries, methods, and lifecycle hooks
+    - Emits a component symbol (sfc=True) carrying the declared name, props, emits, and composables
+    """
+    blocks = _sfc_script_blocks(lines)
+    if not blocks:
+        return []
+    # blocks[0][0] is the content's first index, i.e. the 1-based line of the <script> tag
+    component = {"name": "default", "kind": "component", "line": blocks[0][0], "sfc": True,
+                 "props": [], "composables": []}
+    symbols = [component]
+    for start, end, attributes in blocks:
+        script = [''] * len(lines)
+        script[start:end] = lines[start:end]
+        for symbol in _extract_typescript(script, jsx=False):
+            if symbol["kind"] == "hook":
+                symbol["kind"] = "composable"
+            symbols.append(symbol)
+        if re.search(r'\bsetup\b', attributes):
+            symbols.extend(_vue_setup_metadata(lines, start, end, component))
+        symbols.extend(_vue_options_api(lines, start, end, component))
+    for key in ("props", "emits", "composables"):
+        if key in component and not component[key]:
+            del component[key]
+    return sorted(symbols, key=lambda s: s["line"])
+
+
+# ====== SVELTE ======
+
+_SVELTE_EXPORT_LET = re.compile(r'^\s*export\s+let\s+(.+?);?\s*$')
+_SVELTE_REACTIVE = re.compile(r'^\s*\$:\s*(.*)$')
+_SVELTE_RUNE_DECLARATION = re.compile(
+    r'^\s*(?:export\s+)?(?:let|const)\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*\$(state|derived)(?:\.by|\.raw)?\s*[(<]'
+)
+_SVELTE_RUNE_PROPS = re.compile(r'^\s*let\s*(\{.*)=\s*\$props\s*\(')
+
+
+def _declared_names(declaration: str) -> List[str]:
+    """'a = 1, b: string' -> ['a', 'b'] (top-level comma-separated declarators)"""
+    return [m.group(1) for part in re.split(r',(?![^{(\[]*[})\]])', declaration)
+            for m in [re.match(r'\s*([A-Za-z_$][\w$]*)', part)] if m]
+
+
+@register_extractor('svelte')
+def extract_svelte_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Svelte component extraction from instance and module <script> blocks
+    - Script functions come from the TypeScript extractor with markup lines blanked
+    - 'export let' props and Svelte 5 '$props()' destructuring are recorded as component props
+    - Named reactive statements ('$: total = ...') and $state/$derived runes become reactive
+      symbols; anonymous '$:' blocks and $effect() calls are counted on the component
+    """
+    blocks = _sfc_script_blocks(lines)
+    if not blocks:
+        return []
+    component = {"name": "default", "kind": "component", "line": blocks[0][0], "sfc": True, "props": []}
+    symbols = [component]
+    reactive_blocks = 0
+    for start, end, attributes in blocks:
+        script = [''] * len(lines)
+        script[start:end] = lines[start:end]
+        symbols.extend(_extract_typescript(script, jsx=False))
+        module_context = bool(re.search(r'context\s*=\s*["\']module|\bmodule\b', attributes))
+        depth = 0
+        cleaner = BraceScopeTracker(literals=(_STRING_LITERAL, _SINGLE_QUOTED, _TEMPLATE_LITERAL))
+        for i in range(start, end):
+            line = lines[i]
+            text = cleaner.clean(line)
+            if depth == 0 and not module_context:
+                exported = _SVELTE_EXPORT_LET.match(text)
+                reactive = _SVELTE_REACTIVE.match(line)
+                rune = _SVELTE_RUNE_DECLARATION.match(line)
+                rune_props = _SVELTE_RUNE_PROPS.match(line)
+                if exported:
+                    component["props"].extend(_declared_names(exported.group(1)))
+                elif rune_props:
+                    destructured = re.match(r'\{(.*)\}', rune_props.group(1))
+                    component["props"].extend(_declared_names(destructured.group(1)) if destructured else [])
+                elif rune:
+                    symbols.append({"name": rune.group(1), "kind": "reactive", "line": i + 1, "rune": rune.group(2)})
+                elif reactive:
+                    assignment = re.match(r'([A-Za-z_$][\w$]*)\s*=(?!=)', reactive.group(1))
+                    if assignment:
+                        symbols.append({"name": assignment.group(1), "kind": "reactive", "line": i + 1})
+                    else:
+                        reactive_blocks += 1
+                elif re.match(r'^\s*\$effect(?:\.pre)?\s*\(', line):
+                    reactive_blocks += 1
+            depth = max(0, depth + text.count('{') - text.count('}'))
+    if reactive_blocks:
+        component["reactive_blocks"] = reactive_blocks
+    if not component["props"]:
+        del component["props"]
+    return sorted(symbols, key=lambda s: s["line"])
+
+
+# ====== OBJECTIVE-C ======
+
+_OBJC_CONTAINER = re.compile(
+    r'^\s*@(interface|implementation|protocol)\s+([A-Za-z_]\w*)\s*'
+    r'(?:\(\s*([A-Za-z_]\w*)?\s*\))?\s*(?::\s*([A-Za-z_]\w*))?\s*(?:<([^>]*)>)?\s*(;)?'
+)
+_OBJC_METHOD = re.compile(r'^\s*([-+])\s*(?:\((?:[^()]|\([^()]*\))*\))?\s*([A-Za-z_]\w*)')
+_OBJC_PROPERTY = re.compile(r'^\s*@property\s*(?:\(([^)]*)\))?\s*[^;]*?[\s*]([A-Za-z_]\w*)\s*(?:__\w+\s*)*;')
+_OBJC_TYPE_GROUP = re.compile(r'\((?:[^()]|\((?:[^()]|\([^()]*\))*\))*\)')
+_OBJC_DOC_SKIP = re.compile(r'^\s*(?:NS_\w+|API_\w+|__attribute__)')
+
+
+def header_language(lines: List[str]) -> str:
+    """Language of a .h header: Objective-C when it uses @-directives or #import, C++ when it uses C++ syntax"""
+    text = '\n'.join(lines[:2000])
+    if re.search(r'^\s*(?:@(?:interface|protocol|class|property|end)\b|#import\b)', text, re.MULTILINE):
+        return 'objc'
+    if re.search(r'^\s*(?:(?:template\s*<|namespace\s+\w|class\s+\w+[^;]*\{)|.*\bstd::)', text, re.MULTILINE):
+        return 'cpp'
+    return 'c'
+
+
+def _objc_selector(head: str) -> str:
+    """'- (void)move:(int)x to:(NSPoint)p' -> 'move:to:'; '- (void)reset' -> 'reset'"""
+    body = _OBJC_TYPE_GROUP.sub(' ', re.sub(r'^\s*[-+]\s*', '', head, count=1), count=1)
+    body = _OBJC_TYPE_GROUP.sub(' ', body)
+    parts = re.findall(r'([A-Za-z_]\w*)?\s*:', body.split('__attribute__')[0])
+    if parts:
+        return ''.join(part + ':' for part in parts)
+    word = re.match(r'\s*([A-Za-z_]\w*)', body)
+    return word.group(1) if word else ''
+
+
+@register_extractor('objc')
+def extract_objc_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Objective-C extraction of classes, categories, protocols, selectors, and C functions
+    - Instance (-) and class (+) methods are named by full selector ('initWithName:age:') and
+      parented to their class; categories and class extensions record the category name
+    - @interface/@protocol declarations are dropped when the same file implements the method
+    - @property declarations, protocol @optional requirements, and plain C functions are included
+    - HeaderDoc/appledoc '/**' blocks and '///' comments count as documentation (doc_comment="headerdoc")
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+    cleaner = BraceScopeTracker()
+    cleaned = [cleaner.clean(line) for line in lines]
+    container = None
+    optional = False
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        stripped = text.strip()
+        symbol = None
+        if not stripped or tracker.depth > 0:
+            tracker.feed(text)
+            i += 1
+            continue
+
+        declaration = _OBJC_CONTAINER.match(text)
+        if declaration and not declaration.group(6):
+            directive, name, category, superclass, protocols, _ = declaration.groups()
+            container = {"directive": directive, "name": name, "category": category,
+                         "extension": bool(re.match(r'\s*\(\s*\)', text.split(name, 1)[1]))}
+            optional = False
+            if directive == "protocol":
+                symbol = {"name": name, "kind": "protocol"}
+            elif directive == "interface" and not category and not container["extension"]:
+                symbol = {"name": name, "kind": "class"}
+                if superclass:
+                    symbol["superclass"] = superclass
+            elif category:
+                symbol = {"name": f"{name} ({category})", "kind": "category", "parent": name,
+                          "declaration_only": directive == "interface"}
+            elif directive == "implementation" and not any(s["name"] == name and s["kind"] == "class" for s in symbols):
+                symbol = {"name": name, "kind": "class"}
+            if symbol and protocols:
+                symbol["protocols"] = [p.strip() for p in protocols.split(',') if p.strip()]
+        elif stripped.startswith('@end'):
+            container = None
+        elif stripped.startswith(('@optional', '@required')):
+            optional = stripped.startswith('@optional')
+        elif container and _OBJC_METHOD.match(text):
+            head = _join_declaration_head(text, cleaned, i)
+            signature = head[0] if head else text
+            selector = _objc_selector(signature)
+            if selector:
+                symbol = {"name": selector, "kind": "method",
+                          "method_type": "class" if stripped.startswith('+') else "instance",
+                          "parent": container["name"]}
+                if container["category"]:
+                    symbol["category"] = container["category"]
+                elif container["extension"]:
+                    symbol["class_extension"] = True
+                if container["directive"] == "protocol":
+                    symbol["protocol_requirement"] = True
+                    if optional:
+                        symbol["optional"] = True
+                elif container["directive"] == "interface" or (head and not head[2]):
+                    symbol["declaration_only"] = True
+            if head:
+                consumed = head[1]
+        elif container and stripped.startswith('@property'):
+            prop = _OBJC_PROPERTY.match(text)
+            if prop:
+                symbol = {"name": prop.group(2), "kind": "property", "parent": container["name"]}
+                attributes = [a.strip() for a in (prop.group(1) or '').split(',') if a.strip()]
+                if attributes:
+                    symbol["attributes"] = attributes
+                if "readonly" in attributes:
+                    symbol["readonly"] = True
+        elif not stripped.startswith(('@', '-', '+')) and not _CPP_SKIP_LINE.match(text) and '(' in text:
+            head = _join_declaration_head(text, cleaned, i)
+            if head:
+                symbol = _cpp_function_symbol(head[0], head[2], None, set(), set())
+                if symbol and not head[2]:
+                    symbol["declaration_only"] = True
+                consumed = head[1]
+
+        if symbol:
+            symbol["line"] = i + 1
+            if has_block_doc_comment(lines, i, skip=_OBJC_DOC_SKIP) or has_line_doc_comment(lines, i):
+                symbol["doc_comment"] = "headerdoc"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(cleaned[k])
+        i = consumed + 1
+
+    return _drop_declared_prototypes(symbols)
+
+
+# ====== HASKELL ======
+
+_HASKELL_NAME = r"(?:[a-z_][\w']*|\([!#$%&*+./<=>?@\\^|~:-]+\))"
+_HASKELL_SIGNATURE = re.compile(r'^(' + _HASKELL_NAME + r'(?:\s*,\s*' + _HASKELL_NAME + r')*)\s*::(.*)$')
+_HASKELL_EQUATION = re.compile(r'^(' + _HASKELL_NAME + r')(?=[\s=|]|$)')
+_HASKELL_INFIX_EQUATION = re.compile(r"^[a-z_][\w']*\s+(?:`([a-z_][\w']*)`|([!#$%&*+./<>?@\\^~:-][!#$%&*+./<=>?@\\^|~:-]*))\s")
+_HASKELL_BINDING = re.compile(r"^\s*([a-z_][\w']*)\b[^=]*?(?<![=<>/!:])=(?![=>])")
+_HASKELL_KEYWORDS = {
+    'module', 'import', 'data', 'type', 'newtype', 'class', 'instance', 'where', 'let', 'in',
+    'infix', 'infixl', 'infixr', 'deriving', 'foreign', 'default', 'pattern', 'if', 'then',
+    'else', 'case', 'of', 'do',
+}
+
+
+def _haskell_clean(lines: List[str]) -> List[str]:
+    """Blank string literals, '--' line comments, and (nested) {- -} block comments and pragmas"""
+    cleaned = []
+    depth = 0
+    for line in lines:
+        line = _STRING_LITERAL.sub('""', line)
+        out = []
+        k = 0
+        while k < len(line):
+            if line.startswith('{-', k):
+                depth += 1
+                k += 2
+            elif depth and line.startswith('-}', k):
+                depth -= 1
+                k += 2
+            elif depth:
+                k += 1
+            elif re.match(r'--+(?![!#$%&*+./<=>?@\\^|~:])', line[k:]) and (k == 0 or not re.match(r'[!#$%&*+./<=>?@\\^|~:]', line[k - 1])):
+                break
+            else:
+                out.append(line[k])
+                k += 1
+        cleaned.append(''.join(out).rstrip())
+    return cleaned
+
+
+def _haskell_has_haddock(lines: List[str], index: int) -> bool:
+    """'-- |' or '{-| -}' directly above a declaration (pragmas such as {-# INLINE #-} may sit between)"""
+    j = index - 1
+    while j >= 0 and lines[j].lstrip().startswith('{-#'):
+        j -= 1
+    if j >= 0 and lines[j].rstrip().endswith('-}'):
+        while j >= 0 and '{-' not in lines[j]:
+            j -= 1
+        return j >= 0 and lines[j].lstrip().startswith('{-|')
+    while j >= 0 and lines[j].lstrip().startswith('--'):
+        if re.match(r'\s*--\s*[|$]', lines[j]):
+            return True
+        j -= 1
+    return False
+
+
+@register_extractor('haskell')
+def extract_haskell_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Haskell top-level function extraction with type signature pairing
+    - Pairs each top-level 'name :: Type' signature (including 'a, b :: T' and multi-line
+      signatures) with the equations that define it; multiple equations form one function
+    - Functions report their signature when one exists and the bindings of their where-clause
+    - Haddock '-- |' and '{-| -}' comments above the signature or first equation count as
+      documentation (doc_comment="haddock")
+    """
+    cleaned = _haskell_clean(lines)
+    signatures = {}
+    functions = {}
+    order = []
+    current = None
+    where_indent = None
+    binding_indent = None
+
+    i = 0
+    while i < len(cleaned):
+        text = cleaned[i]
+        if not text.strip():
+            i += 1
+            continue
+
+        if not text[0].isspace():
+            current = None
+            where_indent = binding_indent = None
+            first_word = re.match(r"[\w']+", text)
+            if first_word and first_word.group(0) in _HASKELL_KEYWORDS:
+                i += 1
+                continue
+
+            signature = _HASKELL_SIGNATURE.match(text)
+            if signature:
+                # Continuation lines of a multi-line signature are indented
+                parts = [signature.group(2).strip()]
+                end = i
+                while end + 1 < len(cleaned) and (not cleaned[end + 1].strip() or cleaned[end + 1][0].isspace()):
+                    end += 1
+                    parts.append(cleaned[end].strip())
+                type_text = ' '.join(p for p in parts if p)
+                for name in re.split(r'\s*,\s*', signature.group(1)):
+                    signatures[name] = {"line": i + 1, "type": type_text, "haddock": _haskell_has_haddock(lines, i)}
+                i = end + 1
+                continue
+
+            infix = _HASKELL_INFIX_EQUATION.match(text)
+            equation = _HASKELL_EQUATION.match(text)
+            name = None
+            if infix and '=' in text[infix.end() - 1:]:
+                name = infix.group(1) or f"({infix.group(2)})"
+            elif equation:
+                name = equation.group(1)
+            if name and name not in _HASKELL_KEYWORDS:
+                if name not in functions:
+                    functions[name] = {"name": name, "kind": "function", "line": i + 1,
+                                       "haddock": _haskell_has_haddock(lines, i)}
+                    order.append(name)
+                current = functions[name]
+                where = re.search(r'\swhere\b\s*(.*)$', text)
+                if where:
+                    where_indent = 0
+                    binding = _HASKELL_BINDING.match(where.group(1))
+                    if binding:
+                        current.setdefault("where_bindings", []).append(binding.group(1))
+            i += 1
+            continue
+
+        # Indented line: guards, continuations, or the where-clause of the current function
+        if current is not None:
+            indent = len(text) - len(text.lstrip())
+            body = text.strip()
+            where = re.match(r'where\b\s*', body) if where_indent is None else None
+            if where:
+                where_indent = indent
+                indent += where.end()
+                body = body[where.end():]
+            elif where_indent is None and re.search(r'\swhere$', text):
+                where_indent = indent
+            if where_indent is not None and body:
+                # Bindings share the column of the first one; deeper lines continue a binding
+                if binding_indent is None and indent > where_indent:
+                    binding_indent = indent
+                binding = _HASKELL_BINDING.match(body) if indent == binding_indent else None
+                if binding and binding.group(1) not in current.setdefault("where_bindings", []):
+                    current["where_bindings"].append(binding.group(1))
+        i += 1
+
+    symbols = []
+    for name in order:
+        function = functions[name]
+        symbol = {"name": name, "kind": "function", "line": function["line"]}
+        documented = function["haddock"]
+        if name in signatures:
+            signature = signatures[name]
+            symbol["signature"] = signature["type"]
+            symbol["line"] = min(signature["line"], function["line"])
+            documented = documented or signature["haddock"]
+        if function.get("where_bindings"):
+            symbol["where_bindings"] = function["where_bindings"]
+        if documented:
+            symbol["doc_comment"] = "haddock"
+        symbols.append(symbol)
+    return symbols
+
+
+# ====== LUA ======
+
+_LUA_NAME = r'[A-Za-z_]\w*'
+_LUA_FUNCTION = re.compile(
+    r'^\s*(local\s+)?function\s+(' + _LUA_NAME + r'(?:\s*\.\s*' + _LUA_NAME + r')*)(?:\s*:\s*(' + _LUA_NAME + r'))?\s*\('
+)
+_LUA_ASSIGNED_FUNCTION = re.compile(
+    r'^\s*(local\s+)?(' + _LUA_NAME + r'(?:\s*\.\s*' + _LUA_NAME + r')*)\s*=\s*function\s*\('
+)
+_LUA_LONG_BRACKET = re.compile(r'\[(=*)\[')
+
+
+def _lua_clean(lines: List[str]) -> List[str]:
+    """Blank string literals, '--' comments, and [[ ]] / --[==[ ]==] long strings and comments"""
+    cleaned = []
+    closing, comment = None, False
+    for line in lines:
+        out = ''
+        rest = line
+        while rest:
+            if closing:
+                end = rest.find(closing)
+                if end < 0:
+                    rest = ''
+                    break
+                out += '""' if not comment else ''
+                rest = rest[end + len(closing):]
+                closing = None
+                continue
+            rest = _SINGLE_QUOTED.sub("''", _STRING_LITERAL.sub('""', rest))
+            long_open = _LUA_LONG_BRACKET.search(rest)
+            dash = rest.find('--')
+            if dash >= 0 and (not long_open or dash < long_open.start()):
+                block = _LUA_LONG_BRACKET.match(rest, dash + 2)
+                out += rest[:dash]
+                if not block:
+                    rest = ''
+                    break
+                closing, comment = f"]{block.group(1)}]", True
+                rest = rest[block.end():]
+            elif long_open:
+                out += rest[:long_open.start()]
+                closing, comment = f"]{long_open.group(1)}]", False
+                rest = rest[long_open.end():]
+            else:
+                out += rest
+                rest = ''
+        cleaned.append(out.rstrip())
+    return cleaned
+
+
+def _lua_has_ldoc(lines: List[str], index: int) -> bool:
+    """LDoc '---' comment blocks (or '--[[-- ]]') directly above a function"""
+    j = index - 1
+    if j >= 0 and lines[j].strip().startswith(']') and lines[j].strip().endswith(']'):
+        while j >= 0 and '--[' not in lines[j]:
+            j -= 1
+        return j >= 0 and re.match(r'\s*--\[=*\[--', lines[j]) is not None
+    start = j
+    while j >= 0 and lines[j].lstrip().startswith('--'):
+        j -= 1
+    return start > j and lines[j + 1].lstrip().startswith('---')
+
+
+@register_extractor('lua')
+def extract_lua_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Lua function extraction for global, local, module, and table-method forms
+    - Detects 'function name()', 'local function name()', 'function M.name()', 'function obj:method()'
+      and the assigned forms 'name = function()' / 'local name = function()'
+    - Colon definitions are methods (implicit self) with the table as parent; dotted names
+      report their table path as parent
+    - LDoc '---' comment blocks and '--[[-- ]]' blocks directly above count as documentation
+      (doc_comment="ldoc")
+    """
+    cleaned = _lua_clean(lines)
+    symbols = []
+    for i, text in enumerate(cleaned):
+        match = _LUA_FUNCTION.match(text)
+        method = None
+        if match:
+            local, path, method = match.groups()
+        else:
+            match = _LUA_ASSIGNED_FUNCTION.match(text)
+            if not match:
+                continue
+            local, path = match.groups()
+        parts = [part.strip() for part in path.split('.')]
+        if method:
+            symbol = {"name": method, "kind": "method", "parent": '.'.join(parts), "line": i + 1}
+        else:
+            symbol = {"name": parts[-1], "kind": "function", "line": i + 1}
+            if len(parts) > 1:
+                symbol["parent"] = '.'.join(parts[:-1])
+        if local:
+            symbol["local"] = True
+        if _lua_has_ldoc(lines, i):
+            symbol["doc_comment"] = "ldoc"
+        symbols.append(symbol)
+    return symbols
+
+
+# ====== R ======
+
+_R_NAME = r'(`[^`]+`|[A-Za-z.][\w.]*)'
+_R_ASSIGNED_FUNCTION = re.compile(r'^\s*' + _R_NAME + r'\s*(?:<<?-|=)\s*(?:function\s*\(|\\\()')
+_R_CLASS_CALL = re.compile(r'^\s*' + _R_NAME + r'\s*(?:<<?-|=)\s*(?:R6::)?(R6Class|setRefClass)\s*\(')
+_R_MEMBER_FUNCTION = re.compile(r'^\s*' + _R_NAME + r'\s*=\s*function\s*\(')
+_R_SECTION = re.compile(r'\b(public|private|active|methods)\s*=\s*list\s*$')
+_R_S4_CALL = re.compile(r'^\s*(?:methods::)?(setClass|setGeneric|setMethod|setValidity)\s*\(\s*["\']([^"\']+)["\']\s*(.*)$')
+_R_S4_SIGNATURE = re.compile(r'^,\s*(?:signature\s*\(\s*)?(?:c\s*\(\s*)?(?:\w+\s*=\s*)?["\']([\w.]+)["\']')
+_R_COMMON_GENERICS = {
+    'print', 'format', 'summary', 'plot', 'toString', 'as.character', 'as.data.frame', 'as.list',
+    'length', 'mean', 'predict', 'update', 'head', 'tail', 'str', 'all.equal', 'c', 'merge',
+}
+
+
+def _r_strip_comment(line: str) -> str:
+    """Drop a trailing '#' comment, ignoring '#' inside string literals"""
+    quote = None
+    for k, char in enumerate(line):
+        if quote:
+            if char == '\\':
+                continue
+            if char == quote and line[k - 1] != '\\':
+                quote = None
+        elif char in '"\'':
+            quote = char
+        elif char == '#':
+            return line[:k]
+    return line
+
+
+def _r_roxygen(lines: List[str], index: int) -> Optional[List[str]]:
+    """Roxygen2 "#'" block directly above a definition, or None"""
+    j = index - 1
+    while j >= 0 and lines[j].lstrip().startswith("#'"):
+        j -= 1
+    return lines[j + 1:index] if j < index - 1 else None
+
+
+@register_extractor('r')
+def extract_r_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: R function extraction for top-level functions and S3, S4, R6, and Reference Class methods
+    - Detects top-level 'name <- function(...)', 'name = function(...)', and '\\(x)' lambda assignments
+    - 'generic.class' functions whose generic is defined in the file (UseMethod) or is a common
+      base generic are S3 methods; setGeneric/setMethod/setClass calls give S4 generics, methods
+      (parent = signature class), and classes
+    - R6Class/setRefClass assignments are classes; functions in their public/private/active/methods
+      lists are methods with their visibility
+    - Roxygen2 "#'" blocks directly above count as documentation (doc_comment="roxygen"); an
+      @export tag marks the symbol exported
+    """
+    code = [_SINGLE_QUOTED.sub("''", _STRING_LITERAL.sub('""', _r_strip_comment(line))) for line in lines]
+    generics = set(_R_COMMON_GENERICS)
+    for line in lines:
+        generics.update(re.findall(r'\bUseMethod\s*\(\s*["\']([\w.]+)["\']', _r_strip_comment(line)))
+
+    symbols = []
+    # Open '(' / '{' scopes: (char, label) where label marks R6/RC class calls and their member lists
+    stack = []
+    for i, text in enumerate(code):
+        symbol = None
+        top = stack[-1][1] if stack else None
+        s4 = _R_S4_CALL.match(_r_strip_comment(lines[i])) if not stack else None
+        class_call = _R_CLASS_CALL.match(text) if not stack else None
+        member = _R_MEMBER_FUNCTION.match(text) if top and top[0] == "section" else None
+
+        if s4:
+            call, name, rest = s4.groups()
+            if call == "setClass":
+                symbol = {"name": name, "kind": "class", "class_system": "S4"}
+            elif call == "setGeneric":
+                symbol = {"name": name, "kind": "generic", "class_system": "S4"}
+            elif call == "setMethod":
+                signature = _R_S4_SIGNATURE.match(rest)
+                symbol = {"name": name, "kind": "method", "class_system": "S4"}
+                if signature:
+                    symbol["parent"] = signature.group(1)
+        elif class_call:
+            name, constructor = class_call.groups()
+            symbol = {"name": name.strip('`'), "kind": "class",
+                      "class_system": "R6" if constructor == "R6Class" else "RC"}
+        elif member:
+            _, visibility, class_name, class_system = top
+            symbol = {"name": member.group(1).strip('`'), "kind": "method", "parent": class_name,
+                      "class_system": class_system}
+            if visibility != "methods":
+                symbol["visibility"] = visibility
+        elif not stack:
+            assigned = _R_ASSIGNED_FUNCTION.match(text)
+            if assigned:
+                name = assigned.group(1).strip('`')
+                symbol = {"name": name, "kind": "function"}
+                parts = name.split('.')
+                for k in range(1, len(parts)):
+                    if '.'.join(parts[:k]) in generics:
+                        symbol.update(kind="method", class_system="S3",
+                                      generic='.'.join(parts[:k]), parent='.'.join(parts[k:]))
+                        break
+
+        if symbol:
+            symbol["line"] = i + 1
+            roxygen = _r_roxygen(lines, i)
+            if roxygen is not None:
+                symbol["doc_comment"] = "roxygen"
+                if any(re.match(r"\s*#'\s*@export\b", line) for line in roxygen):
+                    symbol["exported"] = True
+            symbols.append(symbol)
+
+        for k, char in enumerate(text):
+            if char in '({':
+                label = None
+                enclosing = stack[-1][1] if stack else None
+                if char == '(' and class_call and not stack:
+                    label = ("class", symbol["name"], symbol["class_system"])
+                elif char == '(' and enclosing and enclosing[0] == "class":
+                    section = _R_SECTION.search(text[:k].rstrip())
+                    if section:
+                        label = ("section", section.group(1), enclosing[1], enclosing[2])
+                stack.append((char, label))
+            elif char in ')}' and stack:
+                stack.pop()
+    return symbols
+
+
+# ====== SOLIDITY ======
+
+_SOL_CONTAINER = re.compile(
+    r'^\s*(abstract\s+)?(contract|interface|library)\s+([A-Za-z_$][\w$]*)\s*(?:is\s+([^{]+?))?\s*(?:\{|$)'
+)
+_SOL_MEMBER = re.compile(
+    r'^\s*(function\s+([A-Za-z_$][\w$]*)|modifier\s+([A-Za-z_$][\w$]*)|event\s+([A-Za-z_$][\w$]*)|'
+    r'(constructor|fallback|receive))\s*(\(|\{|$)'
+)
+_SOL_VISIBILITY = ('public', 'external', 'internal', 'private')
+_SOL_MUTABILITY = ('view', 'pure', 'payable')
+_SOL_HEAD_KEYWORDS = set(_SOL_VISIBILITY) | set(_SOL_MUTABILITY) | {'virtual', 'override', 'returns', 'nonpayable', 'anonymous'}
+
+
+def _sol_paren_group(text: str, start: int) -> tuple:
+    """(contents, remainder) of the parenthesized group opening at text[start]"""
+    depth = 0
+    for k in range(start, len(text)):
+        if text[k] == '(':
+            depth += 1
+        elif text[k] == ')':
+            depth -= 1
+            if depth == 0:
+                return text[start + 1:k], text[k + 1:]
+    return text[start + 1:], ''
+
+
+@register_extractor('solidity')
+def extract_solidity_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Solidity extraction for contracts, functions, modifiers, and events
+    - Detects contracts (abstract, inheritance list), interfaces, and libraries as containers
+    - Functions report visibility, state mutability, virtual/override, custom modifiers applied,
+      and returns; constructor/fallback/receive are their own kinds
+    - NatSpec '///' lines and '/** */' blocks directly above count as documentation
+      (doc_comment="natspec")
+    """
+    symbols = []
+    tracker = BraceScopeTracker()
+    cleaner = BraceScopeTracker()
+    cleaned = [cleaner.clean(line) for line in lines]
+
+    i = 0
+    while i < len(lines):
+        text = cleaned[i]
+        consumed = i
+        scope = tracker.scopes[-1] if tracker.scopes else None
+        at_declaration_level = tracker.depth == (scope["depth"] if scope else 0)
+        if not text.strip() or not at_declaration_level:
+            tracker.feed(text)
+            i += 1
+            continue
+
+        symbol = None
+        container = _SOL_CONTAINER.match(text)
+        member = _SOL_MEMBER.match(text)
+        if container and not scope:
+            abstract, kind, name, bases = container.groups()
+            symbol = {"name": name, "kind": kind}
+            if abstract:
+                symbol["abstract"] = True
+            if bases:
+                symbol["inherits"] = [re.sub(r'\(.*', '', base).strip() for base in re.split(r',\s*(?![^()]*\))', bases)]
+            tracker.open_scope("container", name, container=kind)
+        elif member:
+            _, function_name, modifier_name, event_name, special, opener = member.groups()
+            head = _join_declaration_head(text, cleaned, i)
+            signature = head[0] if head else text
+            after = _sol_paren_group(signature, member.end() - 1)[1] if opener == '(' else ''
+            if event_name:
+                symbol = {"name": event_name, "kind": "event"}
+                if re.search(r'\banonymous\b', after):
+                    symbol["anonymous"] = True
+            elif modifier_name:
+                symbol = {"name": modifier_name, "kind": "modifier"}
+            else:
+                symbol = {"name": function_name or special, "kind": "function" if function_name else special}
+                returns_at = re.search(r'\breturns\s*\(', after)
+                qualifiers = after[:returns_at.start()] if returns_at else after
+                words = re.findall(r'[A-Za-z_$][\w$]*', re.sub(r'\([^()]*\)', '', qualifiers))
+                visibility = next((w for w in words if w in _SOL_VISIBILITY), None)
+                if visibility:
+                    symbol["visibility"] = visibility
+                mutability = next((w for w in words if w in _SOL_MUTABILITY), None)
+                if mutability:
+                    symbol["state_mutability"] = mutability
+                for word in ('virtual', 'override'):
+                    if word in words:
+                        symbol[word] = True
+                # Anything else in the head is a custom modifier invocation such as onlyOwner
+                applied = [w for w in words if w not in _SOL_HEAD_KEYWORDS]
+                if applied:
+                    symbol["modifiers"] = applied
+                if returns_at:
+                    returns = _sol_paren_group(after, returns_at.end() - 1)[0]
+                    symbol["returns"] = re.sub(r'\s+', ' ', returns).strip()
+            if head:
+                consumed = head[1]
+
+        if symbol:
+            symbol["line"] = i + 1
+            if scope and symbol["kind"] not in ("contract", "interface", "library"):
+                symbol["parent"] = scope["name"]
+            if has_line_doc_comment(lines, i) or has_block_doc_comment(lines, i):
+                symbol["doc_comment"] = "natspec"
+            symbols.append(symbol)
+
+        for k in range(i, consumed + 1):
+            tracker.feed(cleaned[k])
+        i = consumed + 1
+
+    return symbols
+
+
+# ====== CLOJURE ======
+
+_CLOJURE_DELIMITERS = set('()[]{}";,') | set(' \t\r\n')
+_CLOJURE_CLOSERS = {')': '(', ']': '[', '}': '{'}
+_CLOJURE_DEFINITIONS = {
+    "defn": "function", "defn-": "function", "defmacro": "macro", "defmulti": "multimethod",
+    "defmethod": "method", "defprotocol": "protocol", "def": "function",
+}
+
+
+def _clojure_read(text: str) -> List[Dict[str, Any]]:
+    """
+    Read Clojure source into nested forms: {"open": "(", "items": [...]}, {"atom": ...},
+    {"string": ...}, and {"meta": form} for '^' metadata, each with its 1-based line.
+    Comments, '#_' discarded forms, and reader prefixes (' ` ~ @ #') are dropped.
+    """
+    root = {"open": None, "items": [], "line": 1}
+    stack = [root]
+    pending = [{"meta": False, "discard": 0}]
+    line = 1
+    k = 0
+
+    def add(form):
+        state = pending[-1]
+        if state["discard"]:
+            state["discard"] -= 1
+        elif state["meta"]:
+            state["meta"] = False
+            stack[-1]["items"].append({"meta": form, "line": form["line"]})
+        else:
+            stack[-1]["items"].append(form)
+
+    while k < len(text):
+        char = text[k]
+        if char == '\n':
+            line += 1
+            k += 1
+        elif char in ' \t\r,':
+            k += 1
+        elif char == ';':
+            while k < len(text) and text[k] != '\n':
+                k += 1
+        elif char == '"':
+            start_line, end = line, k + 1
+            while end < len(text) and text[end] != '"':
+                if text[end] == '\\':
+                    end += 1
+                elif text[end] == '\n':
+                    line += 1
+                end += 1
+            add({"string": text[k + 1:end], "line": start_line})
+            k = end + 1
+        elif char == '\\':
+            # Character literal: \a, \(, \newline
+            k += 2
+            while k < len(text) and text[k] not in _CLOJURE_DELIMITERS:
+                k += 1
+        elif char in '([{':
+            # '#(' anonymous fns and '#{' sets keep their dispatch character
+            opener = '#' + char if k and text[k - 1] == '#' and char != '[' else char
+            stack.append({"open": opener, "items": [], "line": line})
+            pending.append({"meta": False, "discard": 0})
+            k += 1
+        elif char in ')]}':
+            if len(stack) > 1:
+                form = stack.pop()
+                pending.pop()
+                add(form)
+            k += 1
+        elif char == '^':
+            pending[-1]["meta"] = True
+            k += 1
+        elif char == '#' and text.startswith('#_', k):
+            pending[-1]["discard"] += 1
+            k += 2
+        elif char in "'`~@#?":
+            k += 1
+        else:
+            end = k
+            while end < len(text) and text[end] not in _CLOJURE_DELIMITERS:
+                end += 1
+            add({"atom": text[k:end], "line": line})
+            k = end
+    # Unclosed forms at end of file still count
+    while len(stack) > 1:
+        form = stack.pop()
+        pending.pop()
+        add(form)
+    return root["items"]
+
+
+def _clojure_meta_flags(meta: Dict[str, Any]) -> Dict[str, Any]:
+    """Flags from '^:private' or '^{:private true :doc "..."}' metadata"""
+    form = meta["meta"]
+    if "atom" in form:
+        return {form["atom"].lstrip(':'): True}
+    flags = {}
+    items = form.get("items", [])
+    for key, value in zip(items[::2], items[1::2]):
+        if "atom" in key:
+            flags[key["atom"].lstrip(':')] = value.get("string", value.get("atom") != "false")
+    return flags
+
+
+@register_extractor('clojure')
+def extract_clojure_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Clojure/ClojureScript extraction for top-level definition forms
+    - Detects defn, defn- (private), defmacro, defmulti, defmethod (with its dispatch value),
+      defprotocol with its method signatures, and def bound to an fn
+    - Symbols carry the namespace from the preceding (ns ...) or (in-ns ...) form
+    - Docstrings, attr-map :doc, and ^{:doc ...} metadata count as documentation
+      (doc_comment="docstring"); ^:private metadata marks a definition private
+    """
+    symbols = []
+    namespace = None
+
+    for form in _clojure_read('\n'.join(lines)):
+        items = form.get("items", [])
+        if form.get("open") != '(' or not items or "atom" not in items[0]:
+            continue
+        head = items[0]["atom"]
+        rest = items[1:]
+        metadata = {}
+        while rest and "meta" in rest[0]:
+            metadata.update(_clojure_meta_flags(rest[0]))
+            rest = rest[1:]
+        name_form = rest[0] if rest else {}
+        if head in ("ns", "in-ns") and "atom" in name_form:
+            namespace = name_form["atom"]
+            continue
+        if head not in _CLOJURE_DEFINITIONS or "atom" not in name_form:
+            continue
+
+        name = name_form["atom"]
+        body = rest[1:]
+        if head == "def":
+            # Only (def name (fn ...)), (def name #(...)), and (def name "doc" (fn ...)) are functions
+            value = body[-1] if body else {}
+            value_items = value.get("items", [])
+            is_fn = value.get("open") == '#(' or (
+                value.get("open") == '(' and value_items and value_items[0].get("atom") in ("fn", "fn*"))
+            if not is_fn:
+                continue
+        symbol = {"name": name, "kind": _CLOJURE_DEFINITIONS[head], "line": form["line"]}
+        if head == "defmethod" and body:
+            dispatch = body[0]
+            symbol["dispatch"] = dispatch.get("atom", dispatch.get("string", "default"))
+        if head == "defn-" or metadata.get("private"):
+            symbol["private"] = True
+        if namespace:
+            symbol["namespace"] = namespace
+
+        has_docstring = head != "defmethod" and len(body) > 1 and "string" in body[0]
+        documented = has_docstring or isinstance(metadata.get("doc"), str)
+        attr_map = body[1 if has_docstring else 0] if len(body) > (1 if has_docstring else 0) else {}
+        if attr_map.get("open") == '{' and any(item.get("atom") == ":doc" for item in attr_map.get("items", [])[::2]):
+            documented = True
+        if documented:
+            symbol["doc_comment"] = "docstring"
+        symbols.append(symbol)
+
+        if head == "defprotocol":
+            for signature in body:
+                members = signature.get("items", [])
+                if signature.get("open") != '(' or not members or "atom" not in members[0]:
+                    continue
+                method = {"name": members[0]["atom"], "kind": "method", "parent": name, "line": signature["line"]}
+                if namespace:
+                    method["namespace"] = namespace
+                if any("string" in member for member in members[1:]):
+                    method["doc_comment"] = "docstring"
+                symbols.append(method)
+    return symbols
+
+
+# ====== STARLARK (BAZEL) ======
+
+# Top-level 'name = rule(...)' style definitions in .bzl files
+_STARLARK_DEFINITION_CALLS = {"rule", "repository_rule", "aspect", "provider", "module_extension"}
+
+
+def _starlark_call_name(node: ast.AST) -> Optional[str]:
+    """'cc_library' or 'pkg.rule' for a call's callee"""
+    try:
+        return ast.unparse(node.func)
+    except Exception:
+        return None
+
+
+def _starlark_keyword(call: ast.Call, name: str) -> Optional[ast.AST]:
+    return next((kw.value for kw in call.keywords if kw.arg == name), None)
+
+
+def _starlark_has_comment(lines: List[str], index: int) -> bool:
+    """A '#' comment line directly above the statement"""
+    return index > 0 and lines[index - 1].lstrip().startswith('#')
+
+
+@register_extractor('starlark')
+def extract_starlark_symbols(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Starlark extraction for Bazel .bzl definitions and BUILD targets
+    - .bzl: top-level public defs are macros; 'x = rule(...)' (and repository_rule, aspect, provider,
+      module_extension) are rule definitions with their implementation function and attrs
+    - BUILD: top-level calls with a name= argument are targets (":name") with the rule they use
+    - Def docstrings and a rule's doc= argument count as documentation; a '#' comment directly
+      above a target documents it
+    - Raises SyntaxError for unparsable files so the caller falls back to regex patterns
+    """
+    tree = ast.parse("\n".join(lines))
+    symbols = []
+    for node in tree.body:
+        if isinstance(node, ast.FunctionDef):
+            # Public defs are macros; '_' defs are private helpers such as rule implementations
+            symbol = {"name": node.name, "kind": "function" if node.name.startswith('_') else "macro",
+                      "line": node.lineno,
+                      "end_line": getattr(node, "end_lineno", node.lineno), "build": True}
+            if ast.get_docstring(node):
+                symbol["doc_comment"] = "docstring"
+            symbols.append(symbol)
+        elif isinstance(node, ast.Assign) and isinstance(node.value, ast.Call) and \
+                len(node.targets) == 1 and isinstance(node.targets[0], ast.Name):
+            call = node.value
+            callee = _starlark_call_name(call)
+            if callee not in _STARLARK_DEFINITION_CALLS:
+                continue
+            symbol = {"name": node.targets[0].id, "kind": callee, "line": node.lineno, "build": True}
+            implementation = _starlark_keyword(call, "implementation")
+            if isinstance(implementation, ast.Name):
+                symbol["implementation"] = implementation.id
+            for attribute in ("attrs", "fields"):
+                value = _starlark_keyword(call, attribute)
+                if isinstance(value, ast.Dict):
+                    symbol[attribute] = [k.value for k in value.keys if isinstance(k, ast.Constant)]
+                elif isinstance(value, (ast.List, ast.Tuple)):
+                    symbol[attribute] = [e.value for e in value.elts if isinstance(e, ast.Constant)]
+            doc = _starlark_keyword(call, "doc")
+            if isinstance(doc, ast.Constant) and doc.value:
+                symbol["doc_comment"] = "doc_attribute"
+            symbols.append(symbol)
+        elif isinstance(node, ast.Expr) and isinstance(node.value, ast.Call):
+            call = node.value
+            target = _starlark_keyword(call, "name")
+            callee = _starlark_call_name(call)
+            if not (isinstance(target, ast.Constant) and isinstance(target.value, str)) or not callee:
+                continue
+            symbol = {"name": f":{target.value}", "kind": "target", "rule": callee, "line": node.lineno,
+                      "build": True}
+            if _starlark_has_comment(lines, node.lineno - 1):
+                symbol["doc_comment"] = "comment"
+            symbols.append(symbol)
+    return symbols
@@ -0,0 +1,35 @@
+#!/bin/sh
+# Go apidiff fixture: commits v1/calc.go and then v2/calc.go to a throwaway repository and runs
+# 'apidiff --from v1 --fail-on-breaking' on it. Expected: Sub removed, Add changed, Mul added,
+# and exit status 1. Needs git and a Go toolchain (the go/ast helper reads the blobs on stdin).
+set -eu
+fixture=$(cd "$(dirname "$0")" && pwd)
+scanner_dir=$(cd "$fixture/../.." && pwd)
+work=$(mktemp -d)
+trap 'rm -rf "$work"' EXIT
+
+mkdir -p "$work/codebase_summary" "$work/calc"
+cp "$scanner_dir"/*.py "$work/codebase_summary/"
+cp -R "$scanner_dir/go_ast_parser" "$scanner_dir/schemas" "$work/codebase_summary/"
+printf 'module example.com/calc\n\ngo 1.21\n' > "$work/go.mod"
+cd "$work"
+git init -q
+git config user.email fixture@example.com
+git config user.name fixture
+cp "$fixture/v1/calc.go" calc/calc.go
+git add -A && git commit -qm v1 && git tag v1
+cp "$fixture/v2/calc.go" calc/calc.go
+git commit -qam v2
+
+status=0
+python3 codebase_summary/update_project_summary.py apidiff --from v1 --json --fail-on-breaking \
+    > report.json 2> scan.log || status=$?
+python3 - "$status" <<'PY'
+import json, sys
+report = json.load(open("report.json"))
+names = {category: sorted(e["name"] for e in report[category]) for category in ("removed", "changed", "added")}
+expected = {"removed": ["Sub"], "changed": ["Add"], "added": ["Mul"]}
+if names != expected or sys.argv[1] != "1":
+    sys.exit(f"❌ apidiff reported {names} (exit {sys.argv[1]}), expected {expected} (exit 1)")
+print(f"✅ Go apidiff: {names} (exit 1)")
+PY
@@ -0,0 +1,23 @@
+// Package calc is the "before" side of the Go apidiff fixture (see ../check_apidiff.sh).
+package calc
+
+// Add returns the sum of two integers.
+func Add(a, b int) int {
+	return a + b
+}
+
+// Sub returns a minus b.
+func Sub(a, b int) int {
+	return a - b
+}
+
+// clamp is unexported, so apidiff never reports it.
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
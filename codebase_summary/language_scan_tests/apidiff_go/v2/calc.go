@@ -0,0 +1,28 @@
+// Package calc is the "after" side of the Go apidiff fixture: Add now takes any number of
+// operands, Sub is gone, and Mul is new.
+package calc
+
+// Add returns the sum of its operands.
+func Add(operands ...int) int {
+	total := 0
+	for _, v := range operands {
+		total += v
+	}
+	return total
+}
+
+// Mul returns the product of two integers.
+func Mul(a, b int) int {
+	return a * b
+}
+
+// clamp is unexported, so apidiff never reports it.
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,27 @@
+// Entrypoint fixture - Spring MVC controller with a class-level prefix
+package com.example.orders;
+
+import org.springframework.web.bind.annotation.*;
+
+@RestController
+@RequestMapping("/orders")
+public class OrderController {
+
+    @GetMapping("/{id}")
+    public String getOrder(@PathVariable String id) {
+        return id;
+    }
+
+    @PostMapping
+    public String createOrder(@RequestBody String body) {
+        return body;
+    }
+
+    @RequestMapping(value = "/search", method = {RequestMethod.GET, RequestMethod.POST})
+    public String search() {
+        return "";
+    }
+
+    public static void main(String[] args) {
+    }
+}
@@ -0,0 +1,17 @@
+// Entrypoint fixture - Express routes and a Commander CLI
+const express = require('express');
+const { program } = require('commander');
+
+const app = express();
+const router = express.Router();
+
+router.get('/orders/:id', getOrder);
+router.post('/orders', (req, res) => res.status(201).end());
+app.use('/api', router);
+
+function getOrder(req, res) {
+    res.json({ id: req.params.id });
+}
+
+program.command('import <file>').action((file) => console.log(file));
+program.parse();
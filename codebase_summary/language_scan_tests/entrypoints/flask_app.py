@@ -0,0 +1,40 @@
+# Entrypoint fixture - Flask routes and a Click command group
+import click
+from flask import Flask, Blueprint
+
+app = Flask(__name__)
+admin = Blueprint("admin", __name__)
+
+
+@app.route("/health")
+def health():
+    return "ok"
+
+
+@app.route("/items", methods=["GET", "POST"])
+def items():
+    return []
+
+
+@admin.delete("/users/<int:user_id>")
+def delete_user(user_id):
+    return "", 204
+
+
+@click.group()
+def cli():
+    pass
+
+
+@cli.command()
+def init_db():
+    click.echo("initialized")
+
+
+@cli.command("serve")
+def run_server():
+    app.run()
+
+
+if __name__ == "__main__":
+    cli()
@@ -0,0 +1,39 @@
+// Entrypoint fixture - Gin routes and a Cobra command tree
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Inventory service",
+}
+
+var serveCmd = &cobra.Command{
+	Use: "serve",
+	Run: func(cmd *cobra.Command, args []string) { serve() },
+}
+
+var migrateCmd = &cobra.Command{
+	Use: "migrate [version]",
+}
+
+func listItems(c *gin.Context) { c.JSON(http.StatusOK, nil) }
+
+func serve() {
+	r := gin.Default()
+	api := r.Group("/api")
+	api.GET("/items", listItems)
+	api.POST("/items", func(c *gin.Context) {})
+	http.HandleFunc("GET /metrics", nil)
+	r.Run()
+}
+
+func main() {
+	rootCmd.AddCommand(serveCmd, migrateCmd)
+	rootCmd.Execute()
+}
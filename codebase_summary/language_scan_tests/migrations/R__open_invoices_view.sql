@@ -0,0 +1,2 @@
+CREATE OR REPLACE VIEW billing.open_invoices AS
+    SELECT * FROM billing.invoices WHERE NOT closed;
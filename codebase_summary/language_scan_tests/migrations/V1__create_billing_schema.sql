@@ -0,0 +1,2 @@
+-- Initial billing schema
+CREATE SCHEMA billing;
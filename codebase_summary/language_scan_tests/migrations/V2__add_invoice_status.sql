@@ -0,0 +1 @@
+ALTER TABLE billing.invoices ADD COLUMN status TEXT;
@@ -0,0 +1,5 @@
+CREATE TABLE billing.invoices (
+    id SERIAL PRIMARY KEY,
+    issued_at DATE NOT NULL,
+    closed BOOLEAN DEFAULT FALSE
+);
@@ -0,0 +1,12 @@
+-- @codebase-summary: Maintains invoices.updated_at for cache invalidation
+CREATE OR REPLACE FUNCTION billing.touch_invoice()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER invoices_touch
+    BEFORE UPDATE ON billing.invoices
+    FOR EACH ROW EXECUTE FUNCTION billing.touch_invoice();
@@ -0,0 +1,6 @@
+// Monorepo fixture - app depending on the @acme/ui workspace package
+import { button } from "@acme/ui";
+
+export function render() {
+    return button("Save");
+}
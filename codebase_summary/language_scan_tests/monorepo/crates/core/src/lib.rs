@@ -0,0 +1,10 @@
+// Monorepo fixture - Rust crate bounded by Cargo.toml
+
+/// Sum of two counters
+pub fn add(a: u64, b: u64) -> u64 {
+    a + b
+}
+
+pub fn zero() -> u64 {
+    0
+}
@@ -0,0 +1,12 @@
+// Monorepo fixture - nested package of the text module, imported by its full import path
+package strings
+
+import "unicode"
+
+// Upper reports whether a word starts with an upper-case letter
+func Upper(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
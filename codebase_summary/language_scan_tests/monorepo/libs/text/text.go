@@ -0,0 +1,12 @@
+// Monorepo fixture - Go library module used by services/api
+package text
+
+import "strings"
+
+// Title upper-cases the first letter of a word
+func Title(word string) string {
+	if word == "" {
+		return word
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
@@ -0,0 +1,12 @@
+// Monorepo fixture - workspace package imported by apps/web by its package name
+
+/**
+ * @codebase-summary: Button markup with an escaped label
+ */
+export function button(label) {
+    return `<button>${escape(label)}</button>`;
+}
+
+function escape(text) {
+    return String(text).replace(/</g, "&lt;");
+}
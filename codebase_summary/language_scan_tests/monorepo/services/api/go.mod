@@ -0,0 +1,9 @@
+module example.com/acme/api
+
+go 1.21
+
+require (
+	example.com/acme/text v0.0.0
+	github.com/gin-gonic/gin v1.9.1
+	golang.org/x/net v0.23.0 // indirect
+)
@@ -0,0 +1,12 @@
+// Monorepo fixture - Go service importing the sibling text module
+package main
+
+import (
+	"fmt"
+
+	"example.com/acme/text"
+)
+
+func main() {
+	fmt.Println(text.Title("acme"))
+}
@@ -0,0 +1,9 @@
+// Monorepo fixture - Maven project bounded by pom.xml
+package com.acme.billing;
+
+public class Invoice {
+    /** Total in cents */
+    public long total(long net, long tax) {
+        return net + tax;
+    }
+}
@@ -0,0 +1,7 @@
+module example.com/acme/worker
+
+go 1.22
+
+require example.com/acme/text v0.0.0
+
+replace example.com/acme/text => ../../libs/text
@@ -0,0 +1,15 @@
+// Monorepo fixture - module outside go.work that reaches libs/text through a local replace
+package worker
+
+import (
+	"example.com/acme/text"
+	"example.com/acme/text/strings"
+)
+
+// Label title-cases a job name unless it is already capitalized
+func Label(job string) string {
+	if strings.Upper(job) {
+		return job
+	}
+	return text.Title(job)
+}
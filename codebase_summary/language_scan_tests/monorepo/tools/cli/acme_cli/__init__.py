@@ -0,0 +1,8 @@
+# Monorepo fixture - Python project bounded by pyproject.toml
+import click
+
+
+@click.command()
+def main():
+    """Print the workspace name"""
+    click.echo("acme")
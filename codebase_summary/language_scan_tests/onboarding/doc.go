@@ -0,0 +1,2 @@
+// Package onboarding is a fixture for the docgen directory purpose lookup.
+package onboarding
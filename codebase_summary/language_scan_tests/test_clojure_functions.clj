@@ -85,4 +85,52 @@
 
 ;; Undocumented utility function
 (defn utility-function [data]
-  (reduce + (filter even? data)))
\ No newline at end of file
+  (reduce + (filter even? data)))
+;; Namespaced definitions with docstrings
+(ns example.geometry
+  (:require [clojure.string :as str]))
+
+(defn area
+  "Area of a shape - docstrings count as documentation."
+  [{:keys [w h]}]
+  (* w h))
+
+(defn ^:private scale-by [k x]
+  (* k x))
+
+(defn perimeter
+  {:doc "Attr-map docs count too." :added "1.2"}
+  [{:keys [w h]}]
+  (* 2 (+ w h)))
+
+(def ^{:doc "Metadata docs on a def-bound fn."} square
+  (fn [x] (* x x)))
+
+(def doubler #(* 2 %))
+
+(def not-a-function 42)
+
+(defmulti describe
+  "Dispatches on the :shape key."
+  :shape)
+
+(defmethod describe :circle [s]
+  (str "circle of radius " (:r s)))
+
+(defmethod describe :default [s]
+  "unknown shape")
+
+(defprotocol Drawable
+  "Things that can be drawn."
+  (draw [this] "Render to the canvas.")
+  (bounds [this]))
+
+#_(defn discarded-function [] nil)
+
+(comment
+  (defn scratch-function [] "rich comment forms are not definitions"))
+
+(defn parens-in-strings
+  "Handles \"quoted\" text with ( parens and ; semicolons"
+  []
+  (str \( "(" \)))
@@ -92,6 +92,57 @@ public:
     }
 };
 
+namespace geometry::shapes {
+
+template<typename T, std::size_t N = 3>
+class Vector {
+public:
+    Vector();
+    explicit Vector(const T& fill);
+    ~Vector();
+
+    T length() const;
+    Vector operator+(const Vector& other) const;
+    T& operator[](std::size_t index) { return data_[index]; }
+
+private:
+    T data_[N];
+};
+
+template<typename T, std::size_t N>
+Vector<T, N>::Vector() : data_{} {}
+
+template<typename T, std::size_t N>
+Vector<T, N>::~Vector() {}
+
+// Out-of-line definition split across lines
+template<typename T, std::size_t N>
+T Vector<T, N>::length()
+    const {
+    return T{};
+}
+
+struct Point {
+    double x;
+    double y;
+    Point(double x, double y) : x(x), y(y) {}
+};
+
+std::ostream& operator<<(std::ostream& os, const Point& point);
+double distance(const Point& a, const Point& b);
+
+} // namespace geometry::shapes
+
+namespace {
+    int anonymousHelper(int value) {
+        return value + 1;
+    }
+}
+
+std::ostream& geometry::shapes::operator<<(std::ostream& os, const geometry::shapes::Point& point) {
+    return os << point.x << "," << point.y;
+}
+
 int main() {
     std::cout << "C++ function tests ready" << std::endl;
     std::string result = basicFunction();
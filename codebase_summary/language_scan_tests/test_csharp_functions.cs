@@ -501,4 +501,34 @@ namespace TestNamespace
             Console.WriteLine($"{Name}: {Value}");
         }
     }
+
+    /// <summary>
+    /// Caches computed values; documented with XML doc comments only.
+    /// </summary>
+    public sealed class DocumentedCache
+    {
+        private readonly Dictionary<string, int> entries = new Dictionary<string, int>();
+
+        /// <summary>Number of cached entries.</summary>
+        public int Count
+        {
+            get => entries.Count;
+        }
+
+        /// <summary>Looks up a cached value.</summary>
+        /// <param name="key">Cache key.</param>
+        [Obsolete("Use TryGetAsync instead")]
+        [MethodImpl(MethodImplOptions.AggressiveInlining)]
+        public int Get(string key) => entries[key];
+
+        [Serializable, DebuggerStepThrough] public async Task<bool> TryGetAsync(
+            string key,
+            CancellationToken token = default)
+        {
+            await Task.Yield();
+            return entries.ContainsKey(key);
+        }
+
+        public static implicit operator int(DocumentedCache cache) => cache.Count;
+    }
 }
\ No newline at end of file
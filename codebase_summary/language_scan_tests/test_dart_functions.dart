@@ -97,6 +97,58 @@ Stream<String> streamFunction(List<String> items) async* {
   }
 }
 
+/// A counter widget documented with dartdoc.
+class CounterView extends StatelessWidget {
+  const CounterView({super.key, required this.count});
+
+  final int count;
+
+  /// Builds the counter label.
+  @override
+  Widget build(BuildContext context) {
+    return Text('Count: $count {not a brace}');
+  }
+}
+
+class Point {
+  final double x;
+  final double y;
+
+  const Point(this.x, this.y);
+
+  Point.origin()
+      : x = 0,
+        y = 0;
+
+  /// Parses "x,y" pairs.
+  factory Point.parse(String raw) {
+    final parts = raw.split(',');
+    return Point(double.parse(parts[0]), double.parse(parts[1]));
+  }
+
+  @override
+  bool operator ==(Object other) =>
+      other is Point && other.x == x && other.y == y;
+
+  @Deprecated('Use distanceTo')
+  double distance(Point other) => sqrt(pow(x - other.x, 2) + pow(y - other.y, 2));
+
+  static const help = """
+  Point { x, y }
+  """;
+}
+
+enum Planet {
+  mercury(3.3e23),
+  venus(4.87e24);
+
+  const Planet(this.mass);
+
+  final double mass;
+
+  bool get isHeavy => mass > 1e24;
+}
+
 void main() {
   print("Dart function tests ready");
   String result = basicFunction();
@@ -0,0 +1,28 @@
+// Deprecated Go symbols ("Deprecated:" doc paragraphs) for deprecation marker validation
+package main
+
+import "fmt"
+
+// legacyGreeting builds the old greeting text.
+//
+// Deprecated: use greeting instead.
+func legacyGreeting(name string) string {
+    return "Hello, " + name
+}
+
+// greeting builds the greeting text
+func greeting(name string) string {
+    return fmt.Sprintf("Hello, %s!", name)
+}
+
+// oldConfig was replaced by the options struct.
+//
+// Deprecated: configure through options.
+type oldConfig struct {
+    verbose bool
+}
+
+func printGreetings() {
+    fmt.Println(legacyGreeting("gopher"))
+    fmt.Println(greeting("gopher"))
+}
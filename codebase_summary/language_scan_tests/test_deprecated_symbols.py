@@ -0,0 +1,34 @@
+#!/usr/bin/env python3
+"""
+Deprecated Python symbols (PEP 702 decorator, DeprecationWarning, Sphinx directive) for deprecation marker validation
+"""
+
+import warnings
+from warnings import deprecated
+
+
+@deprecated("Use load_settings() instead")
+def read_settings(path):
+    """Read settings from a file"""
+    return open(path).read()
+
+
+def parse_legacy_format(text):
+    """Parse the v1 settings format"""
+    warnings.warn("parse_legacy_format() is going away, use parse_settings()", DeprecationWarning, stacklevel=2)
+    return text.split("=")
+
+
+def export_settings(settings):
+    """
+    Write settings back out
+
+    .. deprecated:: 2.0
+       Settings are saved automatically.
+    """
+    return str(settings)
+
+
+def load_settings(path):
+    """Load settings, replacing read_settings"""
+    return parse_legacy_format(read_settings(path))
@@ -0,0 +1,17 @@
+// The Python merge_settings helper ported to JavaScript - a cross-language duplicate
+
+function mergeSettings(defaults, overrides) {
+    const merged = clone(defaults);
+    for (const key in overrides) {
+        const value = overrides[key];
+        if (value === null) {
+            continue;
+        }
+        if (key in merged && isObject(value)) {
+            merged[key] = mergeSettings(merged[key], value);
+        } else {
+            merged[key] = value;
+        }
+    }
+    return merged;
+}
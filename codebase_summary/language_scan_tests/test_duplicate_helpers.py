@@ -0,0 +1,40 @@
+"""Copy-pasted helpers for duplicate detection (python3 update_project_summary.py --duplicates)"""
+
+
+def merge_settings(defaults, overrides):
+    """Overrides win; nested dicts merge, None values are skipped"""
+    merged = dict(defaults)
+    for key in overrides:
+        value = overrides[key]
+        if value is None:
+            continue
+        if key in merged and isinstance(value, dict):
+            merged[key] = merge_settings(merged[key], value)
+        else:
+            merged[key] = value
+    return merged
+
+
+def merge_headers(base, extra):
+    """Same shape as merge_settings with renamed variables - a near-duplicate"""
+    result = dict(base)
+    for name in extra:
+        header = extra[name]
+        if header is None:
+            continue
+        if name in result and isinstance(header, dict):
+            result[name] = merge_headers(result[name], header)
+        else:
+            result[name] = header
+    return result
+
+
+def count_words(text):
+    """Structurally different - not part of any cluster"""
+    counts = {}
+    for word in text.split():
+        word = word.strip(".,;:!?").lower()
+        if not word:
+            continue
+        counts[word] = counts.get(word, 0) + 1
+    return sorted(counts.items(), key=lambda item: (-item[1], item[0]))[:10]
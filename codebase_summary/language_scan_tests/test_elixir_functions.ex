@@ -0,0 +1,69 @@
+# Elixir test functions for breadcrumb detection validation
+
+defmodule TestElixir do
+  @moduledoc """
+  Module documented with @moduledoc.
+  def not_a_function, do: :ignored
+  """
+
+  def basic_function do
+    "test"
+  end
+
+  # @codebase-summary: Joins both params - documented with a breadcrumb
+  def function_with_params(param1, param2 \\ 42) do
+    "#{param1}_#{param2}"
+  end
+
+  # Function without breadcrumb documentation
+  def undocumented_function, do: true
+
+  @doc """
+  Computes a factorial; multiple clauses collapse into one symbol.
+  """
+  @spec factorial(non_neg_integer()) :: pos_integer()
+  def factorial(0), do: 1
+  def factorial(n) when n > 0, do: n * factorial(n - 1)
+
+  @doc "Single-line doc string."
+  def greet(name), do: "Hello #{name}"
+
+  @doc false
+  def internal_callback(state) do
+    Enum.map(state, fn item ->
+      item * 2
+    end)
+  end
+
+  defp private_helper(list) do
+    case list do
+      [] -> :empty
+      _ -> :non_empty
+    end
+  end
+
+  defmacro debug(expr) do
+    quote do
+      def generated_inside_quote, do: unquote(expr)
+    end
+  end
+
+  defguard is_even(value) when is_integer(value) and rem(value, 2) == 0
+
+  defdelegate size(map), to: Map
+
+  defmodule Nested do
+    @moduledoc false
+
+    def nested_function(x), do: x
+  end
+end
+
+defprotocol TestProtocol do
+  @doc "Protocol function"
+  def describe(value)
+end
+
+defimpl TestProtocol, for: Integer do
+  def describe(value), do: "integer #{value}"
+end
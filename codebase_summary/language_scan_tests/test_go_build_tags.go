@@ -0,0 +1,12 @@
+//go:build (linux || darwin) && !integration
+
+// +build linux darwin
+// +build !integration
+
+// Go file with //go:build and legacy +build constraints for build constraint validation
+package main
+
+// unixOnlyHelper is compiled on Linux and macOS outside integration builds
+func unixOnlyHelper() bool {
+    return true
+}
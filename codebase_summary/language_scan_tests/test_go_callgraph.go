@@ -0,0 +1,35 @@
+// Go call graph fixture - calls that cross files within package main
+package main
+
+func (t *TestStruct) Describe() string {
+    return formatValue(t.UndocumentedMethod()) + helperSuffix()
+}
+
+func formatValue(v string) string {
+    return "[" + v + "]"
+}
+
+func helperSuffix() string {
+    return basicFunction()
+}
+
+// Never called from anywhere in the package
+func orphanedHelper() int {
+    return genericFunction([]int{1, 2})[1]
+}
+
+func registeredCallback(v int) int {
+    return v * 2
+}
+
+func applyAll(values []int) []int {
+    return mapInts(values, registeredCallback)
+}
+
+func mapInts(values []int, fn func(int) int) []int {
+    out := make([]int, 0, len(values))
+    for _, v := range values {
+        out = append(out, fn(v))
+    }
+    return out
+}
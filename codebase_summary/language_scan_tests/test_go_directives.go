@@ -0,0 +1,43 @@
+// Go directives (//go:generate, //go:embed, compiler directives, cgo) for directive metadata validation
+package main
+
+/*
+#cgo CFLAGS: -O2
+#cgo LDFLAGS: -lm
+#include <math.h>
+#include "fixture.h"
+*/
+import "C"
+
+import (
+    "embed"
+    _ "unsafe"
+)
+
+//go:generate stringer -type=Color
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=test_go_directives.go -destination=mocks.go
+
+//go:embed templates/*.html "static files/logo.png"
+var assets embed.FS
+
+//go:embed version.txt
+var version string
+
+type Color int
+
+// fastAbs is kept out of line so benchmarks measure the call
+//go:noinline
+func fastAbs(x int) int {
+    if x < 0 {
+        return -x
+    }
+    return x
+}
+
+//go:linkname runtimeNano runtime.nanotime
+func runtimeNano() int64
+
+//export goCallback
+func goCallback(value C.int) C.int {
+    return C.int(fastAbs(int(value)))
+}
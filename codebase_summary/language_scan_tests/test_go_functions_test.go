@@ -0,0 +1,42 @@
+// Go test, benchmark, fuzz, and example functions for test classification validation
+package main
+
+import (
+	"fmt"
+	stdtesting "testing"
+)
+
+// TestProcessUser runs as a unit test
+func TestProcessUser(t *stdtesting.T) {
+	if got := fixtureName(); got == "" {
+		t.Fatal("empty name")
+	}
+}
+
+func Test_processUserEmpty(t *stdtesting.T) {}
+
+func BenchmarkProcessUser(b *stdtesting.B) {
+	for i := 0; i < b.N; i++ {
+		fixtureName()
+	}
+}
+
+func FuzzProcessUser(f *stdtesting.F) {
+	f.Fuzz(func(t *stdtesting.T, name string) {})
+}
+
+func ExampleProcessUser() {
+	fmt.Println(fixtureName())
+	// Output: fixture
+}
+
+// Testify is a helper: go test ignores names continuing with a lowercase letter
+func Testify(t *stdtesting.T) {}
+
+func TestMain(m *stdtesting.M) {
+	m.Run()
+}
+
+func fixtureName() string {
+	return "fixture"
+}
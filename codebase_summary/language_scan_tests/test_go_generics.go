@@ -0,0 +1,75 @@
+// Go generics (constraints, multiple type parameters, generic types and methods) for detection validation
+package main
+
+import "fmt"
+
+// Number is a constraint interface listing the numeric types Sum accepts
+type Number interface {
+    ~int | ~int64 | ~float64
+}
+
+// Stringish combines a type set with a method requirement
+type Stringish interface {
+    ~string
+    fmt.Stringer
+}
+
+// Pair holds two values of independent types
+type Pair[K comparable, V any] struct {
+    Key   K
+    Value V
+}
+
+// Stack is a generic LIFO container
+type Stack[T any] struct {
+    items []T
+}
+
+// Push adds an item on top of the stack
+func (s *Stack[T]) Push(item T) {
+    s.items = append(s.items, item)
+}
+
+func (s *Stack[T]) Pop() (T, bool) {
+    var zero T
+    if len(s.items) == 0 {
+        return zero, false
+    }
+    item := s.items[len(s.items)-1]
+    s.items = s.items[:len(s.items)-1]
+    return item, true
+}
+
+// Sum adds numbers of any Number type
+func Sum[N Number](values ...N) N {
+    var total N
+    for _, v := range values {
+        total += v
+    }
+    return total
+}
+
+// MapKeys collects a map's keys, with the map type itself as a type parameter
+func MapKeys[M ~map[K]V, K comparable, V any](m M) []K {
+    keys := make([]K, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    return keys
+}
+
+func mapPairs[K comparable, V any](m map[K]V) []Pair[K, V] {
+    pairs := make([]Pair[K, V], 0, len(m))
+    for k, v := range m {
+        pairs = append(pairs, Pair[K, V]{Key: k, Value: v})
+    }
+    return pairs
+}
+
+func genericsDemo() {
+    s := &Stack[int]{}
+    s.Push(1)
+    total := Sum[float64](1.5, 2.5)
+    keys := MapKeys[map[string]int](map[string]int{"a": 1})
+    fmt.Println(total, keys, mapPairs(map[string]bool{"x": true}))
+}
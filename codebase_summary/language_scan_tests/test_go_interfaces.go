@@ -0,0 +1,46 @@
+// Go interface fixture - struct types satisfying interfaces by method set
+package main
+
+import "io"
+
+type Shape interface {
+    Area() float64
+    Perimeter() float64
+}
+
+type Named interface {
+    Name() string
+}
+
+// Embeds two interfaces declared in this package
+type NamedShape interface {
+    Shape
+    Named
+}
+
+// Embeds an interface from outside the scanned module, so it cannot be resolved
+type ShapeReader interface {
+    io.Reader
+    Shape
+}
+
+type Square struct {
+    side float64
+}
+
+func (s Square) Area() float64      { return s.side * s.side }
+func (s Square) Perimeter() float64 { return 4 * s.side }
+func (s Square) Name() string       { return "square" }
+
+type Circle struct {
+    radius float64
+}
+
+func (c *Circle) Area() float64      { return 3.14159 * c.radius * c.radius }
+func (c *Circle) Perimeter() float64 { return 2 * 3.14159 * c.radius }
+
+// Same method names as Shape but a different signature, so it does not satisfy it
+type Sketch struct{}
+
+func (s Sketch) Area() int      { return 0 }
+func (s Sketch) Perimeter() int { return 0 }
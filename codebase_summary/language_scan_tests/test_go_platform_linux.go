@@ -0,0 +1,13 @@
+// Go platform-specific file (selected by its _linux suffix) for build constraint validation
+package main
+
+import "os"
+
+// openDevice opens the Linux device node for a name
+func openDevice(name string) (*os.File, error) {
+    return os.Open("/dev/" + name)
+}
+
+func platformName() string {
+    return "linux"
+}
@@ -0,0 +1,13 @@
+// Go platform-specific file (selected by its _windows suffix) for build constraint validation
+package main
+
+import "os"
+
+// openDevice opens the Windows device path for a name
+func openDevice(name string) (*os.File, error) {
+    return os.Open(`\\.\` + name)
+}
+
+func platformName() string {
+    return "windows"
+}
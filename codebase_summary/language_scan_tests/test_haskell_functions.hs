@@ -0,0 +1,85 @@
+-- Haskell test functions for signature pairing and Haddock validation
+{-# LANGUAGE ScopedTypeVariables #-}
+module Geometry
+  ( Shape(..)
+  , area
+  , perimeter
+  , scale
+  ) where
+
+import qualified Data.Map as Map
+import Data.List (sortOn)
+
+-- | A two-dimensional shape.
+data Shape
+  = Circle Double
+  | Rect Double Double
+  deriving (Show, Eq)
+
+-- | Area of a shape - documented with a Haddock comment.
+area :: Shape -> Double
+area (Circle r) = pi * r * r
+area (Rect w h) = w * h
+
+perimeter :: Shape -> Double
+perimeter shape = case shape of
+  Circle r -> 2 * pi * r
+  Rect w h -> 2 * (w + h)
+
+{-| Scale a shape by a factor.
+    Block Haddock comments count too.
+-}
+scale :: Double
+      -> Shape
+      -> Shape
+scale k (Circle r) = Circle (k * r)
+scale k (Rect w h) = Rect (k * w) (k * h)
+
+-- | Total area with helpers in a where-clause.
+totalArea :: [Shape] -> Double
+totalArea shapes
+  | null shapes = 0
+  | otherwise = sum areas + bonus
+  where
+    areas = map area shapes
+    bonus :: Double
+    bonus = fromIntegral (length shapes) * epsilon
+      where epsilon = 0.0  -- nested where belongs to bonus
+    count = length shapes
+
+-- Plain comment "-- |" inside a string is not Haddock
+describe :: Shape -> String
+describe s = "shape: -- | " ++ show s
+
+-- | Signature shared by two functions.
+minArea, maxArea :: [Shape] -> Double
+minArea = minimum . map area
+maxArea xs = maximum (map area xs) where unused = ()
+
+{- block comment
+notAFunction :: Int
+notAFunction = 1
+-}
+
+-- | Operator definitions pair with their signatures too.
+(<+>) :: Shape -> Shape -> Double
+a <+> b = area a + area b
+
+x `overlaps` y = area x > 0 && area y > 0
+
+{-# INLINE largest #-}
+-- | Pragmas between a Haddock comment and its function are allowed.
+largest :: [Shape] -> Maybe Shape
+largest [] = Nothing
+largest xs = Just (last (sortOn area xs))
+
+main :: IO ()
+main = do
+  let shapes = [Circle 1, Rect 2 3]
+  print (totalArea shapes)
+
+class Sized a where
+  size :: a -> Int
+
+instance Sized Shape where
+  size _ = 1
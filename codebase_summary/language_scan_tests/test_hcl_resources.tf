@@ -0,0 +1,81 @@
+# Terraform test configuration for infrastructure inventory validation
+
+terraform {
+  required_version = ">= 1.5"
+}
+
+provider "aws" {
+  region = var.region
+}
+
+variable "region" {
+  description = "AWS region to deploy into"
+  type        = string
+  default     = "us-east-1"
+}
+
+variable "instance_count" {
+  type = number
+}
+
+variable "db_password" {
+  description = <<-EOT
+    Master password for the database.
+    Supplied through TF_VAR_db_password.
+  EOT
+  type      = string
+  sensitive = true
+}
+
+variable "tags" { description = "Tags applied to every resource" }
+
+# @codebase-summary: Primary VPC shared by all application tiers
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.1.0"
+
+  cidr = "10.0.0.0/16"
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+
+  filter {
+    name   = "name"
+    values = ["ubuntu/images/*"]
+  }
+}
+
+resource "aws_instance" "web" {
+  count         = var.instance_count
+  ami           = data.aws_ami.ubuntu.id
+  instance_type = "t3.micro"
+
+  user_data = <<EOF
+resource "not_a" "resource" {
+}
+EOF
+
+  tags = {
+    Name = "web-${count.index}"
+  }
+}
+
+/*
+resource "aws_s3_bucket" "commented_out" {
+}
+*/
+
+resource "aws_s3_bucket" "assets" {
+  for_each = toset(["images", "videos"])
+  bucket   = "assets-${each.key}"
+}
+
+output "web_ips" {
+  description = "Public IPs of the web instances"
+  value       = aws_instance.web[*].public_ip
+}
+
+output "vpc_id" {
+  value = module.vpc.vpc_id
+}
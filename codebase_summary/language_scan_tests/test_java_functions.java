@@ -72,6 +72,60 @@ public class TestJavaFunctions {
         this.value = newValue;
     }
     
+    /**
+     * Returns the value with surrounding whitespace removed.
+     */
+    @Override
+    @SuppressWarnings({"unchecked", "rawtypes"})
+    public String toString() {
+        return value.trim();
+    }
+
+    /** Kept for callers that predate setValue. */
+    @Deprecated(since = "2.0", forRemoval = true)
+    public void legacySetter(
+            String newValue,
+            boolean notify) throws IllegalStateException {
+        setValue(newValue);
+    }
+
+    public record Point(int x, int y) {
+        /** Validates the components. */
+        public Point {
+            if (x < 0 || y < 0) {
+                throw new IllegalArgumentException("negative");
+            }
+        }
+
+        public static <T extends Comparable<T>> Point origin() {
+            return new Point(0, 0);
+        }
+    }
+
+    public enum Color {
+        RED("r"),
+        GREEN("g") {
+            @Override
+            public String code() {
+                return "G";
+            }
+        };
+
+        private final String code;
+
+        Color(String code) {
+            this.code = code;
+        }
+
+        public String code() {
+            return code;
+        }
+    }
+
+    public @interface Audited {
+        String reason() default "";
+    }
+
     public static void main(String[] args) {
         System.out.println("Java function tests ready");
         String result = basicMethod();
@@ -79,6 +79,50 @@ fun higherOrderFunction(operation: (Int, Int) -> Int): (Int, Int) -> Int {
     return operation
 }
 
+// @codebase-summary: Generic extension function on a parameterized receiver
+fun <T> List<T>.secondOrNull(): T? {
+    return if (size > 1) this[1] else null
+}
+
+fun String?.orPlaceholder(): String = this ?: "n/a"
+
+suspend fun Flow<String>.collectAll(): List<String> {
+    val out = mutableListOf<String>()
+    collect { out.add(it) }
+    return out
+}
+
+data class MultiLineData(
+    val id: Long,
+    val label: String = "{unset}",
+) : Comparable<MultiLineData> {
+    override fun compareTo(other: MultiLineData): Int = id.compareTo(other.id)
+}
+
+class Repository private constructor(private val name: String) {
+    companion object Factory {
+        @JvmStatic
+        fun create(name: String): Repository = Repository(name)
+
+        suspend fun load(): Repository {
+            delay(10)
+            return Repository("loaded")
+        }
+    }
+
+    fun describe(): String = "Repository($name)"
+}
+
+enum class Direction {
+    NORTH, SOUTH;
+
+    fun opposite(): Direction = if (this == NORTH) SOUTH else NORTH
+}
+
+fun interface Predicate {
+    fun accept(value: Int): Boolean
+}
+
 val lambdaFunction = { x: Int, y: Int -> x + y }
 
 val computedProperty: String
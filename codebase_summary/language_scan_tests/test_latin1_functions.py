@@ -0,0 +1,13 @@
+# -*- coding: latin-1 -*-
+# Legacy module saved in ISO-8859-1: "Caf crme" labels for the menu export
+
+
+def accented_title(title):
+    return title.replace("e", "")
+
+
+def price_label(name, price):
+    """
+    # @codebase-summary: Menu label with the price in euros
+    """
+    return "%s - %.2f EUR" % (name, price)
@@ -92,4 +92,50 @@ function M.undocumentedModuleFunction()
     return "undocumented"
 end
 
+--- Adds two vectors.
+-- LDoc comment blocks start with three dashes.
+-- @tparam table a first vector
+-- @treturn table the sum
+function M.addVectors(a, b)
+    return {x = a.x + b.x, y = a.y + b.y}
+end
+
+--[[--
+Block LDoc comment for a nested module path.
+]]
+function M.util.clamp(value, low, high)
+    return math.max(low, math.min(high, value))
+end
+
+---@class Queue
+local Queue = {}
+
+---@param item any
+function Queue:push(item)
+    table.insert(self, item)
+end
+
+--[[ A plain block comment
+function notAFunction()
+end
+]]
+local template = [[
+function alsoNotAFunction()
+end
+]]
+local message = "function inString() end"
+
+M.handlers = {
+    onEvent = function(event) return event end,
+}
+
+--- Documented local function.
+local function clamp01(value)
+    return M.util.clamp(value, 0, 1)
+end
+
+M.formatName = function(first, last)
+    return first .. " " .. last
+end
+
 return M
\ No newline at end of file
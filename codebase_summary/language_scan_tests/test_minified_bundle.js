@@ -0,0 +1,2 @@
+/*! bundle v1.4.2 | MIT */
+!function(){function h0(a,b){return a.map(function(c){return c*0+b}).filter(Boolean)}var v0=h0([1,2,3],0);function h1(a,b){return a.map(function(c){return c*1+b}).filter(Boolean)}var v1=h1([1,2,3],1);function h2(a,b){return a.map(function(c){return c*2+b}).filter(Boolean)}var v2=h2([1,2,3],2);function h3(a,b){return a.map(function(c){return c*3+b}).filter(Boolean)}var v3=h3([1,2,3],3);function h4(a,b){return a.map(function(c){return c*4+b}).filter(Boolean)}var v4=h4([1,2,3],4);function h5(a,b){return a.map(function(c){return c*5+b}).filter(Boolean)}var v5=h5([1,2,3],5);function h6(a,b){return a.map(function(c){return c*6+b}).filter(Boolean)}var v6=h6([1,2,3],6);function h7(a,b){return a.map(function(c){return c*7+b}).filter(Boolean)}var v7=h7([1,2,3],7);function h8(a,b){return a.map(function(c){return c*8+b}).filter(Boolean)}var v8=h8([1,2,3],8);function h9(a,b){return a.map(function(c){return c*9+b}).filter(Boolean)}var v9=h9([1,2,3],9);function h10(a,b){return a.map(function(c){return c*10+b}).filter(Boolean)}var v10=h10([1,2,3],10);function h11(a,b){return a.map(function(c){return c*11+b}).filter(Boolean)}var v11=h11([1,2,3],11);function h12(a,b){return a.map(function(c){return c*12+b}).filter(Boolean)}var v12=h12([1,2,3],12);function h13(a,b){return a.map(function(c){return c*13+b}).filter(Boolean)}var v13=h13([1,2,3],13);function h14(a,b){return a.map(function(c){return c*14+b}).filter(Boolean)}var v14=h14([1,2,3],14);function h15(a,b){return a.map(function(c){return c*15+b}).filter(Boolean)}var v15=h15([1,2,3],15);function h16(a,b){return a.map(function(c){return c*16+b}).filter(Boolean)}var v16=h16([1,2,3],16);function h17(a,b){return a.map(function(c){return c*17+b}).filter(Boolean)}var v17=h17([1,2,3],17);function h18(a,b){return a.map(function(c){return c*18+b}).filter(Boolean)}var v18=h18([1,2,3],18);function h19(a,b){return a.map(function(c){return c*19+b}).filter(Boolean)}var v19=h19([1,2,3],19);function h20(a,b){return a.map(function(c){return c*20+b}).filter(Boolean)}var v20=h20([1,2,3],20);function h21(a,b){return a.map(function(c){return c*21+b}).filter(Boolean)}var v21=h21([1,2,3],21);function h22(a,b){return a.map(function(c){return c*22+b}).filter(Boolean)}var v22=h22([1,2,3],22);function h23(a,b){return a.map(function(c){return c*23+b}).filter(Boolean)}var v23=h23([1,2,3],23);function h24(a,b){return a.map(function(c){return c*24+b}).filter(Boolean)}var v24=h24([1,2,3],24);function h25(a,b){return a.map(function(c){return c*25+b}).filter(Boolean)}var v25=h25([1,2,3],25);function h26(a,b){return a.map(function(c){return c*26+b}).filter(Boolean)}var v26=h26([1,2,3],26);function h27(a,b){return a.map(function(c){return c*27+b}).filter(Boolean)}var v27=h27([1,2,3],27);function h28(a,b){return a.map(function(c){return c*28+b}).filter(Boolean)}var v28=h28([1,2,3],28);function h29(a,b){return a.map(function(c){return c*29+b}).filter(Boolean)}var v29=h29([1,2,3],29);function h30(a,b){return a.map(function(c){return c*30+b}).filter(Boolean)}var v30=h30([1,2,3],30);function h31(a,b){return a.map(function(c){return c*31+b}).filter(Boolean)}var v31=h31([1,2,3],31);function h32(a,b){return a.map(function(c){return c*32+b}).filter(Boolean)}var v32=h32([1,2,3],32);function h33(a,b){return a.map(function(c){return c*33+b}).filter(Boolean)}var v33=h33([1,2,3],33);function h34(a,b){return a.map(function(c){return c*34+b}).filter(Boolean)}var v34=h34([1,2,3],34);function h35(a,b){return a.map(function(c){return c*35+b}).filter(Boolean)}var v35=h35([1,2,3],35);function h36(a,b){return a.map(function(c){return c*36+b}).filter(Boolean)}var v36=h36([1,2,3],36);function h37(a,b){return a.map(function(c){return c*37+b}).filter(Boolean)}var v37=h37([1,2,3],37);function h38(a,b){return a.map(function(c){return c*38+b}).filter(Boolean)}var v38=h38([1,2,3],38);function h39(a,b){return a.map(function(c){return c*39+b}).filter(Boolean)}var v39=h39([1,2,3],39);function h40(a,b){return a.map(function(c){return c*40+b}).filter(Boolean)}var v40=h40([1,2,3],40);function h41(a,b){return a.map(function(c){return c*41+b}).filter(Boolean)}var v41=h41([1,2,3],41);function h42(a,b){return a.map(function(c){return c*42+b}).filter(Boolean)}var v42=h42([1,2,3],42);function h43(a,b){return a.map(function(c){return c*43+b}).filter(Boolean)}var v43=h43([1,2,3],43);function h44(a,b){return a.map(function(c){return c*44+b}).filter(Boolean)}var v44=h44([1,2,3],44);function h45(a,b){return a.map(function(c){return c*45+b}).filter(Boolean)}var v45=h45([1,2,3],45);function h46(a,b){return a.map(function(c){return c*46+b}).filter(Boolean)}var v46=h46([1,2,3],46);function h47(a,b){return a.map(function(c){return c*47+b}).filter(Boolean)}var v47=h47([1,2,3],47);function h48(a,b){return a.map(function(c){return c*48+b}).filter(Boolean)}var v48=h48([1,2,3],48);function h49(a,b){return a.map(function(c){return c*49+b}).filter(Boolean)}var v49=h49([1,2,3],49);function h50(a,b){return a.map(function(c){return c*50+b}).filter(Boolean)}var v50=h50([1,2,3],50);function h51(a,b){return a.map(function(c){return c*51+b}).filter(Boolean)}var v51=h51([1,2,3],51);function h52(a,b){return a.map(function(c){return c*52+b}).filter(Boolean)}var v52=h52([1,2,3],52);function h53(a,b){return a.map(function(c){return c*53+b}).filter(Boolean)}var v53=h53([1,2,3],53);function h54(a,b){return a.map(function(c){return c*54+b}).filter(Boolean)}var v54=h54([1,2,3],54);function h55(a,b){return a.map(function(c){return c*55+b}).filter(Boolean)}var v55=h55([1,2,3],55);function h56(a,b){return a.map(function(c){return c*56+b}).filter(Boolean)}var v56=h56([1,2,3],56);function h57(a,b){return a.map(function(c){return c*57+b}).filter(Boolean)}var v57=h57([1,2,3],57);function h58(a,b){return a.map(function(c){return c*58+b}).filter(Boolean)}var v58=h58([1,2,3],58);function h59(a,b){return a.map(function(c){return c*59+b}).filter(Boolean)}var v59=h59([1,2,3],59);module.exports={h0:h0,h1:h1,h2:h2,h3:h3,h4:h4,h5:h5,h6:h6,h7:h7,h8:h8,h9:h9,h10:h10,h11:h11,h12:h12,h13:h13,h14:h14,h15:h15,h16:h16,h17:h17,h18:h18,h19:h19,h20:h20,h21:h21,h22:h22,h23:h23,h24:h24,h25:h25,h26:h26,h27:h27,h28:h28,h29:h29,h30:h30,h31:h31,h32:h32,h33:h33,h34:h34,h35:h35,h36:h36,h37:h37,h38:h38,h39:h39,h40:h40,h41:h41,h42:h42,h43:h43,h44:h44,h45:h45,h46:h46,h47:h47,h48:h48,h49:h49,h50:h50,h51:h51,h52:h52,h53:h53,h54:h54,h55:h55,h56:h56,h57:h57,h58:h58,h59:h59}}();
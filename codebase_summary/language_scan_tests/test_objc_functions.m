@@ -154,4 +154,30 @@ int calculateSum(NSArray *numbers) {
     NSLog(@"Doing something");
 }
 
-@end
\ No newline at end of file
+@end
+
+/**
+ * Downloads and caches remote images - documented with a HeaderDoc block.
+ */
+@interface ImageLoader : NSObject <NSURLSessionDelegate>
+
+/// Shared loader used by table cells.
+@property (class, nonatomic, readonly) ImageLoader *sharedLoader;
+
+/**
+ * Loads an image, calling completion on the main queue.
+ */
+- (void)loadImageAtURL:(NSURL *)url
+            completion:(void (^)(UIImage *image, NSError *error))completion;
+
+@end
+
+@implementation ImageLoader
+
+- (void)loadImageAtURL:(NSURL *)url
+            completion:(void (^)(UIImage *image, NSError *error))completion {
+    NSLog(@"Loading %@ { not a scope", url);
+    completion(nil, nil);
+}
+
+@end
@@ -0,0 +1,30 @@
+// Objective-C header test definitions - .h files are classified by their contents
+#import <Foundation/Foundation.h>
+
+NS_ASSUME_NONNULL_BEGIN
+
+/// Posts cache events to registered observers.
+@protocol CacheObserver <NSObject>
+- (void)cacheDidEvict:(NSString *)key;
+@optional
++ (BOOL)wantsPurgeNotifications;
+@end
+
+/**
+ * Thread-safe in-memory cache.
+ */
+@interface MemoryCache : NSObject
+
+@property (nonatomic, readonly) NSUInteger count;
+
+/// Stores an object for a key.
+- (void)setObject:(id)object forKey:(NSString *)key;
+- (nullable id)objectForKey:(NSString *)key;
++ (instancetype)sharedCache;
+
+@end
+
+/// Returns the default cache capacity.
+NSUInteger MemoryCacheDefaultCapacity(void);
+
+NS_ASSUME_NONNULL_END
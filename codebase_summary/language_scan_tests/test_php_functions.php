@@ -95,6 +95,55 @@ function errorHandlingFunction(string $input): string {
     return $input;
 }
 
+trait Timestamps {
+    /**
+     * Marks the model as modified.
+     */
+    public function touch(): void {
+        $this->updatedAt = time();
+    }
+
+    abstract protected function table(): string;
+}
+
+#[Entity(table: "invoices")]
+final class Invoice {
+    use Timestamps, \App\Concerns\SoftDeletes;
+
+    public function __get(string $name): mixed {
+        return $this->data[$name] ?? null;
+    }
+
+    #[Deprecated]
+    #[Route("/invoices/{id}", methods: ["GET"])]
+    public function render(
+        int $id,
+        array $options = []
+    ): string {
+        return <<<HTML
+            <div class="invoice">{$id}</div>
+            function notAFunction() { }
+        HTML;
+    }
+
+    protected function table(): string {
+        return "invoices";
+    }
+}
+
+enum Status: string {
+    case Draft = 'draft';
+    case Paid = 'paid';
+
+    public function label(): string {
+        return ucfirst($this->value);
+    }
+}
+
+$formatter = static function (float $amount): string {
+    return number_format($amount, 2);
+};
+
 function main(): void {
     echo "PHP function tests ready\n";
     $result = basicFunction();
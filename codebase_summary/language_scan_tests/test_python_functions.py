@@ -6,6 +6,9 @@ Python test functions for breadcrumb detection validation
 import os
 import sys
 import json
+import abc
+import functools
+from dataclasses import dataclass
 from typing import Dict, List, Any
 from datetime import datetime
 
@@ -85,6 +88,61 @@ def closure_function(multiplier: int):
     return multiply
 
 
+
+def retry(times: int):
+    """Decorator factory built from nested closures"""
+    def decorator(func):
+        @functools.wraps(func)
+        async def wrapper(*args, **kwargs):
+            for _ in range(times):
+                try:
+                    return await func(*args, **kwargs)
+                except Exception:
+                    continue
+        return wrapper
+    return decorator
+
+
+@dataclass
+class Account(BaseModel):
+    """Class using properties, abstract and async methods"""
+    balance: int = 0
+
+    @property
+    def is_empty(self) -> bool:
+        # @codebase-summary: Property reporting whether the account has no balance
+        return self.balance == 0
+
+    @is_empty.setter
+    def is_empty(self, value: bool):
+        self.balance = 0 if value else self.balance
+
+    @functools.cached_property
+    def summary(self) -> str:
+        return f"Account({self.balance})"
+
+    @abc.abstractmethod
+    def close(self):
+        """Subclasses decide how accounts close"""
+
+    @retry(times=3)
+    async def refresh(self) -> None:
+        """Async method wrapped by a decorator factory"""
+
+    class Meta:
+        table = "accounts"
+
+        def describe(self):
+            return self.table
+
+
+try:
+    import ujson as fast_json
+except ImportError:
+    def fast_dumps(obj):
+        """Fallback defined inside an except block"""
+        return json.dumps(obj)
+
 if __name__ == "__main__":
     def main():
         """Main function for test execution"""
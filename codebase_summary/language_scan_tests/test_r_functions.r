@@ -117,4 +117,70 @@ process_data <- function(data, na.rm = TRUE) {
 # Undocumented utility function
 utility_function <- function(vec) {
   vec[vec > mean(vec)]
-}
\ No newline at end of file
+}
+
+#' Scale a numeric vector to the unit interval
+#'
+#' Roxygen2 blocks count as documentation.
+#' @param x A numeric vector.
+#' @return The rescaled vector.
+#' @export
+rescale <- function(x) {
+  rng <- range(x, na.rm = TRUE)
+  (x - rng[1]) / (rng[2] - rng[1])
+}
+
+#' Lambda shorthand assignment
+square <- \(x) x^2
+
+`%+%` <- function(a, b) paste0(a, b)
+
+label <- "fake <- function() {"  # strings don't define functions
+
+#' Shape classes (S4)
+setClass("Circle", representation(radius = "numeric"))
+
+#' Area generic
+setGeneric("area", function(shape) standardGeneric("area"))
+
+setMethod("area", "Circle", function(shape) {
+  pi * shape@radius^2
+})
+
+setMethod("show", signature("Circle"), function(object) {
+  cat("Circle of radius", object@radius, "\n")
+})
+
+#' Bank account (R6)
+#' @export
+Account <- R6::R6Class("Account",
+  public = list(
+    balance = 0,
+    #' @description Deposit money.
+    deposit = function(amount) {
+      self$balance <- self$balance + amount
+      invisible(self)
+    },
+    withdraw = function(amount) {
+      private$check(amount)
+      self$balance <- self$balance - amount
+    }
+  ),
+  private = list(
+    check = function(amount) {
+      if (amount > self$balance) stop("insufficient funds")
+    }
+  ),
+  active = list(
+    overdrawn = function() self$balance < 0
+  )
+)
+
+Person <- setRefClass("Person",
+  fields = list(name = "character"),
+  methods = list(
+    greet = function() {
+      cat("Hello,", name, "\n")
+    }
+  )
+)
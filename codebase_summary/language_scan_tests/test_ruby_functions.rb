@@ -102,6 +102,52 @@ rescue StandardError => e
   "Error: #{e.message}"
 end
 
+module Billing
+  module Gateways
+    class Stripe < Base
+      attr_reader :api_key, :account
+      attr_writer :timeout
+
+      class << self
+        def configure(options = {})
+          @options = options
+        end
+      end
+
+      def self.connect(key)
+        new(key)
+      end
+
+      def initialize(api_key)
+        @api_key = api_key
+        [1, 2].each do |n|
+          log(n) if n > 1
+        end
+      end
+
+      def charged?(invoice) = invoice.paid
+
+      private def log(message)
+        puts <<~MSG
+          Stripe: #{message}
+          end
+        MSG
+      end
+
+      define_method(:refund) { |id| "refund #{id}" }
+
+      %w[pending settled].each do |state|
+        define_method("#{state}?") { @state == state }
+      end
+    end
+  end
+end
+
+=begin
+def commented_out
+end
+=end
+
 def main
   puts "Ruby method tests ready"
   result = basic_method
@@ -74,6 +74,59 @@ const fn const_function(x: i32, y: i32) -> i32 {
     x + y
 }
 
+pub(crate) unsafe fn unsafe_function(ptr: *const u8) -> u8 {
+    *ptr
+}
+
+macro_rules! square {
+    ($x:expr) => {
+        $x * $x
+    };
+}
+
+#[macro_export]
+macro_rules! make_greeting {
+    ($name:expr) => {
+        format!("Hello, {}!", $name)
+    };
+}
+
+trait DefaultTrait {
+    fn required_method(&self) -> u32;
+
+    fn provided_method(&self) -> u32 {
+        self.required_method() + 1
+    }
+}
+
+struct Wrapper<T> {
+    inner: T,
+}
+
+impl<T: Display + Clone> Wrapper<T> {
+    pub async fn async_method(&self) -> String {
+        format!("{}", self.inner)
+    }
+
+    fn multi_line_signature(
+        &self,
+        suffix: &str,
+    ) -> String {
+        format!("{}{}", self.inner, suffix)
+    }
+}
+
+impl<T: Display + Clone> DefaultTrait for Wrapper<T> {
+    fn required_method(&self) -> u32 {
+        42
+    }
+}
+
+enum TestEnum {
+    First,
+    Second,
+}
+
 fn main() {
     println!("Rust function tests ready");
     let _ = basic_function();
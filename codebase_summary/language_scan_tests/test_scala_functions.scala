@@ -98,4 +98,35 @@ implicit def stringToInt(s: String): Int = {
 // Lazy val function
 lazy val lazyFunction: () => String = () => {
   "lazy evaluation"
-}
\ No newline at end of file
+}
+/** Scaladoc-documented companion pair. */
+case class Money(amount: BigDecimal, currency: String)
+
+object Money:
+  /** Zero in the given currency. */
+  def zero(currency: String): Money = Money(0, currency)
+
+  val parse: String => Option[Money] = raw =>
+    raw.split(" ") match
+      case Array(a, c) => Some(Money(BigDecimal(a), c))
+      case _ => None
+
+  given moneyOrdering: Ordering[Money] with
+    def compare(x: Money, y: Money): Int = x.amount.compare(y.amount)
+
+  given Ordering[String] = Ordering.String
+
+extension (m: Money)
+  def +(other: Money): Money = m.copy(amount = m.amount + other.amount)
+  def isZero: Boolean = m.amount == 0
+
+case object Unknown
+
+implicit class RichString(val s: String) extends AnyVal {
+  @deprecated("use trimmed", "2.0")
+  def clean: String = s.trim
+}
+
+trait Repository[F[_]]:
+  def load(id: String): F[Money]
+  var retries: Int = 3
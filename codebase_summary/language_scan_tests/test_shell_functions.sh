@@ -152,4 +152,20 @@ array_operations() {
     my_array+=("four")
     echo "${my_array[@]}"
     echo "Array length: ${#my_array[@]}"
-}
\ No newline at end of file
+}
+bare_function() {
+    echo "no comment block above"
+}
+
+function keyword_no_parens {
+    cat <<-'USAGE'
+	usage() {
+	  not a function - heredoc text
+	}
+	USAGE
+}
+
+# Subshell-bodied function keeps its cd local
+in_tmp() (
+    cd /tmp && "$@"
+)
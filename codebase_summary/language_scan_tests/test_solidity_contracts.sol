@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// Solidity test contracts for contract, function, modifier, and event extraction validation
+pragma solidity ^0.8.20;
+
+import "./IERC20.sol";
+
+/// @title Minimal token interface
+interface IToken {
+    /// @notice Emitted when tokens move between accounts.
+    event Transfer(address indexed from, address indexed to, uint256 value);
+
+    function balanceOf(address account) external view returns (uint256);
+    function transfer(address to, uint256 amount) external returns (bool);
+}
+
+/**
+ * @title Ownable base contract
+ * @notice Block NatSpec comments count as documentation.
+ */
+abstract contract Ownable {
+    address public owner;
+
+    event OwnershipTransferred(address indexed previousOwner, address indexed newOwner);
+
+    /// @notice Restricts a function to the owner.
+    modifier onlyOwner {
+        require(msg.sender == owner, "Ownable: caller is not the owner {");
+        _;
+    }
+
+    constructor() {
+        owner = msg.sender;
+    }
+
+    function renounceOwnership() public virtual onlyOwner;
+}
+
+library SafeMath {
+    /// @dev Adds two numbers, reverting on overflow.
+    function add(uint256 a, uint256 b) internal pure returns (uint256) {
+        return a + b;
+    }
+}
+
+contract Vault is Ownable, IToken {
+    using SafeMath for uint256;
+
+    mapping(address => uint256) private balances;
+    string private constant URL = "https://example.com/*not-a-comment*/";
+
+    struct Deposit {
+        address from;
+        uint256 amount;
+    }
+
+    modifier nonZero(uint256 amount) {
+        require(amount > 0, "zero");
+        _;
+    }
+
+    /// @notice Deposit ether into the vault.
+    /// @param memo Free-form note stored with the deposit.
+    function deposit(string calldata memo)
+        external
+        payable
+        nonZero(msg.value)
+    {
+        balances[msg.sender] = balances[msg.sender].add(msg.value);
+    }
+
+    function balanceOf(address account) external view override returns (uint256) {
+        return balances[account];
+    }
+
+    function transfer(address to, uint256 amount) external override returns (bool) {
+        balances[msg.sender] -= amount;
+        balances[to] += amount;
+        emit Transfer(msg.sender, to, amount);
+        return true;
+    }
+
+    /// @inheritdoc Ownable
+    function renounceOwnership() public override onlyOwner {
+        owner = address(0);
+    }
+
+    function _split(uint256 amount) private pure returns (uint256 half, uint256 rest) {
+        half = amount / 2;
+        rest = amount - half;
+    }
+
+    receive() external payable {}
+
+    fallback() external payable {
+        revert("unsupported");
+    }
+}
+
+/// @notice Free functions live outside any contract.
+function clamp(uint256 value, uint256 max) pure returns (uint256) {
+    return value > max ? max : value;
+}
@@ -136,4 +136,40 @@ BEGIN
     ORDER BY t.created_at DESC
     LIMIT limit_count;
 END;
-$$ LANGUAGE plpgsql;
\ No newline at end of file
+$$ LANGUAGE plpgsql;
+
+-- @codebase-summary: Keeps updated_at current on every row change
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON billing.invoices
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_function();
+
+CREATE TRIGGER audit_invoice_changes
+AFTER INSERT OR UPDATE OR DELETE ON invoices
+FOR EACH ROW EXECUTE PROCEDURE audit.log_change();
+
+CREATE OR REPLACE PROCEDURE billing.close_period(period_end DATE)
+LANGUAGE plpgsql
+AS $proc$
+BEGIN
+    -- Dynamic SQL inside the body is not a new routine
+    EXECUTE 'CREATE FUNCTION should_not_match() RETURNS void AS $x$ $x$ LANGUAGE sql';
+    UPDATE billing.invoices SET closed = TRUE WHERE issued_at <= period_end;
+END;
+$proc$;
+
+COMMENT ON PROCEDURE billing.close_period IS 'Closes every invoice issued up to period_end.';
+
+/*
+CREATE FUNCTION commented_out() RETURNS void AS $$ $$ LANGUAGE sql;
+*/
+
+-- MySQL-style routine
+CREATE DEFINER=`admin`@`localhost` FUNCTION `order_total`(order_id INT)
+RETURNS DECIMAL(10, 2)
+DETERMINISTIC
+BEGIN
+    RETURN (SELECT SUM(amount) FROM order_lines WHERE order_lines.order_id = order_id);
+END;
+
+COMMENT ON FUNCTION basic_function() IS 'Returns a constant test string.';
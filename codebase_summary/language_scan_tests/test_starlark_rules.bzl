@@ -0,0 +1,37 @@
+# Starlark test definitions for Bazel rule and macro extraction validation
+load("@bazel_skylib//lib:paths.bzl", "paths")
+
+GreetingInfo = provider(
+    doc = "Carries the rendered greeting file.",
+    fields = ["output", "language"],
+)
+
+def _greeting_impl(ctx):
+    out = ctx.actions.declare_file(ctx.label.name + ".txt")
+    ctx.actions.write(out, ctx.attr.message)
+    return [DefaultInfo(files = depset([out])), GreetingInfo(output = out, language = "en")]
+
+greeting = rule(
+    implementation = _greeting_impl,
+    doc = "Writes a greeting to a text file.",
+    attrs = {
+        "message": attr.string(mandatory = True),
+        "deps": attr.label_list(),
+    },
+)
+
+toolchain_repo = repository_rule(
+    implementation = _greeting_impl,
+    attrs = {"version": attr.string()},
+)
+
+def greeting_suite(name, messages, **kwargs):
+    """Macro creating one greeting target per message.
+
+    Docstrings count as documentation.
+    """
+    for i, message in enumerate(messages):
+        greeting(name = "%s_%d" % (name, i), message = message, **kwargs)
+
+def undocumented_macro(name):
+    native.filegroup(name = name, srcs = native.glob(["*.txt"]))
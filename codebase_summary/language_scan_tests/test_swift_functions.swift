@@ -119,6 +119,62 @@ extension TestClass {
     }
 }
 
+/// A view model that bridges to Objective-C.
+@MainActor
+final class ProfileViewModel: NSObject {
+    private(set) var names: [String] = []
+
+    /// Display title built from the stored names.
+    var title: String {
+        return names.joined(separator: ", ")
+    }
+
+    var count: Int {
+        get { names.count }
+        set { names = Array(repeating: "", count: newValue) }
+    }
+
+    init?(names: [String]) {
+        guard !names.isEmpty else { return nil }
+        self.names = names
+    }
+
+    convenience override init() {
+        self.init(names: ["default"])!
+    }
+
+    deinit {
+        names.removeAll()
+    }
+
+    @objc func refresh(_ sender: Any?) {
+        names.sort()
+    }
+
+    @available(iOS 15.0, *)
+    @objc(loadWithCompletion:)
+    public func load(
+        completion: @escaping ([String]) -> Void
+    ) async throws {
+        completion(names)
+    }
+}
+
+protocol Identifiable {
+    var id: String { get }
+    var label: String { get set }
+    init(id: String)
+    func describe() -> String
+}
+
+extension ProfileViewModel {
+    var isEmpty: Bool { names.isEmpty }
+
+    static func == (lhs: ProfileViewModel, rhs: ProfileViewModel) -> Bool {
+        return lhs.names == rhs.names
+    }
+}
+
 func main() {
     print("Swift function tests ready")
     let result = basicFunction()
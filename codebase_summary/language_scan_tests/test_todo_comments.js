@@ -0,0 +1,19 @@
+// TODO/FIXME/HACK/XXX comments for the technical-debt inventory
+
+// TODO: paginate instead of loading every record
+function loadRecords(db) {
+    return db.all(); // FIXME(dana): leaks the connection on error
+}
+
+/* HACK: the API drops trailing slashes, so add one back */
+function normalizeUrl(url) {
+    const label = "TODO list";  // not a comment tag: inside a string
+    return url.endsWith("/") ? url : url + "/";
+}
+
+/**
+ * XXX - remove once every client sends the version header
+ */
+function legacyVersion(request) {
+    return request.headers["x-version"] || "1";
+}
@@ -0,0 +1,93 @@
+// TSX test file for the TypeScript/TSX extractor
+// Covers React components, hooks, decorators, generics and overloads
+
+import React, { useState, useEffect, forwardRef, memo } from 'react';
+
+interface ButtonProps {
+    label: string;
+    onClick: () => void;
+}
+
+// @codebase-summary: Documented function component
+export function Button({ label, onClick }: ButtonProps) {
+    return <button onClick={onClick}>{label}</button>;
+}
+
+export const Card: React.FC<{ title: string }> = ({ title, children }) => {
+    return (
+        <div className="card">
+            <h2>{title}</h2>
+            {children}
+        </div>
+    );
+};
+
+// @codebase-summary: Generic list component with a type parameter
+export const List = <T,>(props: {
+    items: T[];
+    render: (item: T) => React.ReactNode;
+}) => {
+    return <ul>{props.items.map(props.render)}</ul>;
+};
+
+export const FancyInput = forwardRef<HTMLInputElement, { value: string }>((props, ref) => (
+    <input ref={ref} value={props.value} />
+));
+
+const MemoizedRow = memo(function Row({ text }: { text: string }) {
+    return <li>{text}</li>;
+});
+
+// @codebase-summary: Custom hook tracking window width
+export function useWindowWidth(): number {
+    const [width, setWidth] = useState(window.innerWidth);
+    useEffect(() => {
+        const handler = () => setWidth(window.innerWidth);
+        window.addEventListener('resize', handler);
+        return () => window.removeEventListener('resize', handler);
+    }, []);
+    return width;
+}
+
+export const useToggle = (initial = false) => {
+    const [on, setOn] = useState(initial);
+    return [on, () => setOn(!on)] as const;
+};
+
+function formatLabel(value: string): string;
+function formatLabel(value: number): string;
+function formatLabel(value: string | number): string {
+    return `label: ${value}`;
+}
+
+function Injectable(target: any) {
+    return target;
+}
+
+// @codebase-summary: Decorated service class
+@Injectable
+export class DataService<T extends object> {
+    private cache = new Map<string, T>();
+
+    @Memoize()
+    async fetchItem(id: string): Promise<T | undefined> {
+        return this.cache.get(id);
+    }
+
+    handleChange = (event: React.ChangeEvent<HTMLInputElement>) => {
+        console.log(event.target.value);
+    };
+
+    get size(): number {
+        return this.cache.size;
+    }
+}
+
+declare module 'external-widgets' {
+    export function widget(name: string): void;
+}
+
+export default function App() {
+    const width = useWindowWidth();
+    return <Card title={`Width ${width}`}><Button label="ok" onClick={() => {}} /></Card>;
+}
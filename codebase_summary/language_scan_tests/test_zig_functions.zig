@@ -0,0 +1,88 @@
+//! Zig test functions for breadcrumb detection validation
+const std = @import("std");
+
+/// Adds two integers - documented with a /// doc comment.
+pub fn add(a: i32, b: i32) i32 {
+    return a + b;
+}
+
+// Function without breadcrumb documentation
+fn undocumentedFunction() bool {
+    return true;
+}
+
+export fn exportedFunction(x: u32) u32 {
+    return x * 2;
+}
+
+extern "c" fn printf(format: [*:0]const u8, ...) c_int;
+
+inline fn square(x: f64) f64 {
+    return x * x;
+}
+
+pub fn max(comptime T: type, a: T, b: T) T {
+    return if (a > b) a else b;
+}
+
+/// A generic stack built at comptime.
+pub fn Stack(comptime T: type) type {
+    return struct {
+        items: []T,
+        len: usize = 0,
+
+        const Self = @This();
+
+        pub fn push(self: *Self, item: T) void {
+            self.items[self.len] = item;
+            self.len += 1;
+        }
+
+        pub fn init(items: []T) Self {
+            return .{ .items = items };
+        }
+    };
+}
+
+pub const Point = struct {
+    x: f32,
+    y: f32,
+
+    /// Distance from the origin.
+    pub fn length(self: Point) f32 {
+        return @sqrt(self.x * self.x + self.y * self.y);
+    }
+
+    pub fn scale(
+        p: *const Point,
+        factor: f32,
+    ) Point {
+        return .{ .x = p.x * factor, .y = p.y * factor };
+    }
+
+    fn origin() Point {
+        return .{ .x = 0, .y = 0 };
+    }
+};
+
+const Color = enum(u8) {
+    red,
+    green,
+
+    pub fn isWarm(self: Color) bool {
+        return self == .red;
+    }
+};
+
+const banner =
+    \\ fn notAFunction() {
+    \\ }
+;
+
+test "add works" {
+    try std.testing.expect(add(1, 2) == 3);
+}
+
+pub fn main() !void {
+    std.debug.print("Zig function tests ready\n", .{});
+}
@@ -0,0 +1,93 @@
+#!/usr/bin/env python3
+"""
+License Headers - Required license/copyright header check for every scanned source file
+Templates come from arkival.yaml's license_header (per language, or "*") or --license-header FILE and
+are matched against the lines the scanner already read, so the check adds no extra file reads
+"""
+
+import re
+from typing import Dict, Any, List, Optional, Union
+
+# Headers must start within this many lines (after shebangs, encoding lines, and blank lines)
+HEADER_WINDOW = 30
+# Comment syntax stripped from both template and file lines before comparing
+_COMMENT_MARKERS = re.compile(r"^(?:<!--|-->|/\*+|\*+/|\*|//+|#+|--+|;+|%+|'|\"{3}|'{3}|\(\*|\*\)|\{-|-\})\s?")
+_TRAILING_MARKERS = re.compile(r"\s*(?:\*+/|-->|\*\)|-\})$")
+_PLACEHOLDERS = {
+    "{year}": r"\d{4}(?:\s*[-–,]\s*\d{4})*",
+    "{holder}": r".+?",
+    "{any}": r".*?",
+}
+
+
+def _normalize(line: str) -> str:
+    """A header line without its comment markers and surrounding whitespace"""
+    text = line.strip()
+    for _ in range(2):
+        text = _COMMENT_MARKERS.sub("", text).strip()
+    return _TRAILING_MARKERS.sub("", text).strip()
+
+
+def compile_template(template: Union[str, List[str]]) -> List["re.Pattern"]:
+    """
+    # @codebase-summary: One regex per non-empty template line
+    - Comment markers in the template are ignored, so the same header works for '#' and '//' languages
+    - {year} matches a year, a range, or a list ('2019-2024'); {holder} and {any} match any text
+    """
+    lines = template.split("\n") if isinstance(template, str) else template
+    patterns = []
+    for line in lines:
+        text = _normalize(str(line))
+        if not text:
+            continue
+        regex = re.escape(text)
+        for placeholder, replacement in _PLACEHOLDERS.items():
+            regex = regex.replace(re.escape(placeholder), replacement)
+        patterns.append(re.compile(regex))
+    return patterns
+
+
+def compile_templates(definitions: Dict[str, Any]) -> Dict[str, Optional[List["re.Pattern"]]]:
+    """
+    {language | "*": template} from the config; false (or null) exempts a language from the "*" header.
+    Raises ValueError for templates that are neither text nor a list of lines
+    """
+    templates: Dict[str, Optional[List["re.Pattern"]]] = {}
+    for language, template in definitions.items():
+        if template is False or template is None:
+            templates[str(language).lower()] = None
+        elif isinstance(template, (str, list)) and compile_template(template):
+            templates[str(language).lower()] = compile_template(template)
+        else:
+            raise ValueError(f"license_header for '{language}' must be header text, a list of lines, or false")
+    return templates
+
+
+def template_for(templates: Dict[str, Optional[List["re.Pattern"]]], language: str) -> Optional[List["re.Pattern"]]:
+    """The header a language must carry: its own entry, else "*", else None (no requirement)"""
+    return templates[language] if language in templates else templates.get("*")
+
+
+def has_header(lines: List[str], patterns: List["re.Pattern"]) -> bool:
+    """Whether the template's lines appear, in order and consecutively, near the top of the file"""
+    head = [_normalize(line) for line in lines[:HEADER_WINDOW]]
+    head = [line for line in head if line]
+    for start in range(len(head) - len(patterns) + 1):
+        if all(pattern.fullmatch(head[start + i]) for i, pattern in enumerate(patterns)):
+            return True
+    return False
+
+
+def summarize_license_headers(checked: int, violations: List[Dict[str, Any]], limit: int = 50) -> Dict[str, Any]:
+    """Summary section: how many files carry the required header, and the ones that don't"""
+    by_language: Dict[str, int] = {}
+    for violation in violations:
+        by_language[violation["language"]] = by_language.get(violation["language"], 0) + 1
+    return {
+        "checked_files": checked,
+        "compliant_files": checked - len(violations),
+        "violations": len(violations),
+        "compliance_percentage": round((checked - len(violations)) / checked * 100, 2) if checked else 100.0,
+        "by_language": dict(sorted(by_language.items(), key=lambda item: (-item[1], item[0]))),
+        "missing": [violation["file"] for violation in violations[:limit]],
+    }
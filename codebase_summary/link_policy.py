@@ -0,0 +1,142 @@
+#!/usr/bin/env python3
+"""
+Link Policy - Symlink handling, cycle detection, and hardlink deduplication for the directory walk
+--symlinks follow (default) reads linked files and enters linked directories outside the project, skip
+ignores every symlink, and error fails the scan on the first one found; a file reachable through several
+links or hardlinks is scanned once, so file and function counts are not inflated
+"""
+
+import os
+import stat
+from pathlib import Path
+from typing import Dict, Any, Callable, Iterator, List, Optional, Set, Tuple
+
+SYMLINK_POLICIES = ("follow", "skip", "error")
+
+
+class LinkWalker:
+    """
+    # @codebase-summary: os.walk replacement that applies the symlink policy and visits each file once
+    - A link whose target is inside the scan root is a second path to files the walk reaches anyway and is
+      never followed; links to outside targets are followed (follow), ignored (skip), or recorded (error)
+    - Directories are tracked by (device, inode), so a link back to an ancestor is a cycle, not an endless walk
+    - Hardlinked files (and outside files reached through two links) keep the first path in walk order
+    """
+
+    def __init__(self, root: Path, policy: str = "follow"):
+        self.root = Path(root)
+        self.policy = policy
+        self.real_root = os.path.realpath(self.root)
+        self.links: List[Dict[str, Any]] = []  # every symlink met: {path, target, kind, action}
+        self.duplicates: List[Dict[str, str]] = []  # {path, same_as} for hardlinks and repeated outside files
+        self._dirs: Set[Tuple[int, int]] = set()
+        self._files: Dict[Tuple[int, int], str] = {}
+
+    def _relative(self, path: Path) -> str:
+        return os.path.relpath(path, self.root)
+
+    def _inside_root(self, real: str) -> bool:
+        return real == self.real_root or real.startswith(self.real_root.rstrip(os.sep) + os.sep)
+
+    def _link(self, path: Path, kind: str) -> Dict[str, Any]:
+        """Record a symlink; its action is 'followed' when the walk should read or enter the target"""
+        real = os.path.realpath(path)
+        try:
+            target = os.readlink(path)
+        except OSError:
+            target = real
+        entry = {"path": self._relative(path), "target": target, "kind": kind}
+        self.links.append(entry)
+        if self.policy != "follow":
+            entry["action"] = "error" if self.policy == "error" else "skipped"
+        elif not os.path.exists(real):
+            entry["action"] = "broken"
+        elif self._inside_root(real):
+            entry["action"] = "inside project"
+        else:
+            entry["action"] = "followed"
+        return entry
+
+    def _enter(self, path: Path) -> bool:
+        """Whether the walk descends into a directory: links by policy, any directory only once"""
+        link = self._link(path, "directory") if os.path.islink(path) else None
+        if link is not None and link["action"] != "followed":
+            return False
+        try:
+            info = os.stat(path)
+        except OSError:
+            return False
+        key = (info.st_dev, info.st_ino)
+        if key in self._dirs:
+            if link is not None:
+                link["action"] = "cycle"
+            return False
+        self._dirs.add(key)
+        return True
+
+    def _keep(self, path: Path) -> bool:
+        """Whether a file is scanned: links by policy, hardlinks and repeated targets only on their first path"""
+        try:
+            info = os.lstat(path)
+        except OSError:
+            return False
+        if stat.S_ISLNK(info.st_mode):
+            if self._link(path, "file")["action"] != "followed":
+                return False
+            try:
+                info = os.stat(path)
+            except OSError:
+                return False
+        elif info.st_nlink <= 1:
+            return True
+        key = (info.st_dev, info.st_ino)
+        first = self._files.setdefault(key, self._relative(path))
+        if first != self._relative(path):
+            self.duplicates.append({"path": self._relative(path), "same_as": first})
+            return False
+        return True
+
+    def walk(self, top: Optional[Path] = None,
+             ignore: Optional[Callable[[Path], bool]] = None) -> Iterator[Tuple[str, List[str], List[str]]]:
+        """
+        # @codebase-summary: (root, dirs, files) like os.walk(top), filtered by ignore rules and the link policy
+        - dirs and files arrive sorted and already pruned, so ignored paths are never recorded as links
+        - Callers may prune dirs further in place, as with os.walk
+        """
+        top = Path(top or self.root)
+        try:
+            info = os.stat(top)
+            self._dirs.add((info.st_dev, info.st_ino))
+        except OSError:
+            return
+        for root, dirs, files in os.walk(top, followlinks=self.policy == "follow"):
+            root_path = Path(root)
+            dirs[:] = [name for name in sorted(dirs)
+                       if not (ignore and ignore(root_path / name)) and self._enter(root_path / name)]
+            files = [name for name in sorted(files)
+                     if not (ignore and ignore(root_path / name)) and self._keep(root_path / name)]
+            yield root, dirs, files
+
+    def errors(self) -> List[Dict[str, Any]]:
+        """Symlinks that make the scan fail under --symlinks error"""
+        return [entry for entry in self.links if entry.get("action") == "error"]
+
+    def summary(self, limit: int = 50) -> Dict[str, Any]:
+        """project_structure section: policy, symlinks by outcome, and deduplicated files"""
+        return summarize_links(self.policy, self.links, self.duplicates, limit)
+
+
+def summarize_links(policy: str, links: List[Dict[str, Any]], duplicates: List[Dict[str, str]],
+                    limit: int = 50) -> Dict[str, Any]:
+    """'links' section of project_structure for any walker that records links and duplicates"""
+    by_action: Dict[str, int] = {}
+    for entry in links:
+        by_action[entry["action"]] = by_action.get(entry["action"], 0) + 1
+    return {
+        "policy": policy,
+        "symlinks": len(links),
+        "by_action": dict(sorted(by_action.items())),
+        "listed": links[:limit],
+        "duplicate_files": len(duplicates),
+        "duplicates": duplicates[:limit],
+    }
@@ -0,0 +1,180 @@
+#!/usr/bin/env python3
+"""
+LLM Summaries - Prose summaries of each module (directory) from an LLM endpoint (--llm-summaries)
+The symbol outline of every directory is sent to an OpenAI-compatible chat API or a local Ollama server;
+answers are cached by the outline's content hash, so only modules whose symbols changed are sent again
+"""
+
+import json
+import hashlib
+import urllib.request
+import urllib.error
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from summary_diff import index_entry
+from memory_budget import in_file_order
+
+# Bump when the prompt changes so cached answers to the old prompt are not reused
+PROMPT_VERSION = 1
+DEFAULT_BACKEND = "openai"
+DEFAULT_ENDPOINTS = {"openai": "https://api.openai.com/v1", "ollama": "http://localhost:11434"}
+DEFAULT_MODELS = {"openai": "gpt-4o-mini", "ollama": "llama3.1"}
+DEFAULT_MAX_MODULES = 50
+DEFAULT_TIMEOUT = 60
+# Outline lines sent per module; larger modules are cut with a '... N more lines' line
+MAX_OUTLINE_LINES = 200
+# Consecutive failed requests after which the endpoint is treated as down for the rest of the run
+MAX_CONSECUTIVE_FAILURES = 3
+
+SYSTEM_PROMPT = ("You write concise technical documentation. Given the symbol outline of one source directory, "
+                 "describe in 2-4 sentences what the module is responsible for and how its main pieces fit "
+                 "together. Do not list every symbol and do not speculate beyond the outline.")
+
+
+class OpenAICompatibleBackend:
+    """Chat completions API: OpenAI, Azure-style gateways, vLLM, LM Studio, or Ollama's /v1 endpoint"""
+
+    name = "openai"
+
+    def __init__(self, endpoint: str, model: str, api_key: Optional[str] = None, timeout: int = DEFAULT_TIMEOUT):
+        self.endpoint, self.model, self.api_key, self.timeout = endpoint.rstrip("/"), model, api_key, timeout
+
+    def complete(self, system: str, prompt: str) -> str:
+        """Text of the first choice's message"""
+        body = {"model": self.model, "temperature": 0.2,
+                "messages": [{"role": "system", "content": system}, {"role": "user", "content": prompt}]}
+        headers = {"Authorization": f"Bearer {self.api_key}"} if self.api_key else {}
+        response = _post_json(f"{self.endpoint}/chat/completions", body, headers, self.timeout)
+        return response["choices"][0]["message"]["content"].strip()
+
+
+class OllamaBackend:
+    """Ollama's native chat API (non-streaming)"""
+
+    name = "ollama"
+
+    def __init__(self, endpoint: str, model: str, api_key: Optional[str] = None, timeout: int = DEFAULT_TIMEOUT):
+        self.endpoint, self.model, self.timeout = endpoint.rstrip("/"), model, timeout
+
+    def complete(self, system: str, prompt: str) -> str:
+        """Text of the reply message"""
+        body = {"model": self.model, "stream": False, "options": {"temperature": 0.2},
+                "messages": [{"role": "system", "content": system}, {"role": "user", "content": prompt}]}
+        return _post_json(f"{self.endpoint}/api/chat", body, {}, self.timeout)["message"]["content"].strip()
+
+
+BACKENDS = {"openai": OpenAICompatibleBackend, "ollama": OllamaBackend}
+
+
+def _post_json(url: str, body: Dict[str, Any], headers: Dict[str, str], timeout: int) -> Dict[str, Any]:
+    """POST a JSON body and decode the JSON reply; raises RuntimeError with the server's message on failure"""
+    request = urllib.request.Request(url, data=json.dumps(body).encode("utf-8"), method="POST",
+                                     headers={"Content-Type": "application/json", **headers})
+    try:
+        with urllib.request.urlopen(request, timeout=timeout) as response:
+            return json.loads(response.read().decode("utf-8"))
+    except urllib.error.HTTPError as e:
+        detail = e.read().decode("utf-8", errors="replace")[:200]
+        raise RuntimeError(f"HTTP {e.code} from {url}: {detail}")
+    except (urllib.error.URLError, OSError, ValueError) as e:
+        raise RuntimeError(f"{url}: {getattr(e, 'reason', e)}")
+
+
+def make_backend(name: str, endpoint: Optional[str], model: Optional[str], api_key: Optional[str],
+                 timeout: int = DEFAULT_TIMEOUT):
+    """Backend instance by name with default endpoint and model; raises ValueError for unknown backends"""
+    if name not in BACKENDS:
+        raise ValueError(f"unknown LLM backend '{name}' (choose from {', '.join(BACKENDS)})")
+    return BACKENDS[name](endpoint or DEFAULT_ENDPOINTS[name], model or DEFAULT_MODELS[name], api_key, timeout)
+
+
+def module_outlines(file_analysis: List[Dict[str, Any]]) -> Dict[str, Dict[str, Any]]:
+    """
+    # @codebase-summary: Symbol outline of every directory with counted symbols
+    - Each module lists its files with one symbol-index line per symbol ('+ method Box.open (key)');
+      documentation-exempt symbols (tests, generated code) are left out
+    - Returns {directory: {outline, files, symbols}}; '.' is the project root
+    """
+    by_directory: Dict[str, List[Tuple[str, List[str]]]] = defaultdict(list)
+    for analysis in in_file_order(file_analysis):
+        entries = [index_entry(symbol) for symbol in analysis.get("symbols", []) if not symbol.get("doc_exempt")]
+        if entries:
+            by_directory[Path(analysis["file"]).parent.as_posix()].append((Path(analysis["file"]).name, entries))
+    modules = {}
+    for directory, files in by_directory.items():
+        lines = []
+        for name, entries in files:
+            lines.append(f"{name}:")
+            lines.extend(f"  {entry}" for entry in entries)
+        if len(lines) > MAX_OUTLINE_LINES:
+            lines = lines[:MAX_OUTLINE_LINES] + [f"... {len(lines) - MAX_OUTLINE_LINES} more lines"]
+        modules[directory] = {"outline": "\n".join(lines), "files": len(files),
+                              "symbols": sum(len(entries) for _, entries in files)}
+    return modules
+
+
+def content_hash(outline: str, model: str) -> str:
+    """Cache key of a module's answer: its outline, the model, and the prompt version"""
+    return hashlib.sha256(f"{PROMPT_VERSION}\n{model}\n{outline}".encode("utf-8")).hexdigest()
+
+
+def load_cache(path: Path) -> Dict[str, Dict[str, Any]]:
+    """Cached answers by content hash, or {} when there is no readable cache"""
+    try:
+        with open(path, "r", encoding="utf-8") as f:
+            return json.load(f).get("summaries", {})
+    except (OSError, ValueError, AttributeError):
+        return {}
+
+
+def save_cache(path: Path, entries: Dict[str, Dict[str, Any]]):
+    """Write the answers used by this run (older ones are dropped)"""
+    path.parent.mkdir(parents=True, exist_ok=True)
+    with open(path, "w", encoding="utf-8") as f:
+        json.dump({"prompt_version": PROMPT_VERSION, "summaries": entries}, f, indent=2, sort_keys=True)
+
+
+def summarize_modules(file_analysis: List[Dict[str, Any]], backend, cache: Dict[str, Dict[str, Any]],
+                      max_modules: int = DEFAULT_MAX_MODULES) -> Tuple[Dict[str, Any], Dict[str, Dict[str, Any]]]:
+    """
+    # @codebase-summary: Module summaries section and the cache entries to keep
+    - The max_modules directories with the most symbols are summarized; a module whose outline hash is
+      in the cache reuses that answer without a request
+    - After MAX_CONSECUTIVE_FAILURES failed requests the endpoint is assumed down and the remaining
+      uncached modules are skipped; failures are listed under errors
+    """
+    outlines = module_outlines(file_analysis)
+    ranked = sorted(outlines, key=lambda d: (-outlines[d]["symbols"], d))[:max_modules]
+    modules, kept, errors = {}, {}, []
+    generated = failures = 0
+    for directory in sorted(ranked):
+        module = outlines[directory]
+        key = content_hash(module["outline"], backend.model)
+        entry = cache.get(key)
+        if entry is None:
+            if failures >= MAX_CONSECUTIVE_FAILURES:
+                continue
+            try:
+                entry = {"directory": directory, "summary": backend.complete(
+                    SYSTEM_PROMPT, f"Directory: {directory}/\n\n{module['outline']}")}
+                generated += 1
+                failures = 0
+            except (RuntimeError, KeyError, IndexError, TypeError, AttributeError) as e:
+                failures += 1
+                errors.append({"directory": directory, "error": str(e)})
+                continue
+        kept[key] = entry
+        modules[directory] = {"summary": entry["summary"], "files": module["files"], "symbols": module["symbols"],
+                              "content_hash": key, "cached": entry is cache.get(key)}
+    return {
+        "backend": backend.name,
+        "model": backend.model,
+        "endpoint": backend.endpoint,
+        "modules_considered": len(outlines),
+        "generated": generated,
+        "reused": sum(1 for module in modules.values() if module["cached"]),
+        "modules": modules,
+        "errors": errors,
+    }, kept
@@ -0,0 +1,257 @@
+#!/usr/bin/env python3
+"""
+LSP Server - Breadcrumb diagnostics and stub code actions for editors (VS Code, Neovim, ...)
+A minimal Language Server Protocol server on stdio: open and edited buffers are analyzed in memory
+with the scanner's own rules, so the squiggles match what coverage counts without running the CLI
+"""
+
+import difflib
+import json
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, BinaryIO
+from urllib.parse import urlparse, unquote
+
+import breadcrumb_styles
+import doc_drift
+from annotate import BLOCK_COMMENTS, annotate_lines, language_key, line_comment_prefixes
+
+# LSP constants
+SEVERITY_WARNING = 2
+SEVERITY_INFORMATION = 3
+TEXT_DOCUMENT_SYNC_FULL = 1
+METHOD_NOT_FOUND = -32601
+SERVER_NOT_INITIALIZED = -32002
+
+
+def _read_message(stream: BinaryIO) -> Optional[Dict[str, Any]]:
+    """Next Content-Length framed JSON-RPC message, or None at end of input"""
+    length = None
+    while True:
+        header = stream.readline()
+        if not header:
+            return None
+        header = header.decode("ascii", errors="ignore").strip()
+        if not header:
+            break
+        name, _, value = header.partition(":")
+        if name.lower() == "content-length":
+            length = int(value.strip())
+    if length is None:
+        return {}
+    return json.loads(stream.read(length).decode("utf-8"))
+
+
+def _write_message(stream: BinaryIO, payload: Dict[str, Any]):
+    """Write one Content-Length framed JSON-RPC message"""
+    body = json.dumps(payload).encode("utf-8")
+    stream.write(f"Content-Length: {len(body)}\r\n\r\n".encode("ascii") + body)
+    stream.flush()
+
+
+def uri_to_path(uri: str) -> Optional[Path]:
+    """Filesystem path of a file:// URI (None for other schemes)"""
+    parsed = urlparse(uri)
+    if parsed.scheme != "file":
+        return None
+    path = unquote(parsed.path)
+    if re.match(r"^/[A-Za-z]:", path):  # file:///C:/... on Windows
+        path = path[1:]
+    return Path(path)
+
+
+def _line_range(lines: List[str], line: int) -> Dict[str, Any]:
+    """Range covering a 1-based source line"""
+    index = max(0, min(line - 1, len(lines) - 1))
+    return {"start": {"line": index, "character": 0},
+            "end": {"line": index, "character": len(lines[index]) if lines else 0}}
+
+
+class BreadcrumbLanguageServer:
+    """
+    # @codebase-summary: Per-connection LSP state: open documents and their latest analysis
+    - Publishes ARK001 (undocumented), ARK002 (missing required fields), and ARK003 (parameters
+      changed since the doc comment was written, against the last scan's signature snapshot)
+    - Code actions insert breadcrumb stubs in the configured style, for one symbol or the whole file
+    """
+
+    def __init__(self, generator, out: BinaryIO):
+        self.generator = generator
+        self.root = Path(generator.project_root).resolve()
+        self.out = out
+        self.documents: Dict[str, str] = {}
+        self.analyses: Dict[str, Dict[str, Any]] = {}
+        self.previous_signatures: Dict[str, Any] = {}
+        self.initialized = False
+        self.shutdown_requested = False
+        self.comment_prefixes = line_comment_prefixes(generator)
+
+    def _notify(self, method: str, params: Dict[str, Any]):
+        _write_message(self.out, {"jsonrpc": "2.0", "method": method, "params": params})
+
+    def _analyze(self, uri: str) -> Optional[Dict[str, Any]]:
+        """Scanner analysis of an open document, None for files the scanner does not cover"""
+        path = uri_to_path(uri)
+        if path is None or uri not in self.documents:
+            return None
+        path = path.resolve()
+        if not path.is_relative_to(self.root) or not self.generator._is_code_file(path) or \
+                path.suffix.lower() == ".ipynb" or self.generator._should_ignore_path(path):
+            return None
+        try:
+            return self.generator._analyze_code_file(str(path), content=self.documents[uri])
+        except Exception as e:
+            print(f"⚠️ Could not analyze {path}: {e}")
+            return None
+
+    def diagnostics(self, analysis: Dict[str, Any], lines: List[str]) -> List[Dict[str, Any]]:
+        """LSP diagnostics for one analyzed document"""
+        found = []
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("documented") or symbol.get("doc_exempt"):
+                continue
+            name = doc_drift.qualified_name(symbol)
+            if symbol.get("missing_fields"):
+                code, message = "ARK002", f"'{name}' documentation is missing: {', '.join(symbol['missing_fields'])}"
+            else:
+                code, message = "ARK001", f"'{name}' is missing a @codebase-summary breadcrumb"
+            found.append({"range": _line_range(lines, symbol.get("line", 1)), "severity": SEVERITY_WARNING,
+                          "source": "arkival", "code": code, "message": message})
+        drift, _ = doc_drift.build_doc_drift([analysis], self.previous_signatures, limit=len(lines) + 1)
+        for entry in drift["drifted"]:
+            changes = [f"added {', '.join(entry['added'])}" if entry["added"] else "",
+                       f"removed {', '.join(entry['removed'])}" if entry["removed"] else ""]
+            found.append({"range": _line_range(lines, entry["line"]), "severity": SEVERITY_INFORMATION,
+                          "source": "arkival", "code": "ARK003",
+                          "message": f"Parameters of '{entry['name']}' changed since its documentation was written "
+                                     f"({'; '.join(c for c in changes if c)}) - the breadcrumb may be stale"})
+        return sorted(found, key=lambda d: (d["range"]["start"]["line"], d["code"]))
+
+    def publish(self, uri: str):
+        """Re-analyze a document and publish its diagnostics (an empty list clears them)"""
+        analysis = self._analyze(uri)
+        if analysis is None:
+            self.analyses.pop(uri, None)
+            items = []
+        else:
+            self.analyses[uri] = analysis
+            items = self.diagnostics(analysis, self.documents[uri].split("\n"))
+        self._notify("textDocument/publishDiagnostics", {"uri": uri, "diagnostics": items})
+
+    def _stub_edit(self, uri: str, symbols: List[Dict[str, Any]]) -> Optional[Dict[str, Any]]:
+        """WorkspaceEdit inserting breadcrumb stubs for the given symbols, None when none can be inserted"""
+        lines = self.documents[uri].split("\n")
+        path = uri_to_path(uri)
+        language = language_key(self.generator, path, lines)
+        if language not in self.comment_prefixes and language not in BLOCK_COMMENTS:
+            return None
+        template = breadcrumb_styles.style_for(self.generator.breadcrumb_styles, language)["template"]
+        new_lines, annotated = annotate_lines(lines, symbols, language, self.comment_prefixes.get(language), template)
+        if not annotated:
+            return None
+        edits = []
+        for tag, i1, _, j1, j2 in difflib.SequenceMatcher(None, lines, new_lines, autojunk=False).get_opcodes():
+            if tag == "insert":
+                position = {"line": i1, "character": 0}
+                edits.append({"range": {"start": position, "end": position},
+                              "newText": "\n".join(new_lines[j1:j2]) + "\n"})
+        return {"changes": {uri: edits}} if edits else None
+
+    def code_actions(self, params: Dict[str, Any]) -> List[Dict[str, Any]]:
+        """Quick fixes for ARK001 diagnostics in the requested range, plus a whole-file source action"""
+        uri = params["textDocument"]["uri"]
+        analysis = self.analyses.get(uri)
+        if analysis is None:
+            return []
+        undocumented = [s for s in analysis.get("symbols", [])
+                        if not s.get("documented") and not s.get("doc_exempt") and not s.get("missing_fields")]
+        start, end = params["range"]["start"]["line"], params["range"]["end"]["line"]
+        actions = []
+        for symbol in undocumented:
+            if not start <= symbol.get("line", 0) - 1 <= end:
+                continue
+            edit = self._stub_edit(uri, [symbol])
+            if edit is None:
+                continue
+            related = [d for d in params.get("context", {}).get("diagnostics", [])
+                       if d.get("source") == "arkival" and d.get("range", {}).get("start", {}).get("line") == symbol["line"] - 1]
+            actions.append({"title": f"Insert @codebase-summary breadcrumb for '{doc_drift.qualified_name(symbol)}'",
+                            "kind": "quickfix", "diagnostics": related, "isPreferred": True, "edit": edit})
+        if len(undocumented) > 1:
+            edit = self._stub_edit(uri, undocumented)
+            if edit is not None:
+                actions.append({"title": f"Insert breadcrumb stubs for all {len(undocumented)} undocumented symbols",
+                                "kind": "source.fixAll.arkival", "edit": edit})
+        return actions
+
+    def handle(self, message: Dict[str, Any]) -> Optional[Dict[str, Any]]:
+        """
+        # @codebase-summary: Dispatch one client message; returns the response for requests
+        - Full-document sync: didOpen/didChange re-analyze the buffer, didSave also reloads the
+          signature snapshot (a scan may have refreshed it), didClose clears the diagnostics
+        """
+        method, params, request_id = message.get("method"), message.get("params") or {}, message.get("id")
+
+        def result(value: Any) -> Dict[str, Any]:
+            return {"jsonrpc": "2.0", "id": request_id, "result": value}
+
+        if method == "initialize":
+            self.initialized = True
+            self.previous_signatures = doc_drift.load_snapshot(self.generator.paths['signature_snapshot'])
+            return result({"capabilities": {"textDocumentSync": {"openClose": True, "change": TEXT_DOCUMENT_SYNC_FULL,
+                                                                 "save": {"includeText": False}},
+                                            "codeActionProvider": {"codeActionKinds": ["quickfix", "source.fixAll.arkival"]}},
+                           "serverInfo": {"name": "arkival"}})
+        if method == "shutdown":
+            self.shutdown_requested = True
+            return result(None)
+        if not self.initialized and request_id is not None:
+            return {"jsonrpc": "2.0", "id": request_id,
+                    "error": {"code": SERVER_NOT_INITIALIZED, "message": "initialize first"}}
+
+        document = params.get("textDocument", {})
+        if method == "textDocument/didOpen":
+            self.documents[document["uri"]] = document.get("text", "")
+            self.publish(document["uri"])
+        elif method == "textDocument/didChange":
+            changes = params.get("contentChanges") or []
+            if changes:
+                self.documents[document["uri"]] = changes[-1].get("text", "")
+                self.publish(document["uri"])
+        elif method == "textDocument/didSave":
+            self.previous_signatures = doc_drift.load_snapshot(self.generator.paths['signature_snapshot'])
+            if document.get("uri") in self.documents:
+                self.publish(document["uri"])
+        elif method == "textDocument/didClose":
+            self.documents.pop(document["uri"], None)
+            self.analyses.pop(document["uri"], None)
+            self._notify("textDocument/publishDiagnostics", {"uri": document["uri"], "diagnostics": []})
+        elif method == "textDocument/codeAction":
+            return result(self.code_actions(params))
+        elif request_id is not None:
+            return {"jsonrpc": "2.0", "id": request_id,
+                    "error": {"code": METHOD_NOT_FOUND, "message": f"unsupported method '{method}'"}}
+        return None
+
+
+def run_stdio(generator, stdin: BinaryIO, stdout: BinaryIO) -> int:
+    """
+    # @codebase-summary: Serve LSP over stdio until the client sends exit
+    - stdout carries only protocol messages; log output must already be redirected to stderr
+    - Returns the process exit code the protocol asks for (0 after shutdown, 1 otherwise)
+    """
+    server = BreadcrumbLanguageServer(generator, stdout)
+    print(f"✅ Arkival language server ready for {server.root}")
+    while True:
+        try:
+            message = _read_message(stdin)
+        except (ValueError, UnicodeDecodeError) as e:
+            print(f"⚠️ Ignoring malformed message: {e}")
+            continue
+        if message is None or message.get("method") == "exit":
+            return 0 if server.shutdown_requested else 1
+        if not message:
+            continue
+        response = server.handle(message)
+        if response is not None:
+            _write_message(stdout, response)
@@ -0,0 +1,202 @@
+#!/usr/bin/env python3
+"""
+MCP Server - Model Context Protocol tools over the serve-mode index, for coding agents
+Speaks JSON-RPC 2.0 over stdio (one message per line) so agents such as Claude or Cursor can query
+the summary, symbols, and undocumented functions directly instead of reading codebase_summary.json
+"""
+
+import json
+import threading
+from pathlib import Path
+from typing import Dict, Any, Optional, TextIO
+
+from complexity import FUNCTION_KINDS
+from doc_drift import qualified_name
+from serve_mode import WorkspaceIndex, IndexWatcher
+
+PROTOCOL_VERSION = "2024-11-05"
+
+# JSON-RPC error codes
+PARSE_ERROR = -32700
+INVALID_REQUEST = -32600
+METHOD_NOT_FOUND = -32601
+INVALID_PARAMS = -32602
+
+TOOLS = [
+    {
+        "name": "get_codebase_summary",
+        "description": "Project summary from the Arkival index: totals, coverage, languages, components, and "
+                       "dependencies. Pass section to get one top-level key (e.g. code_analysis) only.",
+        "inputSchema": {
+            "type": "object",
+            "properties": {"section": {"type": "string", "description": "Top-level summary key to return"}},
+        },
+    },
+    {
+        "name": "find_symbol",
+        "description": "Find functions, methods, classes, and other symbols whose (qualified) name contains the "
+                       "query, case-insensitively. Exact name matches come first.",
+        "inputSchema": {
+            "type": "object",
+            "properties": {
+                "query": {"type": "string", "description": "Name or part of a name, e.g. 'parse' or 'Cache.get'"},
+                "kind": {"type": "string", "description": "Only this symbol kind (function, method, class...)"},
+                "undocumented": {"type": "boolean", "description": "Only symbols without documentation"},
+                "limit": {"type": "integer", "minimum": 1, "maximum": 1000, "default": 50},
+            },
+            "required": ["query"],
+        },
+    },
+    {
+        "name": "get_undocumented_functions",
+        "description": "Functions and methods that have no @codebase-summary breadcrumb or native doc comment, "
+                       "in file and line order.",
+        "inputSchema": {
+            "type": "object",
+            "properties": {
+                "path_prefix": {"type": "string", "description": "Only files whose relative path starts with this"},
+                "limit": {"type": "integer", "minimum": 1, "maximum": 1000, "default": 100},
+            },
+        },
+    },
+    {
+        "name": "get_file_outline",
+        "description": "Symbols defined in one file (relative path), in line order, with kind, line range, "
+                       "parameters, and documentation status.",
+        "inputSchema": {
+            "type": "object",
+            "properties": {"path": {"type": "string", "description": "Path relative to the project root"}},
+            "required": ["path"],
+        },
+    },
+]
+
+
+class ToolError(Exception):
+    """Tool failure reported to the agent as an isError result rather than a protocol error"""
+
+
+def _limit(arguments: Dict[str, Any], default: int) -> int:
+    """limit argument clamped to 1..1000"""
+    try:
+        return max(1, min(int(arguments.get("limit", default)), 1000))
+    except (TypeError, ValueError):
+        raise ToolError("limit must be an integer")
+
+
+def call_tool(index: WorkspaceIndex, name: str, arguments: Dict[str, Any]) -> Any:
+    """
+    # @codebase-summary: Run one MCP tool against the index and return its JSON result
+    - Raises ToolError for bad arguments or unknown files, KeyError for unknown tool names
+    """
+    summary, files = index.snapshot()
+    if name == "get_codebase_summary":
+        section = arguments.get("section")
+        if not section:
+            return summary
+        if section not in summary:
+            raise ToolError(f"no summary section '{section}' (available: {', '.join(sorted(summary))})")
+        return {section: summary[section]}
+    if name == "find_symbol":
+        query = arguments.get("query")
+        if not isinstance(query, str) or not query:
+            raise ToolError("query is required")
+        symbols = index.find_symbols(query, arguments.get("kind"), bool(arguments.get("undocumented")),
+                                     _limit(arguments, 50))
+        return {"query": query, "count": len(symbols), "symbols": symbols}
+    if name == "get_undocumented_functions":
+        prefix, limit = arguments.get("path_prefix") or "", _limit(arguments, 100)
+        found = []
+        for file, analysis in sorted(files.items()):
+            if not file.startswith(prefix):
+                continue
+            for symbol in sorted(analysis.get("symbols", []), key=lambda s: s.get("line") or 0):
+                if symbol.get("kind", "function") not in FUNCTION_KINDS:
+                    continue
+                if symbol.get("documented") or symbol.get("doc_exempt"):
+                    continue
+                found.append({"file": file, "name": qualified_name(symbol), "kind": symbol.get("kind", "function"),
+                              "line": symbol.get("line"), "params": symbol.get("params", [])})
+        return {"total": len(found), "truncated": len(found) > limit, "functions": found[:limit]}
+    if name == "get_file_outline":
+        path = Path(str(arguments.get("path", ""))).as_posix()
+        analysis = files.get(path)
+        if analysis is None:
+            raise ToolError(f"no analyzed file '{path}'")
+        outline = [
+            {"name": qualified_name(symbol), "kind": symbol.get("kind", "function"), "line": symbol.get("line"),
+             "end_line": symbol.get("end_line"), "params": symbol.get("params", []),
+             "documented": bool(symbol.get("documented"))}
+            for symbol in sorted(analysis.get("symbols", []), key=lambda s: s.get("line") or 0)
+        ]
+        extension = analysis.get("language", "")
+        return {"file": path, "language": index.generator.language_map.get(extension, extension), "lines_of_code": analysis.get("lines_of_code"),
+                "documented": analysis["documented_count"], "total": analysis["function_count"], "symbols": outline}
+    raise KeyError(name)
+
+
+def handle_message(index: WorkspaceIndex, message: Any, server_version: str) -> Optional[Dict[str, Any]]:
+    """
+    # @codebase-summary: JSON-RPC response for one incoming message (None for notifications)
+    - Implements initialize, ping, tools/list, and tools/call; other methods get METHOD_NOT_FOUND
+    """
+    if not isinstance(message, dict) or message.get("jsonrpc") != "2.0" or "method" not in message:
+        return {"jsonrpc": "2.0", "id": message.get("id") if isinstance(message, dict) else None,
+                "error": {"code": INVALID_REQUEST, "message": "not a JSON-RPC 2.0 request"}}
+    method, params, request_id = message["method"], message.get("params") or {}, message.get("id")
+    if "id" not in message:  # notifications (notifications/initialized, cancellations) need no reply
+        return None
+
+    def result(value: Any) -> Dict[str, Any]:
+        return {"jsonrpc": "2.0", "id": request_id, "result": value}
+
+    def error(code: int, text: str) -> Dict[str, Any]:
+        return {"jsonrpc": "2.0", "id": request_id, "error": {"code": code, "message": text}}
+
+    if method == "initialize":
+        return result({"protocolVersion": PROTOCOL_VERSION, "capabilities": {"tools": {}},
+                       "serverInfo": {"name": "arkival", "version": server_version}})
+    if method == "ping":
+        return result({})
+    if method == "tools/list":
+        return result({"tools": TOOLS})
+    if method == "tools/call":
+        name, arguments = params.get("name"), params.get("arguments") or {}
+        if not isinstance(arguments, dict):
+            return error(INVALID_PARAMS, "arguments must be an object")
+        try:
+            value = call_tool(index, name, arguments)
+        except KeyError:
+            return error(INVALID_PARAMS, f"unknown tool '{name}'")
+        except ToolError as e:
+            return result({"content": [{"type": "text", "text": str(e)}], "isError": True})
+        return result({"content": [{"type": "text", "text": json.dumps(value, indent=2)}], "isError": False})
+    return error(METHOD_NOT_FOUND, f"unknown method '{method}'")
+
+
+def run_stdio(generator, stdin: TextIO, stdout: TextIO, interval: float = 1.0):
+    """
+    # @codebase-summary: Serve MCP over stdio until the client closes stdin
+    - stdout carries only protocol messages; log output must already be redirected to stderr
+    - The index is warmed before the first request is read and kept current by a background watcher
+    """
+    index = WorkspaceIndex(generator)
+    print(f"🔥 Warming index for {generator.project_root}...")
+    index.refresh()
+    threading.Thread(target=IndexWatcher(index, interval=interval).run, daemon=True).start()
+    server_version = index.summary.get("version", "")
+    print(f"✅ MCP server ready ({len(index.files)} files) - reading JSON-RPC from stdin")
+
+    for line in stdin:
+        if not line.strip():
+            continue
+        try:
+            message = json.loads(line)
+        except json.JSONDecodeError as e:
+            response = {"jsonrpc": "2.0", "id": None, "error": {"code": PARSE_ERROR, "message": f"invalid JSON: {e}"}}
+        else:
+            response = handle_message(index, message, server_version)
+        if response is not None:
+            stdout.write(json.dumps(response) + "\n")
+            stdout.flush()
+    print("🛑 MCP client disconnected")
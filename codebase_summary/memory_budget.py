@@ -0,0 +1,162 @@
+#!/usr/bin/env python3
+"""
+Memory Budget - Bounded-memory storage of per-file scan results (--max-memory)
+Per-file analyses are the bulk of a scan's memory; once the process nears its budget, they move to a spill
+file on disk in batches and are read back one record at a time whenever a phase walks over them
+"""
+
+import heapq
+import os
+import pickle
+import re
+import tempfile
+from pathlib import Path
+from typing import Any, Callable, Dict, Iterable, Iterator, List, Optional, Tuple
+
+# Results spill once resident memory passes this share of the budget; the rest is headroom for
+# the aggregate phase and the records a phase holds while it runs
+SPILL_AT = 0.6
+# Memory is sampled every CHECK_EVERY stored results (a /proc read, too slow to do per record)
+CHECK_EVERY = 64
+_UNITS = {"": 1, "b": 1, "k": 1024, "m": 1024 ** 2, "g": 1024 ** 3, "t": 1024 ** 4}
+
+
+def parse_size(text: str) -> int:
+    """
+    # @codebase-summary: Byte count from a size like '1G', '900M', '512MiB', or '1048576'
+    - Binary multiples (K = 1024); raises ValueError for anything else
+    """
+    match = re.fullmatch(r"\s*(\d+(?:\.\d+)?)\s*([kmgt]?)(?:i?b)?\s*", text.lower())
+    if not match:
+        raise ValueError(f"'{text}' is not a size (e.g. 900M, 1G)")
+    size = int(float(match.group(1)) * _UNITS[match.group(2)])
+    if size <= 0:
+        raise ValueError(f"'{text}' must be greater than zero")
+    return size
+
+
+def format_size(size: float) -> str:
+    """Human-readable byte count ('1.5 GB')"""
+    for unit in ("B", "KB", "MB", "GB"):
+        if size < 1024 or unit == "GB":
+            return f"{size:.0f} {unit}" if unit == "B" else f"{size:.1f} {unit}"
+        size /= 1024
+    return f"{size:.1f} GB"
+
+
+def resident_memory() -> Optional[int]:
+    """Current resident set size in bytes (Linux), else the peak so far, or None when neither is available"""
+    try:
+        with open("/proc/self/statm", "r") as f:
+            return int(f.read().split()[1]) * os.sysconf("SC_PAGE_SIZE")
+    except (OSError, ValueError, IndexError, AttributeError):
+        pass
+    try:
+        import resource
+        import sys
+        peak = resource.getrusage(resource.RUSAGE_SELF).ru_maxrss
+        return peak if sys.platform == "darwin" else peak * 1024
+    except (ImportError, OSError):
+        return None
+
+
+class SpilledResults:
+    """
+    # @codebase-summary: Append-only, list-like store of per-file results that spills to disk
+    - Without a budget it is a plain in-memory list; with one, buffered records are written to an
+      (already unlinked) temporary file as a run whenever resident memory passes SPILL_AT of the budget
+    - Iteration yields records in append order, or with key in key order: runs are sorted as they
+      are written and merged on the way out, so only one record per run is in memory at a time
+    - Records read back are fresh copies: changes to them are not stored
+    """
+
+    def __init__(self, budget: Optional[int] = None, spill_dir: Optional[Path] = None,
+                 key: Optional[Callable[[Any], Any]] = None):
+        self.budget = budget
+        self.spill_dir = spill_dir
+        self.key = key
+        self.spilled = 0
+        self._buffer: List[Any] = []
+        self._runs: List[Tuple[int, int]] = []  # (offset, record count) in the spill file
+        self._file = None
+        self._since_check = 0
+
+    def __len__(self) -> int:
+        return self.spilled + len(self._buffer)
+
+    def __bool__(self) -> bool:
+        return len(self) > 0
+
+    def append(self, record: Any):
+        self._buffer.append(record)
+        if self.budget is None:
+            return
+        self._since_check += 1
+        if self._since_check >= CHECK_EVERY:
+            self._since_check = 0
+            rss = resident_memory()
+            if rss is None or rss > self.budget * SPILL_AT:
+                self.spill()
+
+    def extend(self, records: Iterable[Any]):
+        for record in records:
+            self.append(record)
+
+    def spill(self):
+        """Write the buffered records to disk as one run"""
+        if not self._buffer:
+            return
+        if self._file is None:
+            if self.spill_dir is not None:
+                self.spill_dir.mkdir(parents=True, exist_ok=True)
+            self._file = tempfile.TemporaryFile(prefix="arkival-spill-", dir=self.spill_dir)
+        records = sorted(self._buffer, key=self.key) if self.key else self._buffer
+        self._file.seek(0, os.SEEK_END)
+        offset = self._file.tell()
+        pickler = pickle.Pickler(self._file, protocol=pickle.HIGHEST_PROTOCOL)
+        for record in records:
+            pickler.dump(record)
+            pickler.clear_memo()
+        self._runs.append((offset, len(records)))
+        self.spilled += len(records)
+        self._buffer = []
+
+    def _read_run(self, offset: int, count: int) -> Iterator[Any]:
+        """Records of one run; each read seeks to its own position so runs can be merged"""
+        for _ in range(count):
+            self._file.seek(offset)
+            record = pickle.load(self._file)
+            offset = self._file.tell()
+            yield record
+
+    def __iter__(self) -> Iterator[Any]:
+        runs = [self._read_run(offset, count) for offset, count in self._runs]
+        if self.key is None:
+            for run in runs:
+                yield from run
+            yield from list(self._buffer)
+            return
+        yield from heapq.merge(*runs, sorted(self._buffer, key=self.key), key=self.key)
+
+    def close(self):
+        """Release the spill file"""
+        if self._file is not None:
+            self._file.close()
+            self._file = None
+        self._runs, self._buffer, self.spilled = [], [], 0
+
+
+def summary_fields(analysis: Dict[str, Any], fields: Tuple[str, ...],
+                   symbol_fields: Tuple[str, ...] = ()) -> Dict[str, Any]:
+    """Copy of a file analysis with only the keys a summarizer reads (symbols cut down to symbol_fields)"""
+    summary = {key: analysis[key] for key in fields if key in analysis}
+    if "symbols" in summary:
+        summary["symbols"] = [{key: symbol[key] for key in symbol_fields if key in symbol} for symbol in summary["symbols"]]
+    return summary
+
+
+def in_file_order(file_analysis: Iterable[Dict[str, Any]]) -> Iterable[Dict[str, Any]]:
+    """File results sorted by path; a spilled scan store is already in that order and is streamed as is"""
+    if isinstance(file_analysis, SpilledResults):
+        return file_analysis
+    return sorted(file_analysis, key=lambda a: a["file"])
@@ -0,0 +1,99 @@
+#!/usr/bin/env python3
+"""
+Mermaid Diagrams - Module dependency and package structure diagrams from scan results
+Renders Mermaid source that the Markdown outputs embed in ```mermaid fences and the HTML report
+renders in the browser
+"""
+
+import re
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Tuple
+
+import dependency_graph
+
+
+def _label(text: str) -> str:
+    """Node label safe inside ["..."] (Mermaid has no escape for quotes)"""
+    return re.sub(r'["\[\]{}<>]', "'", text)
+
+
+def dependency_flowchart(module_edges: List[Dict[str, Any]], max_edges: int = 40) -> str:
+    """
+    # @codebase-summary: Top-level module dependency graph as a Mermaid flowchart
+    - One node per module that imports or is imported; edge labels count the file imports behind them
+    - Keeps the max_edges heaviest edges so large projects stay readable
+    """
+    edges = sorted(module_edges, key=lambda e: (-e["imports"], e["from"], e["to"]))[:max_edges]
+    modules = sorted({e["from"] for e in edges} | {e["to"] for e in edges})
+    ids = {module: f"M{i}" for i, module in enumerate(modules)}
+    out = ["flowchart LR"]
+    out += [f'    {ids[module]}["{_label(module)}"]' for module in modules]
+    out += [f'    {ids[e["from"]]} -->|{e["imports"]}| {ids[e["to"]]}' for e in sorted(edges, key=lambda e: (e["from"], e["to"]))]
+    if not edges:
+        out.append('    NONE["No cross-module imports found"]')
+    return "\n".join(out)
+
+
+def package_tree(file_analysis: List[Dict[str, Any]], project_name: str, max_depth: int = 2, max_children: int = 12) -> str:
+    """
+    # @codebase-summary: Directory tree of the analyzed files as a Mermaid graph
+    - Nodes show file count and breadcrumb coverage for everything below the directory
+    - Goes max_depth directories deep and keeps the max_children largest subdirectories per node
+    """
+    stats: Dict[Tuple[str, ...], List[int]] = defaultdict(lambda: [0, 0, 0])
+    for analysis in file_analysis:
+        parts = Path(analysis["file"]).parent.parts[:max_depth]
+        for depth in range(len(parts) + 1):
+            entry = stats[parts[:depth]]
+            entry[0] += 1
+            entry[1] += analysis["documented_count"]
+            entry[2] += analysis["function_count"]
+
+    def node_label(path: Tuple[str, ...]) -> str:
+        files, documented, total = stats[path]
+        coverage = f"{round(documented / total * 100)}%" if total else "-"
+        name = path[-1] + "/" if path else project_name
+        return f'["{_label(name)}<br/>{files} files · {coverage}"]'
+
+    ids = {(): "P0"}
+    out = ["graph TD", f"    P0{node_label(())}"]
+    queue = [()]
+    while queue:
+        parent = queue.pop(0)
+        children = sorted((path for path in stats if len(path) == len(parent) + 1 and path[:-1] == parent),
+                          key=lambda path: (-stats[path][0], path))
+        for child in children[:max_children]:
+            ids[child] = f"P{len(ids)}"
+            out.append(f"    {ids[parent]} --> {ids[child]}{node_label(child)}")
+            queue.append(child)
+        if len(children) > max_children:
+            more = f"P{len(ids)}"
+            ids[parent + ("…",)] = more
+            out.append(f'    {ids[parent]} --> {more}["+{len(children) - max_children} more"]')
+    return "\n".join(out)
+
+
+def build_diagrams(file_edges: List[Tuple[str, str]], file_analysis: List[Dict[str, Any]],
+                   project_name: str) -> List[Tuple[str, str]]:
+    """
+    (title, Mermaid source) pairs embedded by the Markdown and HTML outputs. The dependency graph
+    uses the shallowest directory depth (up to 3) at which any imports cross module boundaries,
+    and individual files when all imports stay within one directory
+    """
+    module_edges = []
+    for depth in range(1, 4):
+        module_edges = dependency_graph.collapse_edges(file_edges, depth)
+        if module_edges:
+            break
+    else:
+        module_edges = [{"from": source, "to": target, "imports": 1} for source, target in file_edges]
+    return [
+        ("Module dependencies", dependency_flowchart(module_edges)),
+        ("Package structure", package_tree(file_analysis, project_name)),
+    ]
+
+
+def fenced(source: str) -> str:
+    """Mermaid source in a Markdown code fence"""
+    return f"```mermaid\n{source}\n```"
@@ -0,0 +1,122 @@
+#!/usr/bin/env python3
+"""
+Metrics Exporter - Prometheus / OpenMetrics text exposition of serve mode's index ('/metrics')
+Reports scan health (duration, refreshes, failures, per-kind file errors) and documentation coverage
+overall and per language, so coverage regressions and a stuck or failing scanner can be alerted on
+"""
+
+from typing import Dict, Any, List, Optional, Tuple
+
+PROMETHEUS_CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+OPENMETRICS_CONTENT_TYPE = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+PREFIX = "arkival"
+# Sample name suffix each type always carries; OpenMetrics leaves it off the family name
+_TYPE_SUFFIXES = {"counter": "_total", "info": "_info"}
+# Error kinds counted by the scanner, always exported (as 0) so alerts need no absent() handling
+SCAN_ERROR_KINDS = ("read", "analysis", "extractor", "go_ast")
+
+
+def wants_openmetrics(accept: Optional[str]) -> bool:
+    """Whether a scraper's Accept header asks for OpenMetrics rather than the Prometheus text format"""
+    return "application/openmetrics-text" in (accept or "")
+
+
+def _escape(value: str) -> str:
+    """Label value with backslashes, quotes, and newlines escaped"""
+    return str(value).replace("\\", "\\\\").replace('"', '\\"').replace("\n", "\\n")
+
+
+def _value(number: float) -> str:
+    """Sample value; integers without a trailing .0"""
+    return str(int(number)) if float(number).is_integer() else repr(float(number))
+
+
+class MetricFamily:
+    """One metric name with its type, help text, and labelled samples"""
+
+    def __init__(self, name: str, kind: str, help_text: str):
+        self.name, self.kind, self.help_text = f"{PREFIX}_{name}", kind, help_text
+        self.samples: List[Tuple[str, Dict[str, str], float]] = []
+
+    def add(self, value: float, suffix: str = "", **labels: str) -> "MetricFamily":
+        """Add a sample; counters and info metrics get their _total / _info suffix at render time"""
+        self.samples.append((suffix, labels, value))
+        return self
+
+    def render(self, openmetrics: bool) -> List[str]:
+        """
+        # @codebase-summary: Exposition lines of the family in either text format
+        - OpenMetrics names counter and info families without their '_total' / '_info' sample suffix; the
+          Prometheus format uses the full sample name in HELP/TYPE and has no info type (gauge instead)
+        """
+        type_suffix = _TYPE_SUFFIXES.get(self.kind, "")
+        family = self.name if openmetrics else self.name + type_suffix
+        kind = self.kind if openmetrics or self.kind != "info" else "gauge"
+        lines = [f"# HELP {family} {self.help_text}", f"# TYPE {family} {kind}"]
+        for suffix, labels, value in self.samples:
+            name = self.name + (type_suffix or suffix)
+            label_text = ",".join(f'{key}="{_escape(val)}"' for key, val in sorted(labels.items()))
+            lines.append(f"{name}{{{label_text}}} {_value(value)}" if label_text else f"{name} {_value(value)}")
+        return lines
+
+
+def collect(index) -> List[MetricFamily]:
+    """
+    # @codebase-summary: Metric families for a serve mode WorkspaceIndex
+    - Scan health: refreshes and failures (counters), duration summary and last duration, time of the
+      last completed scan, and file-level errors of the last scan and since start, by kind
+    - Coverage: files and functions scanned, documented functions, and coverage ratio (0-1) overall
+      and per language; languages without functions have no ratio sample
+    """
+    summary, files = index.snapshot()
+    stats = index.scan_stats()
+    coverage = index.coverage()
+    families = [
+        MetricFamily("scans", "counter", "Completed scans of the workspace (initial scan and refreshes)").add(stats["scans"]),
+        MetricFamily("scan_failures", "counter", "Scans that raised an error (the previous results keep being served)")
+        .add(stats["failures"]),
+        MetricFamily("scan_duration_seconds", "summary", "Wall-clock duration of completed scans")
+        .add(stats["scans"], "_count").add(round(stats["duration_total"], 6), "_sum"),
+        MetricFamily("last_scan_duration_seconds", "gauge", "Duration of the most recent completed scan")
+        .add(round(stats["last_duration"], 6)),
+        MetricFamily("last_scan_timestamp_seconds", "gauge", "Unix time the most recent scan completed")
+        .add(round(index.refreshed_at or 0, 3)),
+    ]
+    last_errors = MetricFamily("last_scan_errors", "gauge", "Files that failed to read or parse in the most recent scan")
+    total_errors = MetricFamily("scan_errors", "counter", "Files that failed to read or parse, summed over all scans")
+    for kind in sorted(set(stats["last_errors"]) | set(stats["errors_total"]) | set(SCAN_ERROR_KINDS)):
+        last_errors.add(stats["last_errors"].get(kind, 0), kind=kind)
+        total_errors.add(stats["errors_total"].get(kind, 0), kind=kind)
+    families += [last_errors, total_errors,
+                 MetricFamily("files_scanned", "gauge", "Code files analyzed in the most recent scan").add(len(files))]
+
+    overall = coverage["overall"]
+    functions = MetricFamily("functions", "gauge", "Functions and methods counted for documentation coverage")
+    documented = MetricFamily("documented_functions", "gauge", "Functions and methods with a breadcrumb")
+    ratio = MetricFamily("docs_coverage_ratio", "gauge", "Documented share of functions (0-1)")
+    functions.add(overall["total"])
+    documented.add(overall["documented"])
+    if overall["coverage"] is not None:
+        ratio.add(round(overall["coverage"] / 100, 4))
+    language_files = MetricFamily("language_files", "gauge", "Code files analyzed per language")
+    language_functions = MetricFamily("language_functions", "gauge", "Functions per language")
+    language_ratio = MetricFamily("language_docs_coverage_ratio", "gauge", "Documented share of functions per language (0-1)")
+    for language, count in sorted(index.language_file_counts().items()):
+        language_files.add(count, language=language)
+    for language, entry in coverage["languages"].items():
+        language_functions.add(entry["total"], language=language)
+        if entry["coverage"] is not None:
+            language_ratio.add(round(entry["coverage"] / 100, 4), language=language)
+    families += [functions, documented, ratio, language_files, language_functions, language_ratio]
+    if summary.get("version"):
+        families.append(MetricFamily("summary", "info", "Project and summary version of the served results")
+                        .add(1, project=summary.get("project_name") or "", version=summary["version"]))
+    return families
+
+
+def render(index, openmetrics: bool = False) -> str:
+    """Full /metrics response body; OpenMetrics ends with the mandatory '# EOF'"""
+    lines = [line for family in collect(index) for line in family.render(openmetrics)]
+    if openmetrics:
+        lines.append("# EOF")
+    return "\n".join(lines) + "\n"
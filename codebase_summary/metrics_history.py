@@ -0,0 +1,193 @@
+#!/usr/bin/env python3
+"""
+Metrics History - Per-run aggregate metrics (--record) and the 'trend' report over them
+Each recorded run appends one record (coverage, symbol counts, complexity, per-language coverage, and
+the git commit) to a local store: JSON Lines by default, SQLite when the path ends in .sqlite or .db
+"""
+
+import json
+import sqlite3
+import datetime
+import subprocess
+from collections import Counter
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+HISTORY_FORMAT_VERSION = 1
+DEFAULT_LAST = 10
+# Metrics shown by 'trend' unless --metrics names others (any numeric record key works)
+DEFAULT_METRICS = ("coverage", "functions", "undocumented", "average_complexity")
+_SQLITE_SUFFIXES = (".sqlite", ".sqlite3", ".db")
+_SPARKS = "▁▂▃▄▅▆▇█"
+
+
+def _git_value(project_root: Path, *args: str) -> Optional[str]:
+    """Trimmed output of a git query, or None outside a repository"""
+    try:
+        result = subprocess.run(["git", *args], cwd=project_root, capture_output=True, text=True, timeout=10)
+    except (OSError, subprocess.SubprocessError):
+        return None
+    if result.returncode != 0:
+        return None
+    return result.stdout.strip() or None
+
+
+def build_record(summary: Dict[str, Any], file_analysis: List[Dict[str, Any]], project_root: Path) -> Dict[str, Any]:
+    """
+    # @codebase-summary: One run's aggregate metrics for the history store
+    - Counts: files, lines of code, functions, documented/undocumented, and all symbols by kind
+    - Complexity: average and max cyclomatic score and average function length
+    - Per-language coverage and function counts, plus version, commit, branch, and whether the
+      working tree had uncommitted changes
+    """
+    code = summary["code_analysis"]
+    kinds = Counter(symbol.get("kind", "function") for analysis in file_analysis
+                    for symbol in analysis.get("symbols", []) if not symbol.get("doc_exempt"))
+    status = _git_value(project_root, "status", "--porcelain", "--untracked-files=no")
+    commit = _git_value(project_root, "rev-parse", "HEAD")
+    return {
+        "format_version": HISTORY_FORMAT_VERSION,
+        "recorded_at": datetime.datetime.now(datetime.timezone.utc).isoformat(timespec="seconds"),
+        "version": summary.get("version"),
+        "commit": commit,
+        "branch": _git_value(project_root, "rev-parse", "--abbrev-ref", "HEAD"),
+        "dirty": bool(status) if commit else None,
+        "files": code.get("total_files_analyzed", 0),
+        "lines_of_code": code.get("total_lines_of_code", 0),
+        "functions": code.get("total_functions", 0),
+        "documented": code.get("documented_functions", 0),
+        "undocumented": code.get("missing_count", 0),
+        "coverage": code.get("coverage_percentage", 0.0),
+        "symbols": sum(kinds.values()),
+        "symbols_by_kind": dict(sorted(kinds.items())),
+        "average_complexity": code.get("complexity", {}).get("average_complexity", 0),
+        "max_complexity": code.get("complexity", {}).get("max_complexity", 0),
+        "average_function_length": code.get("function_metrics", {}).get("length", {}).get("average", 0),
+        "languages": {language: {"functions": totals.get("functions", 0), "coverage": totals.get("coverage_percentage")}
+                      for language, totals in sorted(code.get("aggregates", {}).get("by_language", {}).items())},
+    }
+
+
+def _is_sqlite(path: Path) -> bool:
+    """SQLite store by file extension; everything else is JSON Lines"""
+    return path.suffix.lower() in _SQLITE_SUFFIXES
+
+
+def append_record(path: Path, record: Dict[str, Any]):
+    """Add a run to the store, creating it (and its directory) on first use"""
+    path.parent.mkdir(parents=True, exist_ok=True)
+    if not _is_sqlite(path):
+        with open(path, "a", encoding="utf-8") as f:
+            f.write(json.dumps(record, sort_keys=True) + "\n")
+        return
+    connection = sqlite3.connect(path)
+    try:
+        with connection:
+            connection.execute("CREATE TABLE IF NOT EXISTS runs (id INTEGER PRIMARY KEY AUTOINCREMENT, "
+                               "recorded_at TEXT, commit_id TEXT, version TEXT, coverage REAL, record TEXT)")
+            connection.execute("INSERT INTO runs (recorded_at, commit_id, version, coverage, record) VALUES (?, ?, ?, ?, ?)",
+                               (record["recorded_at"], record["commit"], record["version"], record["coverage"],
+                                json.dumps(record, sort_keys=True)))
+    finally:
+        connection.close()
+
+
+def load_records(path: Path) -> List[Dict[str, Any]]:
+    """All recorded runs, oldest first; unreadable JSONL lines are skipped; raises OSError/sqlite3.Error"""
+    if _is_sqlite(path):
+        connection = sqlite3.connect(f"file:{path}?mode=ro", uri=True)
+        try:
+            rows = connection.execute("SELECT record FROM runs ORDER BY id").fetchall()
+        finally:
+            connection.close()
+        return [json.loads(row[0]) for row in rows]
+    records = []
+    with open(path, "r", encoding="utf-8") as f:
+        for line in f:
+            try:
+                records.append(json.loads(line))
+            except ValueError:
+                continue
+    return records
+
+
+def select_runs(records: List[Dict[str, Any]], last: int = DEFAULT_LAST, per_commit: bool = False) -> List[Dict[str, Any]]:
+    """The last N runs, or with per_commit the latest run of each of the last N commits"""
+    if per_commit:
+        latest: Dict[Any, Dict[str, Any]] = {}
+        for record in records:
+            key = record.get("commit") or record.get("recorded_at")
+            latest.pop(key, None)
+            latest[key] = record
+        records = list(latest.values())
+    return records[-last:] if last > 0 else records
+
+
+def _metric(record: Dict[str, Any], name: str) -> Optional[float]:
+    """A numeric metric of a record; 'languages.python.coverage' reaches into nested values"""
+    value: Any = record
+    for part in name.split("."):
+        value = value.get(part) if isinstance(value, dict) else None
+    return value if isinstance(value, (int, float)) and not isinstance(value, bool) else None
+
+
+def sparkline(values: List[Optional[float]]) -> str:
+    """One block character per value, scaled between the series' min and max (' ' for gaps)"""
+    present = [v for v in values if v is not None]
+    if not present:
+        return ""
+    low, high = min(present), max(present)
+    scale = (len(_SPARKS) - 1) / (high - low) if high > low else 0
+    return "".join(" " if v is None else _SPARKS[int(round((v - low) * scale)) if scale else len(_SPARKS) // 2]
+                   for v in values)
+
+
+def build_trend(runs: List[Dict[str, Any]], metrics: List[str]) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Series, first/last values, and change of each metric over the selected runs
+    - Runs keep their timestamp, commit, and version so tooling can plot them against either axis
+    """
+    series = {}
+    for name in metrics:
+        values = [_metric(run, name) for run in runs]
+        present = [v for v in values if v is not None]
+        series[name] = {"values": values, "first": present[0] if present else None,
+                        "last": present[-1] if present else None,
+                        "change": round(present[-1] - present[0], 2) if len(present) > 1 else None,
+                        "min": min(present) if present else None, "max": max(present) if present else None}
+    return {"runs": [{"recorded_at": run.get("recorded_at"), "commit": run.get("commit"), "version": run.get("version"),
+                      "dirty": run.get("dirty")} for run in runs],
+            "metrics": series}
+
+
+def _number(value: Optional[float]) -> str:
+    """Table cell for a metric value"""
+    if value is None:
+        return "-"
+    return f"{value:g}" if isinstance(value, float) else str(value)
+
+
+def format_trend(trend: Dict[str, Any], source: str) -> str:
+    """Table of the runs followed by a sparkline and change per metric"""
+    runs, metrics = trend["runs"], trend["metrics"]
+    headers = ["recorded", "commit"] + list(metrics)
+    rows = []
+    for index, run in enumerate(runs):
+        commit = (run["commit"] or "-")[:8] + ("*" if run.get("dirty") else "")
+        rows.append([(run["recorded_at"] or "")[:16].replace("T", " "), commit] +
+                    [_number(series["values"][index]) for series in metrics.values()])
+    widths = [max(len(headers[i]), *(len(row[i]) for row in rows)) for i in range(len(headers))]
+    lines = [f"📈 Last {len(runs)} recorded run(s) from {source}", "",
+             "  ".join(h.ljust(w) for h, w in zip(headers, widths))]
+    lines += ["  ".join(cell.ljust(w) if i < 2 else cell.rjust(w) for i, (cell, w) in enumerate(zip(row, widths)))
+              for row in rows]
+    lines.append("")
+    name_width = max(len(name) for name in metrics)
+    for name, series in metrics.items():
+        change = series["change"]
+        delta = "" if change is None else f" ({'+' if change >= 0 else ''}{change:g})"
+        lines.append(f"{name.ljust(name_width)}  {sparkline(series['values'])}  "
+                     f"{_number(series['first'])} → {_number(series['last'])}{delta}")
+    if any(run.get("dirty") for run in runs):
+        lines.append("\n* recorded with uncommitted changes")
+    return "\n".join(lines)
@@ -0,0 +1,82 @@
+#!/usr/bin/env python3
+"""
+Notebooks - Jupyter .ipynb code cells as scannable source
+Joins a notebook's code cells into one virtual source file for the kernel's language and maps
+symbols found in it back to their cell
+"""
+
+import json
+import re
+from typing import Dict, Any, List, Optional, Tuple
+
+# Kernel language (kernelspec/language_info) -> scanner language key
+KERNEL_LANGUAGES = {"python": "python", "python3": "python", "julia": "julia", "r": "r", "ir": "r"}
+
+# IPython line magics (%time), shell escapes (!pip), and help (?obj / obj?) are not Python
+_IPYTHON_LINE = re.compile(r'^\s*(?:[%!?]|\w[\w.]*\?\??\s*$)')
+
+# Blank lines between cells - wider than the scanner's breadcrumb window, which must not span two cells
+_CELL_GAP = 8
+
+
+def kernel_language(notebook: Dict[str, Any]) -> str:
+    """Scanner language key for the notebook's kernel (Python when unspecified)"""
+    metadata = notebook.get("metadata") or {}
+    for name in ((metadata.get("kernelspec") or {}).get("language"),
+                 (metadata.get("language_info") or {}).get("name"),
+                 (metadata.get("kernelspec") or {}).get("name")):
+        if isinstance(name, str) and name.lower() in KERNEL_LANGUAGES:
+            return KERNEL_LANGUAGES[name.lower()]
+    return "python"
+
+
+def notebook_source(content: str) -> Optional[Tuple[str, List[str], List[Optional[Tuple[int, int]]]]]:
+    """
+    # @codebase-summary: Virtual source file for a notebook's code cells
+    - Returns (language, lines, cell_map): code cells joined by blank lines, with cell_map[i]
+      giving the (0-based cell index, 1-based line within the cell) of virtual line i (None
+      for the blank gap lines that keep cells apart)
+    - Python cells have IPython magics and shell escapes replaced by pass; %% cell magics (e.g. %%bash)
+      skip the whole cell since it is not kernel-language source
+    - Returns None for content that is not notebook JSON
+    """
+    try:
+        notebook = json.loads(content)
+    except ValueError:
+        return None
+    if not isinstance(notebook, dict) or not isinstance(notebook.get("cells"), list):
+        return None
+
+    language = kernel_language(notebook)
+    lines: List[str] = []
+    cell_map: List[Optional[Tuple[int, int]]] = []
+    for index, cell in enumerate(notebook["cells"]):
+        if not isinstance(cell, dict) or cell.get("cell_type") != "code":
+            continue
+        source = cell.get("source", "")
+        text = "".join(source) if isinstance(source, list) else str(source)
+        cell_lines = text.split("\n")
+        if cell_lines and cell_lines[0].lstrip().startswith("%%"):
+            continue
+        if lines:
+            lines.extend([""] * _CELL_GAP)
+            cell_map.extend([None] * _CELL_GAP)
+        for number, line in enumerate(cell_lines, 1):
+            if language == "python" and _IPYTHON_LINE.match(line):
+                # Keep indentation so a magic inside a block does not break parsing
+                line = line[:len(line) - len(line.lstrip())] + "pass"
+            lines.append(line)
+            cell_map.append((index, number))
+    return language, lines, cell_map
+
+
+def attribute_cells(symbols: List[Dict[str, Any]], cell_map: List[Optional[Tuple[int, int]]]):
+    """Rewrite symbol lines from virtual-source lines to cell_index plus the line within that cell"""
+    for symbol in symbols:
+        for key in ("line", "end_line"):
+            index = symbol.get(key, 0) - 1
+            if 0 <= index < len(cell_map) and cell_map[index]:
+                cell_index, cell_line = cell_map[index]
+                if key == "line":
+                    symbol["cell_index"] = cell_index
+                symbol[key] = cell_line
@@ -0,0 +1,465 @@
+#!/usr/bin/env python3
+"""
+Onboarding - ONBOARDING.md for new contributors, generated from a scan ('docgen' subcommand)
+Combines the summary's entrypoints and rollups with what a newcomer asks first: what each directory is
+for, which types matter, and how to set up, build, test, and run the project (from Makefiles, justfiles,
+and package manifests)
+"""
+
+import re
+import json
+from collections import Counter, defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from doc_drift import qualified_name
+from go_api import receiver_base
+
+# First line of a generated file; a file without it is hand-written and only replaced with --overwrite
+GENERATED_MARKER = "<!-- Generated by update_project_summary.py docgen - edits are overwritten -->"
+MAX_DIRECTORIES = 30
+MAX_KEY_TYPES = 20
+MAX_COMMANDS = 40
+MAX_LISTED = 15
+CATEGORIES = ("setup", "build", "test", "lint", "run", "other")
+TYPE_KINDS = {"class", "struct", "interface", "trait", "protocol", "enum", "record", "type", "object",
+              "case_class", "contract", "service", "message", "data", "union", "mixin"}
+
+# Target/script name words that tell what a command is for (first matching category wins)
+_CATEGORY_WORDS = {
+    "setup": ("install", "setup", "bootstrap", "deps", "init", "prepare", "vendor"),
+    "test": ("test", "check", "spec", "coverage", "e2e", "bench"),
+    "lint": ("lint", "fmt", "format", "vet", "typecheck", "tidy", "style"),
+    "build": ("build", "compile", "all", "dist", "bundle", "package", "release", "generate", "gen"),
+    "run": ("run", "serve", "start", "dev", "watch", "up", "server"),
+}
+# Purpose of directories whose name says it, when nothing in them describes the directory
+_DIRECTORY_HINTS = {
+    "test": "Tests", "tests": "Tests", "spec": "Tests", "__tests__": "Tests", "testdata": "Test fixtures",
+    "docs": "Documentation", "doc": "Documentation", "examples": "Examples", "example": "Examples",
+    "cmd": "Command-line entry points", "bin": "Executables and launch scripts", "scripts": "Helper scripts",
+    "internal": "Internal packages (not importable from outside the module)", "pkg": "Library packages",
+    "lib": "Library code", "src": "Source code", "api": "API definitions and handlers",
+    "migrations": "Database migrations", "config": "Configuration", "configs": "Configuration",
+    "deploy": "Deployment configuration", "infra": "Infrastructure as code", "terraform": "Infrastructure as code",
+    "k8s": "Kubernetes manifests", "charts": "Helm charts", ".github": "CI workflows and repository settings",
+    "web": "Web frontend", "frontend": "Web frontend", "ui": "User interface", "static": "Static assets",
+    "assets": "Static assets", "templates": "Templates", "vendor": "Vendored dependencies",
+    "third_party": "Vendored dependencies", "proto": "Protocol Buffers definitions", "tools": "Development tools",
+}
+_MAKE_TARGET = re.compile(r"^([A-Za-z0-9][\w./-]*)\s*:(?![:=])(.*)$")
+_JUST_RECIPE = re.compile(r"^@?([A-Za-z_][\w-]*)(?:\s+[^:=]*)?:(?!=)")
+_HELP_COMMENT = re.compile(r"##\s*(.+)$")
+_GO_PACKAGE_DOC = re.compile(r"^//\s*(Package\s+\w+\s+.+)$")
+_COMMENT_MARKERS = ("///", "//!", "//", "#", "--", ";", "/**", "/*", "*/", "*")
+
+
+def category_of(name: str) -> str:
+    """Which of CATEGORIES a target or script name belongs to"""
+    words = re.split(r"[^a-z0-9]+", name.lower())
+    for category, hints in _CATEGORY_WORDS.items():
+        if any(word == hint or (len(hint) > 3 and word.startswith(hint)) for word in words for hint in hints):
+            return category
+    return "other"
+
+
+def _read(path: Path) -> Optional[str]:
+    """Text of a manifest, or None when it cannot be read"""
+    try:
+        return path.read_text(encoding="utf-8", errors="ignore")
+    except OSError:
+        return None
+
+
+def _in_directory(directory: str, command: str) -> str:
+    """A command run from the project root for a manifest in a subdirectory"""
+    return command if directory == "." else f"cd {directory} && {command}"
+
+
+def _make_commands(text: str, directory: str, tool: str) -> List[Dict[str, Any]]:
+    """Targets of a Makefile (or justfile) with their '## description' or preceding comment"""
+    commands, previous = [], ""
+    pattern = _JUST_RECIPE if tool == "just" else _MAKE_TARGET
+    for line in text.split("\n"):
+        match = pattern.match(line) if not line.startswith((" ", "\t")) else None
+        if match and "%" not in match.group(1) and "=" not in line.split(":", 1)[0]:
+            name = match.group(1)
+            help_text = _HELP_COMMENT.search(line)
+            description = help_text.group(1).strip() if help_text else previous
+            if tool == "make":
+                command = f"make {name}" if directory == "." else f"make -C {directory} {name}"
+            else:
+                command = _in_directory(directory, f"just {name}")
+            commands.append({"category": category_of(name), "command": command, "description": description or None})
+        previous = line.lstrip("#").strip() if line.startswith("#") else ""
+    return commands
+
+
+def _package_json_commands(text: str, directory: str, siblings: set) -> List[Dict[str, Any]]:
+    """npm/yarn/pnpm/bun install plus every package.json script, using the manager its lockfile names"""
+    try:
+        scripts = json.loads(text).get("scripts") or {}
+    except (ValueError, AttributeError):
+        return []
+    manager = "pnpm" if "pnpm-lock.yaml" in siblings else "yarn" if "yarn.lock" in siblings else \
+        "bun" if {"bun.lockb", "bun.lock"} & siblings else "npm"
+    install = "npm ci" if manager == "npm" and "package-lock.json" in siblings else f"{manager} install"
+    commands = [{"category": "setup", "command": _in_directory(directory, install), "description": None}]
+    for name, script in scripts.items() if isinstance(scripts, dict) else ():
+        if name.startswith(("pre", "post")) and name[3 if name.startswith("pre") else 4:] in scripts:
+            continue
+        run = f"{manager} run {name}" if manager in ("npm", "bun") else f"{manager} {name}"
+        commands.append({"category": category_of(name), "command": _in_directory(directory, run),
+                         "description": str(script)})
+    return commands
+
+
+def _python_commands(files: Dict[str, str], directory: str, siblings: set) -> List[Dict[str, Any]]:
+    """Install and test commands from pyproject.toml, setup.py, requirements.txt, and tox.ini"""
+    commands = []
+    pyproject = files.get("pyproject.toml") or ""
+    if "[tool.poetry" in pyproject:
+        commands.append({"category": "setup", "command": "poetry install", "description": None})
+    elif "uv.lock" in siblings:
+        commands.append({"category": "setup", "command": "uv sync", "description": None})
+    elif "[project]" in pyproject or "[build-system]" in pyproject or "setup.py" in siblings:
+        commands.append({"category": "setup", "command": "pip install -e .", "description": None})
+    if "requirements.txt" in siblings:
+        commands.append({"category": "setup", "command": "pip install -r requirements.txt", "description": None})
+    if "tox.ini" in siblings or "[tool.tox" in pyproject:
+        commands.append({"category": "test", "command": "tox", "description": None})
+    elif "[tool.pytest" in pyproject or "pytest.ini" in siblings or "pytest" in (files.get("requirements.txt") or ""):
+        commands.append({"category": "test", "command": "pytest", "description": None})
+    return [dict(c, command=_in_directory(directory, c["command"])) for c in commands]
+
+
+def _toolchain_commands(name: str, text: str, directory: str, siblings: set) -> List[Dict[str, Any]]:
+    """Conventional build and test commands of go.mod, Cargo.toml, Maven, and Gradle projects"""
+    if name == "go.mod":
+        pairs = [("build", "go build ./..."), ("test", "go test ./..."), ("lint", "go vet ./...")]
+    elif name == "Cargo.toml":
+        if "[package]" not in text and "[workspace]" not in text:
+            return []
+        pairs = [("build", "cargo build"), ("test", "cargo test"), ("lint", "cargo clippy")]
+    elif name == "pom.xml":
+        maven = "./mvnw" if "mvnw" in siblings else "mvn"
+        pairs = [("build", f"{maven} package"), ("test", f"{maven} test")]
+    else:
+        gradle = "./gradlew" if "gradlew" in siblings else "gradle"
+        pairs = [("build", f"{gradle} build"), ("test", f"{gradle} test")]
+    return [{"category": category, "command": _in_directory(directory, command), "description": None}
+            for category, command in pairs]
+
+
+_MANIFESTS = ("Makefile", "GNUmakefile", "makefile", "justfile", "Justfile", "package.json", "pyproject.toml",
+              "setup.py", "requirements.txt", "tox.ini", "go.mod", "Cargo.toml", "pom.xml", "build.gradle",
+              "build.gradle.kts")
+
+
+def detect_commands(all_files: List[str], project_root: Path) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Setup/build/test/lint/run commands found in the project's build files
+    - Makefile and justfile targets (with '## description' or the comment above), package.json scripts
+      (npm/yarn/pnpm/bun chosen by lockfile), Python install/test commands, and the standard go, cargo,
+      Maven, and Gradle commands of each module
+    - Manifests in subdirectories get 'cd dir &&' (or make -C dir); the root's come first, and at most
+      MAX_COMMANDS are returned, each {category, command, source, description}
+    """
+    by_directory: Dict[str, set] = defaultdict(set)
+    for file in all_files:
+        path = Path(file)
+        by_directory[path.parent.as_posix()].add(path.name)
+    commands, seen = [], set()
+    for directory in sorted(by_directory, key=lambda d: (0 if d == "." else d.count("/") + 1, d)):
+        siblings = by_directory[directory]
+        texts = {name: _read(project_root / directory / name) for name in _MANIFESTS if name in siblings}
+        found: List[Tuple[str, Dict[str, Any]]] = []
+        for name, text in texts.items():
+            if text is None:
+                continue
+            if name in ("Makefile", "GNUmakefile", "makefile") or name in ("justfile", "Justfile"):
+                entries = _make_commands(text, directory, "make" if "make" in name.lower() else "just")
+            elif name == "package.json":
+                entries = _package_json_commands(text, directory, siblings)
+            elif name in ("go.mod", "Cargo.toml", "pom.xml", "build.gradle", "build.gradle.kts"):
+                entries = _toolchain_commands(name, text, directory, siblings)
+            else:
+                continue
+            found.extend((name, entry) for entry in entries)
+        python = {n: t for n, t in texts.items() if n in ("pyproject.toml", "setup.py", "requirements.txt", "tox.ini")}
+        if python:
+            source = next(n for n in ("pyproject.toml", "setup.py", "requirements.txt", "tox.ini") if n in python)
+            found.extend((source, entry) for entry in _python_commands(python, directory, siblings))
+        for name, entry in found:
+            if entry["command"] not in seen:
+                seen.add(entry["command"])
+                commands.append({**entry, "source": name if directory == "." else f"{directory}/{name}"})
+    return commands[:MAX_COMMANDS]
+
+
+def _comment_text(line: str) -> str:
+    """A comment line without its markers"""
+    text = line.strip()
+    for marker in _COMMENT_MARKERS:
+        if text.startswith(marker):
+            text = text[len(marker):]
+            break
+    return text.strip().strip('"\'').strip()
+
+
+def _first_sentence(text: str) -> str:
+    """First sentence (or line) of a description, trimmed to one table cell"""
+    text = " ".join(text.split())
+    end = re.search(r"(?<=[.!?])\s", text)
+    text = text[:end.start()] if end else text
+    return text if len(text) <= 160 else text[:157] + "..."
+
+
+def _readme_purpose(text: str) -> Optional[str]:
+    """First prose paragraph of a README (headings, badges, and HTML skipped)"""
+    paragraph = []
+    for line in text.split("\n"):
+        stripped = line.strip()
+        if not stripped or stripped.startswith(("#", "!", "[!", "<", "=", "-" * 3, "```")):
+            if paragraph:
+                break
+            continue
+        paragraph.append(stripped)
+    return _first_sentence(" ".join(paragraph)) if paragraph else None
+
+
+def _source_purpose(directory: Path, names: List[str]) -> Optional[str]:
+    """Go package doc comment or Python package/module docstring of a directory"""
+    for name in sorted(names, key=lambda n: (n != "doc.go", n)):
+        if name.endswith(".go") and not name.endswith("_test.go"):
+            for line in (_read(directory / name) or "").split("\n")[:60]:
+                match = _GO_PACKAGE_DOC.match(line.strip())
+                if match:
+                    return _first_sentence(match.group(1))
+    # A module docstring speaks for the directory only in a package's __init__.py or a lone module
+    modules = [name for name in names if name.endswith(".py")]
+    module = "__init__.py" if "__init__.py" in modules else modules[0] if len(modules) == 1 else None
+    if module:
+        match = re.match(r'\s*(?:#[^\n]*\n\s*)*[rRuU]?("""|\'\'\')\s*(.*?)\1', _read(directory / module) or "", re.S)
+        if match and match.group(2).strip():
+            return _first_sentence(match.group(2).strip().split("\n\n")[0])
+    return None
+
+
+def directory_purposes(aggregates: Dict[str, Any], all_files: List[str], project_root: Path,
+                       module_summaries: Optional[Dict[str, Any]] = None) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: One line on what each top-level (and second-level) directory is for
+    - Taken from the LLM module summary when there is one, else the directory's README, Go package doc
+      or Python docstring, else the directory name ('tests', 'cmd', 'migrations', ...), and finally the
+      dominant language
+    - Second-level directories are listed while the table stays within MAX_DIRECTORIES rows
+    """
+    by_directory: Dict[str, List[str]] = defaultdict(list)
+    for file in all_files:
+        by_directory[Path(file).parent.as_posix()].append(Path(file).name)
+    candidates = sorted(d for d in by_directory if d != "." and d.count("/") == 0)
+    candidates += sorted(d for d in aggregates if d.count("/") == 0 and d != "." and d not in candidates)
+    second = sorted(d for d in set(by_directory) | set(aggregates) if d.count("/") == 1)
+    if len(candidates) + len(second) <= MAX_DIRECTORIES:
+        candidates += second
+    rows = []
+    for directory in sorted(candidates)[:MAX_DIRECTORIES]:
+        totals = aggregates.get(directory, {})
+        names = by_directory.get(directory, [])
+        readme = next((n for n in names if n.lower() in ("readme.md", "readme.rst", "readme.txt", "readme")), None)
+        summary = ((module_summaries or {}).get(directory) or {}).get("summary")
+        purpose = _first_sentence(summary) if summary else None
+        purpose = purpose or (_readme_purpose(_read(project_root / directory / readme) or "") if readme else None)
+        purpose = purpose or _source_purpose(project_root / directory, names)
+        purpose = purpose or _DIRECTORY_HINTS.get(directory.rsplit("/", 1)[-1].lower())
+        languages = totals.get("languages") or {}
+        if not purpose and languages:
+            language, count = max(languages.items(), key=lambda item: (item[1], item[0]))
+            purpose = f"{language.capitalize()} code ({count} file{'s' if count != 1 else ''})"
+        rows.append({"directory": directory, "files": totals.get("files", len(names)),
+                     "functions": totals.get("functions", 0), "coverage": totals.get("coverage_percentage"),
+                     "purpose": purpose or ""})
+    return rows
+
+
+def _doc_line(lines: List[str], index: int, language: str) -> Optional[str]:
+    """First line of a declaration's documentation: the Python docstring below it or the comment above it"""
+    if language == "python":
+        for offset, line in enumerate(lines[index + 1:index + 4], index + 1):
+            match = re.match(r'\s*[rRuU]?("""|\'\'\')\s*(.*)', line)
+            if match:
+                text = match.group(2).split(match.group(1))[0].strip()
+                if not text and offset + 1 < len(lines):
+                    text = lines[offset + 1].strip()
+                return _first_sentence(text) if text else None
+    above = []
+    cursor = index - 1
+    while cursor >= 0 and lines[cursor].strip().startswith(("@", "#[", "[")) and not \
+            lines[cursor].strip().startswith("[!"):
+        cursor -= 1
+    while cursor >= 0 and lines[cursor].strip().startswith(_COMMENT_MARKERS):
+        above.insert(0, _comment_text(lines[cursor]))
+        cursor -= 1
+    text = " ".join(line for line in above if line and not line.startswith("@"))
+    return _first_sentence(text) if text else None
+
+
+def key_types(file_analysis: List[Dict[str, Any]], project_root: Path, language_of,
+              interfaces: Optional[List[Dict[str, Any]]] = None) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: The types a newcomer meets first, ranked by how much hangs off them
+    - Classes, structs, interfaces, traits, protocols, enums, and similar declarations score one point
+      per method and three per implementation (Go interface map); tests and generated code are left out
+    - Each entry carries the first line of its documentation from the source, when it has one
+    """
+    def scope(analysis):
+        # Go methods may sit in any file of the package; elsewhere they belong to the declaring file
+        return Path(analysis["file"]).parent.as_posix() if analysis["language"] == ".go" else analysis["file"]
+
+    methods: Counter = Counter()
+    for analysis in file_analysis:
+        directory = scope(analysis)
+        for symbol in analysis.get("symbols", []):
+            owner = symbol.get("parent") or (receiver_base(symbol["receiver"]) if symbol.get("receiver") else None)
+            if isinstance(owner, str) and owner and symbol.get("kind") in ("method", "constructor", "property",
+                                                                             "trait_method", "initializer"):
+                methods[(directory, owner.rsplit(".", 1)[-1])] += 1
+    implementations = {(Path(i["file"]).parent.as_posix(), i["interface"]): len(i.get("implementations", []))
+                       for i in interfaces or []}
+    candidates = []
+    for analysis in file_analysis:
+        directory = scope(analysis)
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("kind") not in TYPE_KINDS or symbol.get("doc_exempt"):
+                continue
+            key = (directory, symbol["name"])
+            score = methods[key] + 3 * implementations.get(key, 0)
+            candidates.append((score, analysis, symbol, methods[key], implementations.get(key, 0)))
+    candidates.sort(key=lambda c: (-c[0], c[1]["file"], c[2].get("line", 0)))
+    types, lines_of = [], {}
+    for score, analysis, symbol, method_count, implementation_count in candidates[:MAX_KEY_TYPES]:
+        if analysis["file"] not in lines_of:
+            lines_of[analysis["file"]] = (_read(project_root / analysis["file"]) or "").split("\n")
+        lines = lines_of[analysis["file"]]
+        index = symbol.get("line", 0) - 1
+        doc = _doc_line(lines, index, language_of(analysis["language"])) if 0 <= index < len(lines) else None
+        types.append({"name": qualified_name(symbol), "kind": symbol["kind"], "file": analysis["file"],
+                      "line": symbol.get("line", 0), "methods": method_count,
+                      "implementations": implementation_count, "documented": bool(symbol.get("documented")),
+                      "doc": doc})
+    return types
+
+
+def _cell(text: Any) -> str:
+    """Table cell text with pipes escaped"""
+    return str(text).replace("|", "\\|").replace("\n", " ")
+
+
+def render_onboarding(summary: Dict[str, Any], directories: List[Dict[str, Any]], types: List[Dict[str, Any]],
+                      commands: List[Dict[str, Any]], generator_path: str) -> str:
+    """
+    # @codebase-summary: ONBOARDING.md text
+    - Sections: overview, getting started (commands by category), project layout, entrypoints, key
+      types and interfaces, and where documentation is thin
+    - Starts with GENERATED_MARKER so later runs know the file may be replaced
+    """
+    code = summary["code_analysis"]
+    entry = summary.get("entrypoints") or {}
+    out = [GENERATED_MARKER, f"# Onboarding: {summary['project_name']}", ""]
+    if summary.get("description"):
+        out += [summary["description"], ""]
+    out += [f"*Generated by `{generator_path} docgen` from scan v{summary['version']} "
+            f"({summary['updated_at'][:10]}). Regenerate it instead of editing by hand.*", ""]
+
+    languages = (code.get("aggregates", {}).get("by_language") or {})
+    ranked = sorted(languages.items(), key=lambda item: (-item[1].get("files", 0), item[0]))
+    out += ["## Overview", ""]
+    if ranked:
+        out.append("- **Languages:** " + ", ".join(f"{name} ({totals.get('files', 0)} files)"
+                                                   for name, totals in ranked[:8]))
+    out.append(f"- **Size:** {code['total_files_analyzed']} source files, {code.get('total_lines_of_code', 0)} lines, "
+               f"{code['total_functions']} functions ({code['coverage_percentage']}% documented)")
+    if entry.get("frameworks"):
+        out.append("- **Frameworks:** " + ", ".join(f"{f['name']} ({f['category']})" for f in entry["frameworks"]))
+    out.append("")
+
+    out += ["## Getting Started", ""]
+    if commands:
+        for category in CATEGORIES:
+            listed = [c for c in commands if c["category"] == category]
+            if not listed:
+                continue
+            out += [f"### {category.capitalize()}", "", "| Command | From | Notes |", "|---|---|---|"]
+            out += [f"| `{_cell(c['command'])}` | `{_cell(c['source'])}` | {_cell(c.get('description') or '')} |"
+                    for c in listed]
+            out.append("")
+    else:
+        out += ["No Makefile, justfile, or package manifest with build commands was found.", ""]
+
+    out += ["## Project Layout", ""]
+    if directories:
+        out += ["| Directory | Files | Functions | Documented | Purpose |", "|---|---|---|---|---|"]
+        out += [f"| `{_cell(d['directory'])}/` | {d['files']} | {d['functions']} | "
+                f"{str(d['coverage']) + '%' if d['functions'] else '-'} | {_cell(d['purpose'])} |"
+                for d in directories]
+    else:
+        out.append("All files are in the project root.")
+    out.append("")
+
+    routes = entry.get("http_routes", {})
+    cli = entry.get("cli_commands", {})
+    if entry.get("mains") or entry.get("containers") or entry.get("processes") or routes.get("total") or \
+            cli.get("total") or entry.get("scripts"):
+        out += ["## Entrypoints", ""]
+        if entry.get("mains"):
+            out += ["### Programs", ""] + [f"- `{m['file']}:{m['line']}` ({m.get('kind', 'main')})"
+                                           for m in entry["mains"][:MAX_LISTED]] + [""]
+        runs = [f"- `{c['file']}`: {c['instruction']} `{c['command']}`" for c in entry.get("containers", [])]
+        runs += [f"- `{p['file']}`: {p['process']} `{p['command']}`" for p in entry.get("processes", [])]
+        runs += [f"- `{s['file']}`: {s.get('name', '')} `{s.get('target', s.get('command', ''))}`"
+                 for s in entry.get("scripts", [])]
+        if runs:
+            out += ["### Processes and Containers", ""] + runs[:MAX_LISTED] + [""]
+        if routes.get("total"):
+            out += [f"### HTTP Routes ({routes['total']})", "", "| Method | Path | Handler | Location |", "|---|---|---|---|"]
+            out += [f"| {r['method']} | `{_cell(r['path'])}` | {_cell(r.get('handler') or '')} | `{r['file']}:{r['line']}` |"
+                    for r in routes.get("routes", [])[:MAX_LISTED]]
+            if routes["total"] > MAX_LISTED:
+                out.append(f"\n...and {routes['total'] - MAX_LISTED} more in `codebase_summary.json` (entrypoints.http_routes).")
+            out.append("")
+        if cli.get("total"):
+            out += [f"### CLI Commands ({cli['total']})", ""]
+            out += [f"- `{c['command']}` ({c['framework']}, `{c['file']}:{c['line']}`)"
+                    for c in cli.get("commands", [])[:MAX_LISTED]]
+            out.append("")
+
+    if types:
+        out += ["## Key Types and Interfaces", ""]
+        for t in types:
+            details = [f"{t['methods']} method{'s' if t['methods'] != 1 else ''}"] if t["methods"] else []
+            if t["implementations"]:
+                details.append(f"{t['implementations']} implementation{'s' if t['implementations'] != 1 else ''}")
+            suffix = f" - {t['doc']}" if t["doc"] else ""
+            out.append(f"- **`{t['name']}`** {t['kind']} in `{t['file']}:{t['line']}`"
+                       f"{' (' + ', '.join(details) + ')' if details else ''}{suffix}")
+        out.append("")
+
+    worst = code.get("aggregates", {}).get("worst_documented", [])
+    if worst:
+        out += ["## Where Documentation Is Thin", "",
+                "Read these with care and document what you learn:", ""]
+        out += [f"- `{d['directory']}/`: {d['coverage_percentage']}% of {d['functions']} functions documented"
+                for d in worst[:5]]
+        out.append("")
+    return "\n".join(out)
+
+
+def write_onboarding(path: Path, text: str, force: bool = False) -> bool:
+    """Write ONBOARDING.md; refuses (returns False) to replace a hand-written file unless force is set"""
+    existing = _read(path) if path.exists() else None
+    if existing is not None and not existing.startswith(GENERATED_MARKER) and not force:
+        return False
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text(text, encoding="utf-8")
+    return True
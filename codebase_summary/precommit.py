@@ -0,0 +1,144 @@
+#!/usr/bin/env python3
+"""
+Pre-commit - Git hook installer and the fast --staged check it runs
+The check analyzes only the staged version of staged files and fails on undocumented symbols whose
+declaration is on an added line and that were not already undocumented at HEAD, so legacy gaps and
+unrelated edits never block a commit and typical commits finish well under a second
+"""
+
+import re
+import stat
+import subprocess
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set
+
+from baseline import undocumented_symbols
+
+HOOK_MARKER = "# arkival pre-commit hook"
+_HUNK = re.compile(r"^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@")
+
+
+def _git(root: Path, *args: str, check: bool = True) -> Optional[str]:
+    """stdout of a git command run at root; None on failure when check is False"""
+    result = subprocess.run(["git", "-c", "core.quotePath=false", *args], cwd=root,
+                            capture_output=True, text=True, encoding="utf-8", errors="replace", timeout=60)
+    if result.returncode != 0:
+        if check:
+            raise RuntimeError(result.stderr.strip() or f"git {' '.join(args)} failed")
+        return None
+    return result.stdout
+
+
+def hook_script(script: str) -> str:
+    """pre-commit hook body running the scanner at script (relative to the repository top level)"""
+    return (
+        "#!/bin/sh\n"
+        f"{HOOK_MARKER} - installed by 'update_project_summary.py install-hooks'\n"
+        "# Blocks commits that add undocumented functions; skip it once with 'git commit --no-verify'\n"
+        f'exec python3 "{script}" --staged\n'
+    )
+
+
+def install_hook(project_root: Path, script: Path, force: bool = False) -> bool:
+    """
+    # @codebase-summary: Write the pre-commit hook into the repository's hooks directory
+    - Honors core.hooksPath and worktrees (git rev-parse --git-path hooks)
+    - An existing hook that was not written by Arkival is kept unless force is set; then it is
+      saved next to the new one as pre-commit.local
+    - Returns True when the hook was installed
+    """
+    try:
+        top_level = Path(_git(project_root, "rev-parse", "--show-toplevel").strip())
+        hooks_dir = Path(_git(project_root, "rev-parse", "--git-path", "hooks").strip())
+    except (RuntimeError, OSError) as e:
+        print(f"❌ Not a git repository (or git is unavailable): {e}")
+        return False
+    if not hooks_dir.is_absolute():
+        hooks_dir = (project_root / hooks_dir).resolve()
+    hook = hooks_dir / "pre-commit"
+
+    if hook.exists():
+        current = hook.read_text(encoding="utf-8", errors="ignore")
+        if HOOK_MARKER not in current:
+            if not force:
+                print(f"❌ {hook} already exists - rerun with --force to replace it (it is kept as pre-commit.local)")
+                return False
+            hook.replace(hook.with_name("pre-commit.local"))
+            print(f"📦 Existing hook saved as {hook.with_name('pre-commit.local')}")
+
+    try:
+        relative_script = script.resolve().relative_to(top_level.resolve()).as_posix()
+    except ValueError:
+        relative_script = script.resolve().as_posix()
+    hooks_dir.mkdir(parents=True, exist_ok=True)
+    hook.write_text(hook_script(relative_script), encoding="utf-8")
+    hook.chmod(hook.stat().st_mode | stat.S_IXUSR | stat.S_IXGRP | stat.S_IXOTH)
+    print(f"✅ pre-commit hook installed at {hook}")
+    return True
+
+
+def added_lines(root: Path) -> Dict[str, Set[int]]:
+    """Per staged file (relative to root), the line numbers the staged hunks add or change"""
+    diff = _git(root, "diff", "--cached", "--relative", "--unified=0", "--no-color", "--no-ext-diff",
+                "--diff-filter=ACMR")
+    lines: Dict[str, Set[int]] = {}
+    current = None
+    for line in diff.splitlines():
+        if line.startswith("+++ "):
+            target = line[4:]
+            current = None if target == "/dev/null" else target[2:] if target.startswith("b/") else target
+            if current is not None:
+                lines.setdefault(current, set())
+            continue
+        hunk = _HUNK.match(line)
+        if hunk and current is not None:
+            start, count = int(hunk.group(1)), int(hunk.group(2) or "1")
+            lines[current].update(range(start, start + count))
+    return lines
+
+
+def check_staged(generator) -> int:
+    """
+    # @codebase-summary: --staged check - exit status for the pre-commit hook
+    - Analyzes the index version of each staged code file (not the working tree), and the HEAD
+      version only for files with candidate findings; Go blobs are piped to the go/ast helper, so
+      exported functions are checked exactly as a full scan sees them
+    - Returns 1 when the commit introduces undocumented symbols, 0 otherwise (including outside git)
+    """
+    root = Path(generator.project_root).resolve()
+    try:
+        staged = added_lines(root)
+    except (RuntimeError, OSError) as e:
+        print(f"⚠️ Could not read staged changes - skipping the breadcrumb check: {e}")
+        return 0
+
+    introduced: List[Dict[str, Any]] = []
+    checked = 0
+    for rel_path, changed in sorted(staged.items()):
+        path = root / rel_path
+        if not changed or not generator._is_code_file(path) or path.suffix.lower() == ".ipynb" or \
+                generator._should_ignore_path(path):
+            continue
+        content = _git(root, "show", f":./{rel_path}", check=False)
+        if content is None:
+            continue
+        checked += 1
+        analysis = generator._analyze_code_file(str(path), content=content)
+        candidates = [entry for entry in undocumented_symbols([analysis]) if entry["line"] in changed]
+        if not candidates:
+            continue
+        before = _git(root, "show", f"HEAD:./{rel_path}", check=False)
+        existing = set()
+        if before is not None:
+            existing = {entry["key"] for entry in undocumented_symbols([generator._analyze_code_file(str(path), content=before)])}
+        introduced += [entry for entry in candidates if entry["key"] not in existing]
+
+    if not introduced:
+        print(f"✅ Breadcrumb check passed ({checked} staged file(s))")
+        return 0
+    print(f"❌ This commit adds {len(introduced)} undocumented symbol(s):")
+    for entry in introduced:
+        print(f"   {entry['file']}:{entry['line']} {entry['name']}")
+    print("   Add @codebase-summary breadcrumbs ('update_project_summary.py annotate <file>' inserts stubs),")
+    print("   then stage them - or commit with --no-verify to skip the check once")
+    return 1
@@ -0,0 +1,222 @@
+#!/usr/bin/env python3
+"""
+Project Config - Scanner settings from an arkival.yaml at the project root
+Covers include/exclude globs, language toggles, thresholds, output formats and options,
+breadcrumb styles, and required license headers; every value is a default that the matching command-line flag overrides
+"""
+
+import fnmatch
+import json
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+CONFIG_FILENAMES = ("arkival.yaml", "arkival.yml", "arkival.json")
+
+# Top-level keys and the CLI option each scalar setting stands in for
+_THRESHOLD_OPTIONS = {"min_coverage": "--min-coverage", "max_complexity": "--max-complexity",
+                      "max_function_length": "--max-function-length", "max_params": "--max-params",
+                      "max_nesting": "--max-nesting"}
+_KNOWN_KEYS = {"include", "exclude", "languages", "thresholds", "formats", "options", "breadcrumbs", "license_header"}
+
+try:
+    import yaml
+    YAML_AVAILABLE = True
+except ImportError:
+    YAML_AVAILABLE = False
+
+
+def _scalar(text: str) -> Any:
+    """YAML scalar: quoted string, number, bool, null, inline [list], or plain string"""
+    text = text.strip()
+    if not text:
+        return None
+    if text[0] in "'\"" and text[-1] == text[0] and len(text) > 1:
+        return text[1:-1].replace("''", "'") if text[0] == "'" else json.loads(text)
+    if text.startswith("[") and text.endswith("]"):
+        inner = text[1:-1].strip()
+        return [_scalar(item) for item in re.findall(r"""'[^']*'|"(?:[^"\\]|\\.)*"|[^,]+""", inner)] if inner else []
+    lowered = text.lower()
+    if lowered in ("true", "yes", "on"):
+        return True
+    if lowered in ("false", "no", "off"):
+        return False
+    if lowered in ("null", "~"):
+        return None
+    if re.fullmatch(r"-?\d+", text):
+        return int(text)
+    if re.fullmatch(r"-?\d+\.\d*", text):
+        return float(text)
+    return text
+
+
+def _strip_comment(line: str) -> str:
+    """Line without a trailing '# comment' (outside quotes)"""
+    quote = None
+    for i, char in enumerate(line):
+        if quote:
+            if char == quote:
+                quote = None
+        elif char in "'\"":
+            quote = char
+        elif char == "#" and (i == 0 or line[i - 1] in " \t"):
+            return line[:i].rstrip()
+    return line.rstrip()
+
+
+def parse_simple_yaml(text: str) -> Any:
+    """
+    # @codebase-summary: Fallback parser for the YAML subset arkival.yaml needs (no PyYAML installed)
+    - Block mappings and block sequences nested by indentation, inline [a, b] lists, quoted and
+      plain scalars, and '#' comments; anchors, multi-line strings, and flow mappings are not supported
+    """
+    rows: List[Tuple[int, str]] = []
+    for number, raw in enumerate(text.splitlines(), 1):
+        line = _strip_comment(raw)
+        if line.strip() and line.strip() != "---":
+            if "\t" in line[:len(line) - len(line.lstrip())]:
+                raise ValueError(f"line {number}: tabs are not allowed for indentation")
+            rows.append((len(line) - len(line.lstrip()), line.strip()))
+
+    def is_item(i: int) -> bool:
+        return i < len(rows) and (rows[i][1] == "-" or rows[i][1].startswith("- "))
+
+    def block(start: int, indent: int) -> Tuple[Any, int]:
+        if is_item(start):
+            items = []
+            i = start
+            while is_item(i) and rows[i][0] == indent:
+                rest = rows[i][1][1:].strip()
+                if rest:
+                    items.append(_scalar(rest))
+                    i += 1
+                else:
+                    value, i = block(i + 1, rows[i + 1][0]) if i + 1 < len(rows) and rows[i + 1][0] > indent else (None, i + 1)
+                    items.append(value)
+            return items, i
+        mapping: Dict[str, Any] = {}
+        i = start
+        while i < len(rows) and rows[i][0] == indent:
+            key, sep, rest = rows[i][1].partition(":")
+            if not sep or (rest and not rest.startswith(" ")):
+                raise ValueError(f"expected 'key: value', got '{rows[i][1]}'")
+            key = _scalar(key)
+            if rest.strip():
+                mapping[key] = _scalar(rest)
+                i += 1
+            elif i + 1 < len(rows) and (rows[i + 1][0] > indent or (rows[i + 1][0] == indent and is_item(i + 1))):
+                mapping[key], i = block(i + 1, rows[i + 1][0])
+            else:
+                mapping[key] = None
+                i += 1
+        return mapping, i
+
+    if not rows:
+        return {}
+    value, end = block(0, rows[0][0])
+    if end != len(rows):
+        raise ValueError(f"unexpected indentation at '{rows[end][1]}'")
+    return value
+
+
+def glob_matches(rel_path: str, pattern: str) -> bool:
+    """
+    # @codebase-summary: include/exclude glob match for a '/'-separated relative path
+    - 'gen' or 'gen/' covers the whole subtree; a pattern without '/' also matches any single path segment
+    - Shared by arkival.yaml include/exclude and .arkival-policy exclude patterns
+    """
+    pattern = pattern.strip().rstrip("/")
+    if not pattern:
+        return False
+    if fnmatch.fnmatch(rel_path, pattern) or rel_path.startswith(pattern + "/"):
+        return True
+    return "/" not in pattern and any(fnmatch.fnmatch(part, pattern) for part in rel_path.split("/"))
+
+
+def find_config(project_root: Path, explicit: Optional[str] = None) -> Optional[Path]:
+    """The config file to use: --config FILE, else the first CONFIG_FILENAMES entry at the project root"""
+    if explicit:
+        return Path(explicit)
+    return next((project_root / name for name in CONFIG_FILENAMES if (project_root / name).is_file()), None)
+
+
+def _string_list(config: Dict[str, Any], key: str) -> List[str]:
+    """A list-of-strings setting (a single string is accepted as a one-item list)"""
+    value = config.get(key, [])
+    if isinstance(value, str):
+        value = [value]
+    if not isinstance(value, list) or not all(isinstance(item, str) for item in value):
+        raise ValueError(f"'{key}' must be a list of strings")
+    return value
+
+
+def load_project_config(path: Path) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Read and validate a project config file
+    - JSON files need nothing extra; YAML uses PyYAML when installed and the built-in subset parser otherwise
+    - Returns include/exclude globs, language toggles, breadcrumb style definitions, license header
+      templates, and "cli" -
+      option defaults ('--format', '--min-coverage', '--workers'...) that explicit flags override
+    - Raises ValueError for unreadable files and invalid settings; unknown keys only warn
+    """
+    try:
+        text = path.read_text(encoding="utf-8")
+    except OSError as e:
+        raise ValueError(f"cannot read {path}: {e}")
+    try:
+        if path.suffix == ".json":
+            data = json.loads(text)
+        elif YAML_AVAILABLE:
+            data = yaml.safe_load(text)
+        else:
+            data = parse_simple_yaml(text)
+    except Exception as e:  # json/yaml/subset parser errors all mean the file is malformed
+        raise ValueError(f"cannot parse {path.name}: {e}")
+    data = data or {}
+    if not isinstance(data, dict):
+        raise ValueError("the config must be a mapping of settings")
+    for key in sorted(set(data) - _KNOWN_KEYS):
+        print(f"⚠️ Unknown setting '{key}' in {path.name} - expected one of: {', '.join(sorted(_KNOWN_KEYS))}")
+
+    languages = data.get("languages") or {}
+    if not isinstance(languages, dict) or not all(isinstance(v, bool) for v in languages.values()):
+        raise ValueError("'languages' must map language names to true/false")
+
+    cli: Dict[str, str] = {}
+    formats = _string_list(data, "formats")
+    if formats:
+        cli["--format"] = ",".join(formats)
+    thresholds = data.get("thresholds") or {}
+    if not isinstance(thresholds, dict):
+        raise ValueError("'thresholds' must be a mapping")
+    for key, value in thresholds.items():
+        if key not in _THRESHOLD_OPTIONS:
+            raise ValueError(f"unknown threshold '{key}' (supported: {', '.join(_THRESHOLD_OPTIONS)})")
+        if isinstance(value, dict):  # min_coverage: {"*": 75, python: 90}
+            value = ",".join(str(v) if k in ("*", "overall") else f"{k}={v}" for k, v in value.items())
+        cli[_THRESHOLD_OPTIONS[key]] = str(value)
+    options = data.get("options") or {}
+    if not isinstance(options, dict):
+        raise ValueError("'options' must map option names (e.g. sarif-output) to values")
+    for key, value in options.items():
+        if isinstance(value, (dict, list, bool)) or value is None:
+            raise ValueError(f"option '{key}' needs a single value (on/off flags such as --incremental are command-line only)")
+        cli["--" + str(key).lstrip("-").replace("_", "-")] = str(value)
+
+    breadcrumbs = data.get("breadcrumbs") or {}
+    if not isinstance(breadcrumbs, dict):
+        raise ValueError("'breadcrumbs' must map languages (or '*') to styles")
+    license_header = data.get("license_header") or {}
+    if isinstance(license_header, (str, list)):  # one header for every language
+        license_header = {"*": license_header}
+    if not isinstance(license_header, dict):
+        raise ValueError("'license_header' must be header text or map languages (or '*') to header text")
+    return {
+        "path": path,
+        "include": _string_list(data, "include"),
+        "exclude": _string_list(data, "exclude"),
+        "languages": {str(k).lower(): v for k, v in languages.items()},
+        "breadcrumbs": breadcrumbs,
+        "license_header": license_header,
+        "cli": cli,
+    }
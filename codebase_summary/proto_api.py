@@ -0,0 +1,105 @@
+#!/usr/bin/env python3
+"""
+Proto API - Protobuf service and message surface, cross-linked to generated Go stubs
+Matches .proto services and messages against the protoc-gen-go / protoc-gen-go-grpc output in the scan
+"""
+
+import re
+from typing import Dict, Any, List, Optional
+
+from memory_budget import in_file_order
+
+
+def go_package_name(go_package: Optional[str]) -> Optional[str]:
+    """'example.com/gen/greeterpb;greeterpb' -> 'greeterpb'; 'example.com/gen/greeter-v1' -> 'greeter_v1'"""
+    if not go_package:
+        return None
+    path, _, explicit = go_package.partition(";")
+    return explicit or re.sub(r'[^\w]', '_', path.rstrip("/").rsplit("/", 1)[-1])
+
+
+def _go_index(file_analysis: List[Dict[str, Any]]) -> Dict[tuple, Dict[str, Any]]:
+    """(package, name) -> location of every scanned Go declaration; package is '' for regex-parsed files"""
+    index = {}
+    for analysis in in_file_order(file_analysis):
+        if analysis.get("language") != ".go":
+            continue
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("kind") == "method":
+                continue
+            location = {"file": analysis["file"], "line": symbol.get("line", 0), "kind": symbol.get("kind")}
+            index.setdefault((symbol.get("package", ""), symbol["name"]), location)
+    return index
+
+
+def _find_go(index: Dict[tuple, Dict[str, Any]], package: Optional[str], name: str,
+             kind: Optional[str] = None) -> Optional[str]:
+    """'file:line' of a Go declaration in the stub package (or anywhere when the package is unknown)"""
+    candidates = [loc for (pkg, n), loc in index.items()
+                  if n == name and (package is None or pkg in (package, "")) and (kind is None or loc["kind"] == kind)]
+    if not candidates:
+        return None
+    return f"{candidates[0]['file']}:{candidates[0]['line']}"
+
+
+def build_proto_api_map(file_analysis: List[Dict[str, Any]], max_listed: int = 50) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Protobuf API surface with generated Go stub cross-links
+    - Lists each service with its RPC methods (request/response types and streaming flags)
+    - Links services to the generated <Service>Server/<Service>Client interfaces and
+      Register<Service>Server, and messages to their Go structs (nested as Outer_Inner)
+    - Stubs are looked up in the Go package named by option go_package when the file sets one
+    - Returns an empty dict when the scan contains no .proto files
+    """
+    index = _go_index(file_analysis)
+    services = []
+    messages = 0
+    linked_messages = 0
+    unlinked = []
+    proto_files = 0
+
+    for analysis in in_file_order(file_analysis):
+        if analysis.get("language") != ".proto":
+            continue
+        proto_files += 1
+        symbols = analysis.get("symbols", [])
+        for symbol in symbols:
+            package = go_package_name(symbol.get("go_package"))
+            if symbol.get("kind") == "service":
+                name = symbol["name"]
+                rpcs = [
+                    {key: rpc[key] for key in ("name", "request", "response", "client_streaming",
+                                               "server_streaming", "deprecated") if key in rpc}
+                    for rpc in symbols if rpc.get("kind") == "rpc" and rpc.get("parent") == name
+                ]
+                stubs = {
+                    "server": _find_go(index, package, f"{name}Server", "interface"),
+                    "client": _find_go(index, package, f"{name}Client", "interface"),
+                    "register": _find_go(index, package, f"Register{name}Server", "function"),
+                }
+                services.append({
+                    "service": f"{symbol['package']}.{name}" if symbol.get("package") else name,
+                    "file": analysis["file"],
+                    "line": symbol.get("line", 0),
+                    "rpcs": rpcs,
+                    "go_stubs": {role: location for role, location in stubs.items() if location}
+                })
+            elif symbol.get("kind") == "message":
+                messages += 1
+                go_name = "_".join(filter(None, [symbol.get("parent", "").replace(".", "_"), symbol["name"]]))
+                if _find_go(index, package, go_name, "struct"):
+                    linked_messages += 1
+                else:
+                    unlinked.append(f"{analysis['file']}:{symbol['name']}")
+
+    if not proto_files:
+        return {}
+    return {
+        "proto_files": proto_files,
+        "services": services[:max_listed],
+        "rpc_count": sum(len(s["rpcs"]) for s in services),
+        "messages": messages,
+        "messages_with_go_structs": linked_messages,
+        # Only meaningful once Go code is generated for the protos at all
+        "messages_without_go_structs": unlinked[:max_listed] if linked_messages else []
+    }
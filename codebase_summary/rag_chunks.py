@@ -0,0 +1,173 @@
+#!/usr/bin/env python3
+"""
+RAG Chunks - Function-level JSONL chunks for embedding pipelines (--format chunks)
+Files are cut along the scanner's symbols instead of fixed-size windows: one chunk per function or
+method (with the comments and decorators above it), one per type declaration header, and one for each
+stretch of code between them (module docs, imports, constants). Every record carries the metadata a
+vector store needs
+"""
+
+import re
+import json
+import hashlib
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from complexity import FUNCTION_KINDS, clean_lines, function_body, indent_width
+from doc_drift import qualified_name
+from symbol_search import signature_of
+from memory_budget import in_file_order
+from text_encoding import file_size, read_text
+
+CHUNK_FORMAT_VERSION = 1
+# Longer chunks are split into parts of this many lines, each repeating the previous part's last lines
+MAX_CHUNK_LINES = 150
+OVERLAP_LINES = 10
+MAX_FILE_BYTES = 1024 * 1024
+# Closing line of a body the indentation-based end detection leaves out
+_CLOSERS = ("end", "end;", "end)", "}", "};")
+# Gap lines not worth a chunk of their own: blank, or a lone token ('end', '}', 'private')
+_STRAY = re.compile(r"^\s*[^\w\s]*\w*[^\w\s]*\s*$")
+# Lines directly above a symbol that belong to it: comments, doc comments, decorators, attributes
+_LEADING_PREFIXES = ("#", "//", "/*", "*", "--", ";", "%", "@", "'''", '"""', "<!--", "(*", "{-")
+
+
+def _leading_start(lines: List[str], index: int, floor: int) -> int:
+    """First line of the comment/decorator block directly above a declaration (never above floor)"""
+    start = index
+    while start - 1 >= floor and lines[start - 1].strip().startswith(_LEADING_PREFIXES):
+        start -= 1
+    return start
+
+
+def _symbol_ranges(symbols: List[Dict[str, Any]], lines: List[str],
+                   language: str) -> List[Tuple[Dict[str, Any], int, int]]:
+    """(symbol, first, last) line indexes of each symbol's declaration and body, in file order"""
+    cleaned = clean_lines(lines, language)
+    ranges = []
+    for symbol in sorted(symbols, key=lambda s: s.get("line", 0)):
+        index = symbol.get("line", 0) - 1
+        if not 0 <= index < len(lines):
+            continue
+        if symbol.get("end_line"):
+            last = symbol["end_line"] - 1
+        else:
+            body = function_body(cleaned, index, language)
+            last = body[1] if body else index
+            # Ruby/Lua/Elixir close the body with an 'end' at the declaration's indentation
+            if last + 1 < len(lines) and lines[last + 1].strip() in _CLOSERS and \
+                    indent_width(lines[last + 1]) == indent_width(lines[index]):
+                last += 1
+        ranges.append((symbol, index, min(max(last, index), len(lines) - 1)))
+    return ranges
+
+
+def _file_chunks(analysis: Dict[str, Any], lines: List[str], language: str) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Chunks of one file before splitting: functions, type headers, and the preamble
+    - Functions nested in another function stay inside the outer chunk; methods get chunks of their own
+    - A class/struct/interface chunk runs from its declaration to its first method, or covers the
+      whole declaration when it has none (structs, enums, messages)
+    - Lines no symbol covers (preamble, constants, unlisted helpers) become 'module' chunks, unless
+      they hold nothing but lone tokens such as a closing 'end' or an access modifier
+    """
+    ranges = _symbol_ranges(analysis.get("symbols", []), lines, language)
+    functions = [(s, first, last) for s, first, last in ranges if s.get("kind", "function") in FUNCTION_KINDS]
+    chunks = []
+    for symbol, first, last in ranges:
+        if symbol.get("kind", "function") in FUNCTION_KINDS:
+            if any(f is not symbol and f_first < first and last <= f_last for f, f_first, f_last in functions):
+                continue
+        else:
+            inner = [f_first for _, f_first, f_last in functions if first < f_first <= last]
+            if inner:
+                last = _leading_start(lines, min(inner), first + 1) - 1
+        chunks.append({"symbol": symbol, "first": _leading_start(lines, first, 0), "last": last})
+
+    # Code outside every symbol (module docs, imports, constants, helpers the scanner does not list)
+    gaps, covered = [], 0
+    for chunk in sorted(chunks, key=lambda c: c["first"]) + [{"first": len(lines), "last": len(lines) - 1}]:
+        if chunk["first"] > covered and not all(_STRAY.match(line) for line in lines[covered:chunk["first"]]):
+            gaps.append({"symbol": None, "first": covered, "last": chunk["first"] - 1})
+        covered = max(covered, chunk["last"] + 1)
+    chunks = sorted(chunks + gaps, key=lambda c: c["first"])
+    for chunk in chunks:
+        while chunk["first"] < chunk["last"] and not lines[chunk["first"]].strip():
+            chunk["first"] += 1
+        while chunk["last"] > chunk["first"] and not lines[chunk["last"]].strip():
+            chunk["last"] -= 1
+    return chunks
+
+
+def _parts(first: int, last: int) -> List[Tuple[int, int]]:
+    """(first, last) windows of at most MAX_CHUNK_LINES lines, overlapping by OVERLAP_LINES"""
+    if last - first + 1 <= MAX_CHUNK_LINES:
+        return [(first, last)]
+    windows, start = [], first
+    while True:
+        end = min(start + MAX_CHUNK_LINES - 1, last)
+        windows.append((start, end))
+        if end == last:
+            return windows
+        start = end + 1 - OVERLAP_LINES
+
+
+def build_chunks(file_analysis: List[Dict[str, Any]], project_root: Path, language_of,
+                 project: Optional[str] = None, version: Optional[str] = None) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Embedding-ready records ({id, text, metadata}) for every scanned file
+    - ids are built from the file and qualified symbol name (not line numbers), so an upsert replaces a
+      function's vectors after edits; metadata.content_hash tells which chunks need re-embedding
+    - Metadata: file, language, kind, symbol, qualified_name, signature, start_line/end_line (1-based,
+      inclusive), documented, complexity, part/parts for split chunks, and a rough token_estimate;
+      keys without a value (a preamble's symbol) are left out
+    - Notebooks and files over MAX_FILE_BYTES are skipped
+    """
+    records, seen = [], set()
+    for analysis in in_file_order(file_analysis):
+        if analysis["language"] == ".ipynb":
+            continue
+        path = project_root / analysis["file"]
+        try:
+            if file_size(path) > MAX_FILE_BYTES:
+                continue
+            lines = read_text(path).split("\n")
+        except OSError:
+            continue
+        language = language_of(analysis["language"])
+        for chunk in _file_chunks(analysis, lines, language):
+            symbol = chunk["symbol"]
+            name = qualified_name(symbol) if symbol else None
+            base_id = f"{analysis['file']}::{name or '<module>'}"
+            if base_id in seen:
+                base_id = f"{base_id}@{symbol.get('line', 0) if symbol else chunk['first'] + 1}"
+            seen.add(base_id)
+            windows = _parts(chunk["first"], chunk["last"])
+            for part, (first, last) in enumerate(windows, 1):
+                text = "\n".join(lines[first:last + 1])
+                metadata = {
+                    "format_version": CHUNK_FORMAT_VERSION, "file": analysis["file"], "language": language,
+                    "kind": symbol.get("kind", "function") if symbol else "module",
+                    "symbol": symbol["name"] if symbol else None, "qualified_name": name,
+                    "signature": signature_of(symbol) if symbol else None,
+                    "start_line": first + 1, "end_line": last + 1,
+                    "documented": bool(symbol.get("documented")) if symbol else None,
+                    "complexity": symbol.get("complexity") if symbol else None,
+                    "part": part, "parts": len(windows),
+                    "content_hash": hashlib.sha256(text.encode("utf-8")).hexdigest(),
+                    "token_estimate": len(text) // 4 + 1,
+                }
+                metadata.update(project=project, version=version)
+                # Several vector stores reject null metadata values
+                records.append({"id": base_id if len(windows) == 1 else f"{base_id}#{part}", "text": text,
+                                "metadata": {key: value for key, value in metadata.items() if value is not None}})
+    return records
+
+
+def write_chunks(output_path: Path, records: List[Dict[str, Any]]) -> int:
+    """Write one JSON record per line; returns the chunk count"""
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    with open(output_path, "w", encoding="utf-8") as f:
+        for record in records:
+            f.write(json.dumps(record, ensure_ascii=False) + "\n")
+    return len(records)
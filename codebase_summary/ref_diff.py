@@ -0,0 +1,260 @@
+#!/usr/bin/env python3
+"""
+Ref Diff - Public function changes between two git refs, as release-notes input ('apidiff --from --to')
+Both trees are read through git plumbing (ls-tree and cat-file) and analyzed in memory, so the working tree,
+index, and current checkout are never touched; every language the scanner knows is compared
+"""
+
+import re
+import subprocess
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set, Tuple
+
+import language_extractors
+from complexity import FUNCTION_KINDS
+from dead_code import is_test_path, python_all_names
+from doc_drift import qualified_name
+from go_api import is_exported, receiver_base
+from symbol_search import signature_of
+from text_encoding import decode
+
+# Languages whose declarations are private unless marked export/pub/public (or flagged by the extractor)
+_KEYWORD_VISIBILITY = {"java", "csharp", "rust", "typescript", "tsx", "javascript", "swift", "solidity"}
+_PUBLIC_KEYWORDS = re.compile(r"^\s*(?:@\w+(?:\([^)]*\))?\s+)*(?:export\b|pub\b|pub\(crate\)|public\b|open\b)")
+_PRIVATE_DECLARATION = re.compile(r"^\s*(?:static\b|private\b|fileprivate\b|internal\b|protected\b|defp\b)")
+MAX_BLOB_BYTES = 1024 * 1024
+
+
+def _git(root: Path, *args: str, data: Optional[bytes] = None) -> bytes:
+    """Raw stdout of a git command at root; raises RuntimeError with git's message on failure"""
+    try:
+        result = subprocess.run(["git", "-c", "core.quotePath=false", *args], cwd=root, input=data,
+                                capture_output=True, timeout=300)
+    except (OSError, subprocess.TimeoutExpired) as e:
+        raise RuntimeError(f"git {args[0]}: {e}")
+    if result.returncode != 0:
+        raise RuntimeError(result.stderr.decode("utf-8", errors="replace").strip() or f"git {args[0]} failed")
+    return result.stdout
+
+
+def resolve_ref(root: Path, ref: str) -> str:
+    """Commit id of a branch, tag, or revision expression"""
+    return _git(root, "rev-parse", "--verify", "--quiet", f"{ref}^{{commit}}").decode().strip()
+
+
+def tree_blobs(root: Path, commit: str) -> List[Tuple[str, str, int]]:
+    """(path relative to root, blob id, size) of each regular file below root at a commit (symlinks and submodules skipped)"""
+    blobs = []
+    for record in _git(root, "ls-tree", "-r", "-l", "-z", commit, "--", ".").split(b"\0"):
+        if not record:
+            continue
+        meta, _, path = record.partition(b"\t")
+        mode, kind, sha, size = meta.split()
+        if kind == b"blob" and mode in (b"100644", b"100755"):
+            blobs.append((path.decode("utf-8", errors="replace"), sha.decode(), int(size)))
+    return blobs
+
+
+def read_blobs(root: Path, shas: List[str]) -> Dict[str, str]:
+    """Contents of many blobs from one 'git cat-file --batch' call"""
+    if not shas:
+        return {}
+    output = _git(root, "cat-file", "--batch", data="\n".join(shas).encode() + b"\n")
+    contents, position = {}, 0
+    while position < len(output):
+        header_end = output.index(b"\n", position)
+        header = output[position:header_end].split()
+        if len(header) < 3:  # '<sha> missing'
+            position = header_end + 1
+            continue
+        sha, kind, size = header[:3]
+        start = header_end + 1
+        contents[sha.decode()] = decode(output[start:start + int(size)])[0]
+        position = start + int(size) + 1
+    return contents
+
+
+def scan_ref(generator, commit: str) -> List[Tuple[Dict[str, Any], List[str]]]:
+    """
+    # @codebase-summary: (analysis, lines) of each code file of the project as it was at a commit
+    - Files are picked like a normal scan: code extensions and build-file names (extensionless scripts
+      by the blob's shebang), minus the current ignore rules and arkival.yaml include/exclude
+    - Blobs over MAX_BLOB_BYTES are skipped; Go blobs are piped to the go/ast helper, as for unsaved text
+    """
+    root = Path(generator.project_root)
+    candidates = []
+    for rel_path, sha, size in tree_blobs(root, commit):
+        path = root / rel_path
+        if size > MAX_BLOB_BYTES or generator._should_ignore_path(path):
+            continue
+        if path.suffix or path.name in generator.code_filenames:
+            if not generator._is_code_file(path):
+                continue
+        candidates.append((path, sha))
+    contents = read_blobs(root, sorted({sha for _, sha in candidates}))
+    analyses = []
+    for path, sha in candidates:
+        content = contents.get(sha)
+        if content is None:
+            continue
+        if not path.suffix and path.name not in generator.code_filenames and \
+                language_extractors.shebang_language(content.split("\n", 1)[0].rstrip()) is None:
+            continue
+        analyses.append((generator._analyze_code_file(str(path), content=content), content.split("\n")))
+    return analyses
+
+
+def _api_name(symbol: Dict[str, Any]) -> str:
+    """Qualified name, with the receiver type for Go methods ('Stack.Push')"""
+    if symbol.get("receiver"):
+        return f"{receiver_base(symbol['receiver'])}.{symbol['name']}"
+    return qualified_name(symbol)
+
+
+def is_public(symbol: Dict[str, Any], analysis: Dict[str, Any], language: str, lines: List[str],
+              module_all: Set[str]) -> bool:
+    """
+    # @codebase-summary: Whether a function is part of the project's public API
+    - Go: exported names (methods also need an exported receiver); export/pub/public languages: the
+      extractor's exported flag or a visibility keyword on the declaration line
+    - Elsewhere: names without a leading underscore that are not declared static/private, limited to
+      __all__ for top-level Python functions of modules that define it
+    - Test code and functions nested in other functions never count
+    """
+    if analysis.get("go_test_file") or is_test_path(analysis["file"]):
+        return False
+    # Functions nested in another function are not reachable from outside it
+    if symbol.get("kind", "function") == "function" and symbol.get("parent"):
+        return False
+    name = symbol["name"]
+    if language == "go":
+        return is_exported(name) and (not symbol.get("receiver") or is_exported(receiver_base(symbol["receiver"])))
+    index = symbol.get("line", 0) - 1
+    declaration = lines[index] if 0 <= index < len(lines) else ""
+    if language in _KEYWORD_VISIBILITY:
+        return bool(symbol.get("exported")) or bool(_PUBLIC_KEYWORDS.match(declaration))
+    if name.startswith("_") or _PRIVATE_DECLARATION.match(declaration):
+        return False
+    if language == "python" and module_all and not symbol.get("parent"):
+        return name in module_all
+    return True
+
+
+def public_functions(scanned: List[Tuple[Dict[str, Any], List[str]]],
+                     language_of) -> Dict[Tuple[str, str], Dict[str, Any]]:
+    """Public functions and methods of scan_ref() results keyed by (file, name); repeated names (overloads) get an occurrence suffix"""
+    keyed = {}
+    for analysis, lines in scanned:
+        language = language_of(analysis["language"])
+        module_all = python_all_names(lines) if language == "python" else set()
+        occurrences: Dict[str, int] = defaultdict(int)
+        for symbol in analysis.get("symbols", []):
+            if symbol.get("kind", "function") not in FUNCTION_KINDS or \
+                    not is_public(symbol, analysis, language, lines, module_all):
+                continue
+            name = _api_name(symbol)
+            occurrences[name] += 1
+            key = name if occurrences[name] == 1 else f"{name}#{occurrences[name]}"
+            keyed[(analysis["file"], key)] = {
+                "file": analysis["file"], "name": name, "kind": symbol.get("kind", "function"),
+                "line": symbol.get("line", 0), "language": language, "signature": signature_of(symbol),
+                "shape": (symbol.get("signature"), symbol.get("params"), symbol.get("receiver")),
+                "documented": bool(symbol.get("documented")),
+            }
+    return keyed
+
+
+def diff_public_api(old: Dict[Tuple[str, str], Dict[str, Any]],
+                    new: Dict[Tuple[str, str], Dict[str, Any]]) -> Dict[str, List[Dict[str, Any]]]:
+    """
+    # @codebase-summary: Categorized public function changes between two public_functions() results
+    - changed: same file and name with a different signature (parameters, Go signature, or receiver)
+    - moved: the same name and kind removed from one file and added to another (not breaking)
+    - added / removed: everything else; removed and changed are the breaking categories
+    """
+    removed = {key: old[key] for key in old.keys() - new.keys()}
+    added = {key: new[key] for key in new.keys() - old.keys()}
+    moved = []
+    for old_key in sorted(removed):
+        match = next((new_key for new_key in sorted(added) if added[new_key]["name"] == removed[old_key]["name"]
+                      and added[new_key]["kind"] == removed[old_key]["kind"]), None)
+        if match:
+            moved.append({**_public(added.pop(match)), "old_file": removed.pop(old_key)["file"]})
+    changed = [{**_public(new[key]), "old_signature": old[key]["signature"]}
+               for key in sorted(old.keys() & new.keys()) if old[key]["shape"] != new[key]["shape"]]
+    return {
+        "removed": [_public(entry) for _, entry in sorted(removed.items())],
+        "changed": changed,
+        "added": [_public(entry) for _, entry in sorted(added.items())],
+        "moved": moved,
+    }
+
+
+def _public(entry: Dict[str, Any]) -> Dict[str, Any]:
+    """Report form of a public_functions() entry"""
+    return {key: value for key, value in entry.items() if key != "shape"}
+
+
+def compare_refs(generator, old_ref: str, new_ref: str, language_of) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Public API change report between two git refs of the project
+    - Raises RuntimeError when a ref does not resolve or git is unavailable
+    - Returns {from, to, totals, removed, changed, added, moved, breaking}
+    """
+    root = Path(generator.project_root)
+    resolved = []
+    for ref in (old_ref, new_ref):
+        try:
+            resolved.append(resolve_ref(root, ref))
+        except RuntimeError:
+            raise RuntimeError(f"'{ref}' is not a commit in {root}")
+    old_api = public_functions(scan_ref(generator, resolved[0]), language_of)
+    new_api = public_functions(scan_ref(generator, resolved[1]), language_of)
+    changes = diff_public_api(old_api, new_api)
+    return {
+        "from": {"ref": old_ref, "commit": resolved[0], "public_functions": len(old_api)},
+        "to": {"ref": new_ref, "commit": resolved[1], "public_functions": len(new_api)},
+        "totals": {category: len(entries) for category, entries in changes.items()},
+        **changes,
+        "breaking": bool(changes["removed"] or changes["changed"]),
+    }
+
+
+def format_release_notes(report: Dict[str, Any], max_listed: int = 200) -> str:
+    """
+    # @codebase-summary: Markdown change report ready to paste into release notes
+    - Breaking changes (removed, changed signatures) first, then additions and moves, each grouped by file
+    """
+    def grouped(entries: List[Dict[str, Any]], describe) -> List[str]:
+        lines, current = [], None
+        for entry in sorted(entries, key=lambda e: (e["file"], e["name"]))[:max_listed]:
+            if entry["file"] != current:
+                current = entry["file"]
+                lines.append(f"- `{current}`")
+            lines.append(f"  - {describe(entry)}")
+        if len(entries) > max_listed:
+            lines.append(f"- ... and {len(entries) - max_listed} more (use --json for the full list)")
+        return lines + [""]
+
+    old, new = report["from"], report["to"]
+    lines = [f"## API changes: {old['ref']} → {new['ref']}", "",
+             f"Public functions: {old['public_functions']} → {new['public_functions']} "
+             f"({old['commit'][:12]}..{new['commit'][:12]})", ""]
+    if report["breaking"]:
+        lines += ["### ⚠️ Breaking changes", ""]
+        if report["removed"]:
+            lines += [f"#### Removed ({len(report['removed'])})", ""]
+            lines += grouped(report["removed"], lambda e: f"`{e['signature']}`")
+        if report["changed"]:
+            lines += [f"#### Changed signatures ({len(report['changed'])})", ""]
+            lines += grouped(report["changed"], lambda e: f"`{e['old_signature']}` → `{e['signature']}`")
+    if report["added"]:
+        lines += [f"### ✨ Added ({len(report['added'])})", ""]
+        lines += grouped(report["added"], lambda e: f"`{e['signature']}`")
+    if report["moved"]:
+        lines += [f"### 📦 Moved ({len(report['moved'])})", ""]
+        lines += grouped(report["moved"], lambda e: f"`{e['name']}` (from `{e['old_file']}`)")
+    if not any(report["totals"].values()):
+        lines += ["✅ No public API changes", ""]
+    return "\n".join(lines).rstrip() + "\n"
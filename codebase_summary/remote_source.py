@@ -0,0 +1,135 @@
+#!/usr/bin/env python3
+"""
+Remote Source - Fetch a third-party repository by URL for a one-off scan ('scan <url>[@ref]')
+A shallow git clone into a temporary directory, or GitHub's tarball API when git is missing or the clone
+fails; the caller scans what was fetched and removes the temporary directory afterwards
+"""
+
+import os
+import re
+import shutil
+import subprocess
+import urllib.error
+import urllib.parse
+import urllib.request
+from pathlib import Path
+from typing import Dict, Any, Optional, Tuple
+
+GITHUB_API = "https://api.github.com"
+FETCH_TIMEOUT = 600
+# Full commit ids cannot be cloned with --branch; they are fetched by id instead
+_COMMIT_ID = re.compile(r"^(?:[0-9a-f]{40}|[0-9a-f]{64})$")
+_URL = re.compile(r"^((?:https?|ssh|git)://[^/]+/)(.+)$")
+_SCP_URL = re.compile(r"^([\w.-]+@[\w.-]+:)(.+)$")  # git@github.com:org/repo
+_GITHUB = re.compile(r"^(?:https?://(?:[^@/]+@)?|ssh://git@|git@)github\.com[/:]([\w.-]+)/([\w.-]+?)(?:\.git)?/?$")
+
+
+def is_remote(spec: str) -> bool:
+    """Whether a scan target is a repository URL rather than a local path"""
+    return bool(_URL.match(spec) or _SCP_URL.match(spec))
+
+
+def parse_remote(spec: str) -> Tuple[str, Optional[str]]:
+    """
+    # @codebase-summary: (clone URL, ref or None) from 'https://github.com/org/repo[@ref]'
+    - The ref follows the first '@' of the repository path: a branch, tag (release/1.2 works), or commit id
+    - scp-style 'git@host:org/repo@ref' and ssh:// URLs keep their user part
+    - Raises ValueError for anything that is not a repository URL
+    """
+    match = _URL.match(spec) or _SCP_URL.match(spec)
+    if not match:
+        raise ValueError(f"'{spec}' is not a repository URL (https://host/org/repo[@ref])")
+    head, path = match.groups()
+    path, at, ref = path.partition("@")
+    if at and not ref:
+        raise ValueError(f"'{spec}' has an empty ref after '@'")
+    return head + path.rstrip("/"), ref or None
+
+
+def repository_name(url: str) -> str:
+    """Last path component of a clone URL without '.git' ('https://github.com/org/repo.git' -> 'repo')"""
+    name = re.split(r"[/:]", url.rstrip("/"))[-1]
+    return name[:-len(".git")] if name.endswith(".git") else name
+
+
+def github_repository(url: str) -> Optional[Tuple[str, str]]:
+    """(owner, repository) of a github.com URL, else None"""
+    match = _GITHUB.match(url)
+    return match.groups() if match else None
+
+
+def _git(cwd: Path, *args: str) -> str:
+    """stdout of a git command; never prompts for credentials, raises RuntimeError with git's message"""
+    env = {**os.environ, "GIT_TERMINAL_PROMPT": "0"}
+    try:
+        result = subprocess.run(["git", *args], cwd=cwd, env=env, capture_output=True, text=True,
+                                timeout=FETCH_TIMEOUT)
+    except (OSError, subprocess.TimeoutExpired) as e:
+        raise RuntimeError(f"git {args[0]}: {e}")
+    if result.returncode != 0:
+        raise RuntimeError(result.stderr.strip().splitlines()[-1] if result.stderr.strip() else f"git {args[0]} failed")
+    return result.stdout
+
+
+def clone(url: str, ref: Optional[str], target: Path) -> Dict[str, Any]:
+    """Shallow clone (depth 1, no tags, no submodules) of a branch, tag, commit id, or the default branch"""
+    if ref and _COMMIT_ID.match(ref):
+        target.mkdir(parents=True)
+        _git(target, "init", "-q")
+        _git(target, "fetch", "-q", "--depth", "1", url, ref)
+        _git(target, "checkout", "-q", "FETCH_HEAD")
+    else:
+        _git(target.parent, "clone", "-q", "--depth", "1", "--single-branch", "--no-tags",
+             *(["--branch", ref] if ref else []), url, str(target))
+    return {"method": "git", "path": target, "commit": _git(target, "rev-parse", "HEAD").strip()}
+
+
+def download_tarball(owner: str, repository: str, ref: Optional[str], target: Path) -> Dict[str, Any]:
+    """GitHub's tarball of a ref (default branch without one); GITHUB_TOKEN or GH_TOKEN authorizes private repositories"""
+    url = f"{GITHUB_API}/repos/{owner}/{repository}/tarball" + (f"/{urllib.parse.quote(ref)}" if ref else "")
+    headers = {"Accept": "application/vnd.github+json", "User-Agent": "arkival-scanner"}
+    token = os.environ.get("GITHUB_TOKEN") or os.environ.get("GH_TOKEN")
+    if token:
+        headers["Authorization"] = f"Bearer {token}"
+    try:
+        with urllib.request.urlopen(urllib.request.Request(url, headers=headers), timeout=FETCH_TIMEOUT) as response, \
+                open(target, "wb") as out:
+            shutil.copyfileobj(response, out)
+    except urllib.error.HTTPError as e:
+        raise RuntimeError(f"HTTP {e.code} from {url}")
+    except (urllib.error.URLError, OSError) as e:
+        raise RuntimeError(f"{url}: {getattr(e, 'reason', e)}")
+    return {"method": "tarball", "path": target, "commit": None}
+
+
+def tarball_commit(prefix: str) -> Optional[str]:
+    """Abbreviated commit id from a GitHub tarball's top-level directory ('org-repo-1a2b3c4')"""
+    match = re.search(r"-([0-9a-f]{7,40})$", prefix)
+    return match.group(1) if match else None
+
+
+def fetch(url: str, ref: Optional[str], dest: Path) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Repository contents under dest: {"method", "path", "commit"}
+    - 'git': a shallow clone of the ref, path is the working tree
+    - 'tarball': GitHub's tarball API, used when git is not installed or the clone fails (no
+      credentials, git blocked by a proxy); path is the downloaded .tar.gz
+    - Raises RuntimeError with every attempt's reason when nothing could be fetched
+    """
+    errors = []
+    name = repository_name(url)
+    if shutil.which("git"):
+        try:
+            return clone(url, ref, dest / name)
+        except RuntimeError as e:
+            errors.append(f"git clone: {e}")
+            shutil.rmtree(dest / name, ignore_errors=True)
+    else:
+        errors.append("git is not installed")
+    github = github_repository(url)
+    if github:
+        try:
+            return download_tarball(*github, ref, dest / f"{name}.tar.gz")
+        except RuntimeError as e:
+            errors.append(f"GitHub tarball: {e}")
+    raise RuntimeError("; ".join(errors))
@@ -0,0 +1,519 @@
+#!/usr/bin/env python3
+"""
+Report Exporters - Alternate output formats for breadcrumb coverage findings
+Converts the scanner's per-file analysis into formats consumed by external tooling
+"""
+
+import os
+import csv
+import json
+import html
+import re
+import datetime
+import xml.etree.ElementTree as ET
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Callable, Optional, Tuple
+
+import mermaid_diagrams
+import secrets_scan
+from complexity import FUNCTION_KINDS
+from doc_drift import qualified_name
+from memory_budget import in_file_order
+
+ARKIVAL_INFO_URI = "https://github.com/Spitfire-Products/Arkival-V4"
+
+MISSING_BREADCRUMB_RULE = {
+    "id": "ARK001",
+    "name": "MissingBreadcrumb",
+    "shortDescription": {"text": "Function or type is missing a @codebase-summary breadcrumb"},
+    "fullDescription": {
+        "text": "Arkival tracks documentation coverage through @codebase-summary breadcrumbs placed "
+                "within a few lines of each declaration. Undocumented symbols lower coverage and "
+                "leave AI agents without context."
+    },
+    "defaultConfiguration": {"level": "warning"},
+    "helpUri": ARKIVAL_INFO_URI
+}
+
+# Markers delimiting the section an existing Markdown file (e.g. README.md) lets the scanner rewrite
+MARKDOWN_START_MARKER = "<!-- arkival:summary:start -->"
+MARKDOWN_END_MARKER = "<!-- arkival:summary:end -->"
+
+
+def iter_undocumented_symbols(file_analysis: List[Dict[str, Any]]):
+    """Yield (file, symbol) pairs for every undocumented symbol, in file then line order"""
+    for analysis in in_file_order(file_analysis):
+        for symbol in sorted(analysis.get("symbols", []), key=lambda s: s.get("line", 0)):
+            if not symbol.get("documented"):
+                yield analysis["file"], symbol
+
+
+def build_sarif_report(file_analysis: List[Dict[str, Any]], tool_version: str,
+                       secrets: Optional[List[Dict[str, Any]]] = None) -> Dict[str, Any]:
+    """
+    # @codebase-summary: SARIF 2.1.0 report builder for missing breadcrumbs
+    - Emits one result per undocumented function/method/type with file and line
+    - Uses repository-relative URIs so GitHub code scanning can annotate PR diffs
+    - --secrets findings are added as error-level results under their ARK1xx rules
+    """
+    results = []
+    for file_path, symbol in iter_undocumented_symbols(file_analysis):
+        uri = Path(file_path).as_posix()
+        kind = symbol.get("kind", "function")
+        results.append({
+            "ruleId": MISSING_BREADCRUMB_RULE["id"],
+            "ruleIndex": 0,
+            "level": "warning",
+            "message": {"text": f"{kind.capitalize()} '{symbol['name']}' is missing a @codebase-summary breadcrumb"},
+            "locations": [{
+                "physicalLocation": {
+                    "artifactLocation": {"uri": uri, "uriBaseId": "%SRCROOT%"},
+                    "region": {"startLine": max(1, symbol.get("line", 1))}
+                }
+            }],
+            "partialFingerprints": {"arkivalSymbol/v1": f"{uri}:{kind}:{symbol['name']}"}
+        })
+
+    rules = [MISSING_BREADCRUMB_RULE] + secrets_scan.sarif_rules(secrets or [])
+    rule_index = {rule["id"]: index for index, rule in enumerate(rules)}
+    for finding in secrets or []:
+        uri = Path(finding["file"]).as_posix()
+        results.append({
+            "ruleId": finding["rule"],
+            "ruleIndex": rule_index[finding["rule"]],
+            "level": "error",
+            "message": {"text": f"Possible secret ({finding['name']}): {finding['redacted']}"},
+            "locations": [{
+                "physicalLocation": {
+                    "artifactLocation": {"uri": uri, "uriBaseId": "%SRCROOT%"},
+                    "region": {"startLine": finding["line"]}
+                }
+            }],
+            "partialFingerprints": {"arkivalSecret/v1": f"{uri}:{finding['rule']}:{finding['redacted']}"}
+        })
+
+    return {
+        "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+        "version": "2.1.0",
+        "runs": [{
+            "tool": {
+                "driver": {
+                    "name": "Arkival",
+                    "informationUri": ARKIVAL_INFO_URI,
+                    "version": tool_version,
+                    "rules": rules
+                }
+            },
+            "columnKind": "unicodeCodePoints",
+            "results": results
+        }]
+    }
+
+
+def write_sarif_report(output_path: Path, file_analysis: List[Dict[str, Any]], tool_version: str,
+                       secrets: Optional[List[Dict[str, Any]]] = None) -> int:
+    """Write the SARIF report to disk and return the number of results"""
+    report = build_sarif_report(file_analysis, tool_version, secrets)
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    with open(output_path, 'w', encoding='utf-8') as f:
+        json.dump(report, f, indent=2)
+    return len(report["runs"][0]["results"])
+
+
+def _github_escape(value: Any, is_property: bool = False) -> str:
+    """Escape workflow-command data (and ':' / ',' inside properties) as GitHub Actions requires"""
+    text = str(value).replace("%", "%25").replace("\r", "%0D").replace("\n", "%0A")
+    return text.replace(":", "%3A").replace(",", "%2C") if is_property else text
+
+
+def github_annotation(level: str, message: str, file: Optional[str] = None, line: Optional[int] = None,
+                      title: Optional[str] = None) -> str:
+    """One ::warning / ::error workflow command; GitHub shows it inline on the PR diff when file is set"""
+    properties = []
+    if file:
+        properties.append(f"file={_github_escape(Path(file).as_posix(), True)}")
+        if line:
+            properties.append(f"line={max(1, int(line))}")
+    if title:
+        properties.append(f"title={_github_escape(title, True)}")
+    prefix = f"::{level} {','.join(properties)}" if properties else f"::{level}"
+    return f"{prefix}::{_github_escape(message)}"
+
+
+def build_github_annotations(file_analysis: List[Dict[str, Any]]) -> List[str]:
+    """Warning annotations for every undocumented symbol (doc-exempt symbols are not findings)"""
+    return [
+        github_annotation("warning", f"{symbol.get('kind', 'function').capitalize()} '{symbol['name']}' "
+                                     f"is missing a @codebase-summary breadcrumb",
+                          file_path, symbol.get("line", 1), "Missing breadcrumb")
+        for file_path, symbol in iter_undocumented_symbols(file_analysis) if not symbol.get("doc_exempt")
+    ]
+
+
+def _symbol_finding(file_path: str, symbol: Dict[str, Any]) -> str:
+    """'path:line name' plus the policy fields it lacks, for report bodies"""
+    finding = f"{file_path}:{symbol.get('line', 1)} {symbol['name']}"
+    if symbol.get("missing_fields"):
+        finding += f" (missing fields: {', '.join(symbol['missing_fields'])})"
+    return finding
+
+
+def _junit_suite(parent: ET.Element, name: str, cases: List[Dict[str, Any]]) -> ET.Element:
+    """<testsuite> of {classname, name, file?, failure?, details?} cases, with tests/failures counts"""
+    suite = ET.SubElement(parent, "testsuite", name=name, tests=str(len(cases)),
+                          failures=str(sum(1 for case in cases if case.get("failure"))), errors="0", skipped="0")
+    for case in cases:
+        element = ET.SubElement(suite, "testcase", classname=case["classname"], name=case["name"])
+        if case.get("file"):
+            element.set("file", case["file"])
+        if case.get("failure"):
+            failure = ET.SubElement(element, "failure", message=case["failure"], type=case.get("type", "arkival"))
+            failure.text = case.get("details", "")
+    return suite
+
+
+def build_junit_report(file_analysis: List[Dict[str, Any]], gate_results: List[Dict[str, Any]],
+                       tool_version: str, by: str = "file") -> ET.Element:
+    """
+    # @codebase-summary: JUnit XML for CI test report views (Jenkins, GitLab)
+    - by="file": one test case per analyzed file, failing when it has undocumented symbols
+    - by="rule": one test case per rule (missing breadcrumb, missing required fields)
+    - Threshold gates that ran (coverage, complexity, baseline) become their own test cases
+    """
+    undocumented = defaultdict(list)
+    for file_path, symbol in iter_undocumented_symbols(file_analysis):
+        if not symbol.get("doc_exempt"):
+            undocumented[file_path].append(symbol)
+
+    if by == "rule":
+        rules = [("ARK001", "MissingBreadcrumb", lambda s: not s.get("missing_fields")),
+                 ("ARK002", "MissingRequiredFields", lambda s: bool(s.get("missing_fields")))]
+        cases = []
+        for rule_id, rule_name, applies in rules:
+            findings = [_symbol_finding(f, s) for f, symbols in sorted(undocumented.items()) for s in symbols if applies(s)]
+            cases.append({"classname": "arkival.rules", "name": f"{rule_id} {rule_name}", "type": rule_id,
+                          "failure": f"{len(findings)} symbol(s)" if findings else None, "details": "\n".join(findings)})
+    else:
+        cases = []
+        for analysis in in_file_order(file_analysis):
+            symbols = undocumented.get(analysis["file"], [])
+            directory = Path(analysis["file"]).parent.as_posix()
+            cases.append({
+                "classname": "root" if directory == "." else directory.replace("/", "."),
+                "name": analysis["file"], "file": analysis["file"], "type": MISSING_BREADCRUMB_RULE["id"],
+                "failure": f"{len(symbols)} undocumented symbol(s)" if symbols else None,
+                "details": "\n".join(_symbol_finding(analysis["file"], symbol) for symbol in symbols),
+            })
+
+    root = ET.Element("testsuites", name=f"Arkival {tool_version}")
+    _junit_suite(root, "arkival.breadcrumbs", cases)
+    if gate_results:
+        _junit_suite(root, "arkival.gates", [{**gate, "classname": "arkival.gates"} for gate in gate_results])
+    root.set("tests", str(sum(int(suite.get("tests")) for suite in root)))
+    root.set("failures", str(sum(int(suite.get("failures")) for suite in root)))
+    return root
+
+
+def write_junit_report(output_path: Path, file_analysis: List[Dict[str, Any]], gate_results: List[Dict[str, Any]],
+                       tool_version: str, by: str = "file") -> int:
+    """Write the JUnit XML report to disk and return the number of failing test cases"""
+    root = build_junit_report(file_analysis, gate_results, tool_version, by)
+    if hasattr(ET, "indent"):  # Python 3.9+; older versions write the same XML unindented
+        ET.indent(root)
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    ET.ElementTree(root).write(output_path, encoding="utf-8", xml_declaration=True)
+    return int(root.get("failures"))
+
+
+CSV_COLUMNS = ["file", "name", "kind", "language", "line", "documented", "complexity",
+               "cognitive_complexity", "length", "params", "max_nesting"]
+
+
+def iter_function_rows(file_analysis: List[Dict[str, Any]], language_of: Callable[[str], str]):
+    """
+    # @codebase-summary: One metrics row per function-like symbol, in file then line order
+    - length is the function's line span (empty when neither the body nor an end line was found);
+      params counts declared parameters, max_nesting is the deepest nested control structure
+    - Symbols excluded from coverage (doc_exempt) are left out
+    """
+    for analysis in in_file_order(file_analysis):
+        language = language_of(analysis.get("language", ""))
+        for symbol in sorted(analysis.get("symbols", []), key=lambda s: s.get("line", 0)):
+            if symbol.get("kind", "function") not in FUNCTION_KINDS or symbol.get("doc_exempt"):
+                continue
+            line, end_line = symbol.get("line"), symbol.get("end_line")
+            length = symbol.get("length") or (end_line - line + 1 if line and end_line else "")
+            yield {
+                "file": analysis["file"],
+                "name": qualified_name(symbol),
+                "kind": symbol.get("kind", "function"),
+                "language": language,
+                "line": line,
+                "documented": "yes" if symbol.get("documented") else "no",
+                "complexity": symbol.get("complexity", ""),
+                "cognitive_complexity": symbol.get("cognitive_complexity", ""),
+                "length": length,
+                "params": len(symbol["params"]) if "params" in symbol else "",
+                "max_nesting": symbol.get("max_nesting", ""),
+            }
+
+
+def write_csv_report(output_path: Path, file_analysis: List[Dict[str, Any]], language_of: Callable[[str], str]) -> int:
+    """Write the per-function CSV (UTF-8 with a header row) and return the number of rows"""
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    count = 0
+    with open(output_path, 'w', encoding='utf-8', newline='') as f:
+        writer = csv.DictWriter(f, fieldnames=CSV_COLUMNS)
+        writer.writeheader()
+        for row in iter_function_rows(file_analysis, language_of):
+            writer.writerow(row)
+            count += 1
+    return count
+
+
+def _markdown_module(file_path: str) -> str:
+    """Top-level directory a file belongs to; files at the project root form the '(root)' module"""
+    parts = Path(file_path).parts
+    return parts[0] if len(parts) > 1 else "(root)"
+
+
+def _markdown_anchor(module: str) -> str:
+    """Stable anchor for a module heading - derived from the path only, so links survive regeneration"""
+    return "arkival-" + (re.sub(r"[^a-z0-9]+", "-", module.lower()).strip("-") or "root")
+
+
+def _markdown_percent(documented: int, total: int) -> str:
+    """Coverage percentage for a table cell ('-' without functions)"""
+    return f"{round(documented / total * 100, 1)}%" if total else "-"
+
+
+def build_markdown_overview(file_analysis: List[Dict[str, Any]], project_name: str,
+                            language_of: Callable[[str], str], max_files: int = 5,
+                            diagrams: Optional[List[Tuple[str, str]]] = None) -> str:
+    """
+    # @codebase-summary: Per-module Markdown overview for embedding in a README
+    - One table row per top-level directory (languages, files, functions, coverage) linking to a
+      section with explicit anchors, listing the module's most undocumented files
+    - Optional Mermaid diagrams follow the table
+    - Leaves out versions and timestamps so regenerating an unchanged tree produces identical text
+    """
+    modules = defaultdict(list)
+    for analysis in in_file_order(file_analysis):
+        modules[_markdown_module(analysis["file"])].append(analysis)
+    total = sum(a["function_count"] for a in file_analysis)
+    documented = sum(a["documented_count"] for a in file_analysis)
+
+    out = [
+        f"## {project_name} code overview", "",
+        f"{len(file_analysis)} files · {documented}/{total} functions documented "
+        f"({_markdown_percent(documented, total)} breadcrumb coverage)", "",
+        "| Module | Languages | Files | Functions | Documented | Coverage |",
+        "| --- | --- | ---: | ---: | ---: | ---: |",
+    ]
+    sections = []
+    for module, analyses in sorted(modules.items()):
+        languages = defaultdict(int)
+        for analysis in analyses:
+            languages[language_of(analysis.get("language", ""))] += 1
+        by_count = sorted(languages.items(), key=lambda item: (-item[1], item[0]))
+        mod_total = sum(a["function_count"] for a in analyses)
+        mod_documented = sum(a["documented_count"] for a in analyses)
+        anchor = _markdown_anchor(module)
+        listed = ", ".join(name for name, _ in by_count[:4]) + (f", +{len(by_count) - 4} more" if len(by_count) > 4 else "")
+        out.append(f"| [{module}](#{anchor}) | {listed} | {len(analyses)} | "
+                   f"{mod_total} | {mod_documented} | {_markdown_percent(mod_documented, mod_total)} |")
+
+        sections += ["", f'<a id="{anchor}"></a>', "", f"### {module}", "",
+                     f"- Languages: {', '.join(f'{name} ({count})' for name, count in by_count)}",
+                     f"- Functions: {mod_documented}/{mod_total} documented ({_markdown_percent(mod_documented, mod_total)})"]
+        worst = sorted((a for a in analyses if a["function_count"] > a["documented_count"]),
+                       key=lambda a: (a["documented_count"] - a["function_count"], a["file"]))[:max_files]
+        if worst:
+            sections += ["", "| Top undocumented files | Undocumented | Coverage |", "| --- | ---: | ---: |"]
+            sections += [f"| `{a['file']}` | {a['function_count'] - a['documented_count']} | "
+                         f"{_markdown_percent(a['documented_count'], a['function_count'])} |" for a in worst]
+    for title, source in diagrams or []:
+        out += ["", f"**{title}**", "", mermaid_diagrams.fenced(source)]
+    return "\n".join(out + sections) + "\n"
+
+
+def write_markdown_overview(output_path: Path, overview: str):
+    """Write the standalone Markdown overview"""
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    with open(output_path, 'w', encoding='utf-8') as f:
+        f.write(overview)
+
+
+def inject_markdown_section(target_path: Path, overview: str) -> Optional[bool]:
+    """
+    Replace the text between MARKDOWN_START_MARKER and MARKDOWN_END_MARKER in an existing file.
+    Returns True when the file changed, False when it was already current, None when the markers are missing
+    """
+    with open(target_path, 'r', encoding='utf-8') as f:
+        text = f.read()
+    start = text.find(MARKDOWN_START_MARKER)
+    end = text.find(MARKDOWN_END_MARKER, start + len(MARKDOWN_START_MARKER)) if start != -1 else -1
+    if start == -1 or end == -1:
+        return None
+    updated = f"{text[:start + len(MARKDOWN_START_MARKER)]}\n{overview}{text[end:]}"
+    if updated == text:
+        return False
+    with open(target_path, 'w', encoding='utf-8') as f:
+        f.write(updated)
+    return True
+
+
+_HTML_STYLE = """
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1 { margin-bottom: 0.2rem; } .meta { color: #59636e; margin-bottom: 1.5rem; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+th, td { text-align: left; padding: 0.3rem 0.6rem; border-bottom: 1px solid #d1d9e0; vertical-align: top; }
+th { background: #f6f8fa; } td.num { text-align: right; font-variant-numeric: tabular-nums; }
+.bar { background: #eef1f4; width: 10rem; height: 0.7rem; border-radius: 0.35rem; display: inline-block; }
+.bar span { display: block; height: 100%; border-radius: 0.35rem; }
+.good { background: #2da44e; } .fair { background: #d4a72c; } .poor { background: #cf222e; }
+details { margin: 0.3rem 0; } summary { cursor: pointer; }
+ul.missing { margin: 0.3rem 0 0.3rem 1rem; padding: 0; font-family: ui-monospace, monospace; font-size: 0.9em; }
+.kind { color: #59636e; }
+pre.mermaid { background: #f6f8fa; padding: 1rem; border-radius: 0.4rem; overflow-x: auto; }
+.tag { font-family: ui-monospace, monospace; font-size: 0.85em; font-weight: 600; }
+"""
+
+# Renders <pre class="mermaid"> blocks when the browser is online; offline, the diagram source stays readable
+_MERMAID_SCRIPT = ('<script type="module">import mermaid from '
+                   '"https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.esm.min.mjs"; '
+                   'mermaid.initialize({startOnLoad: true});</script>')
+
+
+def _html_coverage_cell(documented: int, total: int) -> str:
+    """Coverage percentage with a colored bar"""
+    percent = round(documented / total * 100, 1) if total else 100.0
+    grade = "good" if percent >= 80 else "fair" if percent >= 50 else "poor"
+    return (f'<span class="bar"><span class="{grade}" style="width:{percent}%"></span></span> '
+            f'{percent}%')
+
+
+def _html_source_link(file_path: str, line: int, source_base: str) -> str:
+    """Link to a source line - a URL prefix (e.g. a GitHub blob URL) or a relative path"""
+    target = f"{source_base.rstrip('/')}/{Path(file_path).as_posix()}" if source_base else Path(file_path).as_posix()
+    return f"{html.escape(target, quote=True)}#L{line}"
+
+
+def _html_todo_table(todos: List[Dict[str, Any]], source_base: str) -> List[str]:
+    """Technical-debt section: TODO/FIXME/HACK/XXX comments, oldest first when git blame dated them"""
+    esc = html.escape
+    blamed = any("age_days" in todo for todo in todos)
+    ordered = sorted(todos, key=lambda t: (-t.get("age_days", 0), t["file"], t["line"])) if blamed else todos
+    counts = " · ".join(f"{tag} {sum(1 for t in todos if t['tag'] == tag)}" for tag in sorted({t["tag"] for t in todos}))
+    out = ["<h2>Technical debt</h2>",
+           f'<div class="meta">{len(todos)} comments · {esc(counts)}</div>',
+           '<table><tr><th>Tag</th><th>Location</th><th>Author</th><th class="num">Age (days)</th><th>Comment</th></tr>']
+    for todo in ordered:
+        author = todo.get("author") or todo.get("owner") or ("uncommitted" if todo.get("uncommitted") else "")
+        out.append(f'<tr><td class="tag">{esc(todo["tag"])}</td>'
+                   f'<td><a href="{_html_source_link(todo["file"], todo["line"], source_base)}">'
+                   f'{esc(todo["file"])}:{todo["line"]}</a></td><td>{esc(author)}</td>'
+                   f'<td class="num">{todo.get("age_days", "")}</td><td>{esc(todo["text"])}</td></tr>')
+    out.append("</table>")
+    return out
+
+
+def build_html_report(file_analysis: List[Dict[str, Any]], project_name: str, tool_version: str,
+                      language_of: Callable[[str], str], source_base: str = "",
+                      diagrams: Optional[List[Tuple[str, str]]] = None,
+                      todos: Optional[List[Dict[str, Any]]] = None) -> str:
+    """
+    # @codebase-summary: Self-contained HTML coverage report with per-file drill-down
+    - Summarizes coverage overall, per language, and per directory
+    - Each directory expands to its files; each file expands to its undocumented symbols
+    - Symbols link to their source line (relative path or --source-url prefix); no JavaScript required
+      except for rendering the optional Mermaid diagrams
+    - TODO/FIXME/HACK/XXX comments, when given, get a technical-debt table after the directories
+    """
+    total = sum(a["function_count"] for a in file_analysis)
+    documented = sum(a["documented_count"] for a in file_analysis)
+
+    by_language = defaultdict(lambda: {"files": 0, "total": 0, "documented": 0})
+    by_directory = defaultdict(list)
+    for analysis in in_file_order(file_analysis):
+        stats = by_language[language_of(analysis.get("language", ""))]
+        stats["files"] += 1
+        stats["total"] += analysis["function_count"]
+        stats["documented"] += analysis["documented_count"]
+        by_directory[Path(analysis["file"]).parent.as_posix()].append(analysis)
+
+    esc = html.escape
+    out = [
+        "<!DOCTYPE html>", '<html lang="en">', "<head>", '<meta charset="utf-8">',
+        f"<title>{esc(project_name)} - Breadcrumb Coverage</title>",
+        f"<style>{_HTML_STYLE}</style>", "</head>", "<body>",
+        f"<h1>{esc(project_name)} breadcrumb coverage</h1>",
+        f'<div class="meta">Version {esc(tool_version)} · generated '
+        f'{datetime.datetime.now().strftime("%Y-%m-%d %H:%M")} · {len(file_analysis)} files · '
+        f'{documented}/{total} functions documented · {_html_coverage_cell(documented, total)}</div>',
+        "<h2>By language</h2>",
+        '<table><tr><th>Language</th><th class="num">Files</th><th class="num">Documented</th>'
+        '<th class="num">Functions</th><th>Coverage</th></tr>'
+    ]
+    for language, stats in sorted(by_language.items(), key=lambda item: (-item[1]["total"], item[0])):
+        out.append(f'<tr><td>{esc(language)}</td><td class="num">{stats["files"]}</td>'
+                   f'<td class="num">{stats["documented"]}</td><td class="num">{stats["total"]}</td>'
+                   f'<td>{_html_coverage_cell(stats["documented"], stats["total"])}</td></tr>')
+    out.append("</table>")
+
+    out.append("<h2>By directory</h2>")
+    for directory, analyses in sorted(by_directory.items()):
+        dir_total = sum(a["function_count"] for a in analyses)
+        dir_documented = sum(a["documented_count"] for a in analyses)
+        out.append(f"<details><summary><strong>{esc(directory)}/</strong> · {len(analyses)} files · "
+                   f"{dir_documented}/{dir_total} · {_html_coverage_cell(dir_documented, dir_total)}</summary>")
+        out.append('<table><tr><th>File</th><th class="num">Documented</th><th class="num">Functions</th>'
+                   '<th>Coverage</th></tr>')
+        for analysis in analyses:
+            undocumented = [s for s in sorted(analysis.get("symbols", []), key=lambda s: s.get("line", 0))
+                            if not s.get("documented")]
+            name = esc(Path(analysis["file"]).name)
+            if undocumented:
+                items = "".join(
+                    f'<li><a href="{_html_source_link(analysis["file"], sym.get("line", 1), source_base)}">'
+                    f'{esc(sym["name"])}</a> <span class="kind">{esc(sym.get("kind", "function"))} · '
+                    f'line {sym.get("line", 1)}</span></li>'
+                    for sym in undocumented
+                )
+                cell = (f"<details><summary>{name} ({len(undocumented)} undocumented)</summary>"
+                        f'<ul class="missing">{items}</ul></details>')
+            else:
+                cell = name
+            out.append(f'<tr><td>{cell}</td><td class="num">{analysis["documented_count"]}</td>'
+                       f'<td class="num">{analysis["function_count"]}</td>'
+                       f'<td>{_html_coverage_cell(analysis["documented_count"], analysis["function_count"])}</td></tr>')
+        out.append("</table></details>")
+
+    if todos:
+        out += _html_todo_table(todos, source_base)
+    for title, source in diagrams or []:
+        out += [f"<h2>{esc(title)}</h2>", f'<pre class="mermaid">{esc(source)}</pre>']
+    if diagrams:
+        out.append(_MERMAID_SCRIPT)
+    out.extend(["</body>", "</html>"])
+    return "\n".join(out) + "\n"
+
+
+def write_html_report(output_path: Path, file_analysis: List[Dict[str, Any]], project_name: str,
+                      tool_version: str, language_of: Callable[[str], str],
+                      source_base: Optional[str] = None, project_root: Optional[Path] = None,
+                      diagrams: Optional[List[Tuple[str, str]]] = None,
+                      todos: Optional[List[Dict[str, Any]]] = None) -> int:
+    """
+    Write the HTML report to disk and return the number of undocumented symbols.
+    Without source_base, links are made relative from the report's directory to project_root.
+    """
+    if not source_base and project_root is not None:
+        source_base = Path(os.path.relpath(project_root, output_path.parent)).as_posix()
+        source_base = "" if source_base == "." else source_base
+    report = build_html_report(file_analysis, project_name, tool_version, language_of, source_base or "", diagrams, todos)
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    with open(output_path, 'w', encoding='utf-8') as f:
+        f.write(report)
+    return sum(1 for _ in iter_undocumented_symbols(file_analysis))
@@ -0,0 +1,105 @@
+#!/usr/bin/env python3
+"""
+Incremental Scan Cache - Persistent per-file analysis results keyed by content hash
+Lets update_project_summary.py skip re-parsing files that have not changed since the last run
+"""
+
+import json
+import hashlib
+import datetime
+import threading
+from pathlib import Path
+from typing import Dict, Any, Optional, Iterable
+
+CACHE_FORMAT_VERSION = 1
+
+
+def hash_file_content(file_path: Path) -> Optional[str]:
+    """Return the SHA-256 hex digest of a file's bytes, or None if unreadable"""
+    try:
+        digest = hashlib.sha256()
+        with open(file_path, 'rb') as f:
+            for chunk in iter(lambda: f.read(65536), b''):
+                digest.update(chunk)
+        return digest.hexdigest()
+    except OSError:
+        return None
+
+
+class ScanCache:
+    """
+    # @codebase-summary: Persistent content-hash cache for incremental scanning
+    - Stores one analysis record per relative file path alongside the file's content hash
+    - Invalidates everything when the scanner fingerprint (code + parser settings) changes
+    - Tracks hit/miss counts so the scanner can report how much work was skipped
+    - Safe to share between the scanner's worker threads
+    """
+
+    def __init__(self, cache_dir: Path, fingerprint: str):
+        self.cache_file = Path(cache_dir) / "scan_cache.json"
+        self.fingerprint = fingerprint
+        self.entries: Dict[str, Dict[str, Any]] = {}
+        self.hits = 0
+        self.misses = 0
+        self._lock = threading.Lock()
+        self._load()
+
+    def _load(self):
+        """Load cache entries from disk, discarding them if the fingerprint changed"""
+        if not self.cache_file.exists():
+            return
+        try:
+            with open(self.cache_file, 'r', encoding='utf-8') as f:
+                data = json.load(f)
+            if (data.get("format_version") == CACHE_FORMAT_VERSION and
+                    data.get("fingerprint") == self.fingerprint):
+                self.entries = data.get("files", {})
+            else:
+                print("🔄 Scanner changed since last run - incremental cache invalidated")
+        except Exception as e:
+            print(f"⚠️ Could not read scan cache, starting fresh: {e}")
+
+    def get(self, rel_path: str, content_hash: str) -> Optional[Dict[str, Any]]:
+        """Return the cached analysis for a file if its content hash still matches"""
+        with self._lock:
+            entry = self.entries.get(rel_path)
+            if entry and entry.get("hash") == content_hash:
+                self.hits += 1
+                return entry["analysis"]
+            self.misses += 1
+            return None
+
+    def get_unverified(self, rel_path: str) -> Optional[Dict[str, Any]]:
+        """Return the cached analysis for a file without checking its hash (caller knows it is unchanged)"""
+        with self._lock:
+            entry = self.entries.get(rel_path)
+            if entry:
+                self.hits += 1
+                return entry["analysis"]
+            return None
+
+    def put(self, rel_path: str, content_hash: str, analysis: Dict[str, Any]):
+        """Record a freshly computed analysis for a file"""
+        with self._lock:
+            self.entries[rel_path] = {"hash": content_hash, "analysis": analysis}
+
+    def prune(self, seen_paths: Iterable[str]):
+        """Drop entries for files that no longer exist in the scan"""
+        seen = set(seen_paths)
+        for rel_path in [p for p in self.entries if p not in seen]:
+            del self.entries[rel_path]
+
+    def save(self):
+        """Write the cache to disk"""
+        try:
+            self.cache_file.parent.mkdir(parents=True, exist_ok=True)
+            with open(self.cache_file, 'w', encoding='utf-8') as f:
+                json.dump({
+                    "_generator": "Generated by codebase_summary/scan_cache.py - Incremental scan cache",
+                    "format_version": CACHE_FORMAT_VERSION,
+                    "fingerprint": self.fingerprint,
+                    "saved_at": datetime.datetime.now().isoformat() + "Z",
+                    "files": self.entries
+                }, f)
+        except Exception as e:
+            print(f"⚠️ Could not write scan cache: {e}")
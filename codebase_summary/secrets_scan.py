@@ -0,0 +1,133 @@
+#!/usr/bin/env python3
+"""
+Secrets Scan - Credential patterns, private key headers, and high-entropy strings (--secrets)
+Runs inside the scanner's single file traversal, so CI gets a secrets report without a second
+whole-repository pass; findings are redacted and can be exported as SARIF with the coverage results
+"""
+
+import math
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+from text_encoding import decode, file_size, read_bytes
+
+# (rule id, name, pattern, description); the first matching rule names a finding
+SECRET_RULES = (
+    ("ARK101", "PrivateKey", re.compile(r"-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----"),
+     "Private key block header"),
+    ("ARK102", "AwsAccessKeyId", re.compile(r"\b(?:AKIA|ASIA)[0-9A-Z]{16}\b"), "AWS access key ID"),
+    ("ARK103", "GitHubToken", re.compile(r"\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b"),
+     "GitHub personal access or app token"),
+    ("ARK104", "SlackToken", re.compile(r"\bxox[abposr]-[A-Za-z0-9-]{10,}"), "Slack token"),
+    ("ARK105", "StripeKey", re.compile(r"\b[rs]k_live_[0-9A-Za-z]{16,}\b"), "Stripe live secret or restricted key"),
+    ("ARK106", "GoogleApiKey", re.compile(r"\bAIza[0-9A-Za-z_-]{35}\b"), "Google API key"),
+    ("ARK107", "AnthropicApiKey", re.compile(r"\bsk-ant-[A-Za-z0-9_-]{20,}"), "Anthropic API key"),
+    ("ARK108", "OpenAiApiKey", re.compile(r"\bsk-(?:proj-|svcacct-)?[A-Za-z0-9_-]{32,}"), "OpenAI API key"),
+    ("ARK109", "JsonWebToken", re.compile(r"\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}"),
+     "JSON Web Token"),
+    ("ARK110", "CredentialAssignment",
+     re.compile(r"(?i)(?:api[_-]?key|secret|token|passw(?:or)?d|credentials?)[\"']?\s*[:=]\s*[\"']([^\"'\s]{12,})[\"']"),
+     "High-entropy value assigned to a password, secret, token, or API key"),
+    ("ARK111", "HighEntropyString", re.compile(r"[\"']([A-Za-z0-9+/=_-]{32,})[\"']"), "High-entropy string literal"),
+)
+RULES_BY_ID = {rule_id: (name, description) for rule_id, name, _, description in SECRET_RULES}
+
+# Shannon entropy (bits per character) a value needs before the generic rules report it
+ASSIGNMENT_ENTROPY = 3.5
+STRING_ENTROPY = 4.5
+# Lines carrying one of these markers are reviewed false positives
+ALLOW_MARKERS = ("arkival:allow-secret", "pragma: allowlist secret")
+# Lockfiles and checksums are full of hashes that look random by design
+SKIPPED_NAMES = {"package-lock.json", "yarn.lock", "pnpm-lock.yaml", "go.sum", "poetry.lock", "Cargo.lock",
+                 "composer.lock", "Gemfile.lock", "Pipfile.lock", "uv.lock", "bun.lockb"}
+MAX_FILE_BYTES = 1024 * 1024
+
+
+def shannon_entropy(value: str) -> float:
+    """Bits per character of value's character distribution"""
+    if not value:
+        return 0.0
+    counts: Dict[str, int] = {}
+    for char in value:
+        counts[char] = counts.get(char, 0) + 1
+    return -sum(n / len(value) * math.log2(n / len(value)) for n in counts.values())
+
+
+def _redact(secret: str) -> str:
+    """First four characters and the length - enough to find the value, not to use it"""
+    return f"{secret[:4]}…({len(secret)} chars)"
+
+
+def _looks_random(value: str, threshold: float) -> bool:
+    """Entropy above threshold, with both letters and digits (identifiers and words rarely qualify)"""
+    return any(c.isdigit() for c in value) and any(c.isalpha() for c in value) and shannon_entropy(value) >= threshold
+
+
+def scan_lines(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Secret findings ({line, rule, name, redacted}) in a file's lines
+    - Known credential formats match on shape alone; the generic assignment and string-literal rules
+      also need a value that looks random (Shannon entropy with letters and digits)
+    - One finding per line; lines marked arkival:allow-secret or 'pragma: allowlist secret' are skipped
+    """
+    findings = []
+    for number, line in enumerate(lines, 1):
+        if len(line) > 2000 or any(marker in line for marker in ALLOW_MARKERS):
+            continue
+        for rule_id, name, pattern, _ in SECRET_RULES:
+            match = pattern.search(line)
+            if not match:
+                continue
+            if rule_id in ("ARK110", "ARK111"):
+                threshold = ASSIGNMENT_ENTROPY if rule_id == "ARK110" else STRING_ENTROPY
+                match = next((m for m in pattern.finditer(line) if _looks_random(m.group(1), threshold)), None)
+                if not match:
+                    continue
+            secret = match.group(1) if match.groups() else match.group(0)
+            findings.append({"line": number, "rule": rule_id, "name": name,
+                             "redacted": secret if rule_id == "ARK101" else _redact(secret)})
+            break
+    return findings
+
+
+def scan_file(path: Path) -> List[Dict[str, Any]]:
+    """Findings for one file; binary files, lockfiles, and files over MAX_FILE_BYTES are skipped"""
+    if path.name in SKIPPED_NAMES:
+        return []
+    try:
+        if file_size(path) > MAX_FILE_BYTES:
+            return []
+        data = read_bytes(path)
+    except OSError:
+        return []
+    text, _ = decode(data)
+    if "\0" in text[:1024]:
+        return []
+    return scan_lines(text.split("\n"))
+
+
+def summarize_secrets(findings: List[Dict[str, Any]], limit: int = 50) -> Dict[str, Any]:
+    """Summary section: findings per rule and file, plus the (redacted) findings themselves"""
+    by_rule: Dict[str, int] = {}
+    files = set()
+    for finding in findings:
+        by_rule[finding["name"]] = by_rule.get(finding["name"], 0) + 1
+        files.add(finding["file"])
+    return {
+        "findings": len(findings),
+        "files": len(files),
+        "by_rule": dict(sorted(by_rule.items(), key=lambda item: (-item[1], item[0]))),
+        "items": findings[:limit],
+    }
+
+
+def sarif_rules(findings: Optional[List[Dict[str, Any]]] = None) -> List[Dict[str, Any]]:
+    """SARIF reportingDescriptor entries for the secret rules (only those with findings, when given)"""
+    used = {finding["rule"] for finding in findings} if findings is not None else set(RULES_BY_ID)
+    return [{
+        "id": rule_id,
+        "name": name,
+        "shortDescription": {"text": description},
+        "defaultConfiguration": {"level": "error"},
+    } for rule_id, (name, description) in RULES_BY_ID.items() if rule_id in used]
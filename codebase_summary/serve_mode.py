@@ -0,0 +1,277 @@
+#!/usr/bin/env python3
+"""
+Serve Mode - Long-running JSON API over a warm in-memory index of the workspace
+Scans once, keeps the summary and per-file analysis in memory, refreshes them incrementally as files
+change, and answers /summary, /coverage, /symbols, and /file/<path> queries without re-scanning;
+/metrics exposes scan health and coverage to Prometheus (text or OpenMetrics format)
+"""
+
+import hmac
+import json
+import ssl
+import threading
+import time
+from collections import defaultdict
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Set
+from urllib.parse import urlparse, parse_qs, unquote
+
+import metrics_exporter
+from doc_drift import qualified_name
+from watch_mode import WorkspaceWatcher
+
+
+class WorkspaceIndex:
+    """
+    # @codebase-summary: In-memory scan results shared by all API requests
+    - refresh() rebuilds the summary in memory (incrementally, through the scan cache) without
+      writing any output files or bumping the summary version
+    - Readers get a consistent snapshot: results are swapped in under a lock once complete;
+      concurrent refreshes (watcher, gRPC Scan calls) run one at a time
+    - Keeps scan health counters (durations, failures, file errors) for the /metrics endpoint
+    """
+
+    def __init__(self, generator):
+        self.generator = generator
+        self.generator.incremental = True
+        self._lock = threading.Lock()
+        self._refresh_lock = threading.Lock()
+        self.summary: Dict[str, Any] = {}
+        self.files: Dict[str, Dict[str, Any]] = {}
+        self.refreshed_at: Optional[float] = None
+        self.refresh_count = 0
+        self.refresh_failures = 0
+        self.last_duration = 0.0
+        self.duration_total = 0.0
+        self.last_errors: Dict[str, int] = {}
+        self.errors_total: Dict[str, int] = defaultdict(int)
+
+    def refresh(self, force: bool = False):
+        """Re-scan the workspace (from scratch with force) and swap in the new results"""
+        with self._refresh_lock:
+            self.generator.force_rescan = force
+            started = time.monotonic()
+            try:
+                summary, scan_data = self.generator._generate_optimized_summary(self.generator._get_current_version())
+            except Exception:
+                with self._lock:
+                    self.refresh_failures += 1
+                raise
+            finally:
+                self.generator.force_rescan = False
+            duration = time.monotonic() - started
+            files = {Path(a["file"]).as_posix(): a for a in scan_data['code_analysis']['file_analysis']}
+            errors = dict(self.generator.scan_errors)
+            with self._lock:
+                self.summary, self.files = summary, files
+                self.refreshed_at = time.time()
+                self.refresh_count += 1
+                self.last_duration = duration
+                self.duration_total += duration
+                self.last_errors = errors
+                for kind, count in errors.items():
+                    self.errors_total[kind] += count
+
+    def snapshot(self):
+        """(summary, files) as of the last completed refresh"""
+        with self._lock:
+            return self.summary, self.files
+
+    def scan_stats(self) -> Dict[str, Any]:
+        """Scan counters and timings as one consistent set"""
+        with self._lock:
+            return {"scans": self.refresh_count, "failures": self.refresh_failures,
+                    "last_duration": self.last_duration, "duration_total": self.duration_total,
+                    "last_errors": dict(self.last_errors), "errors_total": dict(self.errors_total)}
+
+    def language_file_counts(self) -> Dict[str, int]:
+        """Analyzed files per language"""
+        counts: Dict[str, int] = defaultdict(int)
+        for analysis in self.snapshot()[1].values():
+            counts[self.generator.language_map.get(analysis.get("language", ""), analysis.get("language", ""))] += 1
+        return dict(counts)
+
+    def coverage(self) -> Dict[str, Any]:
+        """Overall, per-language, and per-directory documented/total counts"""
+        summary, files = self.snapshot()
+        by_language = defaultdict(lambda: [0, 0])
+        by_directory = defaultdict(lambda: [0, 0])
+        for file, analysis in files.items():
+            language = self.generator.language_map.get(analysis.get("language", ""), analysis.get("language", ""))
+            for bucket in (by_language[language], by_directory[Path(file).parent.as_posix()]):
+                bucket[0] += analysis["documented_count"]
+                bucket[1] += analysis["function_count"]
+
+        def entry(documented: int, total: int) -> Dict[str, Any]:
+            return {"documented": documented, "total": total,
+                    "coverage": round(documented / total * 100, 2) if total else None}
+
+        code = summary.get("code_analysis", {})
+        return {
+            "overall": entry(code.get("documented_functions", 0), code.get("total_functions", 0)),
+            "languages": {k: entry(*v) for k, v in sorted(by_language.items())},
+            "directories": {k: entry(*v) for k, v in sorted(by_directory.items())},
+        }
+
+    def find_symbols(self, query: str, kind: Optional[str] = None, undocumented: bool = False,
+                     limit: int = 100) -> List[Dict[str, Any]]:
+        """Symbols whose (qualified) name contains query, case-insensitively; exact names sort first"""
+        _, files = self.snapshot()
+        needle = query.lower()
+        matches = []
+        for file, analysis in sorted(files.items()):
+            for symbol in analysis.get("symbols", []):
+                name = qualified_name(symbol)
+                if needle not in name.lower():
+                    continue
+                if kind and symbol.get("kind", "function") != kind:
+                    continue
+                if undocumented and symbol.get("documented"):
+                    continue
+                matches.append({"file": file, "name": name, "kind": symbol.get("kind", "function"),
+                                "line": symbol.get("line"), "documented": bool(symbol.get("documented"))})
+        matches.sort(key=lambda m: (m["name"].lower() != needle and m["name"].split(".")[-1].lower() != needle,
+                                    m["file"], m["line"] or 0))
+        return matches[:limit]
+
+
+class IndexWatcher(WorkspaceWatcher):
+    """Watch loop that refreshes the in-memory index instead of regenerating output files"""
+
+    def __init__(self, index: WorkspaceIndex, interval: float = 1.0):
+        super().__init__(index.generator, interval=interval)
+        self.index = index
+
+    def _initial_scan(self):
+        """The index is already warm when the watcher starts"""
+
+    def _regenerate(self, changed: Set[str]):
+        """Refresh the index after a settled change"""
+        started = time.monotonic()
+        try:
+            self.index.refresh()
+            print(f"🔄 Index refreshed after {len(changed)} change(s) in {time.monotonic() - started:.2f}s")
+        except Exception as e:
+            print(f"⚠️ Index refresh failed - serving the previous results: {e}")
+
+
+def _make_handler(index: WorkspaceIndex, token: Optional[str]):
+    """Request handler class bound to an index (and an optional bearer token)"""
+
+    class Handler(BaseHTTPRequestHandler):
+        server_version = "Arkival"
+
+        def _send_text(self, status: int, text: str, content_type: str):
+            data = text.encode("utf-8")
+            self.send_response(status)
+            self.send_header("Content-Type", content_type)
+            self.send_header("Content-Length", str(len(data)))
+            self.end_headers()
+            self.wfile.write(data)
+
+        def _send(self, status: int, body: Any):
+            data = json.dumps(body, indent=2).encode("utf-8")
+            self.send_response(status)
+            self.send_header("Content-Type", "application/json; charset=utf-8")
+            self.send_header("Content-Length", str(len(data)))
+            self.end_headers()
+            self.wfile.write(data)
+
+        def _authorized(self) -> bool:
+            if not token:
+                return True
+            supplied = self.headers.get("Authorization", "")
+            return hmac.compare_digest(supplied.encode(), f"Bearer {token}".encode())
+
+        def do_GET(self):
+            if not self._authorized():
+                return self._send(401, {"error": "missing or invalid bearer token"})
+            url = urlparse(self.path)
+            params = {key: values[-1] for key, values in parse_qs(url.query).items()}
+            path = url.path.rstrip("/") or "/"
+            summary, files = index.snapshot()
+
+            if path in ("/", "/health"):
+                return self._send(200, {"status": "ok", "project": summary.get("project_name"),
+                                        "files": len(files), "refreshes": index.refresh_count,
+                                        "refreshed_at": index.refreshed_at,
+                                        "endpoints": ["/summary", "/coverage", "/symbols?query=", "/file/<path>",
+                                                      "/metrics"]})
+            if path == "/metrics":
+                openmetrics = metrics_exporter.wants_openmetrics(self.headers.get("Accept"))
+                return self._send_text(200, metrics_exporter.render(index, openmetrics),
+                                       metrics_exporter.OPENMETRICS_CONTENT_TYPE if openmetrics
+                                       else metrics_exporter.PROMETHEUS_CONTENT_TYPE)
+            if path == "/summary":
+                return self._send(200, summary)
+            if path == "/coverage":
+                return self._send(200, index.coverage())
+            if path == "/symbols":
+                try:
+                    limit = max(1, min(int(params.get("limit", "100")), 1000))
+                except ValueError:
+                    return self._send(400, {"error": "limit must be an integer"})
+                symbols = index.find_symbols(params.get("query", ""), params.get("kind"),
+                                             params.get("undocumented", "").lower() in ("1", "true", "yes"), limit)
+                return self._send(200, {"query": params.get("query", ""), "count": len(symbols), "symbols": symbols})
+            if path.startswith("/file/"):
+                rel_path = unquote(path[len("/file/"):])
+                analysis = files.get(rel_path)
+                if analysis is None:
+                    return self._send(404, {"error": f"no analyzed file '{rel_path}'"})
+                return self._send(200, {k: v for k, v in analysis.items() if k != "functions"})
+            return self._send(404, {"error": f"unknown endpoint '{path}'"})
+
+        def log_message(self, format, *args):
+            print(f"🌐 {self.address_string()} {format % args}")
+
+    return Handler
+
+
+def serve(generator, host: str = "127.0.0.1", port: int = 8765, certfile: Optional[str] = None,
+          keyfile: Optional[str] = None, token: Optional[str] = None, interval: float = 1.0,
+          grpc_port: Optional[int] = None, http: bool = True):
+    """
+    # @codebase-summary: Run the JSON API (and/or the gRPC service) until interrupted
+    - Warms the index with one scan, then keeps it current with a background watcher
+    - certfile/keyfile switch the listeners to HTTPS/TLS; token requires 'Authorization: Bearer <token>'
+    - grpc_port adds the ArkivalScan gRPC service on the same index; http=False serves gRPC only
+    """
+    index = WorkspaceIndex(generator)
+    print(f"🔥 Warming index for {generator.project_root}...")
+    index.refresh()
+    threading.Thread(target=IndexWatcher(index, interval=interval).run, daemon=True).start()
+
+    grpc_server = None
+    if grpc_port is not None:
+        from grpc_service import start_grpc_server
+        grpc_server = start_grpc_server(index, grpc_port, host, certfile, keyfile, token)
+        if grpc_server is None and not http:
+            return
+    if not http:
+        try:
+            grpc_server.wait_for_termination()
+        except KeyboardInterrupt:
+            print("\n🛑 Server stopped")
+            grpc_server.stop(grace=2)
+        return
+
+    server = ThreadingHTTPServer((host, port), _make_handler(index, token))
+    scheme = "http"
+    if certfile:
+        context = ssl.SSLContext(ssl.PROTOCOL_TLS_SERVER)
+        context.load_cert_chain(certfile, keyfile)
+        server.socket = context.wrap_socket(server.socket, server_side=True)
+        scheme = "https"
+    elif host not in ("127.0.0.1", "localhost", "::1"):
+        print("⚠️ Serving plain HTTP on a non-loopback address - pass --tls-cert/--tls-key for HTTPS")
+    print(f"✅ Serving {len(index.files)} files at {scheme}://{host}:{server.server_address[1]} (Ctrl+C to stop)")
+    try:
+        server.serve_forever()
+    except KeyboardInterrupt:
+        print("\n🛑 Server stopped")
+    finally:
+        server.server_close()
+        if grpc_server is not None:
+            grpc_server.stop(grace=2)
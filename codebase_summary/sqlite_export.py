@@ -0,0 +1,153 @@
+#!/usr/bin/env python3
+"""
+SQLite Export - Scan results as a queryable SQLite database
+Writes files, symbols, imports, and per-language coverage into a fresh database whose schema
+(SCHEMA below) is versioned in the meta table, for ad-hoc SQL and joins with other datasets
+"""
+
+import json
+import os
+import sqlite3
+from pathlib import Path
+from typing import Dict, Any, List
+
+from doc_drift import qualified_name
+
+SCHEMA_VERSION = 1
+
+SCHEMA = """
+-- Key/value facts about the scan: schema_version, project_name, version, generated_at,
+-- total_functions, documented_functions, coverage_percentage
+CREATE TABLE meta (
+    key   TEXT PRIMARY KEY,
+    value TEXT
+);
+
+-- One row per analyzed source file (paths are relative to the project root)
+CREATE TABLE files (
+    path            TEXT PRIMARY KEY,
+    directory       TEXT NOT NULL,             -- '.' for files at the project root
+    language        TEXT NOT NULL,             -- language name, e.g. 'python'
+    extension       TEXT NOT NULL,             -- extension the scanner reports, e.g. '.py'
+    lines_of_code   INTEGER NOT NULL,
+    function_count  INTEGER NOT NULL,          -- symbols counted toward coverage
+    documented_count INTEGER NOT NULL,
+    coverage        REAL                       -- percentage; NULL for files without counted symbols
+);
+
+-- One row per detected symbol (functions, methods, types, rules...)
+CREATE TABLE symbols (
+    id                   INTEGER PRIMARY KEY,
+    file                 TEXT NOT NULL REFERENCES files(path),
+    name                 TEXT NOT NULL,
+    qualified_name       TEXT NOT NULL,        -- 'Class.method' when the symbol has a parent scope
+    kind                 TEXT NOT NULL,
+    line                 INTEGER,
+    end_line             INTEGER,
+    documented           INTEGER NOT NULL,     -- 1 when a breadcrumb or native doc comment was found
+    doc_exempt           INTEGER NOT NULL,     -- 1 when excluded from coverage (e.g. Elixir @doc false)
+    complexity           INTEGER,              -- cyclomatic complexity, NULL for non-function symbols
+    cognitive_complexity INTEGER,
+    params               TEXT,                 -- JSON array of parameter names
+    missing_fields       TEXT,                 -- JSON array of .arkival-policy required fields not documented
+    attributes           TEXT                  -- JSON object with any other extractor fields (receiver, package...)
+);
+CREATE INDEX symbols_file ON symbols(file);
+CREATE INDEX symbols_documented ON symbols(documented);
+
+-- Resolved imports: target_file for imports of scanned files, package for third-party packages
+CREATE TABLE imports (
+    file        TEXT NOT NULL,
+    target_file TEXT,
+    package     TEXT
+);
+CREATE INDEX imports_file ON imports(file);
+
+-- Coverage per language across all files
+CREATE TABLE languages (
+    language   TEXT PRIMARY KEY,
+    files      INTEGER NOT NULL,
+    functions  INTEGER NOT NULL,
+    documented INTEGER NOT NULL,
+    coverage   REAL
+);
+"""
+
+# Symbol keys stored in dedicated columns; everything else lands in the attributes JSON
+_SYMBOL_COLUMNS = {"name", "kind", "line", "end_line", "documented", "doc_exempt", "complexity",
+                   "cognitive_complexity", "params", "missing_fields", "doc_hash", "parent"}
+
+
+def _coverage(documented: int, total: int):
+    """Coverage percentage rounded like the summary, None without counted symbols"""
+    return round(documented / total * 100, 2) if total else None
+
+
+def _json_or_none(value: Any):
+    """JSON text for list/dict values, None when absent"""
+    return None if value is None else json.dumps(value, sort_keys=True)
+
+
+def write_sqlite_export(output_path: Path, summary: Dict[str, Any], file_analysis: List[Dict[str, Any]],
+                        dependency_graph: Dict[str, Any], language_of) -> int:
+    """
+    # @codebase-summary: Write the scan into a new SQLite database and return the symbol count
+    - The database is rebuilt from scratch each run (written to a temporary file, then swapped in)
+    - language_of maps the scanner's extension to a language name
+    """
+    output_path.parent.mkdir(parents=True, exist_ok=True)
+    temporary = output_path.with_name(output_path.name + ".tmp")
+    if temporary.exists():
+        temporary.unlink()
+    code = summary["code_analysis"]
+    connection = sqlite3.connect(str(temporary))
+    try:
+        connection.executescript(SCHEMA)
+        meta = {
+            "schema_version": SCHEMA_VERSION,
+            "project_name": summary["project_name"],
+            "version": summary["version"],
+            "generated_at": summary["updated_at"],
+            "total_functions": code["total_functions"],
+            "documented_functions": code["documented_functions"],
+            "coverage_percentage": code["coverage_percentage"],
+        }
+        connection.executemany("INSERT INTO meta VALUES (?, ?)", [(k, str(v)) for k, v in meta.items()])
+
+        languages: Dict[str, List[int]] = {}
+        symbol_rows = []
+        for analysis in file_analysis:
+            language = language_of(analysis.get("language", ""))
+            totals = languages.setdefault(language, [0, 0, 0])
+            totals[0] += 1
+            totals[1] += analysis["function_count"]
+            totals[2] += analysis["documented_count"]
+            directory = Path(analysis["file"]).parent.as_posix()
+            connection.execute(
+                "INSERT INTO files VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+                (analysis["file"], directory, language, analysis.get("language", ""), analysis.get("lines_of_code", 0),
+                 analysis["function_count"], analysis["documented_count"],
+                 _coverage(analysis["documented_count"], analysis["function_count"])))
+            for symbol in analysis.get("symbols", []):
+                extra = {k: v for k, v in symbol.items() if k not in _SYMBOL_COLUMNS}
+                symbol_rows.append((
+                    analysis["file"], symbol["name"], qualified_name(symbol), symbol.get("kind", "function"),
+                    symbol.get("line"), symbol.get("end_line"), int(bool(symbol.get("documented"))),
+                    int(bool(symbol.get("doc_exempt"))), symbol.get("complexity"), symbol.get("cognitive_complexity"),
+                    _json_or_none(symbol.get("params")), _json_or_none(symbol.get("missing_fields")),
+                    _json_or_none(extra) if extra else None))
+        connection.executemany(
+            "INSERT INTO symbols (file, name, qualified_name, kind, line, end_line, documented, doc_exempt, "
+            "complexity, cognitive_complexity, params, missing_fields, attributes) "
+            "VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", symbol_rows)
+        connection.executemany("INSERT INTO imports VALUES (?, ?, NULL)", dependency_graph.get("edges", []))
+        connection.executemany("INSERT INTO imports VALUES (?, NULL, ?)", dependency_graph.get("external_edges", []))
+        connection.executemany(
+            "INSERT INTO languages VALUES (?, ?, ?, ?, ?)",
+            [(language, files, total, documented, _coverage(documented, total))
+             for language, (files, total, documented) in sorted(languages.items())])
+        connection.commit()
+    finally:
+        connection.close()
+    os.replace(temporary, output_path)
+    return len(symbol_rows)
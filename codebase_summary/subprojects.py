@@ -0,0 +1,184 @@
+#!/usr/bin/env python3
+"""
+Subprojects - Monorepo detection: projects bounded by go.mod, package.json, pyproject.toml, or Cargo.toml
+Every scanned file belongs to the project of its nearest manifest directory, so a monorepo gets a summary
+per project plus a combined rollup (and the projects' imports of each other) instead of one blob
+"""
+
+import json
+import os
+import re
+from pathlib import Path
+from typing import Dict, Any, Iterable, List, Optional, Tuple
+
+import aggregates
+from text_encoding import read_text
+
+# Manifest file -> ecosystem; a directory holding several manifests is one project of several kinds
+# (go.work only ties Go modules together, so on its own it marks a workspace root)
+MANIFESTS = {"go.mod": "go", "go.work": "go", "package.json": "node", "pyproject.toml": "python", "Cargo.toml": "rust"}
+_GO_MODULE = re.compile(r"^\s*module\s+(\S+)", re.MULTILINE)
+_TOML_SECTION = re.compile(r"^\[(project|tool\.poetry|package)\]\s*$(.*?)(?=^\[|\Z)", re.MULTILINE | re.DOTALL)
+_TOML_NAME = re.compile(r"^\s*name\s*=\s*[\"']([^\"']+)[\"']", re.MULTILINE)
+_TOML_WORKSPACE = re.compile(r"^\[(?:workspace|tool\.uv\.workspace)\]", re.MULTILINE)
+# rollup() group of every file, next to its project's; a tuple never equals a project path or None
+_COMBINED = ("combined",)
+
+
+def _manifest_info(path: Path, manifest: str) -> Tuple[Optional[str], bool]:
+    """(declared name, whether it declares a workspace of member projects) from one manifest file"""
+    try:
+        text = read_text(path)
+    except OSError:
+        return None, False
+    if manifest == "go.mod":
+        match = _GO_MODULE.search(text)
+        return (match.group(1) if match else None), False
+    if manifest == "go.work":
+        return None, True
+    if manifest == "package.json":
+        try:
+            data = json.loads(text)
+        except ValueError:
+            return None, False
+        if not isinstance(data, dict):
+            return None, False
+        name = data.get("name") if isinstance(data.get("name"), str) else None
+        return name, bool(data.get("workspaces"))
+    section = _TOML_SECTION.search(text)
+    match = _TOML_NAME.search(section.group(2)) if section else None
+    return (match.group(1) if match else None), bool(_TOML_WORKSPACE.search(text))
+
+
+def detect_subprojects(all_files: Iterable[str], project_root: Path) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Projects of a repository, one per directory holding a manifest
+    - all_files are the scanned (not ignored) paths relative to the root, so vendored and
+      node_modules manifests never count
+    - Names come from the manifest (module path, package or crate name), else the directory name
+    - workspace marks a root that declares members (npm/yarn workspaces, go.work, [workspace] in Cargo or uv)
+    """
+    by_directory: Dict[str, List[str]] = {}
+    for file in all_files:
+        path = Path(file)
+        if path.name in MANIFESTS:
+            by_directory.setdefault(path.parent.as_posix(), []).append(path.name)
+    projects = []
+    for directory, manifests in sorted(by_directory.items()):
+        manifests = [manifest for manifest in MANIFESTS if manifest in manifests]
+        name, workspace = None, False
+        for manifest in manifests:
+            declared, declares_workspace = _manifest_info(Path(project_root) / directory / manifest, manifest)
+            name = name or declared
+            workspace = workspace or declares_workspace
+        projects.append({
+            "path": directory,
+            "name": name or (Path(project_root).name if directory == "." else Path(directory).name),
+            "kinds": list(dict.fromkeys(MANIFESTS[manifest] for manifest in manifests)),
+            "manifests": manifests,
+            **({"workspace": True} if workspace else {}),
+        })
+    return projects
+
+
+def project_of(file: str, project_paths: List[str]) -> Optional[str]:
+    """Path of the nearest project containing a file (project_paths sorted deepest first), or None"""
+    for path in project_paths:
+        if path == "." or file.startswith(path + "/"):
+            return path
+    return None
+
+
+def _normalized(name: str) -> str:
+    """Package name compared across ecosystems: case-insensitive, '-' and '_' alike (PyPI and crates)"""
+    return name.lower().replace("-", "_")
+
+
+def _imported_project(package: str, source: str, names: Dict[str, List[str]]) -> Optional[str]:
+    """
+    # @codebase-summary: Project path an external import resolves to by name ('@acme/ui', 'example.com/mono/lib/sub')
+    - The longest matching name wins, so a Go module nested in another module's path resolves to itself
+    - Among projects sharing a name (copied fixtures, templates) the one nearest to the importing file wins
+    """
+    package = _normalized(package)
+    matches = [name for name in names if package == name or package.startswith(name + "/")]
+    if not matches:
+        return None
+    parts = source.split("/")
+    return max(names[max(matches, key=len)],
+               key=lambda path: (len(os.path.commonprefix([parts, path.split("/")])), path))
+
+
+def build_subprojects(projects: List[Dict[str, Any]], source_files: List[Dict[str, Any]],
+                      file_analysis: Iterable[Dict[str, Any]], missing_breadcrumbs: List[Dict[str, Any]],
+                      graph: Dict[str, Any], language_of) -> Tuple[Dict[str, Any], List[Dict[str, Any]]]:
+    """
+    # @codebase-summary: Combined rollup section and one report per project
+    - Totals per project use the same counting as code_analysis.aggregates (coverage, complexity)
+    - depends_on / used_by come from resolved file imports that cross a project boundary, and from
+      imports of another project by its package or module name (workspace packages, Go modules)
+    - Files outside every project (a root without a manifest) are totalled as 'unassigned'
+    """
+    # Deepest first, so a file belongs to its nearest manifest directory; '.' contains everything
+    paths = sorted((p["path"] for p in projects), key=lambda path: -1 if path == "." else path.count("/"), reverse=True)
+    totals = aggregates.rollup(source_files, file_analysis, lambda file: (project_of(file, paths), _COMBINED), paths)
+    languages: Dict[Optional[str], Dict[str, int]] = {}
+    files_of: Dict[Optional[str], List[str]] = {}
+    for source in source_files:
+        owner = project_of(source["file"], paths)
+        language = language_of(source["language"])
+        languages.setdefault(owner, {})[language] = languages.setdefault(owner, {}).get(language, 0) + 1
+        files_of.setdefault(owner, []).append(source["file"])
+
+    names: Dict[str, List[str]] = {}
+    for project in projects:
+        names.setdefault(_normalized(project["name"]), []).append(project["path"])
+    depends: Dict[str, Dict[str, int]] = {p["path"]: {} for p in projects}
+    crossings = [(source, project_of(target, paths)) for source, target in graph.get("edges", [])]
+    crossings += [(source, _imported_project(package, source, names)) for source, package in graph.get("external_edges", [])]
+    for source, target in crossings:
+        owner = project_of(source, paths)
+        if owner is not None and target is not None and owner != target:
+            depends[owner][target] = depends[owner].get(target, 0) + 1
+
+    reports, rows = [], []
+    for project in projects:
+        path = project["path"]
+        project_totals = totals[path]
+        depends_on = [{"project": target, "imports": n} for target, n in sorted(depends[path].items())]
+        used_by = sorted(owner for owner, targets in depends.items() if path in targets)
+        missing = [entry for entry in missing_breadcrumbs if project_of(entry["file"], paths) == path]
+        gaps = sorted(({"file": entry["file"], "undocumented": len(entry["missing"])} for entry in missing),
+                      key=lambda gap: (-gap["undocumented"], gap["file"]))
+        rows.append({**project, **project_totals, "languages": languages.get(path, {}),
+                     "depends_on": [d["project"] for d in depends_on], "used_by": used_by})
+        reports.append({
+            "project": project,
+            "totals": project_totals,
+            "languages": languages.get(path, {}),
+            "files": sorted(files_of.get(path, [])),
+            "documentation_gaps": gaps[:10],
+            "missing_breadcrumbs": missing,
+            "depends_on": depends_on,
+            "used_by": used_by,
+        })
+
+    ranked = sorted((row for row in rows if row["functions"]),
+                    key=lambda row: (row["coverage_percentage"], -row["undocumented"], row["path"]))
+    section = {
+        "projects": len(projects),
+        "by_kind": {kind: sum(kind in p["kinds"] for p in projects) for kind in sorted({k for p in projects for k in p["kinds"]})},
+        "combined": totals[_COMBINED],
+        "listed": rows,
+        "worst_documented": [{"project": row["path"], "name": row["name"], "coverage_percentage": row["coverage_percentage"],
+                              "undocumented": row["undocumented"]} for row in ranked[:10]],
+    }
+    if None in totals:
+        section["unassigned"] = totals[None]
+    return section, reports
+
+
+def report_filename(project: Dict[str, Any]) -> str:
+    """File name of a project's report: its path with '/' as '__' ('(root)' for the repository root)"""
+    slug = "(root)" if project["path"] == "." else project["path"].replace("/", "__")
+    return re.sub(r"[^\w.()@-]", "_", slug) + ".json"
@@ -0,0 +1,194 @@
+#!/usr/bin/env python3
+"""
+Summary Diff - API surface and documentation changes between two codebase_summary.json snapshots
+Compares the summaries' symbol indexes: added, removed, renamed, and moved symbols, coverage per
+directory, and symbols that became undocumented - as a readable report or JSON for tooling
+"""
+
+import json
+from collections import defaultdict
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+from doc_drift import qualified_name
+
+# One line per symbol keeps the index small in the indented summary JSON
+INDEX_FORMAT = "<+ documented | - undocumented> <kind> <name> [(<params>)]"
+
+
+def index_entry(symbol: Dict[str, Any]) -> str:
+    """One comparable line per symbol, '+ method Box.open (key)': '-' when undocumented, params only when it has a list"""
+    entry = f"{'+' if symbol.get('documented') else '-'} {symbol.get('kind', 'function')} {qualified_name(symbol)}"
+    params = symbol.get("params")
+    return entry if params is None else f"{entry} ({', '.join(params)})"
+
+
+def _parse_entry(entry: str) -> Dict[str, Any]:
+    """Inverse of index_entry"""
+    documented, kind, rest = entry.split(" ", 2)
+    params = None
+    if rest.endswith(")") and " (" in rest:
+        rest, _, listed = rest[:-1].rpartition(" (")
+        params = listed.split(", ") if listed else []
+    return {"name": rest, "kind": kind, "documented": documented == "+", "params": params}
+
+
+def build_symbol_index(file_analysis: List[Dict[str, Any]]) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Compact per-file symbol list stored in the summary for later diffs
+    - One INDEX_FORMAT string per counted symbol; names are parent-qualified ('Class.method')
+    - Line numbers are left out so edits elsewhere in a file do not show up as changes
+    """
+    files = {}
+    for analysis in file_analysis:
+        entries = [index_entry(symbol) for symbol in analysis.get("symbols", []) if not symbol.get("doc_exempt")]
+        if entries:
+            files[analysis["file"]] = entries
+    return {"format": INDEX_FORMAT, "files": files}
+
+
+def _load_index(path: Path) -> Tuple[Optional[Dict[str, Any]], Dict[str, List[Dict[str, Any]]]]:
+    """(summary, {file: [symbol dicts]}) - raises ValueError for summaries without a symbol index"""
+    with open(path, "r", encoding="utf-8") as f:
+        summary = json.load(f)
+    index = (summary.get("code_analysis") or {}).get("symbol_index")
+    if not isinstance(index, dict) or "files" not in index:
+        raise ValueError(f"{path} has no code_analysis.symbol_index - regenerate it with the current scanner")
+    files = {file: [_parse_entry(entry) for entry in entries] for file, entries in index["files"].items()}
+    return summary, files
+
+
+def _directory(file: str) -> str:
+    """Directory of a summary file path ('.' for the project root)"""
+    parent = Path(file).parent.as_posix()
+    return parent if parent else "."
+
+
+def _coverage(documented: int, total: int) -> Optional[float]:
+    """Coverage percentage rounded like the summary's coverage_percentage (None without symbols)"""
+    return round(documented / total * 100, 2) if total else None
+
+
+def _directory_coverage(files: Dict[str, List[Dict[str, Any]]]) -> Dict[str, Tuple[int, int]]:
+    """(documented, total) per directory, counting symbols the scanner counts toward coverage"""
+    counts: Dict[str, List[int]] = defaultdict(lambda: [0, 0])
+    for file, symbols in files.items():
+        for symbol in symbols:
+            counts[_directory(file)][0] += 1 if symbol["documented"] else 0
+            counts[_directory(file)][1] += 1
+    return {directory: (documented, total) for directory, (documented, total) in counts.items()}
+
+
+def _keyed(files: Dict[str, List[Dict[str, Any]]]) -> Dict[Tuple[str, str], Dict[str, Any]]:
+    """Symbols by (file, name); repeated names (overloads) get an occurrence suffix"""
+    keyed = {}
+    for file, symbols in files.items():
+        occurrences: Dict[str, int] = defaultdict(int)
+        for symbol in symbols:
+            occurrences[symbol["name"]] += 1
+            name = symbol["name"] if occurrences[symbol["name"]] == 1 else f"{symbol['name']}#{occurrences[symbol['name']]}"
+            keyed[(file, name)] = {**symbol, "file": file}
+    return keyed
+
+
+def _pair_off(removed: Dict, added: Dict, same) -> List[Tuple[Tuple[str, str], Tuple[str, str]]]:
+    """Greedily pair removed and added symbols that same(old, new) considers one symbol"""
+    pairs = []
+    for old_key in sorted(removed):
+        match = next((new_key for new_key in sorted(added) if same(removed[old_key], added[new_key])), None)
+        if match:
+            pairs.append((old_key, match))
+            del added[match]
+    for old_key, _ in pairs:
+        del removed[old_key]
+    return pairs
+
+
+def diff_summaries(old_path: Path, new_path: Path) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Symbol-level comparison of two summary snapshots
+    - renamed: a removed and an added symbol in the same file with the same kind and parameter list
+    - moved: the same name and kind removed from one file and added to another
+    - newly_undocumented: added symbols without documentation plus symbols that lost theirs
+    - directories: documented/total counts and coverage delta for each directory that changed
+    """
+    old_summary, old_files = _load_index(old_path)
+    new_summary, new_files = _load_index(new_path)
+    old_symbols, new_symbols = _keyed(old_files), _keyed(new_files)
+
+    removed = {key: old_symbols[key] for key in old_symbols.keys() - new_symbols.keys()}
+    added = {key: new_symbols[key] for key in new_symbols.keys() - old_symbols.keys()}
+    renamed = _pair_off(removed, added, lambda old, new: old["file"] == new["file"] and old["kind"] == new["kind"]
+                        and old.get("params") is not None and old.get("params") == new.get("params"))
+    moved = _pair_off(removed, added, lambda old, new: old["name"] == new["name"] and old["kind"] == new["kind"])
+
+    def entry(symbol: Dict[str, Any]) -> Dict[str, Any]:
+        return {"file": symbol["file"], "name": symbol["name"], "kind": symbol["kind"]}
+
+    newly_undocumented = [{**entry(symbol), "reason": "added"} for key, symbol in sorted(added.items())
+                          if not symbol["documented"]]
+    newly_undocumented += [{**entry(new_symbols[new_key]), "reason": "renamed"} for old_key, new_key in renamed
+                           if old_symbols[old_key]["documented"] and not new_symbols[new_key]["documented"]]
+    newly_undocumented += [{**entry(new_symbols[key]), "reason": "lost_documentation"}
+                           for key in sorted(old_symbols.keys() & new_symbols.keys())
+                           if old_symbols[key]["documented"] and not new_symbols[key]["documented"]]
+
+    old_coverage, new_coverage = _directory_coverage(old_files), _directory_coverage(new_files)
+    directories = []
+    for directory in sorted(old_coverage.keys() | new_coverage.keys()):
+        before, after = old_coverage.get(directory, (0, 0)), new_coverage.get(directory, (0, 0))
+        if before == after:
+            continue
+        directories.append({
+            "directory": directory,
+            "old": {"documented": before[0], "total": before[1], "coverage": _coverage(*before)},
+            "new": {"documented": after[0], "total": after[1], "coverage": _coverage(*after)},
+            "delta": round(_coverage(*after) - _coverage(*before), 2) if before[1] and after[1] else None,
+        })
+
+    old_analysis, new_analysis = old_summary.get("code_analysis", {}), new_summary.get("code_analysis", {})
+    return {
+        "old": {"path": str(old_path), "version": old_summary.get("version"), "coverage": old_analysis.get("coverage_percentage")},
+        "new": {"path": str(new_path), "version": new_summary.get("version"), "coverage": new_analysis.get("coverage_percentage")},
+        "coverage_delta": round((new_analysis.get("coverage_percentage") or 0) - (old_analysis.get("coverage_percentage") or 0), 2),
+        "added": [entry(symbol) for _, symbol in sorted(added.items())],
+        "removed": [entry(symbol) for _, symbol in sorted(removed.items())],
+        "renamed": [{**entry(new_symbols[new_key]), "old_name": old_symbols[old_key]["name"]} for old_key, new_key in renamed],
+        "moved": [{**entry(new_symbols[new_key]), "old_file": old_symbols[old_key]["file"]} for old_key, new_key in moved],
+        "newly_undocumented": newly_undocumented,
+        "directories": directories,
+    }
+
+
+def format_diff(diff: Dict[str, Any], max_listed: int = 25) -> str:
+    """Human-readable report for a diff_summaries() result"""
+    def listing(title: str, entries: List[Dict[str, Any]], describe) -> List[str]:
+        if not entries:
+            return []
+        lines = [f"{title} ({len(entries)}):"]
+        lines += [f"   {describe(e)}" for e in entries[:max_listed]]
+        if len(entries) > max_listed:
+            lines.append(f"   ... and {len(entries) - max_listed} more (use --json for the full list)")
+        return lines
+
+    old, new = diff["old"], diff["new"]
+    sign = "+" if diff["coverage_delta"] >= 0 else ""
+    lines = [
+        f"📊 {old['path']} (v{old['version']}) → {new['path']} (v{new['version']})",
+        f"Documentation coverage: {old['coverage']}% → {new['coverage']}% ({sign}{diff['coverage_delta']})",
+    ]
+    lines += listing("➕ Added", diff["added"], lambda e: f"{e['file']}: {e['name']} ({e['kind']})")
+    lines += listing("➖ Removed", diff["removed"], lambda e: f"{e['file']}: {e['name']} ({e['kind']})")
+    lines += listing("✏️ Renamed", diff["renamed"], lambda e: f"{e['file']}: {e['old_name']} → {e['name']}")
+    lines += listing("📦 Moved", diff["moved"], lambda e: f"{e['name']}: {e['old_file']} → {e['file']}")
+    lines += listing("⚠️ Newly undocumented", diff["newly_undocumented"],
+                     lambda e: f"{e['file']}: {e['name']} ({e['reason'].replace('_', ' ')})")
+    def percent(side: Dict[str, Any]) -> str:
+        return "-" if side["coverage"] is None else f"{side['coverage']}%"
+
+    lines += listing("📁 Coverage by directory", diff["directories"],
+                     lambda e: f"{e['directory']}: {percent(e['old'])} → {percent(e['new'])} "
+                               f"({e['old']['documented']}/{e['old']['total']} → {e['new']['documented']}/{e['new']['total']})")
+    if len(lines) == 2:
+        lines.append("✅ No symbol changes")
+    return "\n".join(lines)
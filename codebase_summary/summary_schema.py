@@ -0,0 +1,121 @@
+#!/usr/bin/env python3
+"""
+Summary Schema - Versioned JSON Schema contract for codebase_summary.json
+Ships a dependency-free validator for the JSON Schema subset the published schema uses
+"""
+
+import re
+import json
+from pathlib import Path
+from typing import Dict, Any, List
+
+SCHEMA_VERSION = 1
+SCHEMA_ID = f"arkival/codebase-summary/v{SCHEMA_VERSION}"
+SCHEMA_PATH = Path(__file__).resolve().parent / "schemas" / f"codebase_summary.v{SCHEMA_VERSION}.schema.json"
+
+_JSON_TYPES = {
+    "object": dict,
+    "array": list,
+    "string": str,
+    "boolean": bool,
+    "null": type(None),
+}
+
+
+def load_schema() -> Dict[str, Any]:
+    """Load the published schema for the current SCHEMA_VERSION"""
+    with open(SCHEMA_PATH, 'r', encoding='utf-8') as f:
+        return json.load(f)
+
+
+def _matches_type(value: Any, expected: str) -> bool:
+    """JSON type check - booleans are not numbers, and integers are valid numbers"""
+    if expected == "integer":
+        return isinstance(value, int) and not isinstance(value, bool)
+    if expected == "number":
+        return isinstance(value, (int, float)) and not isinstance(value, bool)
+    return isinstance(value, _JSON_TYPES[expected])
+
+
+def _resolve_ref(ref: str, root: Dict[str, Any]) -> Dict[str, Any]:
+    """Resolve a local '#/...' JSON pointer"""
+    node = root
+    for part in ref.lstrip("#/").split("/"):
+        node = node[part.replace("~1", "/").replace("~0", "~")]
+    return node
+
+
+def validate(instance: Any, schema: Dict[str, Any], root: Dict[str, Any] = None, path: str = "$") -> List[str]:
+    """
+    # @codebase-summary: Minimal JSON Schema validator for the summary contract
+    - Supports type, enum, required, properties, additionalProperties, items, $ref,
+      minimum/maximum, and pattern - the keywords codebase_summary.v1.schema.json uses
+    - Returns every violation as "path: message" rather than stopping at the first
+    """
+    root = root if root is not None else schema
+    if "$ref" in schema:
+        return validate(instance, _resolve_ref(schema["$ref"], root), root, path)
+
+    errors = []
+    expected = schema.get("type")
+    if expected is not None:
+        types = expected if isinstance(expected, list) else [expected]
+        if not any(_matches_type(instance, t) for t in types):
+            return [f"{path}: expected {' or '.join(types)}, got {type(instance).__name__}"]
+
+    if "enum" in schema and instance not in schema["enum"]:
+        errors.append(f"{path}: {instance!r} is not one of {schema['enum']}")
+
+    if isinstance(instance, (int, float)) and not isinstance(instance, bool):
+        if "minimum" in schema and instance < schema["minimum"]:
+            errors.append(f"{path}: {instance} is below the minimum {schema['minimum']}")
+        if "maximum" in schema and instance > schema["maximum"]:
+            errors.append(f"{path}: {instance} is above the maximum {schema['maximum']}")
+
+    if isinstance(instance, str) and "pattern" in schema and not re.search(schema["pattern"], instance):
+        errors.append(f"{path}: {instance!r} does not match {schema['pattern']}")
+
+    if isinstance(instance, dict):
+        for key in schema.get("required", []):
+            if key not in instance:
+                errors.append(f"{path}: missing required property '{key}'")
+        properties = schema.get("properties", {})
+        additional = schema.get("additionalProperties", True)
+        for key, value in instance.items():
+            if key in properties:
+                errors.extend(validate(value, properties[key], root, f"{path}.{key}"))
+            elif additional is False:
+                errors.append(f"{path}: unexpected property '{key}'")
+            elif isinstance(additional, dict):
+                errors.extend(validate(value, additional, root, f"{path}.{key}"))
+
+    if isinstance(instance, list) and isinstance(schema.get("items"), dict):
+        for index, item in enumerate(instance):
+            errors.extend(validate(item, schema["items"], root, f"{path}[{index}]"))
+
+    return errors
+
+
+def validate_summary_file(summary_path: Path) -> int:
+    """
+    Validate a summary file against the published schema and print the result.
+    Returns a process exit code: 0 valid, 1 schema violations, 2 unreadable file.
+    """
+    try:
+        with open(summary_path, 'r', encoding='utf-8') as f:
+            summary = json.load(f)
+    except (OSError, json.JSONDecodeError) as e:
+        print(f"❌ Could not read {summary_path}: {e}")
+        return 2
+
+    errors = validate(summary, load_schema())
+    if not errors:
+        print(f"✅ {summary_path} is valid against {SCHEMA_ID}")
+        return 0
+
+    print(f"❌ {summary_path} does not match {SCHEMA_ID} ({len(errors)} problem(s)):")
+    for error in errors[:50]:
+        print(f"   - {error}")
+    if len(errors) > 50:
+        print(f"   ... and {len(errors) - 50} more")
+    return 1
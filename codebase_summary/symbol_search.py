@@ -0,0 +1,113 @@
+#!/usr/bin/env python3
+"""
+Symbol Search - Name lookup over the scanned index for the 'find <query>' subcommand
+Symbols are matched exactly, by prefix, by substring, or fuzzily (the query's characters in order,
+as in 'gnrsum' -> generate_summary) and printed as file:line locations with their signatures
+"""
+
+import re
+from typing import Dict, Any, List, Optional, Tuple
+
+from doc_drift import qualified_name
+
+# Match tiers, best first; --exact and --prefix stop after their tier
+MATCH_TIERS = ("exact", "prefix", "substring", "fuzzy")
+DEFAULT_LIMIT = 50
+
+_BOUNDARY = re.compile(r"[._\-/:]")
+
+
+def _boundaries(name: str) -> set:
+    """Indexes where a word starts: the first character, after '_' or '.', and camelCase humps"""
+    starts = {0}
+    for i in range(1, len(name)):
+        if _BOUNDARY.match(name[i - 1]) or (name[i].isupper() and name[i - 1].islower()):
+            starts.add(i)
+    return starts
+
+
+def fuzzy_score(query: str, name: str) -> Optional[int]:
+    """
+    # @codebase-summary: Subsequence match score of query in name (higher is better), None without a match
+    - Every query character must appear in name in order, case-insensitively; characters are taken
+      greedily, preferring word starts so 'gs' lands on generate_summary's 'g' and 's'
+    - Consecutive characters and word starts score extra; skipped characters cost one point each
+    """
+    lowered, starts = name.lower(), _boundaries(name)
+    score, position, previous = 0, 0, -2
+    for char in query.lower():
+        found = lowered.find(char, position)
+        if found < 0:
+            return None
+        start = next((i for i in sorted(starts) if i >= found and lowered[i] == char), None)
+        if start is not None and start != found and previous + 1 != found:
+            found = start
+        score += 3 if found in starts else 0
+        score += 2 if found == previous + 1 else -(found - position)
+        previous, position = found, found + 1
+    return score
+
+
+def match_tier(query: str, name: str) -> Optional[Tuple[int, int]]:
+    """(tier index, score) of the best way query matches a qualified name or its last segment"""
+    needle = query.lower()
+    candidates = {name.lower(), name.split(".")[-1].lower()}
+    if needle in candidates:
+        return 0, 0
+    if any(candidate.startswith(needle) for candidate in candidates):
+        return 1, -len(name)
+    if any(needle in candidate for candidate in candidates):
+        return 2, -len(name)
+    score = fuzzy_score(query, name.split(".")[-1])
+    score = fuzzy_score(query, name) if score is None else score
+    return None if score is None else (3, score)
+
+
+def signature_of(symbol: Dict[str, Any]) -> str:
+    """Declaration-like display: Go keeps its parsed signature, others list their parameter names"""
+    name = qualified_name(symbol)
+    if symbol.get("receiver"):
+        name = f"({symbol['receiver']}) {name}"
+    if symbol.get("signature"):
+        signature = symbol["signature"]
+        return f"{name}{signature}" if signature.startswith("(") else f"{name} {signature}"
+    params = symbol.get("params")
+    return name if params is None else f"{name}({', '.join(params)})"
+
+
+def search_symbols(files: Dict[str, Dict[str, Any]], query: str, kind: Optional[str] = None,
+                   undocumented: bool = False, mode: str = "fuzzy", limit: int = DEFAULT_LIMIT) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: Ranked symbol matches for query across every scanned file
+    - files maps relative paths to file analyses (WorkspaceIndex.files); mode is the loosest
+      MATCH_TIERS entry allowed
+    - Results sort by tier, then score (shorter names first within prefix/substring), then location;
+      each carries file, line, kind, name, signature, documented, and match (the tier name)
+    """
+    loosest = MATCH_TIERS.index(mode)
+    matches = []
+    for file, analysis in files.items():
+        for symbol in analysis.get("symbols", []):
+            if kind and symbol.get("kind", "function") != kind:
+                continue
+            if undocumented and (symbol.get("documented") or symbol.get("doc_exempt")):
+                continue
+            name = qualified_name(symbol)
+            tier = match_tier(query, name)
+            if tier is None or tier[0] > loosest:
+                continue
+            matches.append(((tier[0], -tier[1], file, symbol.get("line") or 0), {
+                "file": file, "line": symbol.get("line"), "kind": symbol.get("kind", "function"), "name": name,
+                "signature": signature_of(symbol), "documented": bool(symbol.get("documented")),
+                "match": MATCH_TIERS[tier[0]]}))
+    matches.sort(key=lambda m: m[0])
+    return [match for _, match in matches[:limit]]
+
+
+def format_matches(matches: List[Dict[str, Any]]) -> str:
+    """One 'file:line  kind  signature' row per match, columns aligned; undocumented symbols marked '-'"""
+    locations = [f"{m['file']}:{m['line'] or 0}" for m in matches]
+    width = max((len(location) for location in locations), default=0)
+    kind_width = max((len(m["kind"]) for m in matches), default=0)
+    return "\n".join(f"{location:<{width}}  {'+' if m['documented'] else '-'} {m['kind']:<{kind_width}}  {m['signature']}"
+                     for location, m in zip(locations, matches))
@@ -0,0 +1,151 @@
+#!/usr/bin/env python3
+"""
+Text Encoding - Charset detection and decoding of source files to Unicode text
+Legacy sources saved as UTF-16 (with or without a BOM), UTF-8 with a BOM, or a single-byte Windows/Latin-1
+code page are decoded transparently, so extractors always see the real text and symbol names
+Every scanner read goes through here, so a mounted archive (see archive_source) stands in for the disk
+"""
+
+import codecs
+import re
+from pathlib import Path
+from typing import Dict, Any, List, Optional, Tuple
+
+# Encodings are reported by their Python codec name; a '-bom' suffix means the file starts with a byte order mark
+DEFAULT_ENCODING = "utf-8"
+_BOMS = (  # UTF-32 first: its little-endian BOM starts with the UTF-16 one
+    (codecs.BOM_UTF32_LE, "utf-32-le"), (codecs.BOM_UTF32_BE, "utf-32-be"), (codecs.BOM_UTF8, "utf-8"),
+    (codecs.BOM_UTF16_LE, "utf-16-le"), (codecs.BOM_UTF16_BE, "utf-16-be"),
+)
+# Bytes sampled to recognize UTF-16 without a BOM: mostly-ASCII text has a NUL in every other byte
+SNIFF_BYTES = 4096
+UTF16_NUL_SHARE = 0.4
+# cp1252 assigns printable characters to 0x80-0x9F, which Latin-1 leaves as control codes
+_C1_BYTES = re.compile(rb"[\x80-\x9f]")
+# In-memory file source (an archive_source.ArchiveTree) consulted before the disk, set with mount()
+_mounted = None
+
+
+def _bomless_utf16(sample: bytes) -> str:
+    """'utf-16-le' or 'utf-16-be' when NULs fill one byte of each pair and (almost) never the other, else ''"""
+    pairs = len(sample) // 2
+    if pairs < 2:
+        return ""
+    even_nuls = sample[0:pairs * 2:2].count(0)
+    odd_nuls = sample[1:pairs * 2:2].count(0)
+    if odd_nuls >= UTF16_NUL_SHARE * pairs and even_nuls <= 0.05 * pairs:
+        return "utf-16-le"
+    if even_nuls >= UTF16_NUL_SHARE * pairs and odd_nuls <= 0.05 * pairs:
+        return "utf-16-be"
+    return ""
+
+
+def detect_encoding(raw: bytes) -> str:
+    """
+    # @codebase-summary: Encoding of a source file's bytes
+    - A byte order mark decides (UTF-8, UTF-16, UTF-32); then the NUL pattern of BOM-less UTF-16
+    - Otherwise 'utf-8' when the bytes are valid UTF-8, then 'cp1252' when they use its 0x80-0x9F
+      characters, and 'latin-1', which decodes anything
+    """
+    for bom, codec in _BOMS:
+        if raw.startswith(bom):
+            return codec + "-bom"
+    utf16 = _bomless_utf16(raw[:SNIFF_BYTES])
+    if utf16:
+        return utf16
+    try:
+        raw.decode("utf-8")
+        return "utf-8"
+    except UnicodeDecodeError:
+        pass
+    if _C1_BYTES.search(raw):
+        try:
+            raw.decode("cp1252")
+            return "cp1252"
+        except UnicodeDecodeError:
+            pass
+    return "latin-1"
+
+
+def _codec(encoding: str) -> Tuple[str, bytes]:
+    """Python codec and BOM bytes (empty without '-bom') of a reported encoding"""
+    if not encoding.endswith("-bom"):
+        return encoding, b""
+    codec = encoding[:-len("-bom")]
+    return codec, next(bom for bom, name in _BOMS if name == codec)
+
+
+def decode(raw: bytes) -> Tuple[str, str]:
+    """Text and detected encoding of raw file bytes; the BOM is dropped and line endings become '\\n' as in text mode"""
+    encoding = detect_encoding(raw)
+    codec, bom = _codec(encoding)
+    text = raw[len(bom):].decode(codec, errors="replace" if codec.startswith(("utf-16", "utf-32")) else "ignore")
+    return text.replace("\r\n", "\n").replace("\r", "\n"), encoding
+
+
+def encode(text: str, encoding: str) -> bytes:
+    """Bytes of text in a detected encoding (with its BOM), for writing a decoded file back"""
+    codec, bom = _codec(encoding)
+    return bom + text.encode(codec, errors="replace")
+
+
+def mount(source):
+    """Serve reads of paths under source.root from source (read(path), size(path)) until unmount()"""
+    global _mounted
+    _mounted = source
+
+
+def unmount():
+    """Read from the disk again"""
+    global _mounted
+    _mounted = None
+
+
+def _in_mounted(path) -> bool:
+    return _mounted is not None and (Path(path) == _mounted.root or _mounted.root in Path(path).parents)
+
+
+def read_bytes(path, limit: Optional[int] = None) -> bytes:
+    """Raw bytes of a file (the first limit of them), from the mounted source when path is inside it"""
+    if _in_mounted(path):
+        data = _mounted.read(Path(path))
+        if data is None:
+            raise FileNotFoundError(f"No such archive member: {path}")
+        return data if limit is None else data[:limit]
+    with open(path, "rb") as f:
+        return f.read() if limit is None else f.read(limit)
+
+
+def file_size(path) -> int:
+    """Size in bytes of a file on disk or in the mounted source; raises OSError like os.stat()"""
+    if _in_mounted(path):
+        size = _mounted.size(Path(path))
+        if size is None:
+            raise FileNotFoundError(f"No such archive member: {path}")
+        return size
+    return Path(path).stat().st_size
+
+
+def is_file(path) -> bool:
+    """Whether path is a regular file on disk or in the mounted source"""
+    if _in_mounted(path):
+        return _mounted.size(Path(path)) is not None
+    return Path(path).is_file()
+
+
+def read_source(path) -> Tuple[str, str]:
+    """Decoded text and encoding of a file; raises OSError like open()"""
+    return decode(read_bytes(path))
+
+
+def read_text(path) -> str:
+    """Decoded text of a file, for callers that do not need the encoding"""
+    return read_source(path)[0]
+
+
+def summarize_encodings(files: List[Dict[str, str]], limit: int = 100) -> Dict[str, Any]:
+    """Summary section for files that are not plain UTF-8: counts per encoding and the files ({file, encoding})"""
+    by_encoding: Dict[str, int] = {}
+    for entry in files:
+        by_encoding[entry["encoding"]] = by_encoding.get(entry["encoding"], 0) + 1
+    return {"files": len(files), "by_encoding": dict(sorted(by_encoding.items())), "listed": files[:limit]}
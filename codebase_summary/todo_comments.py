@@ -0,0 +1,149 @@
+#!/usr/bin/env python3
+"""
+TODO Comments - TODO/FIXME/HACK/XXX comments as a technical-debt inventory next to doc coverage
+Comments are collected per file during the scan; with --todo-blame, git blame adds the author and
+age of each line, so the summary and HTML report can show the oldest debt first
+"""
+
+import datetime
+import re
+import subprocess
+from pathlib import Path
+from typing import Dict, Any, List, Optional
+
+TAGS = ("TODO", "FIXME", "HACK", "XXX")
+# Age buckets (upper bound in days, label) for blamed comments
+AGE_BUCKETS = ((30, "under_30_days"), (180, "30_to_180_days"), (365, "180_days_to_1_year"), (None, "over_1_year"))
+
+# The tag must open a comment: '// TODO: x', '# FIXME(alice): x', '/* HACK */', '-- XXX x'
+_TODO = re.compile(r"(?:^|\s)(?://+|#+|--+|/\*+|\*|;+|<!--|%+|\(\*|\{-)\s*(TODO|FIXME|HACK|XXX)\b(?!/)"
+                   r"(?:\(([^)]*)\))?(?:\s*[:\-])?\s*(.*?)\s*(?:\*/|-->|\*\)|-\})?\s*$")
+_UNCOMMITTED = "0" * 40
+# Per-file keys collect_todos() uses
+SUMMARIZED_FIELDS = ("file", "todos")
+
+
+def extract_todos(lines: List[str]) -> List[Dict[str, Any]]:
+    """
+    # @codebase-summary: TODO/FIXME/HACK/XXX comments in a file's lines
+    - Each entry has line, tag, and text; 'TODO(alice): ...' also records owner 'alice'
+    - Tags in prose ('a TODO list') or string literals don't count - the tag has to start the comment
+    """
+    todos = []
+    for number, line in enumerate(lines, 1):
+        match = _TODO.search(line)
+        if match:
+            tag, owner, text = match.groups()
+            todos.append({"line": number, "tag": tag, "text": text, **({"owner": owner.strip()} if owner else {})})
+    return todos
+
+
+def collect_todos(analyses: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+    """Flatten the per-file todos of scanned files into one list with file paths"""
+    return [{"file": analysis["file"], **todo} for analysis in analyses for todo in analysis.get("todos", [])]
+
+
+def blame_file(project_root: Path, file: str, line_numbers: Optional[List[int]]) -> Dict[int, Dict[str, Any]]:
+    """
+    # @codebase-summary: git blame of one file as {line: {"sha", "author", "time", "uncommitted"}}
+    - line_numbers limits the blame to those lines; None blames the whole file
+    - Raises RuntimeError when git blame fails (untracked file, not a repository)
+    """
+    ranges = [arg for number in line_numbers or [] for arg in ("-L", f"{number},{number}")]
+    result = subprocess.run(["git", "blame", "--line-porcelain", *ranges, "--", file], cwd=project_root,
+                            capture_output=True, text=True, timeout=60)
+    if result.returncode != 0:
+        raise RuntimeError(result.stderr.strip() or f"git blame {file} failed")
+    blamed: Dict[int, Dict[str, Any]] = {}
+    current: Dict[str, Any] = {}
+    for line in result.stdout.splitlines():
+        if line.startswith("\t"):
+            blamed[current.pop("line")] = current
+            current = {}
+        elif not current:
+            sha, _, final = line.split()[:3]
+            current = {"line": int(final), "sha": sha, "uncommitted": sha == _UNCOMMITTED}
+        elif line.startswith("author "):
+            current["author"] = line[len("author "):]
+        elif line.startswith("author-time "):
+            current["time"] = int(line[len("author-time "):])
+    return blamed
+
+
+def blame_todos(project_root: Path, todos: List[Dict[str, Any]], now: Optional[datetime.datetime] = None) -> Optional[str]:
+    """
+    # @codebase-summary: Add author, date, and age_days from git blame to each todo
+    - One git blame per file, limited to the todo lines; lines not yet committed get uncommitted
+      instead of an author and count as 0 days old
+    - Returns an error message when git blame is unavailable (not a repository, git missing), else None;
+      files git doesn't track are skipped
+    """
+    now = now or datetime.datetime.now(datetime.timezone.utc)
+    by_file: Dict[str, List[Dict[str, Any]]] = {}
+    for todo in todos:
+        by_file.setdefault(todo["file"], []).append(todo)
+    try:
+        subprocess.run(["git", "rev-parse", "--git-dir"], cwd=project_root, capture_output=True, check=True, timeout=10)
+    except (OSError, subprocess.SubprocessError) as e:
+        return f"git blame unavailable: {e}"
+    for file, entries in sorted(by_file.items()):
+        try:
+            blamed = blame_file(project_root, file, [todo["line"] for todo in entries])
+        except (RuntimeError, OSError, subprocess.SubprocessError, ValueError):
+            continue
+        for todo in entries:
+            info = blamed.get(todo["line"])
+            if not info:
+                continue
+            if info["uncommitted"]:
+                todo["uncommitted"] = True
+                todo["age_days"] = 0
+                continue
+            when = datetime.datetime.fromtimestamp(info.get("time", 0), datetime.timezone.utc)
+            todo["author"] = info.get("author", "")
+            todo["date"] = when.strftime("%Y-%m-%d")
+            todo["age_days"] = max(0, (now - when).days)
+    return None
+
+
+def _age_bucket(days: int) -> str:
+    """Label of the AGE_BUCKETS entry a comment age falls into"""
+    return next(label for bound, label in AGE_BUCKETS if bound is None or days < bound)
+
+
+def summarize_todos(todos: List[Dict[str, Any]], limit: int = 50) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Summary section for the TODO/FIXME/HACK/XXX inventory
+    - Counts per tag and the files with the most comments
+    - After git blame: counts per author and age bucket, and the listed comments oldest first
+      (otherwise in file order)
+    """
+    by_tag = {tag: 0 for tag in TAGS}
+    by_file: Dict[str, int] = {}
+    by_author: Dict[str, int] = {}
+    ages = {label: 0 for _, label in AGE_BUCKETS}
+    for todo in todos:
+        by_tag[todo["tag"]] += 1
+        by_file[todo["file"]] = by_file.get(todo["file"], 0) + 1
+        author = todo.get("author") or todo.get("owner")
+        if author:
+            by_author[author] = by_author.get(author, 0) + 1
+        if "age_days" in todo:
+            ages[_age_bucket(todo["age_days"])] += 1
+    blamed = any("age_days" in todo for todo in todos)
+    ordered = sorted(todos, key=lambda t: (-t.get("age_days", 0), t["file"], t["line"])) if blamed else todos
+    section = {
+        "total": len(todos),
+        "by_tag": by_tag,
+        "files": len(by_file),
+        "top_files": [{"file": file, "count": count}
+                      for file, count in sorted(by_file.items(), key=lambda item: (-item[1], item[0]))[:10]],
+        "blamed": blamed,
+        "items": ordered[:limit],
+    }
+    if by_author:
+        section["by_author"] = dict(sorted(by_author.items(), key=lambda item: (-item[1], item[0]))[:limit])
+    if blamed:
+        section["age_buckets"] = ages
+        section["oldest_days"] = max(todo.get("age_days", 0) for todo in todos)
+    return section
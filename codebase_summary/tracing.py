@@ -0,0 +1,133 @@
+#!/usr/bin/env python3
+"""
+Tracing - OpenTelemetry spans for the scan phases (--trace otlp|console)
+Spans cover the whole scan and its walk, parse, aggregate, and export phases; without --trace, or when
+the opentelemetry packages are not installed, every call is a no-op and scans run unchanged
+"""
+
+import atexit
+import contextlib
+import os
+import sys
+from typing import Any, Dict, Optional
+from urllib.parse import urlparse
+
+try:
+    from opentelemetry import trace
+    from opentelemetry.sdk.resources import Resource
+    from opentelemetry.sdk.trace import TracerProvider
+    from opentelemetry.sdk.trace.export import BatchSpanProcessor, ConsoleSpanExporter
+    from opentelemetry.trace import Status, StatusCode
+    from opentelemetry.trace.propagation.tracecontext import TraceContextTextMapPropagator
+    OTEL_AVAILABLE = True
+except ImportError:
+    OTEL_AVAILABLE = False
+
+EXPORTERS = ("otlp", "console")
+PROTOCOLS = ("http", "grpc")
+SERVICE_NAME = "arkival"
+INSTALL_HINT = "pip install opentelemetry-sdk opentelemetry-exporter-otlp"
+
+_tracer = None
+
+
+class _NoopSpan:
+    """Stand-in span while tracing is off"""
+
+    def set_attribute(self, key: str, value: Any):
+        pass
+
+    def set_attributes(self, attributes: Dict[str, Any]):
+        pass
+
+    def end(self, end_time: Optional[int] = None):
+        pass
+
+    def record_exception(self, exception: BaseException):
+        pass
+
+
+_NOOP = _NoopSpan()
+
+
+def enabled() -> bool:
+    """Whether configure() set up an exporter"""
+    return _tracer is not None
+
+
+def _otlp_exporter(endpoint: Optional[str], protocol: str):
+    """OTLP span exporter; http endpoints given without a path get the standard /v1/traces"""
+    if protocol == "grpc":
+        from opentelemetry.exporter.otlp.proto.grpc.trace_exporter import OTLPSpanExporter
+    else:
+        from opentelemetry.exporter.otlp.proto.http.trace_exporter import OTLPSpanExporter
+        if endpoint and urlparse(endpoint).path in ("", "/"):
+            endpoint = endpoint.rstrip("/") + "/v1/traces"
+    # Without an endpoint the exporter reads OTEL_EXPORTER_OTLP_(TRACES_)ENDPOINT, then its default
+    return OTLPSpanExporter(endpoint=endpoint) if endpoint else OTLPSpanExporter()
+
+
+def configure(exporter: str, endpoint: Optional[str] = None, protocol: str = "http") -> bool:
+    """
+    # @codebase-summary: Install the tracer for this process; False (with a warning) if it cannot
+    - 'otlp' sends spans to a collector (--otlp-endpoint, or the standard OTEL_EXPORTER_OTLP_* variables;
+      --otlp-protocol grpc needs opentelemetry-exporter-otlp-proto-grpc); 'console' prints them to stderr
+    - Service name is OTEL_SERVICE_NAME or 'arkival'; spans are flushed at exit
+    - A TRACEPARENT environment variable (W3C trace context) makes the scan part of a CI pipeline trace
+    """
+    global _tracer
+    if exporter not in EXPORTERS:
+        print(f"⚠️ Unknown --trace exporter '{exporter}' (expected {' or '.join(EXPORTERS)}) - scanning without tracing")
+        return False
+    if protocol not in PROTOCOLS:
+        print(f"⚠️ Unknown --otlp-protocol '{protocol}' (expected {' or '.join(PROTOCOLS)}) - scanning without tracing")
+        return False
+    if not OTEL_AVAILABLE:
+        print(f"⚠️ --trace needs the OpenTelemetry SDK ({INSTALL_HINT}) - scanning without tracing")
+        return False
+    try:
+        span_exporter = _otlp_exporter(endpoint, protocol) if exporter == "otlp" else ConsoleSpanExporter(out=sys.stderr)
+    except ImportError:
+        print(f"⚠️ --trace otlp needs an OTLP exporter ({INSTALL_HINT}) - scanning without tracing")
+        return False
+    provider = TracerProvider(resource=Resource.create({"service.name": os.environ.get("OTEL_SERVICE_NAME", SERVICE_NAME)}))
+    provider.add_span_processor(BatchSpanProcessor(span_exporter))
+    atexit.register(provider.shutdown)
+    _tracer = provider.get_tracer("arkival.scan")
+    return True
+
+
+def _attributes(attributes: Dict[str, Any]) -> Dict[str, Any]:
+    """Span attributes without None values (OpenTelemetry rejects them)"""
+    return {key: value for key, value in attributes.items() if value is not None}
+
+
+def _parent_context():
+    """Context of the enclosing span, or of TRACEPARENT for a span started outside any other"""
+    if trace.get_current_span().get_span_context().is_valid or not os.environ.get("TRACEPARENT"):
+        return None
+    return TraceContextTextMapPropagator().extract({"traceparent": os.environ["TRACEPARENT"]})
+
+
+@contextlib.contextmanager
+def span(name: str, **attributes: Any):
+    """Span around a block, current for the spans started inside it; exceptions are recorded on it"""
+    if _tracer is None:
+        yield _NOOP
+        return
+    with _tracer.start_as_current_span(name, context=_parent_context(), attributes=_attributes(attributes)) as current:
+        yield current
+
+
+def start_span(name: str, start_time: Optional[int] = None, **attributes: Any):
+    """Span the caller ends (span.end(end_time=...)), for phases that do not fit one block; start_time in ns"""
+    if _tracer is None:
+        return _NOOP
+    return _tracer.start_span(name, context=_parent_context(), start_time=start_time, attributes=_attributes(attributes))
+
+
+def record_failure(current, error: BaseException):
+    """Mark a span as failed with the exception that was handled instead of propagated"""
+    current.record_exception(error)
+    if _tracer is not None:
+        current.set_status(Status(StatusCode.ERROR, str(error)))
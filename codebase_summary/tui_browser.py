@@ -0,0 +1,267 @@
+#!/usr/bin/env python3
+"""
+TUI Browser - Interactive terminal view of scan results for the 'browse' subcommand
+Walk the directory tree with coverage, undocumented counts, and worst complexity on every row, sort
+by any of them, open a file's symbol outline, or list every undocumented function below a directory
+"""
+
+import os
+import shlex
+import subprocess
+from typing import Dict, Any, List, Optional
+
+try:
+    import curses
+    CURSES_AVAILABLE = True
+except ImportError:  # Windows Python ships without curses (pip install windows-curses)
+    CURSES_AVAILABLE = False
+
+# Sort orders cycled with 's'; coverage sorts worst first, the counts largest first
+SORT_KEYS = ("name", "coverage", "undocumented", "complexity")
+HELP = "↑↓ move  ⏎/→ open  ←/⌫ back  s sort  r reverse  u undocumented  e editor  q quit"
+
+
+def _new_node(name: str, path: str, kind: str) -> Dict[str, Any]:
+    """Tree node for a directory or file with zeroed totals"""
+    return {"name": name, "path": path, "type": kind, "functions": 0, "documented": 0,
+            "max_complexity": 0, "children": {}, "symbols": []}
+
+
+def _add_totals(node: Dict[str, Any], functions: int, documented: int, max_complexity: int):
+    """Fold one file's counts into a node"""
+    node["functions"] += functions
+    node["documented"] += documented
+    node["max_complexity"] = max(node["max_complexity"], max_complexity)
+
+
+def build_tree(files: Dict[str, Dict[str, Any]]) -> Dict[str, Any]:
+    """
+    # @codebase-summary: Directory tree of scanned files with totals rolled up to every level
+    - files maps relative paths to file analyses (WorkspaceIndex.files); the root node's path is '.'
+    - A file node keeps its symbols; every node carries functions, documented, and the worst complexity
+    """
+    root = _new_node(".", ".", "dir")
+    for file, analysis in sorted(files.items()):
+        symbols = analysis.get("symbols", [])
+        counts = (analysis.get("function_count", 0), analysis.get("documented_count", 0),
+                  max((s["complexity"] for s in symbols if "complexity" in s and not s.get("doc_exempt")), default=0))
+        node = root
+        _add_totals(root, *counts)
+        parts = file.split("/")
+        for depth, part in enumerate(parts[:-1], 1):
+            node = node["children"].setdefault(part, _new_node(part, "/".join(parts[:depth]), "dir"))
+            _add_totals(node, *counts)
+        leaf = node["children"].setdefault(parts[-1], _new_node(parts[-1], file, "file"))
+        leaf["symbols"] = symbols
+        _add_totals(leaf, *counts)
+    return root
+
+
+def coverage_of(node: Dict[str, Any]) -> float:
+    """Coverage percentage of a node (100 when it has no counted functions)"""
+    return node["documented"] / node["functions"] * 100 if node["functions"] else 100.0
+
+
+def sorted_children(node: Dict[str, Any], key: str, reverse: bool = False) -> List[Dict[str, Any]]:
+    """A directory's entries, subdirectories first, each group ordered by one of SORT_KEYS"""
+    orders = {
+        "name": lambda n: n["name"].lower(),
+        "coverage": lambda n: (coverage_of(n), -(n["functions"] - n["documented"])),
+        "undocumented": lambda n: -(n["functions"] - n["documented"]),
+        "complexity": lambda n: -n["max_complexity"],
+    }
+    entries = []
+    for kind in ("dir", "file"):
+        group = [child for child in node["children"].values() if child["type"] == kind]
+        group.sort(key=lambda n: (orders[key](n), n["name"].lower()), reverse=reverse)
+        entries.extend(group)
+    return entries
+
+
+def undocumented_under(node: Dict[str, Any]) -> List[Dict[str, Any]]:
+    """Every undocumented, non-exempt symbol in the files below a node, most complex first"""
+    found = []
+    stack = [node]
+    while stack:
+        current = stack.pop()
+        stack.extend(current["children"].values())
+        for symbol in current["symbols"]:
+            if not symbol.get("documented") and not symbol.get("doc_exempt"):
+                found.append({**symbol, "file": current["path"]})
+    found.sort(key=lambda s: (-s.get("complexity", 0), s["file"], s.get("line", 0)))
+    return found
+
+
+class TreeBrowser:
+    """
+    # @codebase-summary: Navigation state of the browser, independent of curses
+    - A stack of views: 'dir' (a directory's entries), 'file' (a file's symbols), and 'undocumented'
+      (the undocumented symbols below a directory); each view remembers its cursor
+    - handle_key() applies one keypress and returns False when the user quits
+    """
+
+    def __init__(self, root: Dict[str, Any]):
+        self.root = root
+        self.sort_key = "coverage"
+        self.reverse = False
+        self.views: List[Dict[str, Any]] = [{"type": "dir", "node": root, "cursor": 0}]
+        self.message = ""
+
+    @property
+    def view(self) -> Dict[str, Any]:
+        """The view on top of the stack"""
+        return self.views[-1]
+
+    def rows(self) -> List[Dict[str, Any]]:
+        """Items listed by the current view"""
+        view = self.view
+        if view["type"] == "dir":
+            return sorted_children(view["node"], self.sort_key, self.reverse)
+        if view["type"] == "undocumented":
+            return undocumented_under(view["node"])
+        return [dict(symbol, file=view["node"]["path"]) for symbol in view["node"]["symbols"]]
+
+    def title(self) -> str:
+        """Header line: where the user is and how the listing is ordered"""
+        view, node = self.view, self.view["node"]
+        if view["type"] == "undocumented":
+            return f"Undocumented in {node['path']}/ ({len(self.rows())}, most complex first)"
+        label = f"{node['path']}{'/' if view['type'] == 'dir' and node['path'] != '.' else ''}"
+        order = f"  [sort: {self.sort_key}{' reversed' if self.reverse else ''}]" if view["type"] == "dir" else ""
+        return f"{label}  {coverage_of(node):.1f}% of {node['functions']} documented{order}"
+
+    def selected(self) -> Optional[Dict[str, Any]]:
+        """Item under the cursor, or None for an empty view"""
+        rows = self.rows()
+        return rows[min(self.view["cursor"], len(rows) - 1)] if rows else None
+
+    def handle_key(self, key: str, page: int = 10) -> bool:
+        """Apply a keypress (curses key names or characters); False means quit"""
+        self.message = ""
+        count = len(self.rows())
+        moves = {"KEY_UP": -1, "k": -1, "KEY_DOWN": 1, "j": 1, "KEY_PPAGE": -page, "KEY_NPAGE": page,
+                 "KEY_HOME": -count, "g": -count, "KEY_END": count, "G": count}
+        if key in ("q", "Q"):
+            return False
+        if key in moves:
+            self.view["cursor"] = max(0, min(count - 1, self.view["cursor"] + moves[key]))
+        elif key in ("\n", "KEY_ENTER", "KEY_RIGHT", "l"):
+            self._open(self.selected())
+        elif key in ("KEY_LEFT", "h", "KEY_BACKSPACE", "\x7f", "\b"):
+            if len(self.views) > 1:
+                self.views.pop()
+        elif key == "s" and self.view["type"] == "dir":
+            self.sort_key = SORT_KEYS[(SORT_KEYS.index(self.sort_key) + 1) % len(SORT_KEYS)]
+            self.view["cursor"] = 0
+        elif key == "r" and self.view["type"] == "dir":
+            self.reverse = not self.reverse
+            self.view["cursor"] = 0
+        elif key == "u":
+            node = self.view["node"]
+            if node["type"] == "dir" and self.view["type"] == "dir":
+                self.views.append({"type": "undocumented", "node": node, "cursor": 0})
+        return True
+
+    def _open(self, item: Optional[Dict[str, Any]]):
+        """Descend into a directory or file row, or jump from an undocumented symbol to its file"""
+        if item is None:
+            return
+        view = self.view
+        if view["type"] == "dir":
+            self.views.append({"type": "dir" if item["type"] == "dir" else "file", "node": item, "cursor": 0})
+        elif view["type"] == "undocumented":
+            node = self._node_at(item["file"])
+            if node is not None:
+                cursor = next((i for i, s in enumerate(node["symbols"])
+                               if s.get("line") == item.get("line") and s["name"] == item["name"]), 0)
+                self.views.append({"type": "file", "node": node, "cursor": cursor})
+
+    def _node_at(self, path: str) -> Optional[Dict[str, Any]]:
+        """Tree node of a relative file path"""
+        node = self.root
+        for part in path.split("/"):
+            node = node["children"].get(part)
+            if node is None:
+                return None
+        return node
+
+
+def format_row(browser: TreeBrowser, item: Dict[str, Any], width: int) -> str:
+    """One listing line for a directory entry or symbol, cut to the screen width"""
+    if browser.view["type"] == "dir":
+        undocumented = item["functions"] - item["documented"]
+        name = item["name"] + ("/" if item["type"] == "dir" else "")
+        stats = f"{coverage_of(item):6.1f}%  {undocumented:5} undoc  {item['functions']:5} fn  cx {item['max_complexity']:3}"
+        name_width = max(10, width - len(stats) - 3)
+        line = f"{name[:name_width]:<{name_width}}  {stats}"
+    else:
+        mark = "+" if item.get("documented") else "·" if item.get("doc_exempt") else "-"
+        where = f"{item['file']}:{item.get('line', 0)}  " if browser.view["type"] == "undocumented" else \
+            f"{item.get('line', 0):>5}  "
+        complexity = f"  cx {item['complexity']}" if "complexity" in item else ""
+        line = f"{mark} {where}{item.get('kind', 'function'):<10} {item['name']}{complexity}"
+    return line[:max(0, width - 1)]
+
+
+def _open_in_editor(item: Dict[str, Any], project_root: str) -> str:
+    """Open a symbol's file at its line in $VISUAL/$EDITOR (vi by default); returns a status message"""
+    editor = os.environ.get("VISUAL") or os.environ.get("EDITOR") or "vi"
+    command = shlex.split(editor) + ([f"+{item['line']}"] if item.get("line") else []) + [item["file"]]
+    try:
+        subprocess.call(command, cwd=project_root)
+    except OSError as e:
+        return f"Could not start {editor}: {e}"
+    return ""
+
+
+def _draw(screen, browser: TreeBrowser):
+    """Render the current view: title, listing around the cursor, and the key help"""
+    height, width = screen.getmaxyx()
+    screen.erase()
+    rows = browser.rows()
+    visible = max(1, height - 3)
+    cursor = browser.view["cursor"] = min(browser.view["cursor"], max(0, len(rows) - 1))
+    top = max(0, min(cursor - visible // 2, len(rows) - visible))
+    screen.addnstr(0, 0, browser.title(), width - 1, curses.A_BOLD)
+    for offset, item in enumerate(rows[top:top + visible]):
+        attributes = curses.A_REVERSE if top + offset == cursor else curses.A_NORMAL
+        screen.addnstr(offset + 1, 0, format_row(browser, item, width), width - 1, attributes)
+    if not rows:
+        screen.addnstr(1, 0, "(nothing here)", width - 1, curses.A_DIM)
+    screen.addnstr(height - 1, 0, browser.message or HELP, width - 1, curses.A_DIM)
+    screen.refresh()
+
+
+def run_browser(files: Dict[str, Dict[str, Any]], project_root: str) -> int:
+    """
+    # @codebase-summary: Run the interactive browser until the user quits
+    - Needs curses and an interactive terminal; returns 2 (after printing why) when either is missing
+    - 'e' suspends the screen to open the selected file or symbol in $VISUAL/$EDITOR
+    """
+    if not CURSES_AVAILABLE:
+        print("❌ The browser needs curses (on Windows: pip install windows-curses)")
+        return 2
+    if not os.isatty(0) or not os.isatty(1):
+        print("❌ The browser needs an interactive terminal")
+        return 2
+    browser = TreeBrowser(build_tree(files))
+
+    def loop(screen):
+        curses.curs_set(0)
+        screen.keypad(True)
+        while True:
+            _draw(screen, browser)
+            key = screen.getkey()
+            if key == "e":
+                item = browser.selected()
+                if item is not None and (browser.view["type"] != "dir" or item["type"] == "file"):
+                    curses.endwin()
+                    browser.message = _open_in_editor({"file": item.get("file", item.get("path")),
+                                                       "line": item.get("line")}, project_root)
+                    screen.refresh()
+                continue
+            if not browser.handle_key(key, page=max(1, screen.getmaxyx()[0] - 3)):
+                return
+
+    curses.wrapper(loop)
+    return 0
@@ -0,0 +1,154 @@
+#!/usr/bin/env python3
+"""
+Watch Mode - Regenerates the codebase summary whenever source files change
+Uses native filesystem notifications (watchdog) when installed, otherwise falls back to stat polling
+"""
+
+import time
+import threading
+from pathlib import Path
+from typing import Dict, Tuple, Optional, Set
+
+import link_policy
+
+try:
+    from watchdog.observers import Observer
+    from watchdog.events import FileSystemEventHandler
+    WATCHDOG_AVAILABLE = True
+except ImportError:
+    WATCHDOG_AVAILABLE = False
+
+Snapshot = Dict[str, Tuple[int, int]]
+
+
+class WorkspaceWatcher:
+    """
+    # @codebase-summary: Workspace watcher driving incremental summary regeneration
+    - Monitors code files (same extensions and ignore rules as the scanner) for changes
+    - Debounces bursts of edits into a single incremental re-scan
+    - Never reacts to the scanner's own output files, so regeneration cannot retrigger itself
+    """
+
+    def __init__(self, generator, interval: float = 1.0, debounce: float = 0.5):
+        self.generator = generator
+        self.project_root = Path(generator.project_root)
+        self.interval = interval
+        self.debounce = debounce
+        self._changed = threading.Event()
+        self._snapshot: Snapshot = {}
+
+        # Watch mode always runs incrementally - unchanged files come from the scan cache
+        self.generator.incremental = True
+
+    def _is_watched(self, path: Path) -> bool:
+        """True for code files the scanner would analyze"""
+        if not self.generator._is_code_file(path):
+            return False
+        try:
+            return not self.generator._should_ignore_path(path)
+        except Exception:
+            return False
+
+    def take_snapshot(self) -> Snapshot:
+        """Map each watched file to its (mtime_ns, size) so edits, additions and deletions are visible"""
+        snapshot = {}
+        walker = link_policy.LinkWalker(self.project_root, getattr(self.generator, "symlink_policy", "follow"))
+        for root, dirs, files in walker.walk(ignore=self.generator._should_ignore_path):
+            root_path = Path(root)
+            for name in files:
+                path = root_path / name
+                if not self._is_watched(path):
+                    continue
+                try:
+                    stat = path.stat()
+                except OSError:
+                    continue
+                snapshot[str(path.relative_to(self.project_root))] = (stat.st_mtime_ns, stat.st_size)
+        return snapshot
+
+    def _diff(self, before: Snapshot, after: Snapshot) -> Set[str]:
+        """Relative paths that were added, removed, or modified between snapshots"""
+        return {p for p in before.keys() | after.keys() if before.get(p) != after.get(p)}
+
+    def _initial_scan(self):
+        """First generation before any change is watched for"""
+        self.generator.generate_summary()
+
+    def _regenerate(self, changed: Set[str]):
+        """Run one incremental summary generation and report what triggered it"""
+        preview = ", ".join(sorted(changed)[:5])
+        more = f" (+{len(changed) - 5} more)" if len(changed) > 5 else ""
+        print(f"\n👀 {len(changed)} file(s) changed: {preview}{more}")
+        started = time.monotonic()
+        self.generator.generate_summary()
+        print(f"⏱️ Regenerated in {time.monotonic() - started:.2f}s - watching for changes (Ctrl+C to stop)")
+
+    def _start_native_observer(self) -> Optional[object]:
+        """Start a watchdog observer that flags changes to watched files, or None if unavailable"""
+        if not WATCHDOG_AVAILABLE:
+            return None
+
+        watcher = self
+
+        class _Handler(FileSystemEventHandler):
+            def on_any_event(self, event):
+                paths = [getattr(event, 'src_path', None), getattr(event, 'dest_path', None)]
+                if any(p and watcher._is_watched(Path(p)) for p in paths):
+                    watcher._changed.set()
+
+        observer = Observer()
+        observer.schedule(_Handler(), str(self.project_root), recursive=True)
+        observer.start()
+        return observer
+
+    def run(self):
+        """
+        # @codebase-summary: Watch loop - initial scan, then regenerate on every settled change
+        - Native events only wake the loop; the snapshot diff decides what actually changed
+        - Polling mode checks the snapshot every interval seconds
+        """
+        print(f"👀 WATCH MODE: monitoring {self.project_root}")
+        self._initial_scan()
+        # --force applies to the first scan only; later scans reuse the cache
+        self.generator.force_rescan = False
+        self._snapshot = self.take_snapshot()
+
+        observer = self._start_native_observer()
+        if observer:
+            print("   Using native filesystem notifications (watchdog)")
+        else:
+            print(f"   Polling every {self.interval:g}s (install 'watchdog' for native notifications)")
+        print("   Watching for changes (Ctrl+C to stop)")
+
+        try:
+            while True:
+                if observer:
+                    self._changed.wait()
+                else:
+                    time.sleep(self.interval)
+
+                self._changed.clear()
+                current = self.take_snapshot()
+                if current == self._snapshot:
+                    continue
+
+                # Let bursts of saves (editors, git checkouts) settle before re-scanning
+                while True:
+                    self._changed.clear()
+                    time.sleep(self.debounce)
+                    settled = self.take_snapshot()
+                    if settled == current and not self._changed.is_set():
+                        break
+                    current = settled
+
+                changed = self._diff(self._snapshot, current)
+                if changed:
+                    # Baseline is the pre-scan snapshot so edits made during the scan trigger another pass
+                    self._snapshot = current
+                    self._regenerate(changed)
+        except KeyboardInterrupt:
+            print("\n🛑 Watch mode stopped")
+        finally:
+            if observer:
+                observer.stop()
+                observer.join()
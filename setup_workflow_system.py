@@ -287,6 +287,77 @@ class WorkflowSystemSetup:
             ("codebase_summary/agent_workflow_orchestrator.py", "arkival/codebase_summary/agent_workflow_orchestrator.py"),
             ("codebase_summary/update_changelog.py", "arkival/codebase_summary/update_changelog.py"),
             ("codebase_summary/update_project_summary.py", "arkival/codebase_summary/update_project_summary.py"),
+            ("codebase_summary/go_ast_parser/go.mod", "arkival/codebase_summary/go_ast_parser/go.mod"),
+            ("codebase_summary/go_ast_parser/main.go", "arkival/codebase_summary/go_ast_parser/main.go"),
+            ("codebase_summary/scan_cache.py", "arkival/codebase_summary/scan_cache.py"),
+            ("codebase_summary/report_exporters.py", "arkival/codebase_summary/report_exporters.py"),
+            ("codebase_summary/language_extractors.py", "arkival/codebase_summary/language_extractors.py"),
+            ("codebase_summary/watch_mode.py", "arkival/codebase_summary/watch_mode.py"),
+            ("codebase_summary/coverage_gate.py", "arkival/codebase_summary/coverage_gate.py"),
+            ("codebase_summary/call_graph.py", "arkival/codebase_summary/call_graph.py"),
+            ("codebase_summary/interface_map.py", "arkival/codebase_summary/interface_map.py"),
+            ("codebase_summary/complexity.py", "arkival/codebase_summary/complexity.py"),
+            ("codebase_summary/summary_schema.py", "arkival/codebase_summary/summary_schema.py"),
+            ("codebase_summary/schemas/codebase_summary.v1.schema.json", "arkival/codebase_summary/schemas/codebase_summary.v1.schema.json"),
+            ("codebase_summary/schemas/arkival_scan.proto", "arkival/codebase_summary/schemas/arkival_scan.proto"),
+            ("codebase_summary/infrastructure_inventory.py", "arkival/codebase_summary/infrastructure_inventory.py"),
+            ("codebase_summary/proto_api.py", "arkival/codebase_summary/proto_api.py"),
+            ("codebase_summary/database_inventory.py", "arkival/codebase_summary/database_inventory.py"),
+            ("codebase_summary/component_inventory.py", "arkival/codebase_summary/component_inventory.py"),
+            ("codebase_summary/build_inventory.py", "arkival/codebase_summary/build_inventory.py"),
+            ("codebase_summary/notebooks.py", "arkival/codebase_summary/notebooks.py"),
+            ("codebase_summary/extractor_plugins.py", "arkival/codebase_summary/extractor_plugins.py"),
+            ("codebase_summary/annotate.py", "arkival/codebase_summary/annotate.py"),
+            ("codebase_summary/breadcrumb_styles.py", "arkival/codebase_summary/breadcrumb_styles.py"),
+            ("codebase_summary/doc_drift.py", "arkival/codebase_summary/doc_drift.py"),
+            ("codebase_summary/doc_policy.py", "arkival/codebase_summary/doc_policy.py"),
+            ("codebase_summary/summary_diff.py", "arkival/codebase_summary/summary_diff.py"),
+            ("codebase_summary/baseline.py", "arkival/codebase_summary/baseline.py"),
+            ("codebase_summary/dependency_graph.py", "arkival/codebase_summary/dependency_graph.py"),
+            ("codebase_summary/mermaid_diagrams.py", "arkival/codebase_summary/mermaid_diagrams.py"),
+            ("codebase_summary/sqlite_export.py", "arkival/codebase_summary/sqlite_export.py"),
+            ("codebase_summary/serve_mode.py", "arkival/codebase_summary/serve_mode.py"),
+            ("codebase_summary/grpc_service.py", "arkival/codebase_summary/grpc_service.py"),
+            ("codebase_summary/mcp_server.py", "arkival/codebase_summary/mcp_server.py"),
+            ("codebase_summary/lsp_server.py", "arkival/codebase_summary/lsp_server.py"),
+            ("codebase_summary/precommit.py", "arkival/codebase_summary/precommit.py"),
+            ("codebase_summary/project_config.py", "arkival/codebase_summary/project_config.py"),
+            ("codebase_summary/ignore_files.py", "arkival/codebase_summary/ignore_files.py"),
+            ("codebase_summary/generated_files.py", "arkival/codebase_summary/generated_files.py"),
+            ("codebase_summary/go_tests.py", "arkival/codebase_summary/go_tests.py"),
+            ("codebase_summary/go_build.py", "arkival/codebase_summary/go_build.py"),
+            ("codebase_summary/go_generics.py", "arkival/codebase_summary/go_generics.py"),
+            ("codebase_summary/go_api.py", "arkival/codebase_summary/go_api.py"),
+            ("codebase_summary/go_directives.py", "arkival/codebase_summary/go_directives.py"),
+            ("codebase_summary/deprecations.py", "arkival/codebase_summary/deprecations.py"),
+            ("codebase_summary/todo_comments.py", "arkival/codebase_summary/todo_comments.py"),
+            ("codebase_summary/secrets_scan.py", "arkival/codebase_summary/secrets_scan.py"),
+            ("codebase_summary/license_headers.py", "arkival/codebase_summary/license_headers.py"),
+            ("codebase_summary/dead_code.py", "arkival/codebase_summary/dead_code.py"),
+            ("codebase_summary/duplicates.py", "arkival/codebase_summary/duplicates.py"),
+            ("codebase_summary/aggregates.py", "arkival/codebase_summary/aggregates.py"),
+            ("codebase_summary/churn.py", "arkival/codebase_summary/churn.py"),
+            ("codebase_summary/codeowners.py", "arkival/codebase_summary/codeowners.py"),
+            ("codebase_summary/symbol_search.py", "arkival/codebase_summary/symbol_search.py"),
+            ("codebase_summary/tui_browser.py", "arkival/codebase_summary/tui_browser.py"),
+            ("codebase_summary/llm_summaries.py", "arkival/codebase_summary/llm_summaries.py"),
+            ("codebase_summary/rag_chunks.py", "arkival/codebase_summary/rag_chunks.py"),
+            ("codebase_summary/entrypoints.py", "arkival/codebase_summary/entrypoints.py"),
+            ("codebase_summary/onboarding.py", "arkival/codebase_summary/onboarding.py"),
+            ("codebase_summary/ref_diff.py", "arkival/codebase_summary/ref_diff.py"),
+            ("codebase_summary/metrics_history.py", "arkival/codebase_summary/metrics_history.py"),
+            ("codebase_summary/badges.py", "arkival/codebase_summary/badges.py"),
+            ("codebase_summary/metrics_exporter.py", "arkival/codebase_summary/metrics_exporter.py"),
+            ("codebase_summary/tracing.py", "arkival/codebase_summary/tracing.py"),
+            ("codebase_summary/memory_budget.py", "arkival/codebase_summary/memory_budget.py"),
+            ("codebase_summary/file_limits.py", "arkival/codebase_summary/file_limits.py"),
+            ("codebase_summary/text_encoding.py", "arkival/codebase_summary/text_encoding.py"),
+            ("codebase_summary/link_policy.py", "arkival/codebase_summary/link_policy.py"),
+            ("codebase_summary/archive_source.py", "arkival/codebase_summary/archive_source.py"),
+            ("codebase_summary/remote_source.py", "arkival/codebase_summary/remote_source.py"),
+            ("codebase_summary/subprojects.py", "arkival/codebase_summary/subprojects.py"),
+            ("codebase_summary/go_workspace.py", "arkival/codebase_summary/go_workspace.py"),
+            ("codebase_summary/dependency_inventory.py", "arkival/codebase_summary/dependency_inventory.py"),
             ("NEW_AGENT_GREETING.md", "arkival/NEW_AGENT_GREETING.md"),
             ("DEVELOPER_ONBOARDING.md", "arkival/DEVELOPER_ONBOARDING.md"),
             ("AGENT_GUIDE.md", "arkival/AGENT_GUIDE.md")
@@ -608,6 +679,77 @@ Existing directories preserved:
             ("codebase_summary/agent_workflow_orchestrator.py", "codebase_summary/agent_workflow_orchestrator.py"),
             ("codebase_summary/update_changelog.py", "codebase_summary/update_changelog.py"),
             ("codebase_summary/update_project_summary.py", "codebase_summary/update_project_summary.py"),
+            ("codebase_summary/go_ast_parser/go.mod", "codebase_summary/go_ast_parser/go.mod"),
+            ("codebase_summary/go_ast_parser/main.go", "codebase_summary/go_ast_parser/main.go"),
+            ("codebase_summary/scan_cache.py", "codebase_summary/scan_cache.py"),
+            ("codebase_summary/report_exporters.py", "codebase_summary/report_exporters.py"),
+            ("codebase_summary/language_extractors.py", "codebase_summary/language_extractors.py"),
+            ("codebase_summary/watch_mode.py", "codebase_summary/watch_mode.py"),
+            ("codebase_summary/coverage_gate.py", "codebase_summary/coverage_gate.py"),
+            ("codebase_summary/call_graph.py", "codebase_summary/call_graph.py"),
+            ("codebase_summary/interface_map.py", "codebase_summary/interface_map.py"),
+            ("codebase_summary/complexity.py", "codebase_summary/complexity.py"),
+            ("codebase_summary/summary_schema.py", "codebase_summary/summary_schema.py"),
+            ("codebase_summary/schemas/codebase_summary.v1.schema.json", "codebase_summary/schemas/codebase_summary.v1.schema.json"),
+            ("codebase_summary/schemas/arkival_scan.proto", "codebase_summary/schemas/arkival_scan.proto"),
+            ("codebase_summary/infrastructure_inventory.py", "codebase_summary/infrastructure_inventory.py"),
+            ("codebase_summary/proto_api.py", "codebase_summary/proto_api.py"),
+            ("codebase_summary/database_inventory.py", "codebase_summary/database_inventory.py"),
+            ("codebase_summary/component_inventory.py", "codebase_summary/component_inventory.py"),
+            ("codebase_summary/build_inventory.py", "codebase_summary/build_inventory.py"),
+            ("codebase_summary/notebooks.py", "codebase_summary/notebooks.py"),
+            ("codebase_summary/extractor_plugins.py", "codebase_summary/extractor_plugins.py"),
+            ("codebase_summary/annotate.py", "codebase_summary/annotate.py"),
+            ("codebase_summary/breadcrumb_styles.py", "codebase_summary/breadcrumb_styles.py"),
+            ("codebase_summary/doc_drift.py", "codebase_summary/doc_drift.py"),
+            ("codebase_summary/doc_policy.py", "codebase_summary/doc_policy.py"),
+            ("codebase_summary/summary_diff.py", "codebase_summary/summary_diff.py"),
+            ("codebase_summary/baseline.py", "codebase_summary/baseline.py"),
+            ("codebase_summary/dependency_graph.py", "codebase_summary/dependency_graph.py"),
+            ("codebase_summary/mermaid_diagrams.py", "codebase_summary/mermaid_diagrams.py"),
+            ("codebase_summary/sqlite_export.py", "codebase_summary/sqlite_export.py"),
+            ("codebase_summary/serve_mode.py", "codebase_summary/serve_mode.py"),
+            ("codebase_summary/grpc_service.py", "codebase_summary/grpc_service.py"),
+            ("codebase_summary/mcp_server.py", "codebase_summary/mcp_server.py"),
+            ("codebase_summary/lsp_server.py", "codebase_summary/lsp_server.py"),
+            ("codebase_summary/precommit.py", "codebase_summary/precommit.py"),
+            ("codebase_summary/project_config.py", "codebase_summary/project_config.py"),
+            ("codebase_summary/ignore_files.py", "codebase_summary/ignore_files.py"),
+            ("codebase_summary/generated_files.py", "codebase_summary/generated_files.py"),
+            ("codebase_summary/go_tests.py", "codebase_summary/go_tests.py"),
+            ("codebase_summary/go_build.py", "codebase_summary/go_build.py"),
+            ("codebase_summary/go_generics.py", "codebase_summary/go_generics.py"),
+            ("codebase_summary/go_api.py", "codebase_summary/go_api.py"),
+            ("codebase_summary/go_directives.py", "codebase_summary/go_directives.py"),
+            ("codebase_summary/deprecations.py", "codebase_summary/deprecations.py"),
+            ("codebase_summary/todo_comments.py", "codebase_summary/todo_comments.py"),
+            ("codebase_summary/secrets_scan.py", "codebase_summary/secrets_scan.py"),
+            ("codebase_summary/license_headers.py", "codebase_summary/license_headers.py"),
+            ("codebase_summary/dead_code.py", "codebase_summary/dead_code.py"),
+            ("codebase_summary/duplicates.py", "codebase_summary/duplicates.py"),
+            ("codebase_summary/aggregates.py", "codebase_summary/aggregates.py"),
+            ("codebase_summary/churn.py", "codebase_summary/churn.py"),
+            ("codebase_summary/codeowners.py", "codebase_summary/codeowners.py"),
+            ("codebase_summary/symbol_search.py", "codebase_summary/symbol_search.py"),
+            ("codebase_summary/tui_browser.py", "codebase_summary/tui_browser.py"),
+            ("codebase_summary/llm_summaries.py", "codebase_summary/llm_summaries.py"),
+            ("codebase_summary/rag_chunks.py", "codebase_summary/rag_chunks.py"),
+            ("codebase_summary/entrypoints.py", "codebase_summary/entrypoints.py"),
+            ("codebase_summary/onboarding.py", "codebase_summary/onboarding.py"),
+            ("codebase_summary/ref_diff.py", "codebase_summary/ref_diff.py"),
+            ("codebase_summary/metrics_history.py", "codebase_summary/metrics_history.py"),
+            ("codebase_summary/badges.py", "codebase_summary/badges.py"),
+            ("codebase_summary/metrics_exporter.py", "codebase_summary/metrics_exporter.py"),
+            ("codebase_summary/tracing.py", "codebase_summary/tracing.py"),
+            ("codebase_summary/memory_budget.py", "codebase_summary/memory_budget.py"),
+            ("codebase_summary/file_limits.py", "codebase_summary/file_limits.py"),
+            ("codebase_summary/text_encoding.py", "codebase_summary/text_encoding.py"),
+            ("codebase_summary/link_policy.py", "codebase_summary/link_policy.py"),
+            ("codebase_summary/archive_source.py", "codebase_summary/archive_source.py"),
+            ("codebase_summary/remote_source.py", "codebase_summary/remote_source.py"),
+            ("codebase_summary/subprojects.py", "codebase_summary/subprojects.py"),
+            ("codebase_summary/go_workspace.py", "codebase_summary/go_workspace.py"),
+            ("codebase_summary/dependency_inventory.py", "codebase_summary/dependency_inventory.py"),
             ("NEW_AGENT_GREETING.md", "NEW_AGENT_GREETING.md"),
             ("DEVELOPER_ONBOARDING.md", "DEVELOPER_ONBOARDING.md"),
             ("AGENT_GUIDE.md", "AGENT_GUIDE.md")
@@ -178,7 +178,74 @@ def validate_export_package():
         "changelog_summary.json",
         "codebase_summary/agent_workflow_orchestrator.py",
         "codebase_summary/update_changelog.py",
-        "codebase_summary/update_project_summary.py"
+        "codebase_summary/update_project_summary.py",
+        "codebase_summary/scan_cache.py",
+        "codebase_summary/report_exporters.py",
+        "codebase_summary/language_extractors.py",
+        "codebase_summary/watch_mode.py",
+        "codebase_summary/coverage_gate.py",
+        "codebase_summary/call_graph.py",
+        "codebase_summary/interface_map.py",
+        "codebase_summary/complexity.py",
+        "codebase_summary/summary_schema.py",
+        "codebase_summary/infrastructure_inventory.py",
+        "codebase_summary/proto_api.py",
+        "codebase_summary/database_inventory.py",
+        "codebase_summary/component_inventory.py",
+        "codebase_summary/build_inventory.py",
+        "codebase_summary/notebooks.py",
+        "codebase_summary/extractor_plugins.py",
+        "codebase_summary/annotate.py",
+        "codebase_summary/breadcrumb_styles.py",
+        "codebase_summary/doc_drift.py",
+        "codebase_summary/doc_policy.py",
+        "codebase_summary/summary_diff.py",
+        "codebase_summary/baseline.py",
+        "codebase_summary/dependency_graph.py",
+        "codebase_summary/mermaid_diagrams.py",
+        "codebase_summary/sqlite_export.py",
+        "codebase_summary/serve_mode.py",
+        "codebase_summary/grpc_service.py",
+        "codebase_summary/mcp_server.py",
+        "codebase_summary/lsp_server.py",
+        "codebase_summary/precommit.py",
+        "codebase_summary/project_config.py",
+        "codebase_summary/ignore_files.py",
+        "codebase_summary/generated_files.py",
+        "codebase_summary/go_tests.py",
+        "codebase_summary/go_build.py",
+        "codebase_summary/go_generics.py",
+        "codebase_summary/go_api.py",
+        "codebase_summary/go_directives.py",
+        "codebase_summary/deprecations.py",
+        "codebase_summary/todo_comments.py",
+        "codebase_summary/secrets_scan.py",
+        "codebase_summary/license_headers.py",
+        "codebase_summary/dead_code.py",
+        "codebase_summary/duplicates.py",
+        "codebase_summary/aggregates.py",
+        "codebase_summary/churn.py",
+        "codebase_summary/codeowners.py",
+        "codebase_summary/symbol_search.py",
+        "codebase_summary/tui_browser.py",
+        "codebase_summary/llm_summaries.py",
+        "codebase_summary/rag_chunks.py",
+        "codebase_summary/entrypoints.py",
+        "codebase_summary/onboarding.py",
+        "codebase_summary/ref_diff.py",
+        "codebase_summary/metrics_history.py",
+        "codebase_summary/badges.py",
+        "codebase_summary/metrics_exporter.py",
+        "codebase_summary/tracing.py",
+        "codebase_summary/memory_budget.py",
+        "codebase_summary/file_limits.py",
+        "codebase_summary/text_encoding.py",
+        "codebase_summary/link_policy.py",
+        "codebase_summary/archive_source.py",
+        "codebase_summary/remote_source.py",
+        "codebase_summary/subprojects.py",
+        "codebase_summary/go_workspace.py",
+        "codebase_summary/dependency_inventory.py"
     ]
     
     # Optional documentation files (not required for existing projects)